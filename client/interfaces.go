@@ -229,6 +229,7 @@ type InstanceServer interface {
 	GetNetworkACLs() (acls []api.NetworkACL, err error)
 	GetNetworkACLsAllProjects() (acls []api.NetworkACL, err error)
 	GetNetworkACL(name string) (acl *api.NetworkACL, ETag string, err error)
+	GetNetworkACLState(name string) (state *api.NetworkACLState, err error)
 	GetNetworkACLLogfile(name string) (log io.ReadCloser, err error)
 	CreateNetworkACL(acl api.NetworkACLsPost) (op Operation, err error)
 	UpdateNetworkACL(name string, acl api.NetworkACLPut, ETag string) (op Operation, err error)
@@ -409,6 +410,14 @@ type InstanceServer interface {
 	UpdateAuthGroup(groupName string, groupPut api.AuthGroupPut, ETag string) error
 	RenameAuthGroup(groupName string, groupPost api.AuthGroupPost) error
 	DeleteAuthGroup(groupName string) error
+	GetAuthGroupRequestIDs() (ids []string, err error)
+	GetAuthGroupRequests() (groupRequests []api.AuthGroupRequest, err error)
+	GetAuthGroupRequest(id string) (groupRequest *api.AuthGroupRequest, err error)
+	CreateAuthGroupRequest(groupRequestsPost api.AuthGroupRequestsPost) (groupRequest *api.AuthGroupRequest, err error)
+	ApproveAuthGroupRequest(id string) error
+	DeleteAuthGroupRequest(id string) error
+	CheckAuth(authCheckPost api.AuthCheckPost) (results []api.AuthCheckResult, err error)
+	ExplainAuth(authCheckPost api.AuthCheckPost) (explanation *api.AuthExplanation, err error)
 	GetIdentityAuthenticationMethodsIdentifiers() (authMethodsIdentifiers map[string][]string, err error)
 	GetIdentityIdentifiersByAuthenticationMethod(authenticationMethod string) (identifiers []string, err error)
 	GetIdentities() (identities []api.Identity, err error)
@@ -422,6 +431,10 @@ type InstanceServer interface {
 	CreateIdentityBearer(identitiesBearerPost api.IdentitiesBearerPost) error
 	IssueBearerIdentityToken(nameOrIdentifier string, identityBearerTokenPost api.IdentityBearerTokenPost) (*api.IdentityBearerToken, error)
 	RevokeBearerIdentityToken(nameOrIdentifier string) error
+	LoginLDAP(credentials api.AuthLDAPLoginPost) (session *api.AuthLDAPSession, err error)
+	CreateIdentitySSH(identitiesSSHPost api.IdentitiesSSHPost) error
+	GetSSHChallenge(username string) (challenge *api.AuthSSHChallenge, err error)
+	LoginSSH(login api.AuthSSHLoginPost) (session *api.AuthSSHSession, err error)
 	GetIdentityProviderGroupNames() (identityProviderGroupNames []string, err error)
 	GetIdentityProviderGroups() (identityProviderGroups []api.IdentityProviderGroup, err error)
 	GetIdentityProviderGroup(identityProviderGroupName string) (identityProviderGroup *api.IdentityProviderGroup, ETag string, err error)
@@ -449,6 +462,17 @@ type InstanceServer interface {
 	DeletePlacementGroup(placementGroupName string) error
 	RenamePlacementGroup(placementGroupName string, placementGroupPost api.PlacementGroupPost) error
 
+	// Instance templates
+	GetInstanceTemplateNames() (instanceTemplateNames []string, err error)
+	GetInstanceTemplateNamesAllProjects() (projectToInstanceTemplates map[string][]string, err error)
+	GetInstanceTemplates() (instanceTemplates []api.InstanceTemplate, err error)
+	GetInstanceTemplatesAllProjects() (instanceTemplates []api.InstanceTemplate, err error)
+	GetInstanceTemplate(instanceTemplateName string) (instanceTemplate *api.InstanceTemplate, ETag string, err error)
+	CreateInstanceTemplate(instanceTemplatesPost api.InstanceTemplatesPost) error
+	UpdateInstanceTemplate(instanceTemplateName string, instanceTemplatePut api.InstanceTemplatePut, ETag string) error
+	DeleteInstanceTemplate(instanceTemplateName string) error
+	RenameInstanceTemplate(instanceTemplateName string, instanceTemplatePost api.InstanceTemplatePost) error
+
 	// Internal functions (for internal use)
 	RawQuery(method string, path string, data any, queryETag string) (resp *api.Response, ETag string, err error)
 	RawWebsocket(path string) (conn *websocket.Conn, err error)
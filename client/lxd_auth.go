@@ -119,6 +119,134 @@ func (r *ProtocolLXD) DeleteAuthGroup(groupName string) error {
 	return nil
 }
 
+// GetAuthGroupRequestIDs returns a list of IDs of requests of temporary group membership.
+func (r *ProtocolLXD) GetAuthGroupRequestIDs() ([]string, error) {
+	err := r.CheckExtension("auth_temporary_groups")
+	if err != nil {
+		return nil, err
+	}
+
+	urls := []string{}
+	baseURL := "auth/group-requests"
+	_, err = r.queryStruct(http.MethodGet, baseURL, nil, "", &urls)
+	if err != nil {
+		return nil, err
+	}
+
+	return urlsToResourceNames(baseURL, urls...)
+}
+
+// GetAuthGroupRequests returns a list of requests of temporary group membership.
+func (r *ProtocolLXD) GetAuthGroupRequests() ([]api.AuthGroupRequest, error) {
+	err := r.CheckExtension("auth_temporary_groups")
+	if err != nil {
+		return nil, err
+	}
+
+	var groupRequests []api.AuthGroupRequest
+	_, err = r.queryStruct(http.MethodGet, api.NewURL().Path("auth", "group-requests").WithQuery("recursion", "1").String(), nil, "", &groupRequests)
+	if err != nil {
+		return nil, err
+	}
+
+	return groupRequests, nil
+}
+
+// GetAuthGroupRequest returns a single request of temporary group membership by its ID.
+func (r *ProtocolLXD) GetAuthGroupRequest(id string) (*api.AuthGroupRequest, error) {
+	err := r.CheckExtension("auth_temporary_groups")
+	if err != nil {
+		return nil, err
+	}
+
+	groupRequest := api.AuthGroupRequest{}
+	_, err = r.queryStruct(http.MethodGet, api.NewURL().Path("auth", "group-requests", id).String(), nil, "", &groupRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &groupRequest, nil
+}
+
+// CreateAuthGroupRequest requests temporary group membership for the current identity.
+func (r *ProtocolLXD) CreateAuthGroupRequest(groupRequestsPost api.AuthGroupRequestsPost) (*api.AuthGroupRequest, error) {
+	err := r.CheckExtension("auth_temporary_groups")
+	if err != nil {
+		return nil, err
+	}
+
+	groupRequest := api.AuthGroupRequest{}
+	_, err = r.queryStruct(http.MethodPost, api.NewURL().Path("auth", "group-requests").String(), groupRequestsPost, "", &groupRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &groupRequest, nil
+}
+
+// ApproveAuthGroupRequest approves a request of temporary group membership.
+func (r *ProtocolLXD) ApproveAuthGroupRequest(id string) error {
+	err := r.CheckExtension("auth_temporary_groups")
+	if err != nil {
+		return err
+	}
+
+	_, _, err = r.query(http.MethodPost, api.NewURL().Path("auth", "group-requests", id, "approve").String(), nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAuthGroupRequest cancels or rejects a request of temporary group membership.
+func (r *ProtocolLXD) DeleteAuthGroupRequest(id string) error {
+	err := r.CheckExtension("auth_temporary_groups")
+	if err != nil {
+		return err
+	}
+
+	_, _, err = r.query(http.MethodDelete, api.NewURL().Path("auth", "group-requests", id).String(), nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CheckAuth evaluates whether an identity has an entitlement on an entity without performing any action.
+// If no identity is given, the identities that have the entitlement are returned.
+func (r *ProtocolLXD) CheckAuth(authCheckPost api.AuthCheckPost) ([]api.AuthCheckResult, error) {
+	err := r.CheckExtension("auth_check")
+	if err != nil {
+		return nil, err
+	}
+
+	var results []api.AuthCheckResult
+	_, err = r.queryStruct(http.MethodPost, api.NewURL().Path("auth", "check").String(), authCheckPost, "", &results)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// ExplainAuth explains why an identity has, or does not have, an entitlement on an entity.
+func (r *ProtocolLXD) ExplainAuth(authCheckPost api.AuthCheckPost) (*api.AuthExplanation, error) {
+	err := r.CheckExtension("auth_check")
+	if err != nil {
+		return nil, err
+	}
+
+	var explanation api.AuthExplanation
+	_, err = r.queryStruct(http.MethodPost, api.NewURL().Path("auth", "explain").String(), authCheckPost, "", &explanation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &explanation, nil
+}
+
 // GetIdentityAuthenticationMethodsIdentifiers returns a map of authentication method to list of identifiers (e.g. certificate fingerprint, email address)
 // for all identities.
 func (r *ProtocolLXD) GetIdentityAuthenticationMethodsIdentifiers() (map[string][]string, error) {
@@ -334,6 +462,13 @@ func (r *ProtocolLXD) IssueBearerIdentityToken(nameOrIdentifier string, identity
 		return nil, err
 	}
 
+	if len(identityBearerTokenPost.Permissions) > 0 || len(identityBearerTokenPost.AllowedCIDRs) > 0 {
+		err := r.CheckExtension("auth_bearer_token_scopes")
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var token api.IdentityBearerToken
 	_, err = r.queryStruct(http.MethodPost, api.NewURL().Path("auth", "identities", api.AuthenticationMethodBearer, nameOrIdentifier, "token").String(), identityBearerTokenPost, "", &token)
 	if err != nil {
@@ -343,6 +478,69 @@ func (r *ProtocolLXD) IssueBearerIdentityToken(nameOrIdentifier string, identity
 	return &token, nil
 }
 
+// LoginLDAP authenticates with the given LDAP credentials and returns a session token.
+func (r *ProtocolLXD) LoginLDAP(credentials api.AuthLDAPLoginPost) (*api.AuthLDAPSession, error) {
+	err := r.CheckExtension("auth_ldap")
+	if err != nil {
+		return nil, err
+	}
+
+	var session api.AuthLDAPSession
+	_, err = r.queryStruct(http.MethodPost, api.NewURL().Path("auth", "ldap", "login").String(), credentials, "", &session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// CreateIdentitySSH creates an SSH identity.
+func (r *ProtocolLXD) CreateIdentitySSH(identitiesSSHPost api.IdentitiesSSHPost) error {
+	err := r.CheckExtension("auth_ssh")
+	if err != nil {
+		return err
+	}
+
+	_, err = r.queryStruct(http.MethodPost, api.NewURL().Path("auth", "identities", api.AuthenticationMethodSSH).String(), identitiesSSHPost, "", nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetSSHChallenge returns a challenge to sign with an SSH key to log in as the given user.
+func (r *ProtocolLXD) GetSSHChallenge(username string) (*api.AuthSSHChallenge, error) {
+	err := r.CheckExtension("auth_ssh")
+	if err != nil {
+		return nil, err
+	}
+
+	var challenge api.AuthSSHChallenge
+	_, err = r.queryStruct(http.MethodPost, api.NewURL().Path("auth", "ssh", "challenge").String(), api.AuthSSHChallengePost{Username: username}, "", &challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	return &challenge, nil
+}
+
+// LoginSSH authenticates with a challenge signed with an SSH key and returns a session token.
+func (r *ProtocolLXD) LoginSSH(login api.AuthSSHLoginPost) (*api.AuthSSHSession, error) {
+	err := r.CheckExtension("auth_ssh")
+	if err != nil {
+		return nil, err
+	}
+
+	var session api.AuthSSHSession
+	_, err = r.queryStruct(http.MethodPost, api.NewURL().Path("auth", "ssh", "login").String(), login, "", &session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
 // RevokeBearerIdentityToken revokes the existing token for the identity.
 func (r *ProtocolLXD) RevokeBearerIdentityToken(nameOrIdentifier string) error {
 	err := r.CheckExtension("auth_bearer_devlxd")
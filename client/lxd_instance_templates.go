@@ -0,0 +1,149 @@
+package lxd
+
+import (
+	"net/http"
+
+	"github.com/canonical/lxd/shared/api"
+)
+
+// GetInstanceTemplateNames returns a list of instance template names in the current project.
+func (r *ProtocolLXD) GetInstanceTemplateNames() ([]string, error) {
+	err := r.CheckExtension("instance_templates")
+	if err != nil {
+		return nil, err
+	}
+
+	urls := []string{}
+	baseURL := api.NewURL().Path("instance-templates").String()
+	_, err = r.queryStruct(http.MethodGet, baseURL, nil, "", &urls)
+	if err != nil {
+		return nil, err
+	}
+
+	return urlsToResourceNames(baseURL, urls...)
+}
+
+// GetInstanceTemplateNamesAllProjects returns a map of project name to slice of instance template names.
+func (r *ProtocolLXD) GetInstanceTemplateNamesAllProjects() (map[string][]string, error) {
+	err := r.CheckExtension("instance_templates")
+	if err != nil {
+		return nil, err
+	}
+
+	urls := []string{}
+	baseURL := api.NewURL().Path("instance-templates").WithQuery("all-projects", "true").String()
+	_, err = r.queryStruct(http.MethodGet, baseURL, nil, "", &urls)
+	if err != nil {
+		return nil, err
+	}
+
+	return urlsToResourceNamesAllProjects(baseURL, urls...)
+}
+
+// GetInstanceTemplates returns instance templates in the current project.
+func (r *ProtocolLXD) GetInstanceTemplates() ([]api.InstanceTemplate, error) {
+	err := r.CheckExtension("instance_templates")
+	if err != nil {
+		return nil, err
+	}
+
+	var instanceTemplates []api.InstanceTemplate
+	_, err = r.queryStruct(http.MethodGet, api.NewURL().Path("instance-templates").WithQuery("recursion", "1").String(), nil, "", &instanceTemplates)
+	if err != nil {
+		return nil, err
+	}
+
+	return instanceTemplates, nil
+}
+
+// GetInstanceTemplatesAllProjects returns the instance templates from all projects.
+func (r *ProtocolLXD) GetInstanceTemplatesAllProjects() ([]api.InstanceTemplate, error) {
+	err := r.CheckExtension("instance_templates")
+	if err != nil {
+		return nil, err
+	}
+
+	var instanceTemplates []api.InstanceTemplate
+	_, err = r.queryStruct(http.MethodGet, api.NewURL().Path("instance-templates").WithQuery("recursion", "1").WithQuery("all-projects", "true").String(), nil, "", &instanceTemplates)
+	if err != nil {
+		return nil, err
+	}
+
+	return instanceTemplates, nil
+}
+
+// GetInstanceTemplate gets a single instance template.
+func (r *ProtocolLXD) GetInstanceTemplate(instanceTemplateName string) (*api.InstanceTemplate, string, error) {
+	err := r.CheckExtension("instance_templates")
+	if err != nil {
+		return nil, "", err
+	}
+
+	var instanceTemplate api.InstanceTemplate
+	eTag, err := r.queryStruct(http.MethodGet, api.NewURL().Path("instance-templates", instanceTemplateName).String(), nil, "", &instanceTemplate)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &instanceTemplate, eTag, nil
+}
+
+// CreateInstanceTemplate creates a new instance template.
+func (r *ProtocolLXD) CreateInstanceTemplate(instanceTemplatesPost api.InstanceTemplatesPost) error {
+	err := r.CheckExtension("instance_templates")
+	if err != nil {
+		return err
+	}
+
+	_, err = r.queryStruct(http.MethodPost, api.NewURL().Path("instance-templates").String(), instanceTemplatesPost, "", nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateInstanceTemplate fully overwrites the updatable fields of the instance template.
+func (r *ProtocolLXD) UpdateInstanceTemplate(instanceTemplateName string, instanceTemplatePut api.InstanceTemplatePut, ETag string) error {
+	err := r.CheckExtension("instance_templates")
+	if err != nil {
+		return err
+	}
+
+	_, err = r.queryStruct(http.MethodPut, api.NewURL().Path("instance-templates", instanceTemplateName).String(), instanceTemplatePut, ETag, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteInstanceTemplate deletes the instance template.
+func (r *ProtocolLXD) DeleteInstanceTemplate(instanceTemplateName string) error {
+	err := r.CheckExtension("instance_templates")
+	if err != nil {
+		return err
+	}
+
+	_, err = r.queryStruct(http.MethodDelete, api.NewURL().Path("instance-templates", instanceTemplateName).String(), nil, "", nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RenameInstanceTemplate renames the instance template.
+func (r *ProtocolLXD) RenameInstanceTemplate(instanceTemplateName string, instanceTemplatePost api.InstanceTemplatePost) error {
+	err := r.CheckExtension("instance_templates")
+	if err != nil {
+		return err
+	}
+
+	_, err = r.queryStruct(http.MethodPost, api.NewURL().Path("instance-templates", instanceTemplateName).String(), instanceTemplatePost, "", nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -591,6 +591,20 @@ func (r *ProtocolLXD) CreateInstance(instance api.InstancesPost) (Operation, err
 		}
 	}
 
+	if instance.Template != "" {
+		err := r.CheckExtension("instance_templates")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if instance.Count > 0 {
+		err := r.CheckExtension("instances_bulk_create")
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Send the request
 	op, _, err := r.queryOperation(http.MethodPost, path, instance, "", true)
 	if err != nil {
@@ -2537,6 +2551,13 @@ func (r *ProtocolLXD) ConsoleInstance(instanceName string, console api.InstanceC
 		}
 	}
 
+	if console.Type == "vnc" {
+		err = r.CheckExtension("instance_console_vnc")
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Send the request
 	useEventListener := r.CheckExtension("operation_wait") != nil
 	op, _, err := r.queryOperation(http.MethodPost, path+"/"+url.PathEscape(instanceName)+"/console", console, "", useEventListener)
@@ -2640,6 +2661,13 @@ func (r *ProtocolLXD) ConsoleInstanceDynamic(instanceName string, console api.In
 		}
 	}
 
+	if console.Type == "vnc" {
+		err = r.CheckExtension("instance_console_vnc")
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	// Send the request.
 	op, _, err := r.queryOperation(http.MethodPost, path+"/"+url.PathEscape(instanceName)+"/console", console, "", true)
 	if err != nil {
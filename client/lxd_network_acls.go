@@ -80,6 +80,24 @@ func (r *ProtocolLXD) GetNetworkACL(name string) (*api.NetworkACL, string, error
 	return &acl, etag, nil
 }
 
+// GetNetworkACLState returns the runtime state (rule hit counters) of a Network ACL.
+func (r *ProtocolLXD) GetNetworkACLState(name string) (*api.NetworkACLState, error) {
+	err := r.CheckExtension("network_acl_state")
+	if err != nil {
+		return nil, err
+	}
+
+	state := api.NetworkACLState{}
+
+	// Fetch the raw value.
+	_, err = r.queryStruct(http.MethodGet, "/network-acls/"+url.PathEscape(name)+"/state", nil, "", &state)
+	if err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
+
 // GetNetworkACLLogfile returns a reader for the ACL log file.
 //
 // Note that it's the caller's responsibility to close the returned ReadCloser.
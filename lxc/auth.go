@@ -11,6 +11,7 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
@@ -35,9 +36,15 @@ func (c *cmdAuth) command() *cobra.Command {
 	cmd.Short = "Manage user authorization"
 	cmd.Long = cli.FormatSection("Description", cmd.Short)
 
+	checkCmd := cmdAuthCheck{global: c.global}
+	cmd.AddCommand(checkCmd.command())
+
 	groupCmd := cmdGroup{global: c.global}
 	cmd.AddCommand(groupCmd.command())
 
+	groupRequestCmd := cmdGroupRequest{global: c.global}
+	cmd.AddCommand(groupRequestCmd.command())
+
 	permissionCmd := cmdPermission{global: c.global}
 	cmd.AddCommand(permissionCmd.command())
 
@@ -56,6 +63,169 @@ func (c *cmdAuth) command() *cobra.Command {
 	return cmd
 }
 
+type cmdAuthCheck struct {
+	global          *cmdGlobal
+	flagIdentity    string
+	flagEntitlement string
+	flagExplain     bool
+	flagFormat      string
+	flagColumns     string
+}
+
+// columns returns the ordered column definitions for auth check.
+func (c *cmdAuthCheck) columns() []cli.ShorthandColumn[api.AuthCheckResult] {
+	return []cli.ShorthandColumn[api.AuthCheckResult]{
+		{Shorthand: 'a', Name: "AUTHENTICATION METHOD", Data: c.authMethodColumnData},
+		{Shorthand: 'n', Name: "NAME", Data: c.nameColumnData},
+		{Shorthand: 'e', Name: "ENTITLEMENT", Data: c.entitlementColumnData},
+		{Shorthand: 'A', Name: "ALLOWED", Data: c.allowedColumnData},
+	}
+}
+
+func (c *cmdAuthCheck) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("check", "[<remote>:]<entity_type> [<entity_name>] [<key>=<value>...]")
+	cmd.Short = "Check permissions of identities"
+	cmd.Long = cli.FormatSection("Description", cmd.Short+`
+
+Evaluates the permissions of identities on an entity without performing any action.
+Entities are given in the same form as for "lxc auth group permission add".
+
+If an identity is given, shows whether it has each entitlement on the entity.
+If no identity is given, shows the identities that have the given entitlement on the entity.
+With --explain, shows the groups and permissions through which the identity is granted the entitlement.`)
+	cmd.Example = cli.FormatSection("", `lxc auth check instance c1 project=default --identity oidc/jane@example.com
+    Show which entitlements identity "jane@example.com" has on instance "c1" in project "default".
+
+lxc auth check project default --entitlement can_view
+    Show the identities that can view project "default".
+
+lxc auth check server --identity tls/alice --entitlement admin --explain
+    Explain why identity "alice" is, or is not, an administrator.`)
+
+	cmd.Flags().StringVar(&c.flagIdentity, "identity", "", cli.FormatStringFlagLabel("Identity to check, of the form <type>/<name>"))
+	cmd.Flags().StringVar(&c.flagEntitlement, "entitlement", "", cli.FormatStringFlagLabel("Entitlement to check"))
+	cmd.Flags().BoolVar(&c.flagExplain, "explain", false, "Explain why the identity has, or does not have, the entitlement")
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", cli.FormatStringFlagLabel("Format (csv|json|table|yaml|compact)"))
+	cmd.Flags().StringVarP(&c.flagColumns, "columns", "c", cli.DefaultColumnString(c.columns()), cli.FormatStringFlagLabel("Columns"))
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdAuthCheck) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, -1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New("Missing entity type")
+	}
+
+	// The entity arguments are parsed as if they were given to "lxc auth group permission add", so add an empty
+	// group name and a placeholder entitlement.
+	permissionArgs := []string{"", resource.name}
+	if entity.Type(resource.name) != entity.TypeServer {
+		if len(args) < 2 {
+			return errors.New("Missing entity name")
+		}
+
+		permissionArgs = append(permissionArgs, args[1])
+		args = args[1:]
+	}
+
+	permissionArgs = append(permissionArgs, "")
+	permissionArgs = append(permissionArgs, args[1:]...)
+	permission, err := parsePermissionArgs(permissionArgs)
+	if err != nil {
+		return err
+	}
+
+	req := api.AuthCheckPost{
+		EntityType:      permission.EntityType,
+		EntityReference: permission.EntityReference,
+		Entitlement:     c.flagEntitlement,
+	}
+
+	if c.flagIdentity != "" {
+		method, _, nameOrID, err := resolveIdentityTypeShorthand(c.flagIdentity)
+		if err != nil {
+			return err
+		}
+
+		req.Identity = method + "/" + nameOrID
+	}
+
+	if c.flagExplain {
+		if req.Identity == "" || req.Entitlement == "" {
+			return errors.New("Both --identity and --entitlement are required with --explain")
+		}
+
+		explanation, err := resource.server.ExplainAuth(req)
+		if err != nil {
+			return err
+		}
+
+		data, err := yaml.Marshal(explanation)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s", data)
+		return nil
+	}
+
+	if req.Identity == "" && req.Entitlement == "" {
+		return errors.New("At least one of --identity or --entitlement is required")
+	}
+
+	results, err := resource.server.CheckAuth(req)
+	if err != nil {
+		return err
+	}
+
+	// Parse column flags.
+	columns, err := cli.ParseShorthandColumns(c.flagColumns, c.columns())
+	if err != nil {
+		return err
+	}
+
+	data := cli.ColumnData(columns, results)
+	header := cli.ColumnHeaders(columns)
+
+	return cli.RenderTable(c.flagFormat, header, data, results)
+}
+
+func (c *cmdAuthCheck) authMethodColumnData(result api.AuthCheckResult) string {
+	return result.AuthenticationMethod
+}
+
+func (c *cmdAuthCheck) nameColumnData(result api.AuthCheckResult) string {
+	return result.Name
+}
+
+func (c *cmdAuthCheck) entitlementColumnData(result api.AuthCheckResult) string {
+	return result.Entitlement
+}
+
+func (c *cmdAuthCheck) allowedColumnData(result api.AuthCheckResult) string {
+	if result.Allowed {
+		return "YES"
+	}
+
+	return "NO"
+}
+
 type cmdGroup struct {
 	global *cmdGlobal
 }
@@ -730,6 +900,346 @@ func parsePermissionArgs(args []string) (*api.Permission, error) {
 	}, nil
 }
 
+type cmdGroupRequest struct {
+	global *cmdGlobal
+}
+
+func (c *cmdGroupRequest) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("group-request")
+	cmd.Short = "Manage requests of temporary group membership"
+	cmd.Long = cli.FormatSection("Description", cmd.Short+`
+
+Identities can request temporary membership of a group. Once approved by an identity that can edit the requesting
+identity, the identity is added to the group for the requested duration.`)
+
+	groupRequestApproveCmd := cmdGroupRequestApprove{global: c.global}
+	cmd.AddCommand(groupRequestApproveCmd.command())
+
+	groupRequestCreateCmd := cmdGroupRequestCreate{global: c.global}
+	cmd.AddCommand(groupRequestCreateCmd.command())
+
+	groupRequestDeleteCmd := cmdGroupRequestDelete{global: c.global}
+	cmd.AddCommand(groupRequestDeleteCmd.command())
+
+	groupRequestListCmd := cmdGroupRequestList{global: c.global}
+	cmd.AddCommand(groupRequestListCmd.command())
+
+	groupRequestShowCmd := cmdGroupRequestShow{global: c.global}
+	cmd.AddCommand(groupRequestShowCmd.command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// Approve.
+type cmdGroupRequestApprove struct {
+	global *cmdGlobal
+}
+
+func (c *cmdGroupRequestApprove) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("approve", "[<remote>:]<id>")
+	cmd.Short = "Approve a group request"
+	cmd.Long = cli.FormatSection("Description", cmd.Short)
+
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdGroupRequestApprove) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New("Missing group request ID")
+	}
+
+	// Approve the group request
+	err = resource.server.ApproveAuthGroupRequest(resource.name)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf("Group request %s approved\n", resource.name)
+	}
+
+	return nil
+}
+
+// Create.
+type cmdGroupRequestCreate struct {
+	global     *cmdGlobal
+	flagReason string
+}
+
+func (c *cmdGroupRequestCreate) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("create", "[<remote>:]<group> <duration>")
+	cmd.Short = "Request temporary membership of a group"
+	cmd.Long = cli.FormatSection("Description", cmd.Short)
+	cmd.Example = cli.FormatSection("", `lxc auth group-request create operators 2h --reason "Investigating incident"
+    Request membership of the "operators" group for two hours.`)
+
+	cmd.Flags().StringVar(&c.flagReason, "reason", "", cli.FormatStringFlagLabel("Reason for the request"))
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdGroupRequestCreate) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New("Missing group name")
+	}
+
+	// Create the group request
+	groupRequest, err := resource.server.CreateAuthGroupRequest(api.AuthGroupRequestsPost{
+		Group:    resource.name,
+		Duration: args[1],
+		Reason:   c.flagReason,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf("Group request %s created\n", groupRequest.ID)
+	}
+
+	return nil
+}
+
+// Delete.
+type cmdGroupRequestDelete struct {
+	global *cmdGlobal
+}
+
+func (c *cmdGroupRequestDelete) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("delete", "[<remote>:]<id>")
+	cmd.Aliases = []string{"rm"}
+	cmd.Short = "Cancel or reject a group request"
+	cmd.Long = cli.FormatSection("Description", cmd.Short)
+
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdGroupRequestDelete) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New("Missing group request ID")
+	}
+
+	// Delete the group request
+	err = resource.server.DeleteAuthGroupRequest(resource.name)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf("Group request %s deleted\n", resource.name)
+	}
+
+	return nil
+}
+
+// List.
+type cmdGroupRequestList struct {
+	global      *cmdGlobal
+	flagFormat  string
+	flagColumns string
+}
+
+// columns returns the ordered column definitions for group request list.
+func (c *cmdGroupRequestList) columns() []cli.ShorthandColumn[api.AuthGroupRequest] {
+	return []cli.ShorthandColumn[api.AuthGroupRequest]{
+		{Shorthand: 'i', Name: "ID", Data: c.idColumnData},
+		{Shorthand: 'a', Name: "AUTHENTICATION METHOD", Data: c.authMethodColumnData},
+		{Shorthand: 'n', Name: "NAME", Data: c.nameColumnData},
+		{Shorthand: 'g', Name: "GROUP", Data: c.groupColumnData},
+		{Shorthand: 'd', Name: "DURATION", Data: c.durationColumnData},
+		{Shorthand: 'r', Name: "REASON", Data: c.reasonColumnData},
+		{Shorthand: 'c', Name: "CREATED AT", Data: c.createdAtColumnData},
+	}
+}
+
+func (c *cmdGroupRequestList) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("list", "[<remote>:]")
+	cmd.Aliases = []string{"ls"}
+	cmd.Short = "List group requests"
+	cmd.Long = cli.FormatSection("Description", cmd.Short)
+
+	cmd.RunE = c.run
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", cli.FormatStringFlagLabel("Format (csv|json|table|yaml|compact)"))
+	cmd.Flags().StringVarP(&c.flagColumns, "columns", "c", cli.DefaultColumnString(c.columns()), cli.FormatStringFlagLabel("Columns"))
+
+	return cmd
+}
+
+func (c *cmdGroupRequestList) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 0, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	remote := ""
+	if len(args) > 0 {
+		remote = args[0]
+	}
+
+	resources, err := c.global.ParseServers(remote)
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	// List group requests
+	groupRequests, err := resource.server.GetAuthGroupRequests()
+	if err != nil {
+		return err
+	}
+
+	// Parse column flags.
+	columns, err := cli.ParseShorthandColumns(c.flagColumns, c.columns())
+	if err != nil {
+		return err
+	}
+
+	data := cli.ColumnData(columns, groupRequests)
+	header := cli.ColumnHeaders(columns)
+
+	return cli.RenderTable(c.flagFormat, header, data, groupRequests)
+}
+
+func (c *cmdGroupRequestList) idColumnData(groupRequest api.AuthGroupRequest) string {
+	return groupRequest.ID
+}
+
+func (c *cmdGroupRequestList) authMethodColumnData(groupRequest api.AuthGroupRequest) string {
+	return groupRequest.AuthenticationMethod
+}
+
+func (c *cmdGroupRequestList) nameColumnData(groupRequest api.AuthGroupRequest) string {
+	return groupRequest.Name
+}
+
+func (c *cmdGroupRequestList) groupColumnData(groupRequest api.AuthGroupRequest) string {
+	return groupRequest.Group
+}
+
+func (c *cmdGroupRequestList) durationColumnData(groupRequest api.AuthGroupRequest) string {
+	return groupRequest.Duration
+}
+
+func (c *cmdGroupRequestList) reasonColumnData(groupRequest api.AuthGroupRequest) string {
+	return groupRequest.Reason
+}
+
+func (c *cmdGroupRequestList) createdAtColumnData(groupRequest api.AuthGroupRequest) string {
+	layout := "2006/01/02 15:04 UTC"
+
+	return groupRequest.CreatedAt.UTC().Format(layout)
+}
+
+// Show.
+type cmdGroupRequestShow struct {
+	global *cmdGlobal
+}
+
+func (c *cmdGroupRequestShow) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("show", "[<remote>:]<id>")
+	cmd.Short = "Show group request details"
+	cmd.Long = cli.FormatSection("Description", cmd.Short)
+
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdGroupRequestShow) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New("Missing group request ID")
+	}
+
+	// Show the group request
+	groupRequest, err := resource.server.GetAuthGroupRequest(resource.name)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(&groupRequest)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s", data)
+
+	return nil
+}
+
 type cmdIdentity struct {
 	global *cmdGlobal
 }
@@ -752,6 +1262,10 @@ func resolveIdentityTypeShorthand(identityArg string) (method string, identityTy
 		return api.AuthenticationMethodBearer, api.IdentityTypeBearerTokenDevLXD, idName, nil
 	case "bearer":
 		return api.AuthenticationMethodBearer, "", idName, nil
+	case api.AuthenticationMethodLDAP:
+		return api.AuthenticationMethodLDAP, api.IdentityTypeLDAPUser, idName, nil
+	case api.AuthenticationMethodSSH:
+		return api.AuthenticationMethodSSH, api.IdentityTypeSSHUser, idName, nil
 	}
 
 	return "", "", "", fmt.Errorf("Unrecognized identity type shorthand %q", shorthandType)
@@ -819,9 +1333,14 @@ type cmdIdentityCreate struct {
 
 func (c *cmdIdentityCreate) command() *cobra.Command {
 	cmd := &cobra.Command{}
-	cmd.Use = usage("create", "[<remote>:]<type>/<name> [<path to PEM encoded certificate>] [[--group <group_name>]]")
+	cmd.Use = usage("create", "[<remote>:]<type>/<name> [<path to PEM encoded certificate or authorized_keys file>] [[--group <group_name>]]")
 	cmd.Short = "Create an identity"
-	cmd.Long = cli.FormatSection("Description", `Create a TLS identity`)
+	cmd.Long = cli.FormatSection("Description", `Create a TLS, bearer or SSH identity
+
+For TLS identities, a PEM encoded certificate can be given. Otherwise, a trust token is issued.
+For SSH identities, a file of public keys in the authorized_keys format can be given.`)
+	cmd.Example = cli.FormatSection("", `lxc auth identity create ssh/jdoe ~/.ssh/id_ed25519.pub --group developers
+    Create an SSH identity for the user "jdoe" that can log in with the given key.`)
 
 	cmd.RunE = c.run
 	cmd.Flags().StringSliceVarP(&c.flagGroups, "group", "g", []string{}, "Groups to add to the identity")
@@ -851,6 +1370,15 @@ func (c *cmdIdentityCreate) run(cmd *cobra.Command, args []string) error {
 		return c.createTLSIdentity(remoteName, name, certFilePath)
 	case api.AuthenticationMethodOIDC:
 		return errors.New("OIDC identities cannot be created manually")
+	case api.AuthenticationMethodLDAP:
+		return errors.New("LDAP identities cannot be created manually")
+	case api.AuthenticationMethodSSH:
+		var keysFilePath string
+		if len(args) == 2 {
+			keysFilePath = args[1]
+		}
+
+		return c.createSSHIdentity(remoteName, name, keysFilePath)
 	case api.AuthenticationMethodBearer:
 		return c.createBearerIdentity(remoteName, name, idType)
 	}
@@ -968,6 +1496,62 @@ func (c *cmdIdentityCreate) createTLSIdentity(remote string, name string, certFi
 // createBearerIdentity is called via `lxc auth identity create devlxd/<name>`.
 // It accepts the remote name, and the name and type of the identity to be created.
 // These parameters, in addition to contents of stdin, are used to compose an [api.IdentitiesBearerPost] request body.
+func (c *cmdIdentityCreate) createSSHIdentity(remoteName string, identityName string, keysFilePath string) error {
+	var stdinData api.IdentitiesSSHPost
+
+	// If stdin isn't a terminal, read text from it
+	if !termios.IsTerminal(getStdinFd()) {
+		contents, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		err = yaml.Unmarshal(contents, &stdinData)
+		if err != nil {
+			return err
+		}
+	}
+
+	client, err := c.identity.global.conf.GetInstanceServer(remoteName)
+	if err != nil {
+		return err
+	}
+
+	// Add name and groups to any stdin data
+	stdinData.Name = identityName
+	for _, group := range c.flagGroups {
+		if !slices.Contains(stdinData.Groups, group) {
+			stdinData.Groups = append(stdinData.Groups, group)
+		}
+	}
+
+	// If the keys argument is provided, read it and add the keys to the stdin data.
+	if keysFilePath != "" {
+		contents, err := os.ReadFile(keysFilePath)
+		if err != nil {
+			return err
+		}
+
+		for line := range strings.SplitSeq(string(contents), "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" && !strings.HasPrefix(line, "#") {
+				stdinData.SSHAuthorizedKeys = append(stdinData.SSHAuthorizedKeys, line)
+			}
+		}
+	}
+
+	err = client.CreateIdentitySSH(stdinData)
+	if err != nil {
+		return err
+	}
+
+	if !c.identity.global.flagQuiet {
+		fmt.Printf("SSH identity %q created\n", identityName)
+	}
+
+	return nil
+}
+
 func (c *cmdIdentityCreate) createBearerIdentity(remoteName string, identityName string, identityType string) error {
 	var stdinData api.IdentitiesBearerPost
 
@@ -1436,16 +2020,23 @@ func (c *cmdIdentityGroup) command() *cobra.Command {
 }
 
 type cmdIdentityGroupAdd struct {
-	global   *cmdGlobal
-	identity *cmdIdentity
+	global      *cmdGlobal
+	identity    *cmdIdentity
+	flagExpires string
 }
 
 func (c *cmdIdentityGroupAdd) command() *cobra.Command {
 	cmd := &cobra.Command{}
 	cmd.Use = usage("add", "[<remote>:]<type>/<name_or_identifier> <group>")
 	cmd.Short = "Add a group to an identity"
-	cmd.Long = cli.FormatSection("Description", cmd.Short)
+	cmd.Long = cli.FormatSection("Description", cmd.Short+`
+
+With --expires, the identity is removed from the group once the duration has elapsed.
+If the identity is already a member of the group, the expiry of its membership is updated.`)
+	cmd.Example = cli.FormatSection("", `lxc auth identity group add oidc/jane.doe@example.com operators --expires 2h
+    Add the identity to the "operators" group for two hours.`)
 
+	cmd.Flags().StringVar(&c.flagExpires, "expires", "", cli.FormatStringFlagLabel("Remove the identity from the group after the given duration (e.g. 30m, 2h)"))
 	cmd.RunE = c.run
 
 	return cmd
@@ -1477,11 +2068,31 @@ func (c *cmdIdentityGroupAdd) run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("Expected identity of type %q but found identity with type %q", idType, identity.Type)
 	}
 
-	if slices.Contains(identity.Groups, args[1]) {
+	isMember := slices.Contains(identity.Groups, args[1])
+	if isMember && c.flagExpires == "" {
 		return fmt.Errorf("Identity %q is already a member of group %q", name, args[1])
 	}
 
-	identity.Groups = append(identity.Groups, args[1])
+	if !isMember {
+		identity.Groups = append(identity.Groups, args[1])
+	}
+
+	if c.flagExpires != "" {
+		duration, err := time.ParseDuration(c.flagExpires)
+		if err != nil {
+			return fmt.Errorf("Invalid expiry %q: %w", c.flagExpires, err)
+		}
+
+		if duration <= 0 {
+			return errors.New("Expiry must be a positive duration")
+		}
+
+		if identity.GroupExpiry == nil {
+			identity.GroupExpiry = map[string]time.Time{}
+		}
+
+		identity.GroupExpiry[args[1]] = time.Now().Add(duration)
+	}
 
 	return server.UpdateIdentity(method, name, identity.Writable(), eTag)
 }
@@ -1536,6 +2147,8 @@ func (c *cmdIdentityGroupRemove) run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("Identity %q is not a member of group %q", name, args[1])
 	}
 
+	delete(identity.GroupExpiry, args[1])
+
 	return server.UpdateIdentity(method, name, identity.Writable(), eTag)
 }
 
@@ -1563,9 +2176,11 @@ func (c *cmdIdentityToken) command() *cobra.Command {
 }
 
 type cmdIdentityTokenIssue struct {
-	global     *cmdGlobal
-	identity   *cmdIdentity
-	flagExpiry string
+	global           *cmdGlobal
+	identity         *cmdIdentity
+	flagExpiry       string
+	flagPermissions  []string
+	flagAllowedCIDRs []string
 }
 
 func (c *cmdIdentityTokenIssue) command() *cobra.Command {
@@ -1574,9 +2189,19 @@ func (c *cmdIdentityTokenIssue) command() *cobra.Command {
 	cmd.Short = "Issue a token for a bearer identity"
 	cmd.Long = cli.FormatSection("Description", cmd.Short+`
 
-Note that this revokes the current token if one is issued`)
+Note that this revokes the current token if one is issued
+
+Tokens of client bearer identities can be restricted to a subset of the permissions of the identity, and to requests
+originating from a set of subnets. Permissions are given in the same form as for "lxc auth group permission add".`)
+	cmd.Example = cli.FormatSection("", `lxc auth identity token issue bearer/ci --permission "instance c1 can_exec project=ci" --permission "instance c1 can_view project=ci"
+    Issue a token for identity "ci" that can only view and execute commands in instance "c1" in project "ci".
+
+lxc auth identity token issue bearer/ci --allowed-cidr 10.0.0.0/8
+    Issue a token for identity "ci" that can only be used from the 10.0.0.0/8 subnet.`)
 
 	cmd.Flags().StringVar(&c.flagExpiry, "expiry", "", `Token expiration as a space separated list of durations in the form (\d)+(S|M|H|d|w|m|y)`)
+	cmd.Flags().StringArrayVar(&c.flagPermissions, "permission", nil, cli.FormatStringFlagLabel("Restrict the token to a permission of the form \"<entity_type> [<entity_name>] <entitlement> [<key>=<value>...]\""))
+	cmd.Flags().StringArrayVar(&c.flagAllowedCIDRs, "allowed-cidr", nil, cli.FormatStringFlagLabel("Restrict the token to requests from a subnet"))
 	cmd.RunE = c.run
 
 	return cmd
@@ -1611,7 +2236,22 @@ func (c *cmdIdentityTokenIssue) run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("Expected identity of type %q but found identity with type %q", idType, identity.Type)
 	}
 
-	token, err := server.IssueBearerIdentityToken(name, api.IdentityBearerTokenPost{Expiry: c.flagExpiry})
+	req := api.IdentityBearerTokenPost{
+		Expiry:       c.flagExpiry,
+		AllowedCIDRs: c.flagAllowedCIDRs,
+	}
+
+	for _, flagPermission := range c.flagPermissions {
+		// The permission arguments are parsed as if they were given to "lxc auth group permission add", so prepend an empty group name.
+		permission, err := parsePermissionArgs(append([]string{""}, strings.Fields(flagPermission)...))
+		if err != nil {
+			return fmt.Errorf("Invalid permission %q: %w", flagPermission, err)
+		}
+
+		req.Permissions = append(req.Permissions, *permission)
+	}
+
+	token, err := server.IssueBearerIdentityToken(name, req)
 	if err != nil {
 		return err
 	}
@@ -70,6 +70,9 @@ var topLevelInstanceServerResourceNameFuncs = map[string]func(server lxd.Instanc
 	"instance": func(server lxd.InstanceServer) ([]string, error) {
 		return server.GetInstanceNames(api.InstanceTypeAny)
 	},
+	"instance_template": func(server lxd.InstanceServer) ([]string, error) {
+		return server.GetInstanceTemplateNames()
+	},
 	"network": func(server lxd.InstanceServer) ([]string, error) {
 		return server.GetNetworkNames()
 	},
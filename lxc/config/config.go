@@ -81,6 +81,16 @@ func (c *Config) OIDCTokenPath(remote string) string {
 	return c.ConfigPath("oidctokens", remote+".json")
 }
 
+// LDAPTokenPath returns the path for the remote's LDAP session token.
+func (c *Config) LDAPTokenPath(remote string) string {
+	return c.ConfigPath("ldaptokens", remote+".token")
+}
+
+// SSHTokenPath returns the path for the remote's SSH session token.
+func (c *Config) SSHTokenPath(remote string) string {
+	return c.ConfigPath("sshtokens", remote+".token")
+}
+
 // SaveCookies saves cookies to file.
 func (c *Config) SaveCookies() {
 	for _, jar := range c.cookieJars {
@@ -214,7 +214,7 @@ func (c *Config) connectRemote(remote Remote, args *lxd.ConnectionArgs) (lxd.Ins
 	// If bearer token is provided, we don't need TLS client certificate and key.
 	// However, do not advertise that bearer token can be configured.
 	// The support for bearer token in LXC is purely for testing purposes.
-	if !slices.Contains([]string{api.AuthenticationMethodOIDC}, remote.AuthType) && (args.TLSClientCert == "" || args.TLSClientKey == "") && args.BearerToken == "" {
+	if !slices.Contains([]string{api.AuthenticationMethodOIDC, api.AuthenticationMethodLDAP, api.AuthenticationMethodSSH}, remote.AuthType) && (args.TLSClientCert == "" || args.TLSClientKey == "") && args.BearerToken == "" {
 		return nil, errors.New("Missing TLS client certificate and key")
 	}
 
@@ -391,6 +391,23 @@ func (c *Config) getConnectionArgs(name string) (*lxd.ConnectionArgs, error) {
 		return &args, nil
 	}
 
+	// LDAP and SSH remotes use the session token obtained when logging in. The token is missing until the first login.
+	if slices.Contains([]string{api.AuthenticationMethodLDAP, api.AuthenticationMethodSSH}, remote.AuthType) {
+		tokenPath := c.LDAPTokenPath(name)
+		if remote.AuthType == api.AuthenticationMethodSSH {
+			tokenPath = c.SSHTokenPath(name)
+		}
+
+		content, err := os.ReadFile(tokenPath)
+		if err == nil {
+			args.BearerToken = strings.TrimSpace(string(content))
+		} else if !os.IsNotExist(err) {
+			return nil, err
+		}
+
+		return &args, nil
+	}
+
 	// Check for LXD_AUTH_BEARER_TOKEN environment variable
 	// Stop here if bearer token is used. It takes precedence over the TLS client certificate and key.
 	token, ok := os.LookupEnv("LXD_AUTH_BEARER_TOKEN")
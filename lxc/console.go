@@ -12,6 +12,7 @@ import (
 	"runtime"
 	"slices"
 	"strconv"
+	"strings"
 	"sync"
 	"syscall"
 
@@ -43,7 +44,7 @@ as well as retrieve past log entries from it.`)
 
 	cmd.RunE = c.run
 	cmd.Flags().BoolVar(&c.flagShowLog, "show-log", false, "Retrieve the container's console log")
-	cmd.Flags().StringVarP(&c.flagType, "type", "t", "console", cli.FormatStringFlagLabel("Type of connection to establish: 'console' for serial console, 'vga' for SPICE graphical output"))
+	cmd.Flags().StringVarP(&c.flagType, "type", "t", "console", cli.FormatStringFlagLabel("Type of connection to establish: 'console' for serial console, 'vga' for SPICE graphical output, 'vnc' for VNC graphical output"))
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return c.global.cmpTopLevelResource("instance", toComplete)
@@ -110,7 +111,7 @@ func (c *cmdConsole) run(cmd *cobra.Command, args []string) error {
 	}
 
 	// Validate flags.
-	if !slices.Contains([]string{"console", "vga"}, c.flagType) {
+	if !slices.Contains([]string{"console", "vga", "vnc"}, c.flagType) {
 		return fmt.Errorf("Unknown output type %q", c.flagType)
 	}
 
@@ -162,8 +163,8 @@ func (c *cmdConsole) runConsole(d lxd.InstanceServer, name string) error {
 	switch c.flagType {
 	case "console":
 		return c.console(d, name)
-	case "vga":
-		return c.vga(d, name)
+	case "vga", "vnc":
+		return c.graphical(d, name)
 	}
 
 	return fmt.Errorf("Unknown console type %q", c.flagType)
@@ -234,7 +235,7 @@ func (c *cmdConsole) console(d lxd.InstanceServer, name string) error {
 	return nil
 }
 
-func (c *cmdConsole) vga(d lxd.InstanceServer, name string) error {
+func (c *cmdConsole) graphical(d lxd.InstanceServer, name string) error {
 	var err error
 	conf := c.global.conf
 
@@ -254,7 +255,7 @@ func (c *cmdConsole) vga(d lxd.InstanceServer, name string) error {
 
 	// Prepare the remote console.
 	req := api.InstanceConsolePost{
-		Type: "vga",
+		Type: c.flagType,
 	}
 
 	chDisconnect := make(chan bool)
@@ -268,7 +269,7 @@ func (c *cmdConsole) vga(d lxd.InstanceServer, name string) error {
 	// Setup local socket.
 	var socket string
 	var listener net.Listener
-	if runtime.GOOS != "windows" {
+	if c.flagType == "vga" && runtime.GOOS != "windows" {
 		// Create a temporary unix socket mirroring the instance's spice socket.
 		err := os.MkdirAll(conf.ConfigPath("sockets"), 0700)
 		if err != nil {
@@ -309,7 +310,12 @@ func (c *cmdConsole) vga(d lxd.InstanceServer, name string) error {
 			return errors.New("Failed getting TCP listen address")
 		}
 
-		socket = "spice://127.0.0.1:" + strconv.Itoa(addr.Port)
+		// VNC viewers generally only support TCP connections.
+		if c.flagType == "vnc" {
+			socket = "vnc://127.0.0.1:" + strconv.Itoa(addr.Port)
+		} else {
+			socket = "spice://127.0.0.1:" + strconv.Itoa(addr.Port)
+		}
 	}
 
 	// Clean everything up when the viewer is done.
@@ -356,18 +362,28 @@ func (c *cmdConsole) vga(d lxd.InstanceServer, name string) error {
 		}
 	}()
 
-	// Use either spicy or remote-viewer if available.
+	// Use a viewer for the protocol if available.
+	var cmd *exec.Cmd
 	remoteViewer := c.findCommand("remote-viewer")
-	spicy := c.findCommand("spicy")
+	if c.flagType == "vnc" {
+		vncViewer := c.findCommand("vncviewer")
 
-	if remoteViewer != "" || spicy != "" {
-		var cmd *exec.Cmd
 		if remoteViewer != "" {
 			cmd = exec.Command(remoteViewer, socket)
-		} else {
+		} else if vncViewer != "" {
+			cmd = exec.Command(vncViewer, strings.Replace(strings.TrimPrefix(socket, "vnc://"), ":", "::", 1))
+		}
+	} else {
+		spicy := c.findCommand("spicy")
+
+		if remoteViewer != "" {
+			cmd = exec.Command(remoteViewer, socket)
+		} else if spicy != "" {
 			cmd = exec.Command(spicy, "--uri="+socket)
 		}
+	}
 
+	if cmd != nil {
 		// Start the command.
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
@@ -394,8 +410,14 @@ func (c *cmdConsole) vga(d lxd.InstanceServer, name string) error {
 			_ = cmd.Process.Kill()
 		}()
 	} else {
-		fmt.Println("LXD automatically uses either spicy or remote-viewer when present.")
-		fmt.Println("As neither could be found, the raw SPICE socket can be found at:")
+		if c.flagType == "vnc" {
+			fmt.Println("LXD automatically uses either remote-viewer or vncviewer when present.")
+			fmt.Println("As neither could be found, the VNC server can be reached at:")
+		} else {
+			fmt.Println("LXD automatically uses either spicy or remote-viewer when present.")
+			fmt.Println("As neither could be found, the raw SPICE socket can be found at:")
+		}
+
 		fmt.Printf("  %s\n", socket)
 
 		// Wait for all connections to complete.
@@ -491,6 +491,10 @@ func (c *cmdInfo) instanceInfo(d lxd.InstanceServer, name string, showLog bool)
 		fmt.Printf("PID: %d\n", inst.State.Pid)
 	}
 
+	if inst.State.Health != nil {
+		fmt.Printf("Health: %s\n", inst.State.Health.Status)
+	}
+
 	if shared.TimeIsSet(inst.CreatedAt) {
 		fmt.Printf("Created: %s\n", inst.CreatedAt.Local().Format(layout))
 	}
@@ -565,6 +569,15 @@ func (c *cmdInfo) instanceInfo(d lxd.InstanceServer, name string, showLog bool)
 			fmt.Print(memoryInfo.String())
 		}
 
+		// Memory hotplug
+		if inst.State.Memory.Hotplug != nil {
+			fmt.Printf("  Memory hotplug:\n")
+			fmt.Printf("    Plugged: %s\n", units.GetByteSizeStringIEC(inst.State.Memory.Hotplug.Plugged, 2))
+			fmt.Printf("    Requested: %s\n", units.GetByteSizeStringIEC(inst.State.Memory.Hotplug.Requested, 2))
+			fmt.Printf("    Maximum memory: %s\n", units.GetByteSizeStringIEC(inst.State.Memory.Hotplug.Max, 2))
+			fmt.Printf("    Guest support: %s\n", inst.State.Memory.Hotplug.GuestSupport)
+		}
+
 		// Network usage and IP info
 		var networkInfo strings.Builder
 		if inst.State.Network != nil {
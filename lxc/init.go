@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
+	"path"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -22,6 +24,7 @@ type cmdInit struct {
 	global *cmdGlobal
 
 	flagConfig        []string
+	flagCount         int
 	flagDevice        []string
 	flagEphemeral     bool
 	flagNetwork       string
@@ -29,6 +32,7 @@ type cmdInit struct {
 	flagStorage       string
 	flagTarget        string
 	flagTargetProject string
+	flagTemplate      string
 	flagType          string
 	flagNoProfiles    bool
 	flagEmpty         bool
@@ -52,6 +56,12 @@ lxc init ubuntu:24.04 v1 --vm -c limits.cpu=4 -c limits.memory=4GiB
 lxc init ubuntu:24.04 v1 --vm -c limits.cpu=2 -c limits.memory=8GiB -d root,size=32GiB
     Create a virtual machine with 2 vCPUs, 8GiB of RAM and a root disk of 32GiB
 
+lxc init --template=web w1
+    Create an instance from the "web" instance template
+
+lxc init ubuntu:24.04 web-{n} --count 3
+    Create 3 containers named web-1, web-2 and web-3
+
 Note: The --project flag sets the project for both the image remote and the instance remote.
 If the image remote is a public remote (e.g. simplestreams) then this project is ignored by the image remote.
 If the image remote is another LXD server, specify the source project for the image remote 
@@ -71,6 +81,8 @@ with --project and the instance remote with --target-project (if different from
 	cmd.Flags().BoolVar(&c.flagNoProfiles, "no-profiles", false, "Create the instance with no profiles applied")
 	cmd.Flags().BoolVar(&c.flagEmpty, "empty", false, "Create an empty instance")
 	cmd.Flags().BoolVar(&c.flagVM, "vm", false, "Create a virtual machine")
+	cmd.Flags().StringVar(&c.flagTemplate, "template", "", cli.FormatStringFlagLabel("Instance template to create the instance from"))
+	cmd.Flags().IntVar(&c.flagCount, "count", 0, cli.FormatStringFlagLabel("Number of instances to create (\"{n}\" in the name is replaced by the instance index)"))
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		if len(args) > 1 {
@@ -88,6 +100,10 @@ with --project and the instance remote with --target-project (if different from
 		return c.global.cmpTopLevelResource("profile", toComplete)
 	})
 
+	_ = cmd.RegisterFlagCompletionFunc("template", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return c.global.cmpTopLevelResource("instance_template", toComplete)
+	})
+
 	return cmd
 }
 
@@ -98,7 +114,7 @@ func (c *cmdInit) run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if len(args) == 0 && !c.flagEmpty {
+	if len(args) == 0 && !c.flagEmpty && c.flagTemplate == "" {
 		_ = cmd.Usage()
 		return nil
 	}
@@ -150,8 +166,13 @@ func (c *cmdInit) create(conf *config.Config, args []string, launch bool) (lxd.I
 		}
 	}
 
-	if c.flagEmpty {
-		if len(args) > 1 {
+	if c.flagEmpty && c.flagTemplate != "" {
+		return nil, "", errors.New("--empty cannot be combined with --template")
+	}
+
+	// An instance template provides the image, so a single argument is the instance name.
+	if c.flagEmpty || c.flagTemplate != "" {
+		if c.flagEmpty && len(args) > 1 {
 			return nil, "", errors.New("--empty cannot be combined with an image name")
 		}
 
@@ -181,8 +202,18 @@ func (c *cmdInit) create(conf *config.Config, args []string, launch bool) (lxd.I
 		profiles = []string{}
 	}
 
+	if c.flagCount < 0 {
+		return nil, "", errors.New("--count must be a positive number")
+	}
+
 	if !c.global.flagQuiet {
-		if d.HasExtension("instance_create_start") && launch {
+		if c.flagCount > 0 {
+			if launch {
+				fmt.Printf("Launching %d instances\n", c.flagCount)
+			} else {
+				fmt.Printf("Creating %d instances\n", c.flagCount)
+			}
+		} else if d.HasExtension("instance_create_start") && launch {
 			if name == "" {
 				fmt.Print("Launching the instance\n")
 			} else {
@@ -268,9 +299,12 @@ func (c *cmdInit) create(conf *config.Config, args []string, launch bool) (lxd.I
 	}
 
 	// Decide whether we are creating a container or a virtual machine.
+	// When using an instance template, the template decides unless --vm is passed.
 	instanceDBType := api.InstanceTypeContainer
 	if c.flagVM {
 		instanceDBType = api.InstanceTypeVM
+	} else if c.flagTemplate != "" {
+		instanceDBType = ""
 	}
 
 	// Set the target if provided.
@@ -289,6 +323,8 @@ func (c *cmdInit) create(conf *config.Config, args []string, launch bool) (lxd.I
 		InstanceType: c.flagType,
 		Type:         instanceDBType,
 		Start:        launch,
+		Template:     c.flagTemplate,
+		Count:        c.flagCount,
 	}
 
 	req.Config = configMap
@@ -327,7 +363,12 @@ func (c *cmdInit) create(conf *config.Config, args []string, launch bool) (lxd.I
 	// that would be applied server-side.
 	if needProfileExpansion {
 		// If the list of profiles is empty then LXD would apply the default profile on the server side.
-		profileDevices, err = getProfileDevices(d, req.Profiles)
+		if req.Template != "" {
+			profileDevices, err = getInstanceTemplateDevices(d, req.Template, req.Profiles)
+		} else {
+			profileDevices, err = getProfileDevices(d, req.Profiles)
+		}
+
 		if err != nil {
 			return nil, "", err
 		}
@@ -342,7 +383,35 @@ func (c *cmdInit) create(conf *config.Config, args []string, launch bool) (lxd.I
 	req.Devices = devicesMap
 
 	var opInfo api.Operation
-	if !c.flagEmpty {
+	if c.flagTemplate != "" && image == "" {
+		// Create the instance from the image of the instance template.
+		op, err := d.CreateInstance(req)
+		if err != nil {
+			return nil, "", err
+		}
+
+		// Watch the background operation.
+		progress := cli.ProgressRenderer{
+			Format: "Retrieving image: %s",
+			Quiet:  c.global.flagQuiet,
+		}
+
+		_, err = op.AddHandler(progress.UpdateOp)
+		if err != nil {
+			progress.Done("")
+			return nil, "", err
+		}
+
+		err = cli.CancelableWait(op, &progress)
+		if err != nil {
+			progress.Done("")
+			return nil, "", err
+		}
+
+		progress.Done("")
+
+		opInfo = op.Get()
+	} else if !c.flagEmpty {
 		// Get the image server and image info.
 		iremote, image = guessImage(conf, d, remote, iremote, image)
 
@@ -414,6 +483,23 @@ func (c *cmdInit) create(conf *config.Config, args []string, launch bool) (lxd.I
 		opInfo = op.Get()
 	}
 
+	// Report the names of the instances created in bulk.
+	if c.flagCount > 0 {
+		instanceURLs, ok := opInfo.Metadata["instances"].([]any)
+		if ok && !c.global.flagQuiet {
+			for _, instanceURL := range instanceURLs {
+				u, err := url.Parse(fmt.Sprint(instanceURL))
+				if err != nil {
+					continue
+				}
+
+				fmt.Printf("Instance created: %s\n", path.Base(u.Path))
+			}
+		}
+
+		return d, "", nil
+	}
+
 	if name == "" {
 		if d.HasExtension("operation_metadata_entity_url") {
 			name, _, err = getEntityFromOperationMetadata(opInfo.Metadata)
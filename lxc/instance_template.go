@@ -0,0 +1,737 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v2"
+
+	"github.com/canonical/lxd/client"
+	"github.com/canonical/lxd/lxc/config"
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/lxd/shared/api"
+	cli "github.com/canonical/lxd/shared/cmd"
+	"github.com/canonical/lxd/shared/termios"
+)
+
+type cmdInstanceTemplate struct {
+	global *cmdGlobal
+}
+
+func (c *cmdInstanceTemplate) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("instance-template")
+	cmd.Short = "Manage instance templates"
+	cmd.Long = cli.FormatSection("Description", `Manage instance templates
+
+Instance templates capture everything needed to create an instance (image, instance type,
+profiles, configuration and devices) so it can be created with "lxc launch --template".`)
+
+	// List.
+	instanceTemplateListCmd := cmdInstanceTemplateList{global: c.global, instanceTemplate: c}
+	cmd.AddCommand(instanceTemplateListCmd.command())
+
+	// Show.
+	instanceTemplateShowCmd := cmdInstanceTemplateShow{global: c.global, instanceTemplate: c}
+	cmd.AddCommand(instanceTemplateShowCmd.command())
+
+	// Create.
+	instanceTemplateCreateCmd := cmdInstanceTemplateCreate{global: c.global, instanceTemplate: c}
+	cmd.AddCommand(instanceTemplateCreateCmd.command())
+
+	// Edit.
+	instanceTemplateEditCmd := cmdInstanceTemplateEdit{global: c.global, instanceTemplate: c}
+	cmd.AddCommand(instanceTemplateEditCmd.command())
+
+	// Delete.
+	instanceTemplateDeleteCmd := cmdInstanceTemplateDelete{global: c.global, instanceTemplate: c}
+	cmd.AddCommand(instanceTemplateDeleteCmd.command())
+
+	// Rename.
+	instanceTemplateRenameCmd := cmdInstanceTemplateRename{global: c.global, instanceTemplate: c}
+	cmd.AddCommand(instanceTemplateRenameCmd.command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// List.
+type cmdInstanceTemplateList struct {
+	global           *cmdGlobal
+	instanceTemplate *cmdInstanceTemplate
+
+	flagFormat      string
+	flagColumns     string
+	flagAllProjects bool
+}
+
+// columns returns the ordered column definitions for instance template list.
+func (c *cmdInstanceTemplateList) columns() []cli.ShorthandColumn[api.InstanceTemplate] {
+	return []cli.ShorthandColumn[api.InstanceTemplate]{
+		{Shorthand: 'n', Name: "NAME", Data: c.nameColumnData},
+		{Shorthand: 'd', Name: "DESCRIPTION", Data: c.descriptionColumnData},
+		{Shorthand: 't', Name: "TYPE", Data: c.typeColumnData},
+		{Shorthand: 'i', Name: "IMAGE", Data: c.imageColumnData},
+	}
+}
+
+func (c *cmdInstanceTemplateList) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("list", "[<remote>:]")
+	cmd.Aliases = []string{"ls"}
+	cmd.Short = "List available instance templates"
+	cmd.Long = cli.FormatSection("Description", cmd.Short)
+
+	cmd.RunE = c.run
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", cli.FormatStringFlagLabel("Format (csv|json|table|yaml|compact)"))
+	cmd.Flags().StringVarP(&c.flagColumns, "columns", "c", cli.DefaultColumnString(c.columns()), cli.FormatStringFlagLabel("Columns"))
+	cmd.Flags().BoolVar(&c.flagAllProjects, "all-projects", false, "Display instance templates from all projects")
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpRemotes(toComplete, ":", true, instanceServerRemoteCompletionFilters(*c.global.conf)...)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdInstanceTemplateList) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 0, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	remote := ""
+	if len(args) > 0 {
+		remote = args[0]
+	}
+
+	resources, err := c.global.ParseServers(remote)
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	// List the instance templates.
+	if resource.name != "" {
+		return errors.New("Filtering is not supported yet")
+	}
+
+	var instanceTemplates []api.InstanceTemplate
+	if c.flagAllProjects {
+		instanceTemplates, err = resource.server.GetInstanceTemplatesAllProjects()
+		if err != nil {
+			return err
+		}
+	} else {
+		instanceTemplates, err = resource.server.GetInstanceTemplates()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Parse column flags.
+	cols := c.columns()
+	defaultColumns := cli.DefaultColumnString(cols)
+
+	// Add project column so shorthand 'e' is always valid.
+	cols = append(cols, cli.ShorthandColumn[api.InstanceTemplate]{Shorthand: 'e', Name: "PROJECT", Data: c.projectColumnData})
+
+	if c.flagAllProjects {
+		if c.flagColumns == defaultColumns {
+			c.flagColumns = "e" + defaultColumns
+		}
+	}
+
+	columns, err := cli.ParseShorthandColumns(c.flagColumns, cols)
+	if err != nil {
+		return err
+	}
+
+	data := cli.ColumnData(columns, instanceTemplates)
+	sort.Sort(cli.SortColumnsNaturally(data))
+	header := cli.ColumnHeaders(columns)
+
+	return cli.RenderTable(c.flagFormat, header, data, instanceTemplates)
+}
+
+func (c *cmdInstanceTemplateList) projectColumnData(instanceTemplate api.InstanceTemplate) string {
+	return instanceTemplate.Project
+}
+
+func (c *cmdInstanceTemplateList) nameColumnData(instanceTemplate api.InstanceTemplate) string {
+	return instanceTemplate.Name
+}
+
+func (c *cmdInstanceTemplateList) descriptionColumnData(instanceTemplate api.InstanceTemplate) string {
+	return instanceTemplate.Description
+}
+
+func (c *cmdInstanceTemplateList) typeColumnData(instanceTemplate api.InstanceTemplate) string {
+	return string(instanceTemplate.Type)
+}
+
+func (c *cmdInstanceTemplateList) imageColumnData(instanceTemplate api.InstanceTemplate) string {
+	image := instanceTemplate.Source.Alias
+	if image == "" {
+		image = instanceTemplate.Source.Fingerprint
+	}
+
+	if image != "" && instanceTemplate.Source.Server != "" {
+		return instanceTemplate.Source.Server + " (" + image + ")"
+	}
+
+	return image
+}
+
+// Show.
+type cmdInstanceTemplateShow struct {
+	global           *cmdGlobal
+	instanceTemplate *cmdInstanceTemplate
+}
+
+func (c *cmdInstanceTemplateShow) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("show", "[<remote>:]<instance_template>")
+	cmd.Short = "Show instance template configurations"
+	cmd.Long = cli.FormatSection("Description", cmd.Short)
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpTopLevelResource("instance_template", toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdInstanceTemplateShow) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New("Missing instance template name")
+	}
+
+	// Show the instance template.
+	instanceTemplate, _, err := resource.server.GetInstanceTemplate(resource.name)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(&instanceTemplate)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s", data)
+
+	return nil
+}
+
+// Create.
+type cmdInstanceTemplateCreate struct {
+	global           *cmdGlobal
+	instanceTemplate *cmdInstanceTemplate
+
+	flagConfig      []string
+	flagDevice      []string
+	flagProfile     []string
+	flagNoProfiles  bool
+	flagStorage     string
+	flagType        string
+	flagVM          bool
+	flagDescription string
+}
+
+func (c *cmdInstanceTemplateCreate) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("create", "[<remote>:]<instance_template> [[<remote>:]<image>]")
+	cmd.Short = "Create instance templates"
+	cmd.Long = cli.FormatSection("Description", cmd.Short)
+	cmd.Example = cli.FormatSection("", `lxc instance-template create web ubuntu:24.04 --vm -t c2-m4 -p default -p webservers -d root,size=20GiB
+    Create the "web" instance template for virtual machines with 2 vCPUs, 4GiB of RAM and a 20GiB root disk
+
+lxc instance-template create web ubuntu:24.04 -c cloud-init.user-data="$(cat cloud-init.yaml)" -c placement.group=web
+    Create the "web" instance template with cloud-init user data and a placement group
+
+lxc instance-template create web < template.yaml
+    Create the "web" instance template with the configuration from template.yaml`)
+
+	cmd.Flags().StringArrayVarP(&c.flagConfig, "config", "c", nil, cli.FormatStringFlagLabel("Config key/value to apply to the new instances"))
+	cmd.Flags().StringArrayVarP(&c.flagDevice, "device", "d", nil, cli.FormatStringFlagLabel("New key/value to apply to a specific device"))
+	cmd.Flags().StringArrayVarP(&c.flagProfile, "profile", "p", nil, cli.FormatStringFlagLabel("Profile to apply to the new instances"))
+	cmd.Flags().BoolVar(&c.flagNoProfiles, "no-profiles", false, "Create the instances with no profiles applied")
+	cmd.Flags().StringVarP(&c.flagStorage, "storage", "s", "", cli.FormatStringFlagLabel("Storage pool name"))
+	cmd.Flags().StringVarP(&c.flagType, "type", "t", "", cli.FormatStringFlagLabel("Instance type"))
+	cmd.Flags().BoolVar(&c.flagVM, "vm", false, "Create virtual machines")
+	cmd.Flags().StringVar(&c.flagDescription, "description", "", cli.FormatStringFlagLabel("Description of the instance template"))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 1 {
+			return c.global.cmpImages(toComplete, false)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	_ = cmd.RegisterFlagCompletionFunc("profile", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return c.global.cmpTopLevelResource("profile", toComplete)
+	})
+
+	return cmd
+}
+
+func (c *cmdInstanceTemplateCreate) run(cmd *cobra.Command, args []string) error {
+	var stdinData api.InstanceTemplatePut
+
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 2)
+	if exit {
+		return err
+	}
+
+	// If stdin isn't a terminal, read yaml from it.
+	if !termios.IsTerminal(getStdinFd()) {
+		contents, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		err = yaml.UnmarshalStrict(contents, &stdinData)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New("Missing instance template name")
+	}
+
+	instanceTemplate := api.InstanceTemplatesPost{
+		Name:                resource.name,
+		InstanceTemplatePut: stdinData,
+	}
+
+	if c.flagDescription != "" {
+		instanceTemplate.Description = c.flagDescription
+	}
+
+	if c.flagType != "" {
+		instanceTemplate.InstanceType = c.flagType
+	}
+
+	if c.flagVM {
+		instanceTemplate.Type = api.InstanceTypeVM
+	}
+
+	if c.flagProfile != nil {
+		instanceTemplate.Profiles = c.flagProfile
+	} else if c.flagNoProfiles {
+		instanceTemplate.Profiles = []string{}
+	}
+
+	// Set the image source.
+	if len(args) > 1 {
+		source, err := c.imageSource(c.global.conf, resource.server, resource.remote, args[1])
+		if err != nil {
+			return err
+		}
+
+		instanceTemplate.Source = *source
+	}
+
+	if instanceTemplate.Config == nil {
+		instanceTemplate.Config = map[string]string{}
+	}
+
+	for _, entry := range c.flagConfig {
+		key, value, found := strings.Cut(entry, "=")
+		if !found {
+			return fmt.Errorf("Bad key=value pair: %q", entry)
+		}
+
+		instanceTemplate.Config[key] = value
+	}
+
+	if instanceTemplate.Devices == nil {
+		instanceTemplate.Devices = map[string]map[string]string{}
+	}
+
+	if c.flagStorage != "" {
+		instanceTemplate.Devices["root"] = map[string]string{
+			"type": "disk",
+			"path": "/",
+			"pool": c.flagStorage,
+		}
+	}
+
+	// Apply device overrides, using the devices of the profiles for devices not defined in the template.
+	if len(c.flagDevice) > 0 {
+		deviceOverrides, err := parseDeviceOverrides(c.flagDevice)
+		if err != nil {
+			return err
+		}
+
+		profileDevices, err := getProfileDevices(resource.server, instanceTemplate.Profiles)
+		if err != nil {
+			return err
+		}
+
+		instanceTemplate.Devices, err = shared.ApplyDeviceOverrides(instanceTemplate.Devices, profileDevices, deviceOverrides)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = resource.server.CreateInstanceTemplate(instanceTemplate)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf("Instance template %s created\n", resource.name)
+	}
+
+	return nil
+}
+
+// imageSource returns the instance source for the given image reference.
+// Images on another remote are referenced by the address of that remote so the server can fetch them.
+func (c *cmdInstanceTemplateCreate) imageSource(conf *config.Config, d lxd.InstanceServer, remote string, imageRef string) (*api.InstanceSource, error) {
+	iremote, image, err := conf.ParseRemote(imageRef)
+	if err != nil {
+		return nil, err
+	}
+
+	iremote, image = guessImage(conf, d, remote, iremote, image)
+
+	source := &api.InstanceSource{Type: api.SourceTypeImage}
+
+	imageServer := lxd.ImageServer(d)
+	if iremote != remote {
+		r := conf.Remotes[iremote]
+		source.Server = r.Addr
+		source.Protocol = r.Protocol
+
+		if r.Protocol == "simplestreams" {
+			source.Alias = image
+			return source, nil
+		}
+
+		imageServer, err = conf.GetImageServer(iremote)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Reference the image by alias if it is one, otherwise by fingerprint.
+	_, _, err = imageServer.GetImageAlias(image)
+	if err == nil {
+		source.Alias = image
+		return source, nil
+	}
+
+	imageInfo, _, err := imageServer.GetImage(image)
+	if err != nil {
+		return nil, fmt.Errorf("Failed finding image %q on remote %q", image, iremote)
+	}
+
+	source.Fingerprint = imageInfo.Fingerprint
+
+	return source, nil
+}
+
+// Edit.
+type cmdInstanceTemplateEdit struct {
+	global           *cmdGlobal
+	instanceTemplate *cmdInstanceTemplate
+}
+
+func (c *cmdInstanceTemplateEdit) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("edit", "[<remote>:]<instance_template>")
+	cmd.Short = "Edit instance template configurations as YAML"
+	cmd.Long = cli.FormatSection("Description", cmd.Short)
+
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpTopLevelResource("instance_template", toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdInstanceTemplateEdit) helpTemplate() string {
+	return `### This is a YAML representation of the instance template.
+### Any line starting with a '# will be ignored.
+###
+### An example instance template structure is shown below.
+### The name and project fields cannot be modified.
+###
+### name: web
+### project: default
+### description: Web server
+### type: virtual-machine
+### instance_type: c2-m4
+### source:
+###   type: image
+###   alias: ubuntu/24.04
+###   server: https://cloud-images.ubuntu.com/releases
+###   protocol: simplestreams
+### profiles:
+### - default
+### config:
+###   cloud-init.user-data: |
+###     #cloud-config
+###     packages: [nginx]
+###   placement.group: web
+### devices:
+###   root:
+###     path: /
+###     pool: default
+###     size: 20GiB
+###     type: disk
+`
+}
+
+func (c *cmdInstanceTemplateEdit) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New("Missing instance template name")
+	}
+
+	// If stdin isn't a terminal, read text from it
+	if !termios.IsTerminal(getStdinFd()) {
+		contents, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		// Allow output of `lxc instance-template show` command to be passed in here, but only take the contents
+		// of the [api.InstanceTemplatePut] fields when updating the instance template. The other fields are silently discarded.
+		newdata := api.InstanceTemplate{}
+		err = yaml.UnmarshalStrict(contents, &newdata)
+		if err != nil {
+			return err
+		}
+
+		return resource.server.UpdateInstanceTemplate(resource.name, newdata.Writable(), "")
+	}
+
+	// Get the current config.
+	instanceTemplate, etag, err := resource.server.GetInstanceTemplate(resource.name)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(&instanceTemplate)
+	if err != nil {
+		return err
+	}
+
+	// Spawn the editor.
+	content, err := shared.TextEditor("", []byte(c.helpTemplate()+"\n\n"+string(data)))
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Parse the text received from the editor.
+		newdata := api.InstanceTemplate{} // We show the full instance template info, but only send the writable fields.
+		err = yaml.UnmarshalStrict(content, &newdata)
+		if err == nil {
+			err = resource.server.UpdateInstanceTemplate(resource.name, newdata.Writable(), etag)
+		}
+
+		// Respawn the editor.
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Config parsing error: %s\n", err)
+			fmt.Println("Press enter to open the editor again or ctrl+c to abort change")
+
+			_, err := os.Stdin.Read(make([]byte, 1))
+			if err != nil {
+				return err
+			}
+
+			content, err = shared.TextEditor("", content)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		break
+	}
+
+	return nil
+}
+
+// Delete.
+type cmdInstanceTemplateDelete struct {
+	global           *cmdGlobal
+	instanceTemplate *cmdInstanceTemplate
+}
+
+func (c *cmdInstanceTemplateDelete) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("delete", "[<remote>:]<instance_template>")
+	cmd.Aliases = []string{"rm"}
+	cmd.Short = "Delete instance templates"
+	cmd.Long = cli.FormatSection("Description", cmd.Short)
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpTopLevelResource("instance_template", toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdInstanceTemplateDelete) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New("Missing instance template name")
+	}
+
+	// Delete the instance template.
+	err = resource.server.DeleteInstanceTemplate(resource.name)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf("Instance template %s deleted\n", resource.name)
+	}
+
+	return nil
+}
+
+// Rename.
+type cmdInstanceTemplateRename struct {
+	global           *cmdGlobal
+	instanceTemplate *cmdInstanceTemplate
+}
+
+func (c *cmdInstanceTemplateRename) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("rename", "[<remote>:]<old_name> <new_name>")
+	cmd.Aliases = []string{"mv"}
+	cmd.Short = "Rename instance templates"
+	cmd.Long = cli.FormatSection("Description", cmd.Short)
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpTopLevelResource("instance_template", toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdInstanceTemplateRename) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New("Missing instance template name")
+	}
+
+	// Rename the instance template.
+	err = resource.server.RenameInstanceTemplate(resource.name, api.InstanceTemplatePost{Name: args[1]})
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf("Instance template %s renamed to %s\n", resource.name, args[1])
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -34,7 +35,16 @@ lxc launch ubuntu:24.04 v1 --vm -c limits.cpu=4 -c limits.memory=4GiB
     Create and start a virtual machine with 4 vCPUs and 4GiB of RAM
 
 lxc launch ubuntu:24.04 v1 --vm -c limits.cpu=2 -c limits.memory=8GiB -d root,size=32GiB
-    Create and start a virtual machine with 2 vCPUs, 8GiB of RAM and a root disk of 32GiB`)
+    Create and start a virtual machine with 2 vCPUs, 8GiB of RAM and a root disk of 32GiB
+
+lxc launch --template=web w1
+    Create and start an instance from the "web" instance template
+
+lxc launch ubuntu:24.04 w2 --template=web -c limits.cpu=4
+    Create and start an instance from the "web" instance template, overriding its image and CPU limit
+
+lxc launch ubuntu:24.04 web-{n} --count 3
+    Create and start 3 containers named web-1, web-2 and web-3`)
 
 	cmd.RunE = c.run
 
@@ -60,17 +70,32 @@ func (c *cmdLaunch) run(cmd *cobra.Command, args []string) error {
 	conf := c.global.conf
 
 	// Quick checks.
-	exit, err := c.global.CheckArgs(cmd, args, 1, 2)
+	exit, err := c.global.CheckArgs(cmd, args, 0, 2)
 	if exit {
 		return err
 	}
 
+	// The image can only be omitted when using an instance template.
+	if len(args) == 0 && c.init.flagTemplate == "" {
+		_ = cmd.Help()
+		return nil
+	}
+
+	if c.flagConsole != "" && c.init.flagCount > 0 {
+		return errors.New("--console cannot be combined with --count")
+	}
+
 	// Call the matching code from init
 	d, name, err := c.init.create(conf, args, true)
 	if err != nil {
 		return err
 	}
 
+	// Instances created in bulk are started by the server.
+	if c.init.flagCount > 0 {
+		return nil
+	}
+
 	// Start the instance if it wasn't started by the server
 	if !d.HasExtension("instance_create_start") {
 		// Get the remote
@@ -298,6 +298,9 @@ For help with any of those, simply call them with --help.`)
 	placementGroupCmd := cmdPlacementGroup{global: &globalCmd}
 	app.AddCommand(placementGroupCmd.command())
 
+	instanceTemplateCmd := cmdInstanceTemplate{global: &globalCmd}
+	app.AddCommand(instanceTemplateCmd.command())
+
 	// Get help command
 	app.InitDefaultHelpCmd()
 	var help *cobra.Command
@@ -1005,6 +1005,15 @@ func (c *cmdNetworkInfo) run(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  Chassis: %s\n", state.OVN.Chassis)
 	}
 
+	// WireGuard information.
+	if state.WireGuard != nil {
+		fmt.Println("")
+		fmt.Println("WireGuard:")
+		fmt.Printf("  Public key: %s\n", state.WireGuard.PublicKey)
+		fmt.Printf("  Listen port: %d\n", state.WireGuard.ListenPort)
+		fmt.Printf("  Peers: %s\n", strings.Join(state.WireGuard.Peers, ", "))
+	}
+
 	return nil
 }
 
@@ -19,6 +19,7 @@ import (
 	"github.com/canonical/lxd/shared/api"
 	cli "github.com/canonical/lxd/shared/cmd"
 	"github.com/canonical/lxd/shared/termios"
+	"github.com/canonical/lxd/shared/units"
 )
 
 type cmdNetworkACL struct {
@@ -39,6 +40,10 @@ func (c *cmdNetworkACL) command() *cobra.Command {
 	networkACLShowCmd := cmdNetworkACLShow{global: c.global, networkACL: c}
 	cmd.AddCommand(networkACLShowCmd.command())
 
+	// Info.
+	networkACLInfoCmd := cmdNetworkACLInfo{global: c.global, networkACL: c}
+	cmd.AddCommand(networkACLInfoCmd.command())
+
 	// Show log.
 	networkACLShowLogCmd := cmdNetworkACLShowLog{global: c.global, networkACL: c}
 	cmd.AddCommand(networkACLShowLogCmd.command())
@@ -264,6 +269,86 @@ func (c *cmdNetworkACLShow) run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// Info.
+type cmdNetworkACLInfo struct {
+	global     *cmdGlobal
+	networkACL *cmdNetworkACL
+}
+
+func (c *cmdNetworkACLInfo) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("info", "[<remote>:]<ACL>")
+	cmd.Short = "Get runtime information on network ACLs"
+	cmd.Long = cli.FormatSection("Description", `Get runtime information on network ACLs
+
+The packet and byte counters of each rule are aggregated across all cluster members.
+Counters are only available for rules applied by the host firewall (bridge networks and instance NICs).`)
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpTopLevelResource("network_acl", toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkACLInfo) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+	if resource.name == "" {
+		return errors.New("Missing network ACL name")
+	}
+
+	netACL, _, err := resource.server.GetNetworkACL(resource.name)
+	if err != nil {
+		return err
+	}
+
+	state, err := resource.server.GetNetworkACLState(resource.name)
+	if err != nil {
+		return err
+	}
+
+	printRules := func(header string, rules []api.NetworkACLRule, ruleStates []api.NetworkACLRuleState) {
+		fmt.Println("")
+		fmt.Println(header)
+
+		for i, rule := range rules {
+			if i >= len(ruleStates) {
+				break
+			}
+
+			fmt.Printf("  Rule %d (%s, %s): %d packets, %s\n", i, rule.Action, rule.State, ruleStates[i].Packets, units.GetByteSizeString(ruleStates[i].Bytes, 2))
+		}
+	}
+
+	fmt.Printf("Name: %s\n", netACL.Name)
+	printRules("Ingress rules:", netACL.Ingress, state.Ingress)
+	printRules("Egress rules:", netACL.Egress, state.Egress)
+
+	if state.Incomplete {
+		fmt.Println("")
+		fmt.Println("The ACL is used by OVN networks, whose rule hits aren't counted.")
+	}
+
+	return nil
+}
+
 // Show log.
 type cmdNetworkACLShowLog struct {
 	global     *cmdGlobal
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"crypto/x509"
 	"encoding/pem"
@@ -10,12 +11,15 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
 	"slices"
 	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
 	"golang.org/x/term"
 
 	"github.com/canonical/lxd/client"
@@ -23,6 +27,8 @@ import (
 	"github.com/canonical/lxd/shared"
 	"github.com/canonical/lxd/shared/api"
 	cli "github.com/canonical/lxd/shared/cmd"
+	"github.com/canonical/lxd/shared/sshsig"
+	"github.com/canonical/lxd/shared/termios"
 )
 
 type cmdRemote struct {
@@ -82,6 +88,7 @@ type cmdRemoteAdd struct {
 	flagProtocol   string
 	flagAuthType   string
 	flagProject    string
+	flagSSHKey     string
 }
 
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
@@ -102,9 +109,10 @@ Basic authentication can be used when combined with the "simplestreams" protocol
 	cmd.Flags().StringVar(&c.flagPassword, "password", "", cli.FormatStringFlagLabel("Remote admin password"))
 	cmd.Flags().StringVar(&c.flagToken, "token", "", cli.FormatStringFlagLabel("Remote trust token"))
 	cmd.Flags().StringVar(&c.flagProtocol, "protocol", "", cli.FormatStringFlagLabel("Server protocol (lxd or simplestreams"))
-	cmd.Flags().StringVar(&c.flagAuthType, "auth-type", "", cli.FormatStringFlagLabel("Server authentication type (tls or oidc"))
+	cmd.Flags().StringVar(&c.flagAuthType, "auth-type", "", cli.FormatStringFlagLabel("Server authentication type (tls, oidc, ldap or ssh"))
 	cmd.Flags().BoolVar(&c.flagPublic, "public", false, "Public image server")
 	cmd.Flags().StringVar(&c.flagProject, "project", "", cli.FormatStringFlagLabel("Project to use for the remote"))
+	cmd.Flags().StringVar(&c.flagSSHKey, "ssh-key", "", cli.FormatStringFlagLabel("SSH private key file to log in with (its certificate is used if there is one)"))
 
 	return cmd
 }
@@ -335,6 +343,21 @@ func (c *cmdRemoteAdd) run(cmd *cobra.Command, args []string) error {
 		return errors.New("Trust token cannot be used with OIDC authentication")
 	}
 
+	// Trust token cannot be used when auth type is set to LDAP.
+	if c.flagToken != "" && c.flagAuthType == api.AuthenticationMethodLDAP {
+		return errors.New("Trust token cannot be used with LDAP authentication")
+	}
+
+	// Trust token cannot be used when auth type is set to SSH.
+	if c.flagToken != "" && c.flagAuthType == api.AuthenticationMethodSSH {
+		return errors.New("Trust token cannot be used with SSH authentication")
+	}
+
+	// SSH keys can only be used with SSH authentication.
+	if c.flagSSHKey != "" && c.flagAuthType != api.AuthenticationMethodSSH {
+		return errors.New("SSH keys can only be used with SSH authentication")
+	}
+
 	// Trust token cannot be used for public remotes.
 	if c.flagToken != "" && c.flagPublic {
 		return errors.New("Trust token cannot be used for public remotes")
@@ -698,6 +721,16 @@ func (c *cmdRemoteAdd) run(cmd *cobra.Command, args []string) error {
 			if err != nil {
 				return err
 			}
+		} else if c.flagAuthType == api.AuthenticationMethodLDAP {
+			d, err = c.loginLDAP(conf, server, d.(lxd.InstanceServer))
+			if err != nil {
+				return err
+			}
+		} else if c.flagAuthType == api.AuthenticationMethodSSH {
+			d, err = c.loginSSH(conf, server, d.(lxd.InstanceServer))
+			if err != nil {
+				return err
+			}
 		} else {
 			d.(lxd.InstanceServer).RequireAuthenticated(true)
 		}
@@ -730,6 +763,272 @@ func (c *cmdRemoteAdd) run(cmd *cobra.Command, args []string) error {
 	return conf.SaveConfig(c.global.confPath)
 }
 
+// loginLDAP prompts for LDAP credentials, logs in and stores the session token of the remote.
+// It returns a new client using the session token.
+func (c *cmdRemoteAdd) loginLDAP(conf *config.Config, server string, d lxd.InstanceServer) (lxd.InstanceServer, error) {
+	username, err := c.global.asker.AskString(fmt.Sprintf("LDAP username for %s: ", server), "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the password from the same input as the username if this isn't a terminal.
+	var password string
+	if termios.IsTerminal(getStdinFd()) {
+		password = c.global.asker.AskPasswordOnce(fmt.Sprintf("LDAP password for %s: ", server))
+	} else {
+		password, err = c.global.asker.AskString(fmt.Sprintf("LDAP password for %s: ", server), "", nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	session, err := d.LoginLDAP(api.AuthLDAPLoginPost{Username: username, Password: password})
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(conf.ConfigPath("ldaptokens"), 0750)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.WriteFile(conf.LDAPTokenPath(server), []byte(session.Token), 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	// Re-setup the client with the session token.
+	return conf.GetInstanceServer(server)
+}
+
+// loginSSH signs a login challenge with the selected SSH key of the user, and stores the session token of the remote.
+// The key is signed by the SSH agent if it holds it, or read from the key file otherwise.
+func (c *cmdRemoteAdd) loginSSH(conf *config.Config, server string, d lxd.InstanceServer) (lxd.InstanceServer, error) {
+	defaultUsername := os.Getenv("USER")
+	username, err := c.global.asker.AskString(fmt.Sprintf("SSH username for %s [default=%s]: ", server, defaultUsername), defaultUsername, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, cleanup, err := sshSigner(c.flagSSHKey)
+	if err != nil {
+		return nil, err
+	}
+
+	defer cleanup()
+
+	challenge, err := d.GetSSHChallenge(username)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := sshsig.Sign(signer, api.AuthSSHSignatureNamespace, []byte(challenge.Challenge))
+	if err != nil {
+		return nil, fmt.Errorf("Failed signing SSH challenge: %w", err)
+	}
+
+	session, err := d.LoginSSH(api.AuthSSHLoginPost{
+		Username:  username,
+		Challenge: challenge.Challenge,
+		PublicKey: string(ssh.MarshalAuthorizedKey(signer.PublicKey())),
+		Signature: signature,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(conf.ConfigPath("sshtokens"), 0750)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.WriteFile(conf.SSHTokenPath(server), []byte(session.Token), 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	// Re-setup the client with the session token.
+	return conf.GetInstanceServer(server)
+}
+
+// sshSigner returns the SSH key to log in with, as a certificate if there is one for the key.
+// If a key file is given, the key is taken from it and the certificate from the "-cert.pub" file next to it.
+// Otherwise, the key must be the only key held by the SSH agent, or the first of the default key files if no SSH
+// agent is running. Keys are signed by the SSH agent if it holds them, so keys protected by a passphrase must be added
+// to an SSH agent.
+func sshSigner(path string) (signer ssh.Signer, cleanup func(), err error) {
+	cleanup = func() {}
+
+	var agentSigners []ssh.Signer
+	socket := os.Getenv("SSH_AUTH_SOCK")
+	if socket != "" {
+		conn, err := net.Dial("unix", socket)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Failed connecting to SSH agent: %w", err)
+		}
+
+		agentSigners, err = agent.NewClient(conn).Signers()
+		if err != nil {
+			_ = conn.Close()
+			return nil, nil, fmt.Errorf("Failed getting keys from SSH agent: %w", err)
+		}
+
+		cleanup = func() { _ = conn.Close() }
+	}
+
+	signer, err = sshSelectSigner(path, agentSigners)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	return signer, cleanup, nil
+}
+
+// sshSelectSigner returns the signer for the key in the given file, or the only key of the given agent signers if no
+// file is given.
+func sshSelectSigner(path string, agentSigners []ssh.Signer) (ssh.Signer, error) {
+	if path == "" && len(agentSigners) > 0 {
+		var selected ssh.Signer
+		for _, signer := range agentSigners {
+			if selected != nil && !bytes.Equal(sshBaseKey(selected.PublicKey()).Marshal(), sshBaseKey(signer.PublicKey()).Marshal()) {
+				return nil, errors.New("The SSH agent holds multiple keys, select one with --ssh-key")
+			}
+
+			// Prefer the certificate of the key as it doesn't need to be added to the identity of the user.
+			_, isCert := signer.PublicKey().(*ssh.Certificate)
+			if selected == nil || isCert {
+				selected = signer
+			}
+		}
+
+		return selected, nil
+	}
+
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range []string{"id_ed25519", "id_ecdsa", "id_rsa"} {
+			if shared.PathExists(filepath.Join(home, ".ssh", name)) {
+				path = filepath.Join(home, ".ssh", name)
+				break
+			}
+		}
+
+		if path == "" {
+			return nil, errors.New("No SSH keys found")
+		}
+	}
+
+	// Allow the public key or certificate file to be given instead of the private key file.
+	path = strings.TrimSuffix(strings.TrimSuffix(path, ".pub"), "-cert")
+
+	var key ssh.PublicKey
+	var privateKey ssh.Signer
+	content, err := os.ReadFile(path + ".pub")
+	if err == nil {
+		key, _, _, _, err = ssh.ParseAuthorizedKey(content)
+		if err != nil {
+			return nil, fmt.Errorf("Failed parsing SSH public key %q: %w", path+".pub", err)
+		}
+	} else {
+		privateKey, err = sshParsePrivateKey(path)
+		if err != nil {
+			return nil, err
+		}
+
+		key = privateKey.PublicKey()
+	}
+
+	var cert *ssh.Certificate
+	content, err = os.ReadFile(path + "-cert.pub")
+	if err == nil {
+		certKey, _, _, _, err := ssh.ParseAuthorizedKey(content)
+		if err != nil {
+			return nil, fmt.Errorf("Failed parsing SSH certificate %q: %w", path+"-cert.pub", err)
+		}
+
+		var ok bool
+		cert, ok = certKey.(*ssh.Certificate)
+		if !ok || !bytes.Equal(cert.Key.Marshal(), key.Marshal()) {
+			return nil, fmt.Errorf("%q is not a certificate of the SSH key %q", path+"-cert.pub", path)
+		}
+	}
+
+	// Use the SSH agent if it holds the key.
+	var signer ssh.Signer
+	for _, agentSigner := range agentSigners {
+		if !bytes.Equal(sshBaseKey(agentSigner.PublicKey()).Marshal(), key.Marshal()) {
+			continue
+		}
+
+		signer = agentSigner
+		if cert == nil || bytes.Equal(agentSigner.PublicKey().Marshal(), cert.Marshal()) {
+			break
+		}
+	}
+
+	if signer == nil {
+		if privateKey == nil {
+			privateKey, err = sshParsePrivateKey(path)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		signer = privateKey
+	}
+
+	if cert == nil {
+		return signer, nil
+	}
+
+	_, isCert := signer.PublicKey().(*ssh.Certificate)
+	if isCert {
+		return signer, nil
+	}
+
+	certSigner, err := ssh.NewCertSigner(cert, signer)
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading SSH certificate %q: %w", path+"-cert.pub", err)
+	}
+
+	return certSigner, nil
+}
+
+// sshParsePrivateKey reads the unencrypted SSH private key in the given file.
+func sshParsePrivateKey(path string) (ssh.Signer, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading SSH key: %w", err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(content)
+	if err != nil {
+		var passphraseErr *ssh.PassphraseMissingError
+		if errors.As(err, &passphraseErr) {
+			return nil, fmt.Errorf("SSH key %q is protected by a passphrase, add it to an SSH agent", path)
+		}
+
+		return nil, fmt.Errorf("Failed parsing SSH key %q: %w", path, err)
+	}
+
+	return signer, nil
+}
+
+// sshBaseKey returns the key of a certificate, or the key itself if it isn't a certificate.
+func sshBaseKey(key ssh.PublicKey) ssh.PublicKey {
+	cert, ok := key.(*ssh.Certificate)
+	if ok {
+		return cert.Key
+	}
+
+	return key
+}
+
 // Get default.
 type cmdRemoteGetDefault struct {
 	global *cmdGlobal
@@ -1036,6 +1335,8 @@ func (c *cmdRemoteRemove) run(cmd *cobra.Command, args []string) error {
 	_ = os.Remove(conf.ServerCertPath(args[0]))
 	_ = os.Remove(conf.CookiesPath(args[0]))
 	_ = os.Remove(conf.OIDCTokenPath(args[0]))
+	_ = os.Remove(conf.LDAPTokenPath(args[0]))
+	_ = os.Remove(conf.SSHTokenPath(args[0]))
 
 	return conf.SaveConfig(c.global.confPath)
 }
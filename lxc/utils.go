@@ -70,6 +70,28 @@ func getProfileDevices(destRemote lxd.InstanceServer, serverSideProfiles []strin
 	return profileDevices, nil
 }
 
+// getInstanceTemplateDevices returns the devices an instance created from the instance template gets from the
+// template and its profiles. The server side profiles override the profiles of the template if not nil.
+func getInstanceTemplateDevices(destRemote lxd.InstanceServer, templateName string, serverSideProfiles []string) (map[string]map[string]string, error) {
+	template, _, err := destRemote.GetInstanceTemplate(templateName)
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading instance template %q: %w", templateName, err)
+	}
+
+	if serverSideProfiles == nil && len(template.Profiles) > 0 {
+		serverSideProfiles = template.Profiles
+	}
+
+	devices, err := getProfileDevices(destRemote, serverSideProfiles)
+	if err != nil {
+		return nil, err
+	}
+
+	maps.Copy(devices, template.Devices)
+
+	return devices, nil
+}
+
 // Add a device to an instance.
 func instanceDeviceAdd(client lxd.InstanceServer, name string, devName string, dev map[string]string) error {
 	// Get the instance entry
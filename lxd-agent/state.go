@@ -7,6 +7,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -114,9 +115,38 @@ func memoryState() api.InstanceStateMemory {
 		memory.UsagePeak = valueInt
 	}
 
+	memory.Hotplug = memoryHotplugState()
+
 	return memory
 }
 
+// memoryHotplugState reports whether the guest kernel drives the virtio-mem device used for memory hotplug.
+// Returns nil if the VM has no such device.
+func memoryHotplugState() *api.InstanceStateMemoryHotplug {
+	devices, err := os.ReadDir("/sys/bus/virtio/devices")
+	if err != nil {
+		return nil
+	}
+
+	for _, device := range devices {
+		devicePath := filepath.Join("/sys/bus/virtio/devices", device.Name())
+
+		// Virtio device ID 24 is virtio-mem.
+		deviceID, err := os.ReadFile(filepath.Join(devicePath, "device"))
+		if err != nil || strings.TrimSpace(string(deviceID)) != "0x0018" {
+			continue
+		}
+
+		if shared.PathExists(filepath.Join(devicePath, "driver")) {
+			return &api.InstanceStateMemoryHotplug{GuestSupport: "supported"}
+		}
+
+		return &api.InstanceStateMemoryHotplug{GuestSupport: "unsupported"}
+	}
+
+	return nil
+}
+
 func networkState() map[string]api.InstanceStateNetwork {
 	result := map[string]api.InstanceStateNetwork{}
 
@@ -16,7 +16,9 @@ import (
 
 	"github.com/canonical/lxd/client"
 	"github.com/canonical/lxd/lxd/auth"
+	"github.com/canonical/lxd/lxd/auth/ldap"
 	"github.com/canonical/lxd/lxd/auth/oidc"
+	"github.com/canonical/lxd/lxd/auth/ssh"
 	backupConfig "github.com/canonical/lxd/lxd/backup/config"
 	"github.com/canonical/lxd/lxd/cluster"
 	clusterConfig "github.com/canonical/lxd/lxd/cluster/config"
@@ -52,6 +54,8 @@ var api10Cmd = APIEndpoint{
 var api10 = []APIEndpoint{
 	api10Cmd,
 	api10ResourcesCmd,
+	auditCmd,
+	auditVerifyCmd,
 	certificateCmd,
 	certificatesCmd,
 	clusterCmd,
@@ -103,6 +107,7 @@ var api10 = []APIEndpoint{
 	networkACLCmd,
 	networkACLsCmd,
 	networkACLLogCmd,
+	networkACLStateCmd,
 	networkAllocationsCmd,
 	networkForwardCmd,
 	networkForwardsCmd,
@@ -151,8 +156,21 @@ var api10 = []APIEndpoint{
 	bearerIdentitiesCmd,
 	bearerIdentityCmd,
 	bearerIdentityTokenCmd,
+	ldapIdentitiesCmd,
+	ldapIdentityCmd,
+	ldapLoginCmd,
+	ldapLogoutCmd,
+	sshIdentitiesCmd,
+	sshIdentityCmd,
+	sshChallengeCmd,
+	sshLoginCmd,
 	authGroupsCmd,
 	authGroupCmd,
+	authGroupRequestsCmd,
+	authGroupRequestCmd,
+	authGroupRequestApproveCmd,
+	authCheckCmd,
+	authExplainCmd,
 	identityProviderGroupsCmd,
 	identityProviderGroupCmd,
 	permissionsCmd,
@@ -162,6 +180,8 @@ var api10 = []APIEndpoint{
 	oidcSessionCmd,
 	placementGroupsCmd,
 	placementGroupCmd,
+	instanceTemplatesCmd,
+	instanceTemplateCmd,
 }
 
 // swagger:operation GET /1.0?public server server_get_untrusted
@@ -258,6 +278,19 @@ func api10Get(d *Daemon, r *http.Request) response.Response {
 		authMethods = append(authMethods, api.AuthenticationMethodOIDC)
 	}
 
+	if d.ldapAuthenticator != nil {
+		authMethods = append(authMethods, api.AuthenticationMethodLDAP)
+	}
+
+	sshAvailable, err := d.sshAuthAvailable(r.Context())
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed checking if SSH authentication is available: %w", err))
+	}
+
+	if sshAvailable {
+		authMethods = append(authMethods, api.AuthenticationMethodSSH)
+	}
+
 	srv := api.ServerUntrusted{
 		APIExtensions:     version.APIExtensions,
 		APIStatus:         "stable",
@@ -422,6 +455,9 @@ func api10Get(d *Daemon, r *http.Request) response.Response {
 		for k, v := range s.OS.LXCFeatures {
 			env.LXCFeatures[k] = strconv.FormatBool(v)
 		}
+
+		env.LXCFeatures["criu"] = strconv.FormatBool(s.OS.CRIU)
+		env.LXCFeatures["criu_memory_tracking"] = strconv.FormatBool(s.OS.CRIUMemoryTracking)
 	}
 
 	supportedStorageDrivers, usedStorageDrivers := readStoragePoolDriversCache()
@@ -792,6 +828,96 @@ func validateOIDCSessionExpiry(config *clusterConfig.Config, requestConfig map[s
 	return nil
 }
 
+// validateLDAPSessionExpiry enforces that "ldap.session.expiry" is not greater than "core.auth_secret_expiry".
+//
+// LDAP session tokens are signed by a key derived from the core secret, so they can't be verified once the secret that
+// was used has been rotated out and deleted.
+func validateLDAPSessionExpiry(config *clusterConfig.Config, requestConfig map[string]string, patch bool) error {
+	coreAuthSecretExpiry := requestConfig["core.auth_secret_expiry"]
+	if coreAuthSecretExpiry == "" {
+		// If value is unset in request. For PATCH it is unchanged, but for PUT it will reset to the default.
+		if patch {
+			coreAuthSecretExpiry = config.AuthSecretExpiry()
+		} else {
+			coreAuthSecretExpiry = "1m"
+		}
+	}
+
+	ldapSessionExpiry := requestConfig["ldap.session.expiry"]
+	if ldapSessionExpiry == "" {
+		// If value is unset in request. For PATCH it is unchanged, but for PUT it will reset to the default.
+		if patch {
+			ldapSessionExpiry = config.LDAPSessionExpiry()
+		} else {
+			ldapSessionExpiry = "1d"
+		}
+	}
+
+	// Calculate expirations with reference to the current time.
+	now := time.Now().UTC()
+	coreAuthSecretExpiryTime, err := shared.GetExpiry(now, coreAuthSecretExpiry)
+	if err != nil {
+		return api.StatusErrorf(http.StatusBadRequest, "Failed validating core auth secret expiry: %w", err)
+	}
+
+	ldapSessionExpiryTime, err := shared.GetExpiry(now, ldapSessionExpiry)
+	if err != nil {
+		return api.StatusErrorf(http.StatusBadRequest, "Failed validating ldap session expiry: %w", err)
+	}
+
+	// Check if an LDAP session created now, would expire after a core auth secret created now.
+	if ldapSessionExpiryTime.After(coreAuthSecretExpiryTime) {
+		return api.StatusErrorf(http.StatusBadRequest, "LDAP session expiry %q must not be greater than the auth secret expiry %q", ldapSessionExpiry, coreAuthSecretExpiry)
+	}
+
+	return nil
+}
+
+// validateSSHSessionExpiry enforces that "ssh.session.expiry" is not greater than "core.auth_secret_expiry".
+//
+// SSH session tokens are signed by a key derived from the core secret, so they can't be verified once the secret that
+// was used has been rotated out and deleted.
+func validateSSHSessionExpiry(config *clusterConfig.Config, requestConfig map[string]string, patch bool) error {
+	coreAuthSecretExpiry := requestConfig["core.auth_secret_expiry"]
+	if coreAuthSecretExpiry == "" {
+		// If value is unset in request. For PATCH it is unchanged, but for PUT it will reset to the default.
+		if patch {
+			coreAuthSecretExpiry = config.AuthSecretExpiry()
+		} else {
+			coreAuthSecretExpiry = "1m"
+		}
+	}
+
+	sshSessionExpiry := requestConfig["ssh.session.expiry"]
+	if sshSessionExpiry == "" {
+		// If value is unset in request. For PATCH it is unchanged, but for PUT it will reset to the default.
+		if patch {
+			sshSessionExpiry = config.SSHSessionExpiry()
+		} else {
+			sshSessionExpiry = "1d"
+		}
+	}
+
+	// Calculate expirations with reference to the current time.
+	now := time.Now().UTC()
+	coreAuthSecretExpiryTime, err := shared.GetExpiry(now, coreAuthSecretExpiry)
+	if err != nil {
+		return api.StatusErrorf(http.StatusBadRequest, "Failed validating core auth secret expiry: %w", err)
+	}
+
+	sshSessionExpiryTime, err := shared.GetExpiry(now, sshSessionExpiry)
+	if err != nil {
+		return api.StatusErrorf(http.StatusBadRequest, "Failed validating ssh session expiry: %w", err)
+	}
+
+	// Check if an SSH session created now, would expire after a core auth secret created now.
+	if sshSessionExpiryTime.After(coreAuthSecretExpiryTime) {
+		return api.StatusErrorf(http.StatusBadRequest, "SSH session expiry %q must not be greater than the auth secret expiry %q", sshSessionExpiry, coreAuthSecretExpiry)
+	}
+
+	return nil
+}
+
 func doAPI10Update(d *Daemon, r *http.Request, req api.ServerPut, patch bool) response.Response {
 	s := d.State()
 
@@ -827,6 +953,18 @@ func doAPI10Update(d *Daemon, r *http.Request, req api.ServerPut, patch bool) re
 		return response.SmartError(err)
 	}
 
+	// Same for the LDAP session expiry.
+	err = validateLDAPSessionExpiry(d.globalConfig, stringReqConfig, patch)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Same for the SSH session expiry.
+	err = validateSSHSessionExpiry(d.globalConfig, stringReqConfig, patch)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
 	// First deal with config specific to the local daemon
 	nodeValues := map[string]string{}
 
@@ -1054,9 +1192,15 @@ func doAPI10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 	bgpChanged := false
 	dnsChanged := false
 	lokiChanged := false
+	otlpLogsChanged := false
+	syslogChanged := false
+	auditChanged := false
+	tracingChanged := false
 	acmeDomainChanged := false
 	acmeCAURLChanged := false
 	oidcChanged := false
+	ldapChanged := false
+	sshChanged := false
 	syslogSocketChanged := false
 
 	for key := range clusterChanged {
@@ -1103,12 +1247,24 @@ func doAPI10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 			fallthrough
 		case "loki.types":
 			lokiChanged = true
+		case "logs.otlp.endpoint", "logs.otlp.ca_cert", "logs.otlp.instance", "logs.otlp.labels", "logs.otlp.loglevel", "logs.otlp.types":
+			otlpLogsChanged = true
+		case "logs.syslog.address", "logs.syslog.ca_cert", "logs.syslog.instance", "logs.syslog.labels", "logs.syslog.loglevel", "logs.syslog.types":
+			syslogChanged = true
+		case "audit.enabled", "audit.max_files", "audit.max_size":
+			auditChanged = true
+		case "tracing.otlp.endpoint", "tracing.otlp.protocol", "tracing.sample_ratio":
+			tracingChanged = true
 		case "acme.ca_url":
 			acmeCAURLChanged = true
 		case "acme.domain":
 			acmeDomainChanged = true
 		case "oidc.issuer", "oidc.client.id", "oidc.client.secret", "oidc.scopes", "oidc.audience", "oidc.groups.claim":
 			oidcChanged = true
+		case "ldap.url", "ldap.starttls", "ldap.ca_cert", "ldap.bind.dn", "ldap.bind.password", "ldap.user.base_dn", "ldap.user.filter", "ldap.groups.base_dn", "ldap.groups.filter", "ldap.groups.attribute":
+			ldapChanged = true
+		case "ssh.ca_keys":
+			sshChanged = true
 		}
 	}
 
@@ -1232,17 +1388,40 @@ func doAPI10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 	if lokiChanged {
 		lokiURL, lokiUsername, lokiPassword, lokiCACert, lokiInstance, lokiLoglevel, lokiLabels, lokiTypes := newClusterConfig.LokiServer()
 
-		if lokiURL == "" || lokiLoglevel == "" || len(lokiTypes) == 0 {
-			d.internalListener.RemoveHandler("loki")
-			if d.lokiClient != nil {
-				d.lokiClient.Stop()
-				d.lokiClient = nil
-			}
-		} else {
-			err := d.setupLoki(lokiURL, lokiUsername, lokiPassword, lokiCACert, lokiInstance, lokiLoglevel, lokiLabels, lokiTypes)
-			if err != nil {
-				return err
-			}
+		err := d.setupLoki(lokiURL, lokiUsername, lokiPassword, lokiCACert, lokiInstance, lokiLoglevel, lokiLabels, lokiTypes)
+		if err != nil {
+			return err
+		}
+	}
+
+	if otlpLogsChanged {
+		otlpEndpoint, otlpCACert, otlpInstance, otlpLoglevel, otlpLabels, otlpTypes := newClusterConfig.LogsOTLP()
+
+		err := d.setupOTLPLogs(otlpEndpoint, otlpCACert, otlpInstance, otlpLoglevel, otlpLabels, otlpTypes)
+		if err != nil {
+			return err
+		}
+	}
+
+	if syslogChanged {
+		syslogAddress, syslogCACert, syslogInstance, syslogLoglevel, syslogLabels, syslogTypes := newClusterConfig.LogsSyslog()
+
+		err := d.setupSyslog(syslogAddress, syslogCACert, syslogInstance, syslogLoglevel, syslogLabels, syslogTypes)
+		if err != nil {
+			return err
+		}
+	}
+
+	if auditChanged {
+		d.auditLog.Configure(newClusterConfig.Audit())
+	}
+
+	if tracingChanged {
+		tracingEndpoint, tracingProtocol, tracingSampleRatio := newClusterConfig.TracingServer()
+
+		err := d.setupTracing(tracingEndpoint, tracingProtocol, tracingSampleRatio)
+		if err != nil {
+			return fmt.Errorf("Failed reconfiguring tracing: %w", err)
 		}
 	}
 
@@ -1273,6 +1452,27 @@ func doAPI10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 		}
 	}
 
+	if ldapChanged {
+		ldapArgs := newClusterConfig.LDAP()
+		if ldapArgs.URL == "" {
+			d.ldapAuthenticator = nil
+		} else {
+			var err error
+			d.ldapAuthenticator, err = ldap.NewAuthenticator(ldapArgs)
+			if err != nil {
+				return fmt.Errorf("Failed creating LDAP authenticator: %w", err)
+			}
+		}
+	}
+
+	if sshChanged {
+		var err error
+		d.sshAuthenticator, err = ssh.NewAuthenticator(newClusterConfig.SSHCAKeys())
+		if err != nil {
+			return fmt.Errorf("Failed creating SSH authenticator: %w", err)
+		}
+	}
+
 	if syslogSocketChanged {
 		err := d.setupSyslogSocket(newNodeConfig.SyslogSocket())
 		if err != nil {
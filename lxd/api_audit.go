@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/canonical/lxd/lxd/audit"
+	"github.com/canonical/lxd/lxd/auth"
+	"github.com/canonical/lxd/lxd/request"
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/entity"
+)
+
+var auditCmd = APIEndpoint{
+	Path:        "audit",
+	MetricsType: entity.TypeServer,
+
+	Get: APIEndpointAction{Handler: auditGet, AccessHandler: allowPermission(entity.TypeServer, auth.EntitlementAdmin)},
+}
+
+var auditVerifyCmd = APIEndpoint{
+	Path:        "audit/verify",
+	MetricsType: entity.TypeServer,
+
+	Get: APIEndpointAction{Handler: auditVerifyGet, AccessHandler: allowPermission(entity.TypeServer, auth.EntitlementAdmin)},
+}
+
+// swagger:operation GET /1.0/audit server audit_get
+//
+//	Get the audit log
+//
+//	Returns the entries of the audit log of the cluster member, from the oldest to the most recent.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: lxd01
+//	  - in: query
+//	    name: since
+//	    description: Only return the entries recorded after this time (RFC 3339)
+//	    type: string
+//	    example: 2024-02-14T21:31:20Z
+//	  - in: query
+//	    name: until
+//	    description: Only return the entries recorded before this time (RFC 3339)
+//	    type: string
+//	    example: 2024-02-15T21:31:20Z
+//	  - in: query
+//	    name: username
+//	    description: Only return the entries of this identity
+//	    type: string
+//	    example: foo@example.com
+//	  - in: query
+//	    name: result
+//	    description: Only return the entries with this result (success, failure or denied)
+//	    type: string
+//	    example: denied
+//	  - in: query
+//	    name: limit
+//	    description: Only return this number of most recent entries
+//	    type: integer
+//	    example: 100
+//	responses:
+//	  "200":
+//	    description: Audit log entries
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of audit log entries
+//	          items:
+//	            $ref: "#/definitions/AuditEntry"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func auditGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// If a target was specified, forward the request to the relevant node.
+	target := request.QueryParam(r, "target")
+	resp := forwardedResponseToNode(r.Context(), s, target)
+	if resp != nil {
+		return resp
+	}
+
+	filter := audit.Filter{
+		Username: request.QueryParam(r, "username"),
+		Result:   request.QueryParam(r, "result"),
+	}
+
+	if filter.Result != "" && !slices.Contains([]string{api.AuditResultSuccess, api.AuditResultFailure, api.AuditResultDenied}, filter.Result) {
+		return response.BadRequest(fmt.Errorf("Invalid result %q", filter.Result))
+	}
+
+	var err error
+	for name, value := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		param := request.QueryParam(r, name)
+		if param == "" {
+			continue
+		}
+
+		*value, err = time.Parse(time.RFC3339, param)
+		if err != nil {
+			return response.BadRequest(fmt.Errorf("Invalid %q time: %w", name, err))
+		}
+	}
+
+	limit := request.QueryParam(r, "limit")
+	if limit != "" {
+		filter.Limit, err = strconv.Atoi(limit)
+		if err != nil || filter.Limit < 0 {
+			return response.BadRequest(fmt.Errorf("Invalid limit %q", limit))
+		}
+	}
+
+	entries, err := d.auditLog.Entries(filter)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, entries)
+}
+
+// swagger:operation GET /1.0/audit/verify server audit_verify_get
+//
+//	Verify the audit log
+//
+//	Checks the hash chain of the audit log of the cluster member.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: lxd01
+//	responses:
+//	  "200":
+//	    description: Audit log verification
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/AuditLogVerification"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func auditVerifyGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// If a target was specified, forward the request to the relevant node.
+	target := request.QueryParam(r, "target")
+	resp := forwardedResponseToNode(r.Context(), s, target)
+	if resp != nil {
+		return resp
+	}
+
+	verification, err := d.auditLog.Verify()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, verification)
+}
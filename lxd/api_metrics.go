@@ -18,17 +18,26 @@ import (
 	"github.com/canonical/lxd/lxd/db/warningtype"
 	"github.com/canonical/lxd/lxd/instance"
 	instanceDrivers "github.com/canonical/lxd/lxd/instance/drivers"
+	"github.com/canonical/lxd/lxd/instance/healthcheck"
 	"github.com/canonical/lxd/lxd/instance/instancetype"
 	"github.com/canonical/lxd/lxd/locking"
 	"github.com/canonical/lxd/lxd/metrics"
+	"github.com/canonical/lxd/lxd/operations"
+	"github.com/canonical/lxd/lxd/project/limits"
 	"github.com/canonical/lxd/lxd/request"
 	"github.com/canonical/lxd/lxd/response"
 	"github.com/canonical/lxd/lxd/state"
+	storagePools "github.com/canonical/lxd/lxd/storage"
+	"github.com/canonical/lxd/shared"
 	"github.com/canonical/lxd/shared/api"
 	"github.com/canonical/lxd/shared/entity"
 	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/lxd/shared/units"
 )
 
+// metricsCacheDuration is the duration for which the metrics are cached.
+const metricsCacheDuration = 8 * time.Second
+
 type metricsCacheEntry struct {
 	metrics *metrics.MetricSet
 	expiry  time.Time
@@ -37,6 +46,10 @@ type metricsCacheEntry struct {
 var metricsCache map[string]metricsCacheEntry
 var metricsCacheLock sync.Mutex
 
+// storagePoolMetricsCache holds the storage pool metrics of this cluster member.
+// It is protected by metricsCacheLock.
+var storagePoolMetricsCache metricsCacheEntry
+
 var metricsCmd = APIEndpoint{
 	Path:        "metrics",
 	MetricsType: entity.TypeServer,
@@ -138,6 +151,14 @@ func metricsGet(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	leaderInfo, err := s.LeaderInfo()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Include the storage pool metrics along with the internal metrics.
+	intMetrics.Merge(storagePoolMetrics(r.Context(), s, leaderInfo.Leader))
+
 	// invalidProjectFilters returns project filters which are either not in cache or have expired.
 	invalidProjectFilters := func(projectNames []string) []dbCluster.InstanceFilter {
 		metricsCacheLock.Lock()
@@ -174,10 +195,8 @@ func metricsGet(d *Daemon, r *http.Request) response.Response {
 		return getFilteredMetrics(s, r, compress, metricSet)
 	}
 
-	cacheDuration := 8 * time.Second
-
 	// Acquire update lock.
-	lockCtx, lockCtxCancel := context.WithTimeout(r.Context(), cacheDuration)
+	lockCtx, lockCtxCancel := context.WithTimeout(r.Context(), metricsCacheDuration)
 	defer lockCtxCancel()
 
 	unlock, err := locking.Lock(lockCtx, "metricsGet")
@@ -269,6 +288,19 @@ func metricsGet(d *Daemon, r *http.Request) response.Response {
 					// Add the metrics if available.
 					if instanceMetrics != nil {
 						newMetrics[projectName].Merge(instanceMetrics)
+
+						// Add the health of running instances with a health check.
+						if inst.ExpandedConfig()["healthcheck.type"] != "" {
+							healthy := 0.0
+							if healthcheck.Get(projectName, inst.Name(), inst.InitPID()).Status == healthcheck.StatusHealthy {
+								healthy = 1.0
+							}
+
+							newMetrics[projectName].AddSamples(metrics.InstanceHealthy, metrics.Sample{
+								Labels: map[string]string{"project": projectName, "name": inst.Name(), "type": inst.Type().String()},
+								Value:  healthy,
+							})
+						}
 					}
 
 					newMetricsLock.Unlock()
@@ -288,6 +320,15 @@ func metricsGet(d *Daemon, r *http.Request) response.Response {
 	wg.Wait()
 	close(instMetricsCh)
 
+	// Add the custom storage volume and project limit metrics.
+	for projectName, projectMetrics := range projectStorageMetrics(r.Context(), s, projectsToFetch, leaderInfo.Leader) {
+		if newMetrics[projectName] == nil {
+			newMetrics[projectName] = metrics.NewMetricSet(nil)
+		}
+
+		newMetrics[projectName].Merge(projectMetrics)
+	}
+
 	// Put the new data in the global cache and in response.
 	metricsCacheLock.Lock()
 
@@ -326,7 +367,7 @@ func metricsGet(d *Daemon, r *http.Request) response.Response {
 		}
 
 		metricsCache[project] = metricsCacheEntry{
-			expiry:  time.Now().Add(cacheDuration),
+			expiry:  time.Now().Add(metricsCacheDuration),
 			metrics: entries,
 		}
 
@@ -340,7 +381,7 @@ func metricsGet(d *Daemon, r *http.Request) response.Response {
 		}
 
 		metricsCache[*project.Project] = metricsCacheEntry{
-			expiry: time.Now().Add(cacheDuration),
+			expiry: time.Now().Add(metricsCacheDuration),
 		}
 	}
 
@@ -380,6 +421,184 @@ func getFilteredMetrics(s *state.State, r *http.Request, compress bool, metricSe
 	return response.SyncResponsePlain(true, compress, metricSet.String())
 }
 
+// storagePoolMetrics returns the size, usage and allocation metrics of the storage pools of this cluster member.
+// Remote storage pools are shared by all cluster members so they are only included on the leader.
+// The result is cached for the same duration as the instance metrics.
+func storagePoolMetrics(ctx context.Context, s *state.State, leader bool) *metrics.MetricSet {
+	metricsCacheLock.Lock()
+	defer metricsCacheLock.Unlock()
+
+	if storagePoolMetricsCache.metrics != nil && storagePoolMetricsCache.expiry.After(time.Now()) {
+		return storagePoolMetricsCache.metrics
+	}
+
+	out := metrics.NewMetricSet(nil)
+
+	var poolNames []string
+	var volumes []*db.StorageVolume
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		poolNames, err = tx.GetCreatedStoragePoolNames(ctx)
+		if err != nil && !response.IsNotFoundError(err) {
+			return fmt.Errorf("Failed loading storage pools: %w", err)
+		}
+
+		volumes, err = tx.GetStorageVolumes(ctx, true)
+		if err != nil {
+			return fmt.Errorf("Failed loading storage volumes: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Warn("Failed getting storage pool metrics", logger.Ctx{"err": err})
+		return out
+	}
+
+	// Sum the configured sizes of the volumes (excluding snapshots) on each pool.
+	allocated := make(map[string]int64, len(poolNames))
+	for _, vol := range volumes {
+		if shared.IsSnapshot(vol.Name) || vol.Config["size"] == "" {
+			continue
+		}
+
+		size, err := units.ParseByteSizeString(vol.Config["size"])
+		if err != nil {
+			continue
+		}
+
+		allocated[vol.Pool] += size
+	}
+
+	for _, poolName := range poolNames {
+		pool, err := storagePools.LoadByName(s, poolName)
+		if err != nil {
+			logger.Warn("Failed loading storage pool", logger.Ctx{"pool": poolName, "err": err})
+			continue
+		}
+
+		if pool.Driver().Info().Remote && !leader {
+			continue
+		}
+
+		labels := map[string]string{"pool": poolName, "driver": pool.Driver().Info().Name}
+
+		out.AddSamples(metrics.StoragePoolAllocatedBytes, metrics.Sample{Labels: labels, Value: float64(allocated[poolName])})
+
+		res, err := pool.GetResources()
+		if err != nil {
+			logger.Warn("Failed getting storage pool resources", logger.Ctx{"pool": poolName, "err": err})
+			continue
+		}
+
+		out.AddSamples(metrics.StoragePoolSizeBytes, metrics.Sample{Labels: labels, Value: float64(res.Space.Total)})
+		out.AddSamples(metrics.StoragePoolUsedBytes, metrics.Sample{Labels: labels, Value: float64(res.Space.Used)})
+	}
+
+	storagePoolMetricsCache = metricsCacheEntry{
+		expiry:  time.Now().Add(metricsCacheDuration),
+		metrics: out,
+	}
+
+	return out
+}
+
+// projectStorageMetrics returns the custom storage volume metrics and, on the leader, the project limit metrics
+// of the given projects. Custom volumes on remote storage pools are only included on the leader.
+func projectStorageMetrics(ctx context.Context, s *state.State, projectFilters []dbCluster.InstanceFilter, leader bool) map[string]*metrics.MetricSet {
+	out := make(map[string]*metrics.MetricSet, len(projectFilters))
+
+	volumeType := dbCluster.StoragePoolVolumeTypeCustom
+	volumeFilters := make([]db.StorageVolumeFilter, 0, len(projectFilters))
+	for _, filter := range projectFilters {
+		out[*filter.Project] = metrics.NewMetricSet(nil)
+		volumeFilters = append(volumeFilters, db.StorageVolumeFilter{Type: &volumeType, Project: filter.Project})
+	}
+
+	if len(volumeFilters) == 0 {
+		return out
+	}
+
+	var volumes []*db.StorageVolume
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		// Project limits apply to the whole cluster so only the leader reports them.
+		if leader {
+			for projectName, projectMetrics := range out {
+				allocations, err := limits.GetCurrentAllocations(ctx, s.GlobalConfig.Dump(), tx, projectName)
+				if err != nil {
+					logger.Warn("Failed getting project resource usage", logger.Ctx{"project": projectName, "err": err})
+					continue
+				}
+
+				for resource, allocation := range allocations {
+					labels := map[string]string{"project": projectName, "resource": resource}
+
+					projectMetrics.AddSamples(metrics.ProjectUsage, metrics.Sample{Labels: labels, Value: float64(allocation.Usage)})
+
+					if allocation.Limit >= 0 {
+						projectMetrics.AddSamples(metrics.ProjectLimit, metrics.Sample{Labels: labels, Value: float64(allocation.Limit)})
+					}
+				}
+			}
+		}
+
+		var err error
+		volumes, err = tx.GetStorageVolumes(ctx, true, volumeFilters...)
+		return err
+	})
+	if err != nil {
+		logger.Warn("Failed getting storage volume metrics", logger.Ctx{"err": err})
+		return out
+	}
+
+	// Count the snapshots of each volume.
+	snapshots := map[string]int{}
+	for _, vol := range volumes {
+		if shared.IsSnapshot(vol.Name) {
+			parentName, _, _ := api.GetParentAndSnapshotName(vol.Name)
+			snapshots[vol.Project+"/"+vol.Pool+"/"+parentName]++
+		}
+	}
+
+	pools := map[string]storagePools.Pool{}
+	for _, vol := range volumes {
+		// Volumes on remote storage pools have no location.
+		if shared.IsSnapshot(vol.Name) || (vol.Location == "" && !leader) {
+			continue
+		}
+
+		pool, ok := pools[vol.Pool]
+		if !ok {
+			pool, err = storagePools.LoadByName(s, vol.Pool)
+			if err != nil {
+				logger.Warn("Failed loading storage pool", logger.Ctx{"pool": vol.Pool, "err": err})
+				continue
+			}
+
+			pools[vol.Pool] = pool
+		}
+
+		labels := map[string]string{"project": vol.Project, "pool": vol.Pool, "name": vol.Name}
+
+		out[vol.Project].AddSamples(metrics.StorageVolumeSnapshots, metrics.Sample{Labels: labels, Value: float64(snapshots[vol.Project+"/"+vol.Pool+"/"+vol.Name])})
+
+		usage, err := pool.GetCustomVolumeUsage(vol.Project, vol.Name)
+		if err != nil {
+			logger.Warn("Failed getting storage volume usage", logger.Ctx{"project": vol.Project, "pool": vol.Pool, "volume": vol.Name, "err": err})
+			continue
+		}
+
+		out[vol.Project].AddSamples(metrics.StorageVolumeUsedBytes, metrics.Sample{Labels: labels, Value: float64(usage.Used)})
+
+		if usage.Total > 0 {
+			out[vol.Project].AddSamples(metrics.StorageVolumeSizeBytes, metrics.Sample{Labels: labels, Value: float64(usage.Total)})
+		}
+	}
+
+	return out
+}
+
 // clusterMemberWarnings returns the list of unresolved and unacknowledged warnings related to this cluster member.
 // If this member is the leader, also include nodeless warnings.
 // This way we include them while avoiding counting them redundantly across cluster members.
@@ -408,6 +627,94 @@ func clusterMemberWarnings(ctx context.Context, s *state.State, tx *db.ClusterTx
 	return dbCluster.GetWarnings(ctx, tx.Tx(), filters...)
 }
 
+// clusterMetrics returns the leadership, raft role, member status and heartbeat metrics of this cluster member.
+// The heartbeat metrics are only available on the leader as it is the one sending the heartbeats.
+func clusterMetrics(ctx context.Context, s *state.State, tx *db.ClusterTx) *metrics.MetricSet {
+	out := metrics.NewMetricSet(nil)
+
+	leaderInfo, err := s.LeaderInfo()
+	if err != nil {
+		logger.Warn("Failed getting cluster leader", logger.Ctx{"err": err})
+	} else {
+		leader := 0.0
+		if leaderInfo.Leader {
+			leader = 1.0
+		}
+
+		out.AddSamples(metrics.ClusterLeader, metrics.Sample{Value: leader})
+	}
+
+	out.AddSamples(metrics.ClusterLeaderChangesTotal, metrics.Sample{Value: float64(metrics.GetLeaderChanges())})
+
+	// Raft role of this member.
+	localClusterAddress := s.LocalConfig.ClusterAddress()
+	err = s.DB.Node.Transaction(ctx, func(ctx context.Context, tx *db.NodeTx) error {
+		raftNodes, err := tx.GetRaftNodes(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, raftNode := range raftNodes {
+			if raftNode.Address == localClusterAddress {
+				out.AddSamples(metrics.ClusterRaftRole, metrics.Sample{
+					Labels: map[string]string{"role": raftNode.Role.String()},
+					Value:  1,
+				})
+
+				break
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Warn("Failed getting raft members", logger.Ctx{"err": err})
+	}
+
+	members, err := tx.GetNodes(ctx)
+	if err != nil {
+		logger.Warn("Failed getting cluster members", logger.Ctx{"err": err})
+		return out
+	}
+
+	// Number of members by status as seen by this member.
+	offlineThreshold := s.GlobalConfig.OfflineThreshold()
+	statuses := map[string]int{"online": 0, "offline": 0, "evacuated": 0}
+	for _, member := range members {
+		if member.State == db.ClusterMemberStateEvacuated {
+			statuses["evacuated"]++
+		} else if member.IsOffline(offlineThreshold) {
+			statuses["offline"]++
+		} else {
+			statuses["online"]++
+		}
+	}
+
+	for status, count := range statuses {
+		out.AddSamples(metrics.ClusterMembers, metrics.Sample{
+			Labels: map[string]string{"status": status},
+			Value:  float64(count),
+		})
+	}
+
+	// Heartbeats sent to the current members.
+	if leaderInfo != nil && leaderInfo.Leader {
+		heartbeats := metrics.GetHeartbeats()
+		for _, member := range members {
+			stats, ok := heartbeats[member.Name]
+			if !ok {
+				continue
+			}
+
+			labels := map[string]string{"member": member.Name}
+			out.AddSamples(metrics.ClusterHeartbeatSeconds, metrics.Sample{Labels: labels, Value: stats.RoundTrip.Seconds()})
+			out.AddSamples(metrics.ClusterHeartbeatFailuresTotal, metrics.Sample{Labels: labels, Value: float64(stats.Failures)})
+		}
+	}
+
+	return out
+}
+
 func internalMetrics(ctx context.Context, s *state.State, tx *db.ClusterTx) *metrics.MetricSet {
 	out := metrics.NewMetricSet(nil)
 
@@ -422,12 +729,12 @@ func internalMetrics(ctx context.Context, s *state.State, tx *db.ClusterTx) *met
 
 	// Create local variable to get a pointer.
 	nodeID := tx.GetNodeID()
-	operations, err := dbCluster.GetOperations(ctx, tx.Tx(), dbCluster.OperationFilter{NodeID: &nodeID})
+	dbOperations, err := dbCluster.GetOperations(ctx, tx.Tx(), dbCluster.OperationFilter{NodeID: &nodeID})
 	if err != nil {
 		logger.Warn("Failed getting operations", logger.Ctx{"err": err})
 	} else {
 		// Total number of operations
-		out.AddSamples(metrics.OperationsTotal, metrics.Sample{Value: float64(len(operations))})
+		out.AddSamples(metrics.OperationsTotal, metrics.Sample{Value: float64(len(dbOperations))})
 	}
 
 	// API request metrics
@@ -451,6 +758,33 @@ func internalMetrics(ctx context.Context, s *state.State, tx *db.ClusterTx) *met
 		}
 	}
 
+	// Operations by type and status
+	operationCounts := map[[2]string]int{}
+	for _, op := range operations.Clone() {
+		operationCounts[[2]string{op.Type().Description(), op.Status().String()}]++
+	}
+
+	for labels, count := range operationCounts {
+		out.AddSamples(metrics.Operations, metrics.Sample{
+			Labels: map[string]string{"type": labels[0], "status": labels[1]},
+			Value:  float64(count),
+		})
+	}
+
+	// Event listeners
+	out.AddSamples(metrics.EventListeners, metrics.Sample{Value: float64(s.Events.ListenerCount())})
+
+	// Database transactions
+	transactions, transactionsDuration := metrics.GetDatabaseTransactions()
+	out.AddSamples(metrics.DatabaseTransactionsTotal, metrics.Sample{Value: float64(transactions)})
+	out.AddSamples(metrics.DatabaseTransactionSecondsTotal, metrics.Sample{Value: transactionsDuration.Seconds()})
+	out.AddSamples(metrics.DatabaseTransactionRetriesTotal, metrics.Sample{Value: float64(metrics.GetDatabaseTransactionRetries())})
+
+	// Cluster health
+	if s.ServerClustered {
+		out.Merge(clusterMetrics(ctx, s, tx))
+	}
+
 	// Daemon uptime
 	out.AddSamples(metrics.UptimeSeconds, metrics.Sample{Value: time.Since(s.StartTime).Seconds()})
 
@@ -15,6 +15,7 @@ import (
 	"net/url"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 
@@ -240,6 +241,7 @@ func projectUsedByMap(ctx context.Context, tx *sql.Tx, projectName string) (map[
 		entity.TypeNetworkACL,
 		entity.TypeStorageBucket,
 		entity.TypePlacementGroup,
+		entity.TypeInstanceTemplate,
 	}
 
 	entityURLs, err := dbCluster.GetEntityURLs(ctx, tx, projectName, reportedEntityTypes...)
@@ -251,7 +253,7 @@ func projectUsedByMap(ctx context.Context, tx *sql.Tx, projectName string) (map[
 }
 
 // projectUsedBy returns a list of URLs for all instances, images, profiles,
-// storage volumes, storage buckets, networks, acls, and placement groups that use this project.
+// storage volumes, storage buckets, networks, acls, placement groups, and instance templates that use this project.
 func projectUsedBy(ctx context.Context, tx *db.ClusterTx, project *dbCluster.Project) ([]string, error) {
 	m, err := projectUsedByMap(ctx, tx.Tx(), project.Name)
 	if err != nil {
@@ -1604,6 +1606,35 @@ func projectValidateConfig(ctx context.Context, s *state.State, config map[strin
 		//  type: integer
 		//  shortdesc: When an unused cached remote image is flushed in the project
 		"images.remote_cache_expiry": validate.Optional(validate.IsInt64),
+		// lxdmeta:generate(entities=project; group=specific; key=sessions.recording)
+		// When enabled, the interactive console and `exec` sessions of the project's instances are recorded in the asciicast v2 format, which can be replayed with `asciinema`.
+		// See {ref}`instances-console-recording`.
+		// ---
+		//  type: bool
+		//  defaultdesc: `false`
+		//  shortdesc: Whether to record interactive sessions
+		"sessions.recording": validate.Optional(validate.IsBool),
+		// lxdmeta:generate(entities=project; group=specific; key=sessions.recording.expiry)
+		// Specify an expression like `30d`, `2w` or `1M` (see {config:option}`instance-snapshots:snapshots.expiry` for the syntax).
+		// Recordings older than this are removed.
+		// If not set, recordings are kept.
+		// ---
+		//  type: string
+		//  shortdesc: When session recordings are removed
+		"sessions.recording.expiry": validate.Optional(func(value string) error {
+			_, err := shared.GetExpiry(time.Time{}, value)
+			return err
+		}),
+		// lxdmeta:generate(entities=project; group=specific; key=sessions.recording.volume)
+		// Specify the custom storage volume in the format `<pool>/<volume>`.
+		// If not set, recordings are stored in the log directory of each instance and are removed together with the instance.
+		// ---
+		//  type: string
+		//  shortdesc: Custom volume to store session recordings on
+		"sessions.recording.volume": validate.Optional(func(value string) error {
+			_, _, err := daemonStorageSplitVolume(value)
+			return err
+		}),
 		// lxdmeta:generate(entities=project; group=limits; key=limits.instances)
 		//
 		// ---
@@ -0,0 +1,625 @@
+package audit
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/canonical/lxd/shared/api"
+)
+
+// logFileName is the name of the current audit log file. Rotated files have a numeric suffix, ".1" being the most recent.
+const logFileName = "audit.log"
+
+// stateFileName is the name of the file storing the position of the log, outside of the log files.
+const stateFileName = "audit.state"
+
+// maxLineSize is the maximum size of an audit log entry when reading the log files.
+const maxLineSize = 1024 * 1024
+
+// errInvalidEntry is returned when a line of the log files isn't a valid entry.
+var errInvalidEntry = errors.New("Invalid audit log entry")
+
+// errStop is returned by the readEntries callbacks to stop reading the log file.
+var errStop = errors.New("Stop reading")
+
+// errNoKey is returned when the log is used before its key is set.
+var errNoKey = errors.New("Audit log key isn't set")
+
+// Log is a tamper-evident audit log stored as JSON lines in a local directory.
+//
+// Each entry contains the hash of the previous entry and its own hash covering all its fields. The hashes are keyed
+// with a server secret so that the chain can't be rewritten without it. Modifying or removing an entry therefore
+// breaks the hash chain, which is detected by Verify. The chain continues across rotated files.
+//
+// The sequence numbers of the oldest remaining entry and of the last entry, as well as the hash of the last entry,
+// are stored outside of the log files so that removing entries from the start or the end of the log is detected too.
+type Log struct {
+	dir string
+
+	mu       sync.Mutex
+	key      []byte
+	enabled  bool
+	maxSize  int64
+	maxFiles int
+	forward  func(entry api.AuditEntry)
+
+	// Set when the log is first written to.
+	file   *os.File
+	size   int64
+	loaded bool
+	state  state
+}
+
+// state is the position of the log, stored in the state file.
+type state struct {
+	// Sequence number of the oldest entry that wasn't removed by rotation.
+	FirstSequence int64 `json:"first_sequence"`
+
+	// Sequence number of the last entry.
+	LastSequence int64 `json:"last_sequence"`
+
+	// Hash of the last entry.
+	LastHash string `json:"last_hash"`
+}
+
+// NewLog returns an audit log stored in the given directory. The log is disabled until configured.
+func NewLog(dir string) *Log {
+	return &Log{dir: dir}
+}
+
+// Configure enables or disables the log and sets the size (in bytes) above which the log file is rotated, as well as
+// the number of rotated files to keep.
+func (l *Log) Configure(enabled bool, maxSize int64, maxFiles int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.enabled = enabled
+	l.maxSize = maxSize
+	l.maxFiles = maxFiles
+
+	// Reload the position of the log when it's enabled again, in case the log files were removed meanwhile.
+	if !enabled {
+		_ = l.closeFile()
+		l.loaded = false
+	}
+}
+
+// SetKey sets the key of the hash chain, derived from the given server secret. It must be set before entries are
+// appended or verified.
+func (l *Log) SetKey(secret []byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("LXD audit log"))
+	l.key = mac.Sum(nil)
+}
+
+// SetForwarder sets a function called with every entry appended to the log.
+func (l *Log) SetForwarder(forward func(entry api.AuditEntry)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.forward = forward
+}
+
+// Enabled returns whether entries are recorded in the log.
+func (l *Log) Enabled() bool {
+	if l == nil {
+		return false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.enabled
+}
+
+// Close closes the current log file.
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.closeFile()
+}
+
+func (l *Log) closeFile() error {
+	if l.file == nil {
+		return nil
+	}
+
+	err := l.file.Close()
+	l.file = nil
+
+	return err
+}
+
+// Append adds the entry to the log, setting its sequence number and hashes.
+func (l *Log) Append(entry api.AuditEntry) error {
+	l.mu.Lock()
+
+	if !l.enabled {
+		l.mu.Unlock()
+		return nil
+	}
+
+	entry, err := l.append(entry)
+	forward := l.forward
+	l.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	if forward != nil {
+		forward(entry)
+	}
+
+	return nil
+}
+
+func (l *Log) append(entry api.AuditEntry) (api.AuditEntry, error) {
+	if l.key == nil {
+		return entry, errNoKey
+	}
+
+	if !l.loaded {
+		err := l.load()
+		if err != nil {
+			return entry, fmt.Errorf("Failed loading audit log: %w", err)
+		}
+	}
+
+	if l.file == nil {
+		err := os.MkdirAll(l.dir, 0700)
+		if err != nil {
+			return entry, err
+		}
+
+		l.file, err = os.OpenFile(filepath.Join(l.dir, logFileName), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+		if err != nil {
+			return entry, err
+		}
+
+		info, err := l.file.Stat()
+		if err != nil {
+			return entry, err
+		}
+
+		l.size = info.Size()
+	}
+
+	entry.Sequence = l.state.LastSequence + 1
+	entry.Timestamp = entry.Timestamp.UTC()
+	entry.PreviousHash = l.state.LastHash
+
+	hash, err := entryHash(l.key, entry)
+	if err != nil {
+		return entry, err
+	}
+
+	entry.Hash = hash
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return entry, err
+	}
+
+	n, err := l.file.Write(append(line, '\n'))
+	if err != nil {
+		return entry, err
+	}
+
+	l.size += int64(n)
+	l.state.LastSequence = entry.Sequence
+	l.state.LastHash = entry.Hash
+
+	err = l.writeState()
+	if err != nil {
+		return entry, fmt.Errorf("Failed writing audit log state: %w", err)
+	}
+
+	if l.maxSize > 0 && l.size >= l.maxSize {
+		err = l.rotate()
+		if err != nil {
+			return entry, fmt.Errorf("Failed rotating audit log: %w", err)
+		}
+	}
+
+	return entry, nil
+}
+
+// load restores the position of the log from the state file.
+// If the state file doesn't exist, the position is restored from the log files. Invalid lines are skipped so that
+// a corrupted log doesn't prevent recording new entries, Verify reports them.
+func (l *Log) load() error {
+	st, err := l.readState()
+	if err != nil {
+		return err
+	}
+
+	if st == nil {
+		st = &state{FirstSequence: 1}
+
+		for _, path := range l.files() {
+			err := readEntries(path, true, func(entry api.AuditEntry) error {
+				if st.LastSequence == 0 {
+					st.FirstSequence = entry.Sequence
+				}
+
+				st.LastSequence = entry.Sequence
+				st.LastHash = entry.Hash
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	l.state = *st
+	l.loaded = true
+
+	return nil
+}
+
+// readState returns the position of the log stored in the state file, or nil if the state file doesn't exist.
+func (l *Log) readState() (*state, error) {
+	content, err := os.ReadFile(filepath.Join(l.dir, stateFileName))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	st := &state{}
+	err = json.Unmarshal(content, st)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing audit log state: %w", err)
+	}
+
+	return st, nil
+}
+
+// writeState atomically replaces the state file with the current position of the log.
+func (l *Log) writeState() error {
+	content, err := json.Marshal(l.state)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(l.dir, stateFileName)
+
+	err = os.WriteFile(path+".tmp", content, 0600)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(path+".tmp", path)
+}
+
+// rotate renames the current log file and deletes the oldest rotated files.
+func (l *Log) rotate() error {
+	err := l.closeFile()
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(l.dir, logFileName)
+
+	for i := l.maxFiles; i > 0; i-- {
+		src := path
+		if i > 1 {
+			src = path + "." + strconv.Itoa(i-1)
+		}
+
+		err := os.Rename(src, path+"."+strconv.Itoa(i))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	// Remove the current file if no rotated files are kept.
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	// Remove any file above the limit (the limit may have been lowered).
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		return err
+	}
+
+	for _, match := range matches {
+		i, err := strconv.Atoi(match[len(path)+1:])
+		if err != nil || i <= l.maxFiles {
+			continue
+		}
+
+		err = os.Remove(match)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Record the first remaining entry so that the entries removed by the rotation aren't reported as missing.
+	l.state.FirstSequence = l.state.LastSequence + 1
+	for _, path := range l.files() {
+		var first *api.AuditEntry
+		err := readEntries(path, true, func(entry api.AuditEntry) error {
+			first = &entry
+			return errStop
+		})
+		if err != nil && !errors.Is(err, errStop) {
+			return err
+		}
+
+		if first != nil {
+			l.state.FirstSequence = first.Sequence
+			break
+		}
+	}
+
+	return l.writeState()
+}
+
+// files returns the paths of the existing log files, from the oldest to the current one.
+// All rotated files are returned even if some are missing in between, so that Verify reports the missing entries.
+func (l *Log) files() []string {
+	path := filepath.Join(l.dir, logFileName)
+
+	matches, _ := filepath.Glob(path + ".*")
+
+	var rotated []int
+	for _, match := range matches {
+		i, err := strconv.Atoi(match[len(path)+1:])
+		if err == nil && i > 0 {
+			rotated = append(rotated, i)
+		}
+	}
+
+	// The highest suffix is the oldest file.
+	slices.Sort(rotated)
+	slices.Reverse(rotated)
+
+	files := make([]string, 0, len(rotated)+1)
+	for _, i := range rotated {
+		files = append(files, path+"."+strconv.Itoa(i))
+	}
+
+	_, err := os.Stat(path)
+	if err == nil {
+		files = append(files, path)
+	}
+
+	return files
+}
+
+// Filter selects the entries returned by Entries.
+type Filter struct {
+	// Since excludes the entries recorded before this time.
+	Since time.Time
+
+	// Until excludes the entries recorded after this time.
+	Until time.Time
+
+	// Username only includes the entries of this identity.
+	Username string
+
+	// Result only includes the entries with this result.
+	Result string
+
+	// Limit only includes the given number of most recent entries.
+	Limit int
+}
+
+// Entries returns the entries of the log matching the filter, from the oldest to the most recent.
+func (l *Log) Entries(filter Filter) ([]api.AuditEntry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entries := []api.AuditEntry{}
+	for _, path := range l.files() {
+		err := readEntries(path, true, func(entry api.AuditEntry) error {
+			if !filter.Since.IsZero() && entry.Timestamp.Before(filter.Since) {
+				return nil
+			}
+
+			if !filter.Until.IsZero() && entry.Timestamp.After(filter.Until) {
+				return nil
+			}
+
+			if filter.Username != "" && entry.Username != filter.Username {
+				return nil
+			}
+
+			if filter.Result != "" && entry.Result != filter.Result {
+				return nil
+			}
+
+			entries = append(entries, entry)
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if filter.Limit > 0 && len(entries) > filter.Limit {
+		entries = entries[len(entries)-filter.Limit:]
+	}
+
+	return entries, nil
+}
+
+// Verify checks the hash chain of the log. Entries removed by rotation are not taken into account, the chain is
+// checked from the oldest remaining entry recorded in the state file. Missing entries, including at the start or the
+// end of the log, are reported as inconsistencies.
+func (l *Log) Verify() (*api.AuditLogVerification, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.key == nil {
+		return nil, errNoKey
+	}
+
+	result := &api.AuditLogVerification{Valid: true}
+	errInvalid := errors.New("Invalid audit log")
+
+	files := l.files()
+
+	st, err := l.readState()
+	if err != nil {
+		return nil, err
+	}
+
+	if st == nil {
+		if len(files) > 0 {
+			result.Valid = false
+			result.Error = "Audit log state is missing"
+		}
+
+		return result, nil
+	}
+
+	for _, path := range files {
+		err := readEntries(path, false, func(entry api.AuditEntry) error {
+			hash, err := entryHash(l.key, entry)
+			if err != nil {
+				return err
+			}
+
+			if !hmac.Equal([]byte(hash), []byte(entry.Hash)) {
+				result.Error = fmt.Sprintf("Entry %d has an invalid hash", entry.Sequence)
+				return errInvalid
+			}
+
+			expected := st.FirstSequence
+			if result.Entries > 0 {
+				if entry.PreviousHash != result.LastHash {
+					result.Error = fmt.Sprintf("Entry %d doesn't follow entry %d", entry.Sequence, result.LastSequence)
+					return errInvalid
+				}
+
+				expected = result.LastSequence + 1
+			}
+
+			if entry.Sequence > expected {
+				result.Error = fmt.Sprintf("Entries %d to %d are missing", expected, entry.Sequence-1)
+				return errInvalid
+			}
+
+			if entry.Sequence < expected {
+				result.Error = fmt.Sprintf("Entry %d is out of sequence", entry.Sequence)
+				return errInvalid
+			}
+
+			result.Entries++
+			result.LastSequence = entry.Sequence
+			result.LastHash = entry.Hash
+
+			return nil
+		})
+		if errors.Is(err, errInvalid) {
+			result.Valid = false
+			return result, nil
+		}
+
+		if errors.Is(err, errInvalidEntry) {
+			result.Valid = false
+			result.Error = err.Error()
+			return result, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Check that the log ends with the last recorded entry.
+	lastSequence := result.LastSequence
+	if result.Entries == 0 {
+		lastSequence = st.FirstSequence - 1
+	}
+
+	if lastSequence < st.LastSequence {
+		result.Valid = false
+		result.Error = fmt.Sprintf("Entries %d to %d are missing", lastSequence+1, st.LastSequence)
+	} else if lastSequence > st.LastSequence || (result.Entries > 0 && result.LastHash != st.LastHash) {
+		result.Valid = false
+		result.Error = fmt.Sprintf("Entry %d doesn't match the last recorded entry %d", lastSequence, st.LastSequence)
+	}
+
+	return result, nil
+}
+
+// entryHash returns the HMAC-SHA256 of the JSON encoding of the entry without its own hash, keyed with the given key.
+func entryHash(key []byte, entry api.AuditEntry) (string, error) {
+	entry.Hash = ""
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// readEntries calls the given function for each entry of the log file.
+// Invalid lines are either skipped or cause an errInvalidEntry error to be returned.
+func readEntries(path string, skipInvalid bool, f func(entry api.AuditEntry) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		return err
+	}
+
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, maxLineSize)
+
+	line := 0
+	for scanner.Scan() {
+		var entry api.AuditEntry
+
+		line++
+		err := json.Unmarshal(scanner.Bytes(), &entry)
+		if err != nil {
+			if skipInvalid {
+				continue
+			}
+
+			return fmt.Errorf("%w in %q at line %d: %v", errInvalidEntry, filepath.Base(path), line, err)
+		}
+
+		err = f(entry)
+		if err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,245 @@
+package audit
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/canonical/lxd/lxd/auth"
+	"github.com/canonical/lxd/lxd/request"
+	"github.com/canonical/lxd/shared/api"
+)
+
+// testSecret is the server secret used to key the hash chain of the test logs.
+var testSecret = []byte("secret")
+
+func appendEntries(t *testing.T, l *Log, count int) {
+	t.Helper()
+
+	for i := range count {
+		err := l.Append(api.AuditEntry{
+			Timestamp: time.Date(2024, 2, 14, 21, 31, i, 0, time.UTC),
+			Username:  "user" + string(rune('a'+i%2)),
+			Method:    http.MethodPost,
+			URL:       "/1.0/instances",
+			Result:    api.AuditResultSuccess,
+		})
+		require.NoError(t, err)
+	}
+}
+
+func TestLog(t *testing.T) {
+	dir := t.TempDir()
+
+	l := NewLog(dir)
+	l.SetKey(testSecret)
+
+	// Nothing is recorded until the log is enabled.
+	appendEntries(t, l, 1)
+	require.NoFileExists(t, filepath.Join(dir, logFileName))
+
+	var forwarded []api.AuditEntry
+	l.SetForwarder(func(entry api.AuditEntry) { forwarded = append(forwarded, entry) })
+	l.Configure(true, 0, 1)
+	appendEntries(t, l, 4)
+	require.Len(t, forwarded, 4)
+
+	entries, err := l.Entries(Filter{})
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	require.Equal(t, int64(1), entries[0].Sequence)
+	require.Empty(t, entries[0].PreviousHash)
+	require.Equal(t, entries[0].Hash, entries[1].PreviousHash)
+	require.Equal(t, forwarded[3], entries[3])
+
+	entries, err = l.Entries(Filter{Username: "userb", Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, int64(4), entries[0].Sequence)
+
+	entries, err = l.Entries(Filter{Since: time.Date(2024, 2, 14, 21, 31, 2, 0, time.UTC)})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	verification, err := l.Verify()
+	require.NoError(t, err)
+	require.True(t, verification.Valid)
+	require.Equal(t, int64(4), verification.Entries)
+	require.Equal(t, entries[1].Hash, verification.LastHash)
+
+	// The chain continues after reopening the log.
+	require.NoError(t, l.Close())
+	l = NewLog(dir)
+	l.SetKey(testSecret)
+	l.Configure(true, 0, 1)
+	appendEntries(t, l, 1)
+
+	verification, err = l.Verify()
+	require.NoError(t, err)
+	require.True(t, verification.Valid)
+	require.Equal(t, int64(5), verification.LastSequence)
+
+	// Modifying an entry is detected.
+	path := filepath.Join(dir, logFileName)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte(strings.Replace(string(content), `"username":"usera"`, `"username":"userc"`, 1)), 0600))
+
+	verification, err = l.Verify()
+	require.NoError(t, err)
+	require.False(t, verification.Valid)
+	require.Equal(t, "Entry 1 has an invalid hash", verification.Error)
+
+	// Removing an entry is detected.
+	lines := strings.SplitAfter(string(content), "\n")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(append(lines[:1], lines[2:]...), "")), 0600))
+
+	verification, err = l.Verify()
+	require.NoError(t, err)
+	require.False(t, verification.Valid)
+	require.Equal(t, "Entry 3 doesn't follow entry 1", verification.Error)
+
+	// Removing the first or the last entries is detected.
+	lines = strings.SplitAfter(string(content), "\n")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines[1:], "")), 0600))
+
+	verification, err = l.Verify()
+	require.NoError(t, err)
+	require.False(t, verification.Valid)
+	require.Equal(t, "Entries 1 to 1 are missing", verification.Error)
+
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines[:3], "")), 0600))
+
+	verification, err = l.Verify()
+	require.NoError(t, err)
+	require.False(t, verification.Valid)
+	require.Equal(t, "Entries 4 to 5 are missing", verification.Error)
+
+	// The chain can't be verified with another key.
+	require.NoError(t, os.WriteFile(path, content, 0600))
+	l.SetKey([]byte("other"))
+
+	verification, err = l.Verify()
+	require.NoError(t, err)
+	require.False(t, verification.Valid)
+	require.Equal(t, "Entry 1 has an invalid hash", verification.Error)
+
+	// Removing the state file is detected.
+	l.SetKey(testSecret)
+	require.NoError(t, os.Remove(filepath.Join(dir, stateFileName)))
+
+	verification, err = l.Verify()
+	require.NoError(t, err)
+	require.False(t, verification.Valid)
+	require.Equal(t, "Audit log state is missing", verification.Error)
+}
+
+func TestLogRotation(t *testing.T) {
+	dir := t.TempDir()
+
+	l := NewLog(dir)
+	l.SetKey(testSecret)
+	l.Configure(true, 1, 2)
+	appendEntries(t, l, 4)
+
+	// Each entry fills a file and only the two most recent rotated files are kept.
+	require.NoFileExists(t, filepath.Join(dir, logFileName))
+	require.FileExists(t, filepath.Join(dir, logFileName+".1"))
+	require.FileExists(t, filepath.Join(dir, logFileName+".2"))
+	require.NoFileExists(t, filepath.Join(dir, logFileName+".3"))
+
+	entries, err := l.Entries(Filter{})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, int64(3), entries[0].Sequence)
+
+	// The chain is verified from the oldest remaining entry.
+	verification, err := l.Verify()
+	require.NoError(t, err)
+	require.True(t, verification.Valid)
+	require.Equal(t, int64(2), verification.Entries)
+
+	// Removing a rotated file is detected, even if more recent files remain.
+	l.Configure(true, 1, 3)
+	appendEntries(t, l, 1)
+	require.NoError(t, os.Remove(filepath.Join(dir, logFileName+".2")))
+
+	verification, err = l.Verify()
+	require.NoError(t, err)
+	require.False(t, verification.Valid)
+	require.Equal(t, "Entry 5 doesn't follow entry 3", verification.Error)
+
+	require.NoError(t, os.Remove(filepath.Join(dir, logFileName+".3")))
+
+	verification, err = l.Verify()
+	require.NoError(t, err)
+	require.False(t, verification.Valid)
+	require.Equal(t, "Entries 3 to 4 are missing", verification.Error)
+}
+
+func TestRecord(t *testing.T) {
+	l := NewLog(t.TempDir())
+	l.SetKey(testSecret)
+	l.Configure(true, 0, 1)
+
+	record := func(method string, statusCode int, allowed ...bool) {
+		r := httptest.NewRequest(method, "/1.0/instances/c1?project=default", strings.NewReader(`{"config":{"user.foo":"bar","user.password":"secret"}}`))
+		r.RemoteAddr = "10.0.0.1:1234"
+
+		// The body is recorded as it is read by the handler.
+		body := NewBodyRecorder(r.Body)
+		r.Body = body
+		_, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		checks := &Checks{}
+		request.SetContextValue(r, request.CtxAuditChecks, checks)
+		for _, a := range allowed {
+			var err error
+			if !a {
+				err = api.NewGenericStatusError(http.StatusForbidden)
+			}
+
+			recordCheck(r.Context(), api.NewURL().Path("1.0", "instances", "c1").Project("p1"), auth.EntitlementCanEdit, err)
+		}
+
+		w := NewResponseWriter(httptest.NewRecorder())
+		if statusCode == http.StatusAccepted {
+			w.Header().Set("Location", "/1.0/operations/1234")
+		}
+
+		w.WriteHeader(statusCode)
+
+		require.NoError(t, l.Record(r, w, body, checks, "node1", time.Now()))
+	}
+
+	record(http.MethodGet, http.StatusOK, true)
+	record(http.MethodDelete, http.StatusAccepted, true)
+	record(http.MethodPatch, http.StatusInternalServerError)
+	record(http.MethodGet, http.StatusNotFound, false)
+	record(http.MethodGet, http.StatusForbidden)
+
+	entries, err := l.Entries(Filter{})
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+
+	require.Equal(t, http.MethodDelete, entries[0].Method)
+	require.Equal(t, api.AuditResultSuccess, entries[0].Result)
+	require.Equal(t, "/1.0/operations/1234", entries[0].Operation)
+	require.Equal(t, "10.0.0.1:1234", entries[0].SourceAddress)
+	require.Equal(t, "node1", entries[0].Location)
+	require.Equal(t, []api.AuditAuthorizationCheck{{EntityURL: "/1.0/instances/c1?project=p1", Entitlement: "can_edit", Allowed: true}}, entries[0].Authorization)
+	require.Equal(t, map[string]any{"config": map[string]any{"user.foo": "bar", "user.password": redactedValue}}, entries[0].Body)
+
+	require.Equal(t, api.AuditResultFailure, entries[1].Result)
+	require.Equal(t, api.AuditResultDenied, entries[2].Result)
+	require.Equal(t, http.StatusNotFound, entries[2].StatusCode)
+	require.Equal(t, api.AuditResultDenied, entries[3].Result)
+}
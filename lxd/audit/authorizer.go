@@ -0,0 +1,35 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/canonical/lxd/lxd/auth"
+	"github.com/canonical/lxd/shared/api"
+)
+
+// authorizer wraps an auth.Authorizer to record the permission checks of the requests in the audit log.
+type authorizer struct {
+	auth.Authorizer
+}
+
+// NewAuthorizer returns an auth.Authorizer recording the permission checks performed by the given authorizer in the
+// audit checks of the request context, if any.
+func NewAuthorizer(a auth.Authorizer) auth.Authorizer {
+	return authorizer{Authorizer: a}
+}
+
+// CheckPermission checks the permission and records the result.
+func (a authorizer) CheckPermission(ctx context.Context, entityURL *api.URL, entitlement auth.Entitlement) error {
+	err := a.Authorizer.CheckPermission(ctx, entityURL, entitlement)
+	recordCheck(ctx, entityURL, entitlement, err)
+
+	return err
+}
+
+// CheckPermissionWithoutEffectiveProject checks the permission and records the result.
+func (a authorizer) CheckPermissionWithoutEffectiveProject(ctx context.Context, entityURL *api.URL, entitlement auth.Entitlement) error {
+	err := a.Authorizer.CheckPermissionWithoutEffectiveProject(ctx, entityURL, entitlement)
+	recordCheck(ctx, entityURL, entitlement, err)
+
+	return err
+}
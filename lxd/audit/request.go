@@ -0,0 +1,267 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/canonical/lxd/lxd/auth"
+	"github.com/canonical/lxd/lxd/request"
+	"github.com/canonical/lxd/shared/api"
+)
+
+// Checks collects the authorization checks performed while handling a request.
+type Checks struct {
+	mu     sync.Mutex
+	checks []api.AuditAuthorizationCheck
+}
+
+// Add records an authorization check.
+func (c *Checks) Add(entityURL *api.URL, entitlement auth.Entitlement, allowed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checks = append(c.checks, api.AuditAuthorizationCheck{
+		EntityURL:   entityURL.String(),
+		Entitlement: string(entitlement),
+		Allowed:     allowed,
+	})
+}
+
+// List returns the recorded authorization checks.
+func (c *Checks) List() []api.AuditAuthorizationCheck {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return slices.Clone(c.checks)
+}
+
+// denied returns true if any of the recorded authorization checks was denied.
+func (c *Checks) denied() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return slices.ContainsFunc(c.checks, func(check api.AuditAuthorizationCheck) bool { return !check.Allowed })
+}
+
+// recordCheck adds the authorization check to the request audit checks in the context, if any.
+func recordCheck(ctx context.Context, entityURL *api.URL, entitlement auth.Entitlement, checkErr error) {
+	checks, err := request.GetContextValue[*Checks](ctx, request.CtxAuditChecks)
+	if err != nil || checks == nil {
+		return
+	}
+
+	checks.Add(entityURL, entitlement, checkErr == nil)
+}
+
+// maxBodySize is the maximum size of a request body recorded in the audit log.
+const maxBodySize = 64 * 1024
+
+// redactedValue replaces the values of the secret fields of the recorded request bodies.
+const redactedValue = "[redacted]"
+
+// BodyRecorder is an io.ReadCloser recording the request body as it is read by the handler.
+type BodyRecorder struct {
+	io.ReadCloser
+
+	mu        sync.Mutex
+	buf       bytes.Buffer
+	truncated bool
+}
+
+// NewBodyRecorder returns a BodyRecorder wrapping the given request body.
+func NewBodyRecorder(body io.ReadCloser) *BodyRecorder {
+	return &BodyRecorder{ReadCloser: body}
+}
+
+// Read reads from the wrapped request body and records the data, up to maxBodySize.
+func (b *BodyRecorder) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.truncated {
+		if b.buf.Len()+n > maxBodySize {
+			b.truncated = true
+			b.buf.Reset()
+		} else {
+			b.buf.Write(p[:n])
+		}
+	}
+
+	return n, err
+}
+
+// Body returns the recorded JSON body with the values of the secret fields redacted.
+// It returns nil if the body is empty, isn't JSON or is larger than maxBodySize.
+func (b *BodyRecorder) Body() any {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.truncated || b.buf.Len() == 0 {
+		return nil
+	}
+
+	var body any
+	err := json.Unmarshal(b.buf.Bytes(), &body)
+	if err != nil {
+		return nil
+	}
+
+	return redact(body)
+}
+
+// redact replaces the string values of the fields whose name refers to a secret (such as passwords, tokens and
+// private keys, including in configuration maps) so that they aren't recorded in the audit log.
+func redact(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, fieldValue := range v {
+			name := strings.ToLower(key)
+			_, isString := fieldValue.(string)
+			if isString && fieldValue != "" && (strings.Contains(name, "password") || strings.Contains(name, "secret") || strings.Contains(name, "token") || strings.Contains(name, "private")) {
+				v[key] = redactedValue
+				continue
+			}
+
+			v[key] = redact(fieldValue)
+		}
+
+	case []any:
+		for i := range v {
+			v[i] = redact(v[i])
+		}
+	}
+
+	return value
+}
+
+// ResponseWriter is an http.ResponseWriter recording the status code of the response.
+type ResponseWriter struct {
+	http.ResponseWriter
+
+	statusCode int
+}
+
+// NewResponseWriter returns a ResponseWriter wrapping the given http.ResponseWriter.
+func NewResponseWriter(w http.ResponseWriter) *ResponseWriter {
+	return &ResponseWriter{ResponseWriter: w}
+}
+
+// WriteHeader records the status code and writes it to the wrapped http.ResponseWriter.
+func (w *ResponseWriter) WriteHeader(statusCode int) {
+	if w.statusCode == 0 {
+		w.statusCode = statusCode
+	}
+
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write writes the data to the wrapped http.ResponseWriter.
+func (w *ResponseWriter) Write(data []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+
+	return w.ResponseWriter.Write(data)
+}
+
+// Flush flushes the wrapped http.ResponseWriter if supported.
+func (w *ResponseWriter) Flush() {
+	flusher, ok := w.ResponseWriter.(http.Flusher)
+	if ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack hijacks the connection of the wrapped http.ResponseWriter. The status code is recorded as 101 (Switching Protocols).
+func (w *ResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("Response writer doesn't support hijacking")
+	}
+
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusSwitchingProtocols
+	}
+
+	return hijacker.Hijack()
+}
+
+// Unwrap returns the wrapped http.ResponseWriter.
+func (w *ResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// StatusCode returns the status code of the response.
+func (w *ResponseWriter) StatusCode() int {
+	if w.statusCode == 0 {
+		return http.StatusOK
+	}
+
+	return w.statusCode
+}
+
+// Record appends an entry for the request to the log if the request is mutating or was denied.
+// The body is the recorder of the request body, if any. The location is the name of the cluster member handling the request.
+func (l *Log) Record(r *http.Request, w *ResponseWriter, body *BodyRecorder, checks *Checks, location string, timestamp time.Time) error {
+	statusCode := w.StatusCode()
+
+	result := api.AuditResultSuccess
+	if statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden || (statusCode >= http.StatusBadRequest && checks.denied()) {
+		result = api.AuditResultDenied
+	} else if statusCode >= http.StatusBadRequest {
+		result = api.AuditResultFailure
+	}
+
+	mutating := !slices.Contains([]string{http.MethodGet, http.MethodHead, http.MethodOptions}, r.Method)
+	if !mutating && result != api.AuditResultDenied {
+		return nil
+	}
+
+	entry := api.AuditEntry{
+		Timestamp:     timestamp,
+		Location:      location,
+		SourceAddress: r.RemoteAddr,
+		Method:        r.Method,
+		URL:           r.URL.RequestURI(),
+		Body:          body.Body(),
+		StatusCode:    statusCode,
+		Result:        result,
+		Authorization: checks.List(),
+	}
+
+	// The requestor isn't set if authentication failed.
+	requestor, err := request.GetRequestor(r.Context())
+	if err == nil {
+		// Notifications are the result of a request already recorded on the cluster member that received it.
+		if requestor.IsClusterNotification() {
+			return nil
+		}
+
+		entry.Username = requestor.CallerUsername()
+		entry.Protocol = requestor.CallerProtocol()
+		entry.Groups = requestor.CallerEffectiveAuthorizationGroupNames()
+		entry.SourceAddress = requestor.OriginAddress()
+	}
+
+	if statusCode == http.StatusAccepted {
+		entry.Operation = w.Header().Get("Location")
+	}
+
+	return l.Append(entry)
+}
@@ -20,6 +20,9 @@ import (
 // CookieNameSession is the name of the cookie used to store session bearer tokens.
 const CookieNameSession = "token_bearer_session"
 
+// CookieNameLDAPSession is the name of the cookie used to store LDAP session tokens.
+const CookieNameLDAPSession = "ldap_session"
+
 // IsDevLXDRequest returns true if the caller sent a bearer token in the Authorization header that is a JWT and appears to
 // have this LXD cluster as the issuer. If true, it returns the raw token, and the subject.
 func IsDevLXDRequest(r *http.Request, clusterUUID string) (isRequest bool, token string, subject string) {
@@ -48,6 +51,59 @@ func IsAPIRequest(r *http.Request, clusterUUID string) (isRequest bool, location
 	return false, 0, "", ""
 }
 
+// IsLDAPRequest returns true if the caller sent an LDAP session token that has this LXD cluster as the issuer, either in
+// the Authorization header or in the [CookieNameLDAPSession] cookie. If true, it returns the raw token, the subject, and
+// the issued at claim. The issued at claim is used to determine the cluster secret used when the signing key was derived.
+// The JWT is not verified. The caller must call [VerifyLDAPSessionToken] to verify the returned raw token.
+func IsLDAPRequest(r *http.Request, clusterUUID string) (isRequest bool, token string, subject string, issuedAt *time.Time) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		cookie, err := r.Cookie(CookieNameLDAPSession)
+		if err != nil || cookie.Value == "" {
+			return false, "", "", nil
+		}
+
+		token = cookie.Value
+	}
+
+	subject, issuedAt, err := isLXDToken(token, clusterUUID, encryption.LDAPAudience(clusterUUID))
+	if err != nil {
+		return false, "", "", nil
+	}
+
+	return true, token, subject, issuedAt
+}
+
+// IsSSHRequest returns true if the caller sent an SSH session token in the Authorization header that has this LXD
+// cluster as the issuer. If true, it returns the raw token, the subject, and the issued at claim. The issued at claim is
+// used to determine the cluster secret used when the signing key was derived.
+// The JWT is not verified. The caller must call [VerifySSHSessionToken] to verify the returned raw token.
+func IsSSHRequest(r *http.Request, clusterUUID string) (isRequest bool, token string, subject string, issuedAt *time.Time) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return false, "", "", nil
+	}
+
+	subject, issuedAt, err := isLXDToken(token, clusterUUID, encryption.SSHAudience(clusterUUID))
+	if err != nil {
+		return false, "", "", nil
+	}
+
+	return true, token, subject, issuedAt
+}
+
+// IsSSHChallenge returns true if the given challenge looks like an SSH login challenge that was issued by this LXD
+// cluster. If true, it returns the subject and the issued at claim.
+// The JWT is not verified. The caller must call [VerifySSHChallengeToken] to verify the challenge.
+func IsSSHChallenge(challenge string, clusterUUID string) (isChallenge bool, subject string, issuedAt *time.Time) {
+	subject, issuedAt, err := isLXDToken(challenge, clusterUUID, encryption.SSHChallengeAudience(clusterUUID))
+	if err != nil {
+		return false, "", nil
+	}
+
+	return true, subject, issuedAt
+}
+
 // isQueryRequest returns true if the caller sent a bearer token in the "token" query parameter that is a JWT and appears
 // to have this LXD cluster as the issuer. If true, it returns the raw token, and the subject.
 func isQueryRequest(r *http.Request, clusterUUID string) (isRequest bool, token string, subject string) {
@@ -217,7 +273,8 @@ func Authenticate(subject string, token string, tokenLocation auth.TokenLocation
 		return nil, fmt.Errorf("Invalid token location %d", tokenLocation)
 	}
 
-	expiresAt, getSecretErr := verifyToken(token, func() ([]byte, error) {
+	var claims encryption.BearerTokenClaims
+	expiresAt, getSecretErr := verifyTokenClaims(token, &claims, func() ([]byte, error) {
 		return secret, nil
 	})
 	if getSecretErr != nil {
@@ -225,10 +282,11 @@ func Authenticate(subject string, token string, tokenLocation auth.TokenLocation
 	}
 
 	return &request.RequestorArgs{
-		Trusted:   true,
-		Protocol:  api.AuthenticationMethodBearer,
-		Username:  subject,
-		ExpiresAt: expiresAt,
+		Trusted:    true,
+		Protocol:   api.AuthenticationMethodBearer,
+		Username:   subject,
+		ExpiresAt:  expiresAt,
+		TokenScope: claims.Scope,
 	}, nil
 }
 
@@ -242,9 +300,39 @@ func VerifySessionToken(token string, clusterSecret []byte, sessionID uuid.UUID)
 	return err
 }
 
+// VerifyLDAPSessionToken verifies that a given LDAP session token was signed by a key derived from the given cluster
+// secret using the username as a salt. For a valid token, an expiration time is returned.
+func VerifyLDAPSessionToken(token string, clusterSecret []byte, username string, clusterUUID string) (*time.Time, error) {
+	return verifyToken(token, func() ([]byte, error) {
+		return encryption.TokenSigningKey(clusterSecret, encryption.LDAPSessionTokenSalt(username, clusterUUID))
+	})
+}
+
+// VerifySSHSessionToken verifies that a given SSH session token was signed by a key derived from the given cluster
+// secret using the username as a salt. For a valid token, an expiration time is returned.
+func VerifySSHSessionToken(token string, clusterSecret []byte, username string, clusterUUID string) (*time.Time, error) {
+	return verifyToken(token, func() ([]byte, error) {
+		return encryption.TokenSigningKey(clusterSecret, encryption.SSHSessionTokenSalt(username, clusterUUID))
+	})
+}
+
+// VerifySSHChallengeToken verifies that a given SSH login challenge was signed by a key derived from the given cluster
+// secret using the username as a salt. For a valid challenge, an expiration time is returned.
+func VerifySSHChallengeToken(challenge string, clusterSecret []byte, username string, clusterUUID string) (*time.Time, error) {
+	return verifyToken(challenge, func() ([]byte, error) {
+		return encryption.TokenSigningKey(clusterSecret, encryption.SSHChallengeTokenSalt(username, clusterUUID))
+	})
+}
+
 // verifyToken verifies that the given token was signed by the key returned by the given key func.
 // For a valid token, an expiration time is returned.
 func verifyToken(token string, keyFunc func() ([]byte, error)) (expiresAt *time.Time, err error) {
+	return verifyTokenClaims(token, &jwt.RegisteredClaims{}, keyFunc)
+}
+
+// verifyTokenClaims verifies that the given token was signed by the key returned by the given key func, and parses
+// the claims of the token into the given claims. For a valid token, an expiration time is returned.
+func verifyTokenClaims(token string, claims jwt.Claims, keyFunc func() ([]byte, error)) (expiresAt *time.Time, err error) {
 	// Always use UTC time.
 	timeFunc := func() time.Time {
 		return time.Now().UTC()
@@ -265,8 +353,7 @@ func verifyToken(token string, keyFunc func() ([]byte, error)) (expiresAt *time.
 	}
 
 	// Verify the token.
-	var claims jwt.RegisteredClaims
-	_, err = parser.ParseWithClaims(token, &claims, jwtKeyFunc)
+	_, err = parser.ParseWithClaims(token, claims, jwtKeyFunc)
 	if err != nil {
 		return nil, api.StatusErrorf(http.StatusForbidden, "Token is not valid: %w", err)
 	}
@@ -190,6 +190,38 @@ func (e *embeddedOpenFGA) GetViewableProjects(ctx context.Context, permissions [
 	return projects, nil
 }
 
+// GetGrantingPermissions accepts a list of permissions and returns those that, on their own, would grant a member of a
+// group with the permission the given entitlement on the entity found at the given URL.
+func (e *embeddedOpenFGA) GetGrantingPermissions(ctx context.Context, entityURL *api.URL, entitlement auth.Entitlement, permissions []api.Permission) ([]api.Permission, error) {
+	entityType, projectName, location, pathArguments, err := entity.ParseURL(entityURL.URL)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing entity URL: %w", err)
+	}
+
+	// Construct the URL in a standardised form (adding the project parameter if it was not present).
+	entityURL, err = entityType.URL(projectName, location, pathArguments...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed standardizing entity URL: %w", err)
+	}
+
+	entityObject := fmt.Sprintf("%s:%s", entityType, entityURL.String())
+
+	// Check each permission on its own.
+	var grantingPermissions []api.Permission
+	for _, permission := range permissions {
+		allowed, err := e.checkGroupPermissions(ctx, []api.Permission{permission}, entitlement, entityObject)
+		if err != nil {
+			return nil, err
+		}
+
+		if allowed {
+			grantingPermissions = append(grantingPermissions, permission)
+		}
+	}
+
+	return grantingPermissions, nil
+}
+
 // CheckPermission checks if the current requestor has the given entitlement on the given entity URL.
 func (e *embeddedOpenFGA) CheckPermission(ctx context.Context, entityURL *api.URL, entitlement auth.Entitlement) error {
 	return e.checkPermission(ctx, entityURL, entitlement, true)
@@ -350,8 +382,21 @@ func (e *embeddedOpenFGA) checkPermission(ctx context.Context, entityURL *api.UR
 		return fmt.Errorf("Failed checking OpenFGA relation: %w", err)
 	}
 
+	allowed := resp.GetAllowed()
+
+	// If the caller authenticated with a scoped token, the entitlement must also be granted by the token scope.
+	tokenScope := requestor.CallerTokenScope()
+	if allowed && tokenScope != nil && len(tokenScope.Permissions) > 0 {
+		l.Debug("Checking OpenFGA relation for token scope")
+		allowed, err = e.checkGroupPermissions(ctx, tokenScope.Permissions, entitlement, entityObject)
+		if err != nil {
+			l.Error("Failed checking OpenFGA relation for token scope", logger.Ctx{"err": err})
+			return err
+		}
+	}
+
 	// If not allowed, decide if the user can view the resource.
-	if !resp.GetAllowed() {
+	if !allowed {
 		err := auth.ValidateEntitlement(entityType, auth.EntitlementCanView)
 		doCheckCanView := err == nil
 
@@ -382,8 +427,16 @@ func (e *embeddedOpenFGA) checkPermission(ctx context.Context, entityURL *api.UR
 				return fmt.Errorf("Failed checking OpenFGA relation: %w", err)
 			}
 
+			canView := resp.GetAllowed()
+			if canView && tokenScope != nil && len(tokenScope.Permissions) > 0 {
+				canView, err = e.checkGroupPermissions(ctx, tokenScope.Permissions, auth.EntitlementCanView, entityObject)
+				if err != nil {
+					return err
+				}
+			}
+
 			// If we can't view the resource, return a generic not found error.
-			if !resp.GetAllowed() {
+			if !canView {
 				responseCode = http.StatusNotFound
 			}
 		}
@@ -522,6 +575,21 @@ func (e *embeddedOpenFGA) getPermissionChecker(ctx context.Context, entitlement
 
 	objects := resp.GetObjects()
 
+	// If the caller authenticated with a scoped token, only keep the objects on which the token scope grants the entitlement.
+	tokenScope := requestor.CallerTokenScope()
+	if tokenScope != nil && len(tokenScope.Permissions) > 0 {
+		l.Debug("Listing related objects for token scope")
+		scopeObjects, err := e.listGroupPermissionsObjects(ctx, tokenScope.Permissions, entitlement, entityType)
+		if err != nil {
+			l.Error("Failed listing OpenFGA objects for token scope", logger.Ctx{"err": err})
+			return nil, err
+		}
+
+		objects = slices.DeleteFunc(objects, func(object string) bool {
+			return !slices.Contains(scopeObjects, object)
+		})
+	}
+
 	// Return a permission checker that constructs an OpenFGA object from the given URL and returns true if the object is
 	// found in the list of objects in the response.
 	return func(entityURL *api.URL) bool {
@@ -557,6 +625,77 @@ func (e *embeddedOpenFGA) getPermissionChecker(ctx context.Context, entitlement
 	}, nil
 }
 
+// groupPermissionsTuples returns a user object for a dummy identity and contextual tuples that grant the given
+// permissions to the dummy identity. The dummy identity is a member of a dummy group which cannot exist because group
+// names cannot contain a forward slash.
+func groupPermissionsTuples(permissions []api.Permission) (string, []*openfgav1.TupleKey) {
+	userObject := string(entity.TypeIdentity) + ":" + entity.IdentityURL("dummy", "dummy").String()
+	groupObject := string(entity.TypeAuthGroup) + ":" + entity.AuthGroupURL("dummy/dummy").String()
+
+	tuples := []*openfgav1.TupleKey{
+		{
+			// The dummy identity is a member of the dummy group.
+			User:     userObject,
+			Relation: "member",
+			Object:   groupObject,
+		},
+	}
+
+	for _, permission := range permissions {
+		tuples = append(tuples, &openfgav1.TupleKey{
+			User:     groupObject + "#member", // Members of the dummy group have permission, not the group itself.
+			Relation: permission.Entitlement,
+			Object:   permission.EntityType + ":" + permission.EntityReference,
+		})
+	}
+
+	return userObject, tuples
+}
+
+// checkGroupPermissions returns true if a member of a group with the given permissions has the given entitlement on the
+// given object.
+func (e *embeddedOpenFGA) checkGroupPermissions(ctx context.Context, permissions []api.Permission, entitlement auth.Entitlement, object string) (bool, error) {
+	// The request cache is optimised for the permissions of the caller, and none of the tuples we pass contextually.
+	ctx = context.WithValue(ctx, request.CtxOpenFGARequestCache, nil)
+
+	userObject, tuples := groupPermissionsTuples(permissions)
+	resp, err := e.server.Check(ctx, &openfgav1.CheckRequest{
+		StoreId: dummyDatastoreULID,
+		TupleKey: &openfgav1.CheckRequestTupleKey{
+			User:     userObject,
+			Relation: string(entitlement),
+			Object:   object,
+		},
+		ContextualTuples: &openfgav1.ContextualTupleKeys{TupleKeys: tuples},
+	})
+	if err != nil {
+		return false, fmt.Errorf("Failed checking OpenFGA relation for group permissions: %w", err)
+	}
+
+	return resp.GetAllowed(), nil
+}
+
+// listGroupPermissionsObjects returns the objects of the given entity type on which a member of a group with the given
+// permissions has the given entitlement.
+func (e *embeddedOpenFGA) listGroupPermissionsObjects(ctx context.Context, permissions []api.Permission, entitlement auth.Entitlement, entityType entity.Type) ([]string, error) {
+	// The request cache is optimised for the permissions of the caller, and none of the tuples we pass contextually.
+	ctx = context.WithValue(ctx, request.CtxOpenFGARequestCache, nil)
+
+	userObject, tuples := groupPermissionsTuples(permissions)
+	resp, err := e.server.ListObjects(ctx, &openfgav1.ListObjectsRequest{
+		StoreId:          dummyDatastoreULID,
+		Type:             entityType.String(),
+		Relation:         string(entitlement),
+		User:             userObject,
+		ContextualTuples: &openfgav1.ContextualTupleKeys{TupleKeys: tuples},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed listing OpenFGA objects for group permissions: %w", err)
+	}
+
+	return resp.GetObjects(), nil
+}
+
 // openfgaLogger implements OpenFGAs logger.Logger interface but delegates to our logger.
 type openfgaLogger struct {
 	l logger.Logger
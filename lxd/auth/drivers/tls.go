@@ -37,6 +37,11 @@ func (t *tls) GetViewableProjects(ctx context.Context, permissions []api.Permiss
 	return nil, api.NewGenericStatusError(http.StatusNotImplemented)
 }
 
+// GetGrantingPermissions is not implemented for the TLS authorizer.
+func (t *tls) GetGrantingPermissions(ctx context.Context, entityURL *api.URL, entitlement auth.Entitlement, permissions []api.Permission) ([]api.Permission, error) {
+	return nil, api.NewGenericStatusError(http.StatusNotImplemented)
+}
+
 // CheckPermission returns an error if the user does not have the given Entitlement on the given Object.
 func (t *tls) CheckPermission(ctx context.Context, entityURL *api.URL, entitlement auth.Entitlement) error {
 	entityType, projectName, _, pathArguments, err := entity.ParseURL(entityURL.URL)
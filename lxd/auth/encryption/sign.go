@@ -10,17 +10,61 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/canonical/lxd/client"
+	"github.com/canonical/lxd/lxd/auth"
 )
 
 const (
 	audienceDevLXD = "devlxd"
+	audienceLDAP   = "ldap"
+
+	audienceSSH          = "ssh"
+	audienceSSHChallenge = "ssh-challenge"
 )
 
+// BearerTokenClaims are the claims of tokens issued by LXD. They extend [lxd.ClientBearerTokenClaims] with claims that
+// are only inspected by LXD.
+type BearerTokenClaims struct {
+	lxd.ClientBearerTokenClaims
+
+	// Optional scope restricting the access granted by a client bearer token.
+	Scope *auth.TokenScope `json:"scope,omitempty"`
+}
+
 // DevLXDAudience returns the aud claim for all DevLXD tokens issued by this cluster.
 func DevLXDAudience(clusterUUID string) string {
 	return strings.Join([]string{audienceDevLXD, clusterUUID}, ":")
 }
 
+// LDAPAudience returns the aud claim for all LDAP session tokens issued by this cluster.
+func LDAPAudience(clusterUUID string) string {
+	return strings.Join([]string{audienceLDAP, clusterUUID}, ":")
+}
+
+// LDAPSessionTokenSalt returns the salt used to derive the signing key of the LDAP session tokens of the given user.
+func LDAPSessionTokenSalt(username string, clusterUUID string) []byte {
+	return []byte(LDAPAudience(clusterUUID) + ":" + username)
+}
+
+// SSHAudience returns the aud claim for all SSH session tokens issued by this cluster.
+func SSHAudience(clusterUUID string) string {
+	return strings.Join([]string{audienceSSH, clusterUUID}, ":")
+}
+
+// SSHSessionTokenSalt returns the salt used to derive the signing key of the SSH session tokens of the given user.
+func SSHSessionTokenSalt(username string, clusterUUID string) []byte {
+	return []byte(SSHAudience(clusterUUID) + ":" + username)
+}
+
+// SSHChallengeAudience returns the aud claim for all SSH login challenges issued by this cluster.
+func SSHChallengeAudience(clusterUUID string) string {
+	return strings.Join([]string{audienceSSHChallenge, clusterUUID}, ":")
+}
+
+// SSHChallengeTokenSalt returns the salt used to derive the signing key of the SSH login challenges of the given user.
+func SSHChallengeTokenSalt(username string, clusterUUID string) []byte {
+	return []byte(SSHChallengeAudience(clusterUUID) + ":" + username)
+}
+
 // Issuer returns the iss claim for all tokens issued by this LXD cluster.
 func Issuer(clusterUUID string) string {
 	return strings.Join([]string{"lxd", clusterUUID}, ":")
@@ -40,7 +84,7 @@ func LXDAudience(clusterUUID string) string {
 // - Issued at (iat): time now (UTC)
 // - Expiry (exp): The given time (UTC).
 func GetDevLXDBearerToken(secret []byte, identityIdentifier string, clusterUUID string, expiresAt time.Time) (string, error) {
-	return getToken(secret, nil, identityIdentifier, clusterUUID, DevLXDAudience, expiresAt, "")
+	return getToken(secret, nil, identityIdentifier, clusterUUID, DevLXDAudience, expiresAt, "", nil)
 }
 
 // GetClientBearerToken generates and signs a token for use with the main LXD API. For claims it has:
@@ -51,12 +95,13 @@ func GetDevLXDBearerToken(secret []byte, identityIdentifier string, clusterUUID
 // - Issued at (iat): time now (UTC)
 // - Expiry (exp): The given time (UTC).
 // - Server certificate fingerprint (server_cert_fingerprint): The given serverCertFingerprint.
-func GetClientBearerToken(secret []byte, identityIdentifier string, clusterUUID string, expiresAt time.Time, serverCertFingerprint string) (string, error) {
+// - Scope (scope): The given scope, if not nil.
+func GetClientBearerToken(secret []byte, identityIdentifier string, clusterUUID string, expiresAt time.Time, serverCertFingerprint string, scope *auth.TokenScope) (string, error) {
 	if serverCertFingerprint == "" {
 		return "", errors.New("Server certificate fingerprint must be provided for LXD bearer tokens")
 	}
 
-	return getToken(secret, nil, identityIdentifier, clusterUUID, LXDAudience, expiresAt, serverCertFingerprint)
+	return getToken(secret, nil, identityIdentifier, clusterUUID, LXDAudience, expiresAt, serverCertFingerprint, scope)
 }
 
 // GetOIDCSessionToken generates and signs a token to be set as an OIDC session cookie. For claims it has:
@@ -67,7 +112,41 @@ func GetClientBearerToken(secret []byte, identityIdentifier string, clusterUUID
 // - Issued at (iat): time now (UTC)
 // - Expiry (exp): The given time (UTC).
 func GetOIDCSessionToken(secret []byte, sessionID uuid.UUID, clusterUUID string, expiresAt time.Time) (string, error) {
-	return getToken(secret, sessionID[:], sessionID.String(), clusterUUID, LXDAudience, expiresAt, "")
+	return getToken(secret, sessionID[:], sessionID.String(), clusterUUID, LXDAudience, expiresAt, "", nil)
+}
+
+// GetLDAPSessionToken generates and signs a session token for a user authenticated with LDAP. For claims it has:
+// - Subject (sub): Identity identifier (username)
+// - Issuer (iss): "lxd:{cluster_uuid}"
+// - Audience (aud): "ldap:{cluster_uuid}"
+// - Not before (nbf): time now (UTC)
+// - Issued at (iat): time now (UTC)
+// - Expiry (exp): The given time (UTC).
+func GetLDAPSessionToken(secret []byte, username string, clusterUUID string, expiresAt time.Time) (string, error) {
+	return getToken(secret, LDAPSessionTokenSalt(username, clusterUUID), username, clusterUUID, LDAPAudience, expiresAt, "", nil)
+}
+
+// GetSSHSessionToken generates and signs a session token for a user authenticated with an SSH key. For claims it has:
+// - Subject (sub): Identity identifier (username)
+// - Issuer (iss): "lxd:{cluster_uuid}"
+// - Audience (aud): "ssh:{cluster_uuid}"
+// - Not before (nbf): time now (UTC)
+// - Issued at (iat): time now (UTC)
+// - Expiry (exp): The given time (UTC).
+func GetSSHSessionToken(secret []byte, username string, clusterUUID string, expiresAt time.Time) (string, error) {
+	return getToken(secret, SSHSessionTokenSalt(username, clusterUUID), username, clusterUUID, SSHAudience, expiresAt, "", nil)
+}
+
+// GetSSHChallengeToken generates and signs a challenge to be signed with the SSH key of a user when logging in.
+// For claims it has:
+// - Subject (sub): Username
+// - Issuer (iss): "lxd:{cluster_uuid}"
+// - Audience (aud): "ssh-challenge:{cluster_uuid}"
+// - Not before (nbf): time now (UTC)
+// - Issued at (iat): time now (UTC)
+// - Expiry (exp): The given time (UTC).
+func GetSSHChallengeToken(secret []byte, username string, clusterUUID string, expiresAt time.Time) (string, error) {
+	return getToken(secret, SSHChallengeTokenSalt(username, clusterUUID), username, clusterUUID, SSHChallengeAudience, expiresAt, "", nil)
 }
 
 // getToken generates and signs a token for use with the LXD. If a salt is provided, a signing key will be generated
@@ -80,16 +159,20 @@ func GetOIDCSessionToken(secret []byte, sessionID uuid.UUID, clusterUUID string,
 // - Issued at (iat): time now (UTC)
 // - Expiry (exp): The given time (UTC).
 // - Server certificate fingerprint (server_cert_fingerprint): The given serverCertFingerprint, if not empty.
-func getToken(secret []byte, salt []byte, subject string, clusterUUID string, audienceFunc func(string) string, expiresAt time.Time, serverCertFingerprint string) (string, error) {
-	claims := lxd.ClientBearerTokenClaims{
-		RegisteredClaims: jwt.RegisteredClaims{
-			Issuer:    Issuer(clusterUUID),
-			Subject:   subject,
-			Audience:  jwt.ClaimStrings{audienceFunc(clusterUUID)},
-			NotBefore: jwt.NewNumericDate(time.Now().UTC()),
-			IssuedAt:  jwt.NewNumericDate(time.Now().UTC()),
-			ExpiresAt: jwt.NewNumericDate(expiresAt.UTC()),
+// - Scope (scope): The given scope, if not nil.
+func getToken(secret []byte, salt []byte, subject string, clusterUUID string, audienceFunc func(string) string, expiresAt time.Time, serverCertFingerprint string, scope *auth.TokenScope) (string, error) {
+	claims := BearerTokenClaims{
+		ClientBearerTokenClaims: lxd.ClientBearerTokenClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    Issuer(clusterUUID),
+				Subject:   subject,
+				Audience:  jwt.ClaimStrings{audienceFunc(clusterUUID)},
+				NotBefore: jwt.NewNumericDate(time.Now().UTC()),
+				IssuedAt:  jwt.NewNumericDate(time.Now().UTC()),
+				ExpiresAt: jwt.NewNumericDate(expiresAt.UTC()),
+			},
 		},
+		Scope: scope,
 	}
 
 	// If server certificate fingerprint is provided, include it in the claims.
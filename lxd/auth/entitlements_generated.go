@@ -10,13 +10,13 @@ import (
 type Entitlement string
 
 const (
-	// EntitlementCanView is the "can_view" entitlement. It applies to the following entities: entity.TypeCertificate, entity.TypeClusterLink, entity.TypeAuthGroup, entity.TypeIdentity, entity.TypeIdentityProviderGroup, entity.TypeImage, entity.TypeImageAlias, entity.TypeInstance, entity.TypeNetwork, entity.TypeNetworkACL, entity.TypeNetworkZone, entity.TypePlacementGroup, entity.TypeProfile, entity.TypeProject, entity.TypeReplicator, entity.TypeStorageBucket, entity.TypeStorageVolume.
+	// EntitlementCanView is the "can_view" entitlement. It applies to the following entities: entity.TypeCertificate, entity.TypeClusterLink, entity.TypeAuthGroup, entity.TypeIdentity, entity.TypeIdentityProviderGroup, entity.TypeImage, entity.TypeImageAlias, entity.TypeInstance, entity.TypeInstanceTemplate, entity.TypeNetwork, entity.TypeNetworkACL, entity.TypeNetworkZone, entity.TypePlacementGroup, entity.TypeProfile, entity.TypeProject, entity.TypeReplicator, entity.TypeStorageBucket, entity.TypeStorageVolume.
 	EntitlementCanView Entitlement = "can_view"
 
-	// EntitlementCanEdit is the "can_edit" entitlement. It applies to the following entities: entity.TypeCertificate, entity.TypeClusterLink, entity.TypeAuthGroup, entity.TypeIdentity, entity.TypeIdentityProviderGroup, entity.TypeImage, entity.TypeImageAlias, entity.TypeInstance, entity.TypeNetwork, entity.TypeNetworkACL, entity.TypeNetworkZone, entity.TypePlacementGroup, entity.TypeProfile, entity.TypeProject, entity.TypeReplicator, entity.TypeServer, entity.TypeStorageBucket, entity.TypeStoragePool, entity.TypeStorageVolume.
+	// EntitlementCanEdit is the "can_edit" entitlement. It applies to the following entities: entity.TypeCertificate, entity.TypeClusterLink, entity.TypeAuthGroup, entity.TypeIdentity, entity.TypeIdentityProviderGroup, entity.TypeImage, entity.TypeImageAlias, entity.TypeInstance, entity.TypeInstanceTemplate, entity.TypeNetwork, entity.TypeNetworkACL, entity.TypeNetworkZone, entity.TypePlacementGroup, entity.TypeProfile, entity.TypeProject, entity.TypeReplicator, entity.TypeServer, entity.TypeStorageBucket, entity.TypeStoragePool, entity.TypeStorageVolume.
 	EntitlementCanEdit Entitlement = "can_edit"
 
-	// EntitlementCanDelete is the "can_delete" entitlement. It applies to the following entities: entity.TypeCertificate, entity.TypeClusterLink, entity.TypeAuthGroup, entity.TypeIdentity, entity.TypeIdentityProviderGroup, entity.TypeImage, entity.TypeImageAlias, entity.TypeInstance, entity.TypeNetwork, entity.TypeNetworkACL, entity.TypeNetworkZone, entity.TypePlacementGroup, entity.TypeProfile, entity.TypeProject, entity.TypeReplicator, entity.TypeStorageBucket, entity.TypeStoragePool, entity.TypeStorageVolume.
+	// EntitlementCanDelete is the "can_delete" entitlement. It applies to the following entities: entity.TypeCertificate, entity.TypeClusterLink, entity.TypeAuthGroup, entity.TypeIdentity, entity.TypeIdentityProviderGroup, entity.TypeImage, entity.TypeImageAlias, entity.TypeInstance, entity.TypeInstanceTemplate, entity.TypeNetwork, entity.TypeNetworkACL, entity.TypeNetworkZone, entity.TypePlacementGroup, entity.TypeProfile, entity.TypeProject, entity.TypeReplicator, entity.TypeStorageBucket, entity.TypeStoragePool, entity.TypeStorageVolume.
 	EntitlementCanDelete Entitlement = "can_delete"
 
 	// EntitlementAdmin is the "admin" entitlement. It applies to the following entities: entity.TypeServer.
@@ -298,6 +298,21 @@ const (
 	// EntitlementCanDeleteReplicators is the "can_delete_replicators" entitlement. It applies to the following entities: entity.TypeProject.
 	EntitlementCanDeleteReplicators Entitlement = "can_delete_replicators"
 
+	// EntitlementInstanceTemplateManager is the "instance_template_manager" entitlement. It applies to the following entities: entity.TypeProject.
+	EntitlementInstanceTemplateManager Entitlement = "instance_template_manager"
+
+	// EntitlementCanCreateInstanceTemplates is the "can_create_instance_templates" entitlement. It applies to the following entities: entity.TypeProject.
+	EntitlementCanCreateInstanceTemplates Entitlement = "can_create_instance_templates"
+
+	// EntitlementCanViewInstanceTemplates is the "can_view_instance_templates" entitlement. It applies to the following entities: entity.TypeProject.
+	EntitlementCanViewInstanceTemplates Entitlement = "can_view_instance_templates"
+
+	// EntitlementCanEditInstanceTemplates is the "can_edit_instance_templates" entitlement. It applies to the following entities: entity.TypeProject.
+	EntitlementCanEditInstanceTemplates Entitlement = "can_edit_instance_templates"
+
+	// EntitlementCanDeleteInstanceTemplates is the "can_delete_instance_templates" entitlement. It applies to the following entities: entity.TypeProject.
+	EntitlementCanDeleteInstanceTemplates Entitlement = "can_delete_instance_templates"
+
 	// EntitlementUser is the "user" entitlement. It applies to the following entities: entity.TypeInstance.
 	EntitlementUser Entitlement = "user"
 
@@ -406,6 +421,14 @@ var EntityTypeToEntitlements = map[entity.Type][]Entitlement{
 		// Grants permission to start a terminal session.
 		EntitlementCanExec,
 	},
+	entity.TypeInstanceTemplate: {
+		// Grants permission to edit the instance template.
+		EntitlementCanEdit,
+		// Grants permission to delete the instance template.
+		EntitlementCanDelete,
+		// Grants permission to view the instance template.
+		EntitlementCanView,
+	},
 	entity.TypeNetwork: {
 		// Grants permission to edit the network.
 		EntitlementCanEdit,
@@ -569,6 +592,16 @@ var EntityTypeToEntitlements = map[entity.Type][]Entitlement{
 		EntitlementCanEditReplicators,
 		// Grants permission to delete replicators.
 		EntitlementCanDeleteReplicators,
+		// Grants permission to create, view, edit, and delete all instance templates belonging to the project.
+		EntitlementInstanceTemplateManager,
+		// Grants permission to create instance templates.
+		EntitlementCanCreateInstanceTemplates,
+		// Grants permission to view instance templates.
+		EntitlementCanViewInstanceTemplates,
+		// Grants permission to edit instance templates.
+		EntitlementCanEditInstanceTemplates,
+		// Grants permission to delete instance templates.
+		EntitlementCanDeleteInstanceTemplates,
 		// Grants permission to view operations relating to the project.
 		EntitlementCanViewOperations,
 		// Grants permission to view life cycle events relating to the project.
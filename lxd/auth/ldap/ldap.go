@@ -0,0 +1,246 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	goLDAP "github.com/go-ldap/ldap/v3"
+
+	"github.com/canonical/lxd/shared/api"
+)
+
+const (
+	// PlaceholderUsername is replaced by the escaped username in the user search filter and in the group search filter.
+	PlaceholderUsername = "{username}"
+
+	// PlaceholderDN is replaced by the escaped distinguished name of the user in the group search filter.
+	PlaceholderDN = "{dn}"
+
+	// timeout is the timeout used when connecting to the LDAP server and for each LDAP request.
+	timeout = 10 * time.Second
+)
+
+// errInvalidCredentials is returned for any authentication failure, so as not to reveal whether a user exists.
+var errInvalidCredentials = api.NewStatusError(http.StatusForbidden, "Invalid username or password")
+
+// Args contains the configuration of an [Authenticator].
+type Args struct {
+	// URL of the LDAP server, using the ldap or ldaps scheme.
+	URL string
+
+	// StartTLS upgrades ldap connections to TLS.
+	StartTLS bool
+
+	// CACert is a PEM encoded CA certificate used to verify the LDAP server certificate.
+	CACert string
+
+	// BindDN and BindPassword are the credentials used to search the directory.
+	// If not set, the directory is searched anonymously.
+	BindDN       string
+	BindPassword string
+
+	// UserBaseDN is where users are searched for.
+	UserBaseDN string
+
+	// UserFilter selects the entry of the user. It must contain [PlaceholderUsername].
+	UserFilter string
+
+	// GroupsBaseDN is where groups are searched for. If not set, groups are not searched for.
+	GroupsBaseDN string
+
+	// GroupsFilter selects the groups of the user. It may contain [PlaceholderDN] and [PlaceholderUsername].
+	GroupsFilter string
+
+	// GroupsAttribute is the attribute of the group entries used as the identity provider group name.
+	GroupsAttribute string
+}
+
+// Authenticator authenticates users against an LDAP directory (such as OpenLDAP or Active Directory) by binding with
+// their credentials, and gets the groups they are a member of.
+type Authenticator struct {
+	args      Args
+	tlsConfig *tls.Config
+}
+
+// AuthenticationResult represents a user authenticated by the LDAP directory.
+type AuthenticationResult struct {
+	// Username is the normalized (lower case) username. It is used as the identity identifier.
+	Username string
+
+	// DN is the distinguished name of the user entry.
+	DN string
+
+	// Name is the display name of the user, if set in the directory.
+	Name string
+
+	// IdentityProviderGroups contains the names of the groups the user is a member of.
+	IdentityProviderGroups []string
+}
+
+// NewAuthenticator validates the given configuration and returns an [Authenticator].
+func NewAuthenticator(args Args) (*Authenticator, error) {
+	u, err := url.Parse(args.URL)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid LDAP server URL: %w", err)
+	}
+
+	if !slices.Contains([]string{"ldap", "ldaps"}, u.Scheme) || u.Host == "" {
+		return nil, fmt.Errorf("Invalid LDAP server URL %q: Expected ldap://HOST[:PORT] or ldaps://HOST[:PORT]", args.URL)
+	}
+
+	if args.StartTLS && u.Scheme != "ldap" {
+		return nil, errors.New("StartTLS can only be used with the ldap scheme")
+	}
+
+	if args.UserBaseDN == "" {
+		return nil, errors.New("A user base DN is required")
+	}
+
+	if !strings.Contains(args.UserFilter, PlaceholderUsername) {
+		return nil, fmt.Errorf("The user filter must contain %q", PlaceholderUsername)
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName: u.Hostname(),
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if args.CACert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(args.CACert)) {
+			return nil, errors.New("Failed parsing the LDAP server CA certificate")
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	return &Authenticator{args: args, tlsConfig: tlsConfig}, nil
+}
+
+// Login checks the given credentials by binding to the directory as the user, and returns the user details.
+// An [api.StatusError] with [http.StatusForbidden] is returned if the credentials are invalid.
+func (a *Authenticator) Login(username string, password string) (*AuthenticationResult, error) {
+	// An empty password would result in an unauthenticated bind, which always succeeds.
+	if username == "" || password == "" {
+		return nil, errInvalidCredentials
+	}
+
+	conn, err := a.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	err = a.bindSearch(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the user entry.
+	filter := strings.ReplaceAll(a.args.UserFilter, PlaceholderUsername, goLDAP.EscapeFilter(username))
+	res, err := conn.Search(goLDAP.NewSearchRequest(a.args.UserBaseDN, goLDAP.ScopeWholeSubtree, goLDAP.NeverDerefAliases, 2, int(timeout.Seconds()), false, filter, []string{"cn", "displayName"}, nil))
+	if err != nil && !goLDAP.IsErrorWithCode(err, goLDAP.LDAPResultSizeLimitExceeded) {
+		return nil, fmt.Errorf("Failed searching for LDAP user: %w", err)
+	}
+
+	// The filter must match exactly one user.
+	if len(res.Entries) != 1 {
+		return nil, errInvalidCredentials
+	}
+
+	user := res.Entries[0]
+
+	// Check the password.
+	err = conn.Bind(user.DN, password)
+	if err != nil {
+		if goLDAP.IsErrorWithCode(err, goLDAP.LDAPResultInvalidCredentials) {
+			return nil, errInvalidCredentials
+		}
+
+		return nil, fmt.Errorf("Failed authenticating LDAP user: %w", err)
+	}
+
+	result := &AuthenticationResult{
+		Username: strings.ToLower(username),
+		DN:       user.DN,
+		Name:     user.GetAttributeValue("displayName"),
+	}
+
+	if result.Name == "" {
+		result.Name = user.GetAttributeValue("cn")
+	}
+
+	if a.args.GroupsBaseDN == "" {
+		return result, nil
+	}
+
+	// Search for the groups with the search credentials, as users may not be allowed to read group entries.
+	err = a.bindSearch(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	filter = strings.ReplaceAll(a.args.GroupsFilter, PlaceholderDN, goLDAP.EscapeFilter(user.DN))
+	filter = strings.ReplaceAll(filter, PlaceholderUsername, goLDAP.EscapeFilter(username))
+	res, err = conn.Search(goLDAP.NewSearchRequest(a.args.GroupsBaseDN, goLDAP.ScopeWholeSubtree, goLDAP.NeverDerefAliases, 0, int(timeout.Seconds()), false, filter, []string{a.args.GroupsAttribute}, nil))
+	if err != nil {
+		return nil, fmt.Errorf("Failed searching for LDAP groups: %w", err)
+	}
+
+	result.IdentityProviderGroups = make([]string, 0, len(res.Entries))
+	for _, entry := range res.Entries {
+		name := entry.GetAttributeValue(a.args.GroupsAttribute)
+		if name != "" && !slices.Contains(result.IdentityProviderGroups, name) {
+			result.IdentityProviderGroups = append(result.IdentityProviderGroups, name)
+		}
+	}
+
+	slices.Sort(result.IdentityProviderGroups)
+
+	return result, nil
+}
+
+// connect opens a connection to the LDAP server, upgrading it to TLS if configured.
+func (a *Authenticator) connect() (*goLDAP.Conn, error) {
+	conn, err := goLDAP.DialURL(a.args.URL, goLDAP.DialWithTLSDialer(a.tlsConfig, &net.Dialer{Timeout: timeout}))
+	if err != nil {
+		return nil, fmt.Errorf("Failed connecting to LDAP server: %w", err)
+	}
+
+	conn.SetTimeout(timeout)
+
+	if a.args.StartTLS {
+		err = conn.StartTLS(a.tlsConfig)
+		if err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("Failed starting TLS with LDAP server: %w", err)
+		}
+	}
+
+	return conn, nil
+}
+
+// bindSearch binds with the credentials used to search the directory.
+func (a *Authenticator) bindSearch(conn *goLDAP.Conn) error {
+	var err error
+	if a.args.BindDN == "" {
+		err = conn.UnauthenticatedBind("")
+	} else {
+		err = conn.Bind(a.args.BindDN, a.args.BindPassword)
+	}
+
+	if err != nil {
+		return fmt.Errorf("Failed binding to LDAP server: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,203 @@
+package ssh
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/sshsig"
+)
+
+// errInvalidCredentials is returned for any authentication failure, so as not to reveal whether a user exists or which
+// keys are trusted.
+var errInvalidCredentials = api.NewStatusError(http.StatusForbidden, "Invalid SSH credentials")
+
+// Authenticator authenticates users that sign a challenge with an SSH key. The key must either be a user certificate
+// signed by one of the trusted CA keys, or one of the authorized keys of the identity of the user.
+type Authenticator struct {
+	caKeys []ssh.PublicKey
+}
+
+// AuthenticationResult represents a user authenticated with an SSH key.
+type AuthenticationResult struct {
+	// Username is the username the challenge was signed for. It is used as the identity identifier.
+	Username string
+
+	// Certificate is true if the user authenticated with a certificate signed by a trusted CA key.
+	Certificate bool
+
+	// IdentityProviderGroups contains the principals of the certificate other than the username.
+	IdentityProviderGroups []string
+
+	// ExpiresAt is when the certificate expires. It is zero for keys and for certificates that don't expire.
+	ExpiresAt time.Time
+}
+
+// ParseAuthorizedKeys parses the given keys in the authorized_keys format (one key per line).
+// Empty lines and comments are ignored.
+func ParseAuthorizedKeys(keys string) ([]ssh.PublicKey, error) {
+	var publicKeys []ssh.PublicKey
+	for line := range strings.SplitSeq(keys, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, _, options, _, err := ssh.ParseAuthorizedKey([]byte(line))
+		if err != nil {
+			return nil, fmt.Errorf("Failed parsing SSH key %q: %w", line, err)
+		}
+
+		if len(options) > 0 {
+			return nil, fmt.Errorf("SSH key options are not supported: %q", line)
+		}
+
+		_, ok := key.(*ssh.Certificate)
+		if ok {
+			return nil, fmt.Errorf("SSH certificates cannot be used as keys: %q", line)
+		}
+
+		publicKeys = append(publicKeys, key)
+	}
+
+	return publicKeys, nil
+}
+
+// NormalizeAuthorizedKeys validates the given keys and returns them in the authorized_keys format, keeping their
+// comments. Duplicate keys are removed.
+func NormalizeAuthorizedKeys(keys []string) ([]string, error) {
+	normalized := make([]string, 0, len(keys))
+	var seen [][]byte
+	for _, line := range keys {
+		key, comment, options, rest, err := ssh.ParseAuthorizedKey([]byte(line))
+		if err != nil {
+			return nil, api.StatusErrorf(http.StatusBadRequest, "Failed parsing SSH key %q: %w", line, err)
+		}
+
+		if len(options) > 0 || len(bytes.TrimSpace(rest)) > 0 {
+			return nil, api.StatusErrorf(http.StatusBadRequest, "Expected a single SSH key without options, got %q", line)
+		}
+
+		_, ok := key.(*ssh.Certificate)
+		if ok {
+			return nil, api.StatusErrorf(http.StatusBadRequest, "SSH certificates cannot be used as authorized keys: %q", line)
+		}
+
+		if slices.ContainsFunc(seen, func(b []byte) bool { return bytes.Equal(b, key.Marshal()) }) {
+			continue
+		}
+
+		seen = append(seen, key.Marshal())
+		authorizedKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key)))
+		if comment != "" {
+			authorizedKey += " " + comment
+		}
+
+		normalized = append(normalized, authorizedKey)
+	}
+
+	return normalized, nil
+}
+
+// NewAuthenticator parses the given trusted CA keys (in the authorized_keys format) and returns an [Authenticator].
+// If no CA keys are given, users can only authenticate with the authorized keys of their identity.
+func NewAuthenticator(caKeys string) (*Authenticator, error) {
+	keys, err := ParseAuthorizedKeys(caKeys)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid SSH CA keys: %w", err)
+	}
+
+	return &Authenticator{caKeys: keys}, nil
+}
+
+// HasCAKeys returns true if trusted CA keys are configured.
+func (a *Authenticator) HasCAKeys() bool {
+	return len(a.caKeys) > 0
+}
+
+// Login checks that the challenge was signed by the given public key (in the authorized_keys format), and that the
+// key may be used to authenticate as the given user. A certificate must be a user certificate that was signed by a
+// trusted CA key and that contains the username as a principal. Otherwise, the key must be one of the given authorized
+// keys. The signature is an armored SSH signature (SSHSIG) of the challenge for the [api.AuthSSHSignatureNamespace]
+// namespace, as created by "ssh-keygen -Y sign".
+// An [api.StatusError] with [http.StatusForbidden] is returned if the credentials are invalid.
+func (a *Authenticator) Login(username string, challenge string, publicKey string, signature string, authorizedKeys []string) (*AuthenticationResult, error) {
+	if username == "" || challenge == "" {
+		return nil, errInvalidCredentials
+	}
+
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "Failed parsing SSH public key: %w", err)
+	}
+
+	signingKey, err := sshsig.Verify(signature, api.AuthSSHSignatureNamespace, []byte(challenge))
+	if err != nil || !bytes.Equal(signingKey.Marshal(), key.Marshal()) {
+		return nil, errInvalidCredentials
+	}
+
+	result := &AuthenticationResult{Username: username}
+
+	cert, ok := key.(*ssh.Certificate)
+	if ok {
+		err = a.checkCertificate(username, cert)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Certificate = true
+		for _, principal := range cert.ValidPrincipals {
+			if principal != username && !slices.Contains(result.IdentityProviderGroups, principal) {
+				result.IdentityProviderGroups = append(result.IdentityProviderGroups, principal)
+			}
+		}
+
+		slices.Sort(result.IdentityProviderGroups)
+
+		if cert.ValidBefore != ssh.CertTimeInfinity {
+			result.ExpiresAt = time.Unix(int64(cert.ValidBefore), 0).UTC()
+		}
+	} else {
+		authorized := slices.ContainsFunc(authorizedKeys, func(authorizedKey string) bool {
+			k, _, _, _, err := ssh.ParseAuthorizedKey([]byte(authorizedKey))
+			return err == nil && bytes.Equal(k.Marshal(), key.Marshal())
+		})
+
+		if !authorized {
+			return nil, errInvalidCredentials
+		}
+	}
+
+	return result, nil
+}
+
+// checkCertificate checks that the certificate is a valid user certificate for the given username that was signed by
+// a trusted CA key.
+func (a *Authenticator) checkCertificate(username string, cert *ssh.Certificate) error {
+	if len(a.caKeys) == 0 || cert.CertType != ssh.UserCert {
+		return errInvalidCredentials
+	}
+
+	trusted := slices.ContainsFunc(a.caKeys, func(caKey ssh.PublicKey) bool {
+		return bytes.Equal(caKey.Marshal(), cert.SignatureKey.Marshal())
+	})
+
+	if !trusted {
+		return errInvalidCredentials
+	}
+
+	// This checks the principals, the validity period, the CA signature and that there are no critical options.
+	checker := ssh.CertChecker{}
+	err := checker.CheckCert(username, cert)
+	if err != nil {
+		return errInvalidCredentials
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package ssh
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/sshsig"
+)
+
+// newSigner returns a new ed25519 SSH signer.
+func newSigner(t *testing.T) ssh.Signer {
+	t.Helper()
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signer
+}
+
+// newCertificate returns a user certificate for the key of the given signer, signed by the given CA.
+func newCertificate(t *testing.T, ca ssh.Signer, signer ssh.Signer, certType uint32, principals []string, validBefore time.Time) ssh.Signer {
+	t.Helper()
+
+	cert := &ssh.Certificate{
+		Key:             signer.PublicKey(),
+		CertType:        certType,
+		ValidPrincipals: principals,
+		ValidAfter:      uint64(time.Now().Add(-time.Minute).Unix()),
+		ValidBefore:     uint64(validBefore.Unix()),
+	}
+
+	err := cert.SignCert(rand.Reader, ca)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certSigner, err := ssh.NewCertSigner(cert, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return certSigner
+}
+
+// sign returns the public key and the signature of the challenge as sent by clients.
+func sign(t *testing.T, signer ssh.Signer, challenge string) (publicKey string, signature string) {
+	t.Helper()
+
+	sig, err := sshsig.Sign(signer, api.AuthSSHSignatureNamespace, []byte(challenge))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(ssh.MarshalAuthorizedKey(signer.PublicKey())), sig
+}
+
+func TestAuthenticatorLogin(t *testing.T) {
+	ca := newSigner(t)
+	otherCA := newSigner(t)
+	user := newSigner(t)
+	challenge := "challenge"
+
+	authenticator, err := NewAuthenticator("# Trusted CA\n" + string(ssh.MarshalAuthorizedKey(ca.PublicKey())))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A valid certificate is accepted, and the other principals are returned as groups.
+	validBefore := time.Now().Add(time.Hour).Truncate(time.Second)
+	cert := newCertificate(t, ca, user, ssh.UserCert, []string{"jdoe", "operators", "admins"}, validBefore)
+	publicKey, signature := sign(t, cert, challenge)
+	res, err := authenticator.Login("jdoe", challenge, publicKey, signature, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Username != "jdoe" || !res.Certificate || !slices.Equal(res.IdentityProviderGroups, []string{"admins", "operators"}) || !res.ExpiresAt.Equal(validBefore) {
+		t.Fatalf("Unexpected authentication result %+v", res)
+	}
+
+	// The signature must be for the LXD namespace.
+	otherSignature, err := sshsig.Sign(cert, "file", []byte(challenge))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = authenticator.Login("jdoe", challenge, publicKey, otherSignature, nil)
+	if !api.StatusErrorCheck(err, http.StatusForbidden) {
+		t.Fatalf("Expected signature for another namespace to be forbidden, got %v", err)
+	}
+
+	// The signature must be made with the given key.
+	_, otherSignature = sign(t, user, challenge)
+	_, err = authenticator.Login("jdoe", challenge, publicKey, otherSignature, nil)
+	if !api.StatusErrorCheck(err, http.StatusForbidden) {
+		t.Fatalf("Expected signature of another key to be forbidden, got %v", err)
+	}
+
+	// The signature must be over the given challenge.
+	_, err = authenticator.Login("jdoe", "other", publicKey, signature, nil)
+	if !api.StatusErrorCheck(err, http.StatusForbidden) {
+		t.Fatalf("Expected signature of another challenge to be forbidden, got %v", err)
+	}
+
+	// The username must be a principal of the certificate.
+	_, err = authenticator.Login("operators2", challenge, publicKey, signature, nil)
+	if !api.StatusErrorCheck(err, http.StatusForbidden) {
+		t.Fatalf("Expected unknown principal to be forbidden, got %v", err)
+	}
+
+	// Certificates must be signed by a trusted CA, must be user certificates, and must not have expired.
+	for name, cert := range map[string]ssh.Signer{
+		"untrusted CA": newCertificate(t, otherCA, user, ssh.UserCert, []string{"jdoe"}, time.Now().Add(time.Hour)),
+		"host":         newCertificate(t, ca, user, ssh.HostCert, []string{"jdoe"}, time.Now().Add(time.Hour)),
+		"expired":      newCertificate(t, ca, user, ssh.UserCert, []string{"jdoe"}, time.Now().Add(-time.Second)),
+	} {
+		publicKey, signature := sign(t, cert, challenge)
+		_, err = authenticator.Login("jdoe", challenge, publicKey, signature, nil)
+		if !api.StatusErrorCheck(err, http.StatusForbidden) {
+			t.Fatalf("Expected %s certificate to be forbidden, got %v", name, err)
+		}
+	}
+
+	// Plain keys must be authorized.
+	publicKey, signature = sign(t, user, challenge)
+	_, err = authenticator.Login("jdoe", challenge, publicKey, signature, nil)
+	if !api.StatusErrorCheck(err, http.StatusForbidden) {
+		t.Fatalf("Expected unauthorized key to be forbidden, got %v", err)
+	}
+
+	res, err = authenticator.Login("jdoe", challenge, publicKey, signature, []string{strings.TrimSpace(publicKey) + " jdoe@laptop"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Certificate || len(res.IdentityProviderGroups) != 0 || !res.ExpiresAt.IsZero() {
+		t.Fatalf("Unexpected authentication result %+v", res)
+	}
+
+	// CA keys must be valid keys.
+	_, err = NewAuthenticator("not a key")
+	if err == nil {
+		t.Fatal("Expected invalid CA keys to be rejected")
+	}
+
+	// Without any CA keys, only authorized keys can be used.
+	authenticator, err = NewAuthenticator("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if authenticator.HasCAKeys() {
+		t.Fatal("Expected no CA keys")
+	}
+}
+
+func TestNormalizeAuthorizedKeys(t *testing.T) {
+	publicKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(newSigner(t).PublicKey())))
+
+	keys, err := NormalizeAuthorizedKeys([]string{publicKey + " jdoe@laptop\n", publicKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(keys, []string{publicKey + " jdoe@laptop"}) {
+		t.Fatalf("Unexpected keys %v", keys)
+	}
+
+	for _, key := range []string{"not a key", `command="true" ` + publicKey, publicKey + "\n" + publicKey} {
+		_, err = NormalizeAuthorizedKeys([]string{key})
+		if !api.StatusErrorCheck(err, http.StatusBadRequest) {
+			t.Fatalf("Expected %q to be rejected, got %v", key, err)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"net"
 	"net/http"
 
 	"github.com/canonical/lxd/shared/api"
@@ -51,6 +52,10 @@ type Authorizer interface {
 
 	// GetViewableProjects accepts a list of permissions and returns a list of projects that a member of a group with these permissions is able to view.
 	GetViewableProjects(ctx context.Context, permissions []api.Permission) ([]string, error)
+
+	// GetGrantingPermissions accepts a list of permissions and returns those that, on their own, would grant a member
+	// of a group with the permission the given entitlement on the entity found at the given URL.
+	GetGrantingPermissions(ctx context.Context, entityURL *api.URL, entitlement Entitlement, permissions []api.Permission) ([]api.Permission, error)
 }
 
 // IsDeniedError returns true if the error is not found or forbidden. This is because the CheckPermission method on
@@ -75,3 +80,44 @@ const (
 	// It may only be set when converting a token issued for the initial UI identity from a query parameter into a cookie.
 	TokenLocationQuery
 )
+
+// TokenScope restricts the access granted by a bearer token to a subset of the access granted to its identity.
+type TokenScope struct {
+	// Permissions is the list of permissions that the token is restricted to. The identity must also be granted
+	// a permission for it to take effect. If empty, the token carries all permissions of its identity.
+	Permissions []api.Permission `json:"permissions,omitempty"`
+
+	// AllowedCIDRs is the list of subnets that requests using the token must originate from.
+	// If empty, requests may originate from any address.
+	AllowedCIDRs []string `json:"allowed_cidrs,omitempty"`
+}
+
+// AllowsAddress returns true if the given remote address (host and port) is within one of the allowed subnets of the scope.
+func (s TokenScope) AllowsAddress(remoteAddr string) bool {
+	if len(s.AllowedCIDRs) == 0 {
+		return true
+	}
+
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	for _, cidr := range s.AllowedCIDRs {
+		_, subnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+
+		if subnet.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"testing"
+)
+
+func TestTokenScopeAllowsAddress(t *testing.T) {
+	tests := []struct {
+		name         string
+		allowedCIDRs []string
+		remoteAddr   string
+		want         bool
+	}{
+		{
+			name:       "No restriction",
+			remoteAddr: "192.0.2.1:8443",
+			want:       true,
+		},
+		{
+			name:         "IPv4 address in subnet",
+			allowedCIDRs: []string{"198.51.100.0/24", "192.0.2.0/24"},
+			remoteAddr:   "192.0.2.1:8443",
+			want:         true,
+		},
+		{
+			name:         "IPv4 address not in subnet",
+			allowedCIDRs: []string{"192.0.2.0/24"},
+			remoteAddr:   "198.51.100.1:8443",
+			want:         false,
+		},
+		{
+			name:         "IPv6 address in subnet",
+			allowedCIDRs: []string{"2001:db8::/32"},
+			remoteAddr:   "[2001:db8::1]:8443",
+			want:         true,
+		},
+		{
+			name:         "Address without port",
+			allowedCIDRs: []string{"192.0.2.0/24"},
+			remoteAddr:   "192.0.2.1",
+			want:         true,
+		},
+		{
+			name:         "Unix socket",
+			allowedCIDRs: []string{"0.0.0.0/0"},
+			remoteAddr:   "@",
+			want:         false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scope := TokenScope{AllowedCIDRs: tt.allowedCIDRs}
+			got := scope.AllowsAddress(tt.remoteAddr)
+			if got != tt.want {
+				t.Errorf("AllowsAddress(%q) = %v, want %v", tt.remoteAddr, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,481 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/canonical/lxd/lxd/auth"
+	"github.com/canonical/lxd/lxd/db"
+	dbCluster "github.com/canonical/lxd/lxd/db/cluster"
+	"github.com/canonical/lxd/lxd/identity"
+	"github.com/canonical/lxd/lxd/request"
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/entity"
+)
+
+var authCheckCmd = APIEndpoint{
+	Name:        "auth_check",
+	Path:        "auth/check",
+	MetricsType: entity.TypeIdentity,
+	Post: APIEndpointAction{
+		Handler:       authCheck,
+		AccessHandler: allowPermission(entity.TypeServer, auth.EntitlementCanViewPermissions),
+	},
+}
+
+var authExplainCmd = APIEndpoint{
+	Name:        "auth_explain",
+	Path:        "auth/explain",
+	MetricsType: entity.TypeIdentity,
+	Post: APIEndpointAction{
+		Handler:       authExplain,
+		AccessHandler: allowPermission(entity.TypeServer, auth.EntitlementCanViewPermissions),
+	},
+}
+
+// swagger:operation POST /1.0/auth/check permissions auth_check_post
+//
+//	Check permissions
+//
+//	Evaluates whether an identity has an entitlement on an entity, without performing any action.
+//	If no entitlement is given, all entitlements of the entity type are evaluated for the identity.
+//	If no identity is given, returns the identities that have the entitlement on the entity.
+//	Token scopes are not considered.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: check
+//	    description: Identity, entity, and entitlement to check
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/AuthCheckPost"
+//	responses:
+//	  "200":
+//	    description: Check results
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of check results
+//	          items:
+//	            $ref: "#/definitions/AuthCheckResult"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func authCheck(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	var req api.AuthCheckPost
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	entityURL, err := validateAuthCheck(r.Context(), d, req)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if req.Identity == "" && req.Entitlement == "" {
+		return response.BadRequest(errors.New("An entitlement must be given when no identity is given"))
+	}
+
+	entitlements := []auth.Entitlement{auth.Entitlement(req.Entitlement)}
+	if req.Entitlement == "" {
+		entitlements = auth.EntitlementsByEntityType(entity.Type(req.EntityType))
+	}
+
+	// Check the given identity.
+	if req.Identity != "" {
+		id, err := loadAuthCheckIdentity(r, d, req.Identity)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		results, err := checkIdentityEntitlements(r.Context(), d, *id, entityURL, entitlements)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		return response.SyncResponse(true, results)
+	}
+
+	// Otherwise, check all identities that the caller can view.
+	canViewIdentity, err := s.Authorizer.GetPermissionChecker(r.Context(), auth.EntitlementCanView, entity.TypeIdentity)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	var identities []dbCluster.IdentitiesRow
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		identities, _, err = dbCluster.GetIdentitiesAndURLs(ctx, tx.Tx(), nil, func(id dbCluster.IdentitiesRow) bool {
+			return canViewIdentity(entity.IdentityURL(string(id.AuthMethod), id.Identifier))
+		})
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	results := []api.AuthCheckResult{}
+	for _, id := range identities {
+		idType, err := identity.New(string(id.Type))
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		// Pending identities can't authenticate.
+		if idType.IsPending() {
+			continue
+		}
+
+		identityResults, err := checkIdentityEntitlements(r.Context(), d, id, entityURL, entitlements)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		for _, result := range identityResults {
+			if result.Allowed {
+				results = append(results, result)
+			}
+		}
+	}
+
+	return response.SyncResponse(true, results)
+}
+
+// swagger:operation POST /1.0/auth/explain permissions auth_explain_post
+//
+//	Explain permissions
+//
+//	Explains why an identity has, or does not have, an entitlement on an entity.
+//	The explanation lists the groups and permissions through which the entitlement is granted.
+//	Token scopes are not considered.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: check
+//	    description: Identity, entity, and entitlement to explain
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/AuthCheckPost"
+//	responses:
+//	  "200":
+//	    description: Explanation
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/AuthExplanation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func authExplain(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	var req api.AuthCheckPost
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	entityURL, err := validateAuthCheck(r.Context(), d, req)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if req.Identity == "" || req.Entitlement == "" {
+		return response.BadRequest(errors.New("An identity and an entitlement must be given"))
+	}
+
+	entitlement := auth.Entitlement(req.Entitlement)
+
+	id, err := loadAuthCheckIdentity(r, d, req.Identity)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	ctx, err := identityRequestContext(r.Context(), d, *id)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	explanation := api.AuthExplanation{Reasons: []api.AuthExplanationReason{}}
+	err = s.Authorizer.CheckPermissionWithoutEffectiveProject(ctx, entityURL, entitlement)
+	if err != nil && !auth.IsDeniedError(err) {
+		return response.SmartError(err)
+	} else if err != nil {
+		return response.SyncResponse(true, explanation)
+	}
+
+	explanation.Allowed = true
+
+	requestor, err := request.GetRequestor(ctx)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	idType, err := requestor.CallerIdentityType()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Administrators and identities that don't use fine-grained authorization are not granted access via groups.
+	if requestor.IsAdmin() {
+		explanation.Reasons = append(explanation.Reasons, api.AuthExplanationReason{
+			Description: fmt.Sprintf("Identities of type %q have full access", idType.Name()),
+		})
+
+		return response.SyncResponse(true, explanation)
+	}
+
+	if !idType.IsFineGrained() {
+		explanation.Reasons = append(explanation.Reasons, api.AuthExplanationReason{
+			Description: fmt.Sprintf("Identities of type %q have access to the projects they are restricted to", idType.Name()),
+		})
+
+		return response.SyncResponse(true, explanation)
+	}
+
+	if entityURL.String() == entity.IdentityURL(string(id.AuthMethod), id.Identifier).String() && slices.Contains([]auth.Entitlement{auth.EntitlementCanView, auth.EntitlementCanDelete}, entitlement) {
+		explanation.Reasons = append(explanation.Reasons, api.AuthExplanationReason{
+			Description: "Identities can always view and delete themselves",
+		})
+	}
+
+	// Get the permissions of each group of the identity, and the identity provider groups through which the identity
+	// is a member if it is not a direct member.
+	directGroups := requestor.CallerAuthorizationGroupNames()
+	identityProviderGroups := requestor.CallerIdentityProviderGroups()
+	groupPermissions := make(map[string][]api.Permission)
+	groupIdentityProviderGroups := make(map[string][]string)
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		allowAll := func(*api.URL) bool { return true }
+		for _, groupName := range requestor.CallerEffectiveAuthorizationGroupNames() {
+			group, err := dbCluster.GetAuthGroup(ctx, tx.Tx(), groupName)
+			if err != nil {
+				return err
+			}
+
+			apiGroup, err := group.ToAPI(ctx, tx.Tx(), allowAll, allowAll)
+			if err != nil {
+				return err
+			}
+
+			groupPermissions[groupName] = apiGroup.Permissions
+			if slices.Contains(directGroups, groupName) {
+				continue
+			}
+
+			for _, idpGroup := range apiGroup.IdentityProviderGroups {
+				if slices.Contains(identityProviderGroups, idpGroup) {
+					groupIdentityProviderGroups[groupName] = append(groupIdentityProviderGroups[groupName], idpGroup)
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	for _, groupName := range requestor.CallerEffectiveAuthorizationGroupNames() {
+		grantingPermissions, err := s.Authorizer.GetGrantingPermissions(r.Context(), entityURL, entitlement, groupPermissions[groupName])
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		for _, permission := range grantingPermissions {
+			explanation.Reasons = append(explanation.Reasons, api.AuthExplanationReason{
+				Description:            fmt.Sprintf("Group %q has entitlement %q on %q", groupName, permission.Entitlement, permission.EntityReference),
+				Group:                  groupName,
+				IdentityProviderGroups: groupIdentityProviderGroups[groupName],
+				Permission:             &permission,
+			})
+		}
+	}
+
+	return response.SyncResponse(true, explanation)
+}
+
+// validateAuthCheck validates the entity and entitlement of the given check, and that the entity exists.
+// It returns the URL of the entity in a standardised form.
+func validateAuthCheck(ctx context.Context, d *Daemon, req api.AuthCheckPost) (*api.URL, error) {
+	entityType := entity.Type(req.EntityType)
+	err := entityType.Validate()
+	if err != nil {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "Failed validating entity type: %w", err)
+	}
+
+	u, err := url.Parse(req.EntityReference)
+	if err != nil {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "Failed parsing entity reference %q: %w", req.EntityReference, err)
+	}
+
+	referenceEntityType, projectName, location, pathArguments, err := entity.ParseURL(*u)
+	if err != nil {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "Failed parsing entity reference %q: %w", req.EntityReference, err)
+	}
+
+	if entityType != referenceEntityType {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "Entity type %q does not correspond to entity reference %q", entityType, req.EntityReference)
+	}
+
+	if req.Entitlement != "" {
+		err = auth.ValidateEntitlement(entityType, auth.Entitlement(req.Entitlement))
+		if err != nil {
+			return nil, api.StatusErrorf(http.StatusBadRequest, "Failed validating entitlement: %w", err)
+		}
+	}
+
+	entityURL, err := entityType.URL(projectName, location, pathArguments...)
+	if err != nil {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "Failed parsing entity reference %q: %w", req.EntityReference, err)
+	}
+
+	// Check that the entity exists, otherwise all checks are denied.
+	err = d.State().DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		return dbCluster.PopulateEntityReferencesFromURLs(ctx, tx.Tx(), map[*api.URL]*dbCluster.EntityRef{entityURL: {}})
+	})
+	if err != nil {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "Could not resolve entity reference %q: %w", req.EntityReference, err)
+	}
+
+	return entityURL, nil
+}
+
+// loadAuthCheckIdentity loads the identity given in the form "<authentication_method>/<name_or_identifier>".
+// A not found error is returned if the identity does not exist or if the caller cannot view it.
+func loadAuthCheckIdentity(r *http.Request, d *Daemon, identityArg string) (*dbCluster.IdentitiesRow, error) {
+	authenticationMethod, nameOrID, ok := strings.Cut(identityArg, "/")
+	if !ok || nameOrID == "" {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "Identity must be of the form <authentication_method>/<name_or_identifier>")
+	}
+
+	err := identity.ValidateAuthenticationMethod(authenticationMethod)
+	if err != nil {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "%w", err)
+	}
+
+	var id *dbCluster.IdentitiesRow
+	err = d.State().DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		id, err = dbCluster.GetIdentityByNameOrIdentifier(ctx, tx.Tx(), authenticationMethod, nameOrID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.State().Authorizer.CheckPermission(r.Context(), entity.IdentityURL(string(id.AuthMethod), id.Identifier), auth.EntitlementCanView)
+	if err != nil {
+		return nil, err
+	}
+
+	return id, nil
+}
+
+// identityRequestContext returns a context in which the requestor is the given identity. Permission checks performed
+// with the returned context are evaluated as if the identity had made the request.
+func identityRequestContext(ctx context.Context, d *Daemon, id dbCluster.IdentitiesRow) (context.Context, error) {
+	// Don't record the simulated checks in the audit log of the request.
+	ctx = context.WithValue(ctx, request.CtxAuditChecks, nil)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = request.SetRequestor(req, d.requestorHook, request.RequestorArgs{
+		Trusted:  true,
+		Username: id.Identifier,
+		Protocol: string(id.AuthMethod),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed setting requestor for identity %q: %w", id.Identifier, err)
+	}
+
+	return req.Context(), nil
+}
+
+// checkIdentityEntitlements checks whether the given identity has each of the given entitlements on the entity with the
+// given URL.
+func checkIdentityEntitlements(ctx context.Context, d *Daemon, id dbCluster.IdentitiesRow, entityURL *api.URL, entitlements []auth.Entitlement) ([]api.AuthCheckResult, error) {
+	ctx, err := identityRequestContext(ctx, d, id)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]api.AuthCheckResult, 0, len(entitlements))
+	for _, entitlement := range entitlements {
+		err := d.State().Authorizer.CheckPermissionWithoutEffectiveProject(ctx, entityURL, entitlement)
+		if err != nil && !auth.IsDeniedError(err) {
+			return nil, err
+		}
+
+		results = append(results, api.AuthCheckResult{
+			AuthenticationMethod: string(id.AuthMethod),
+			Identifier:           id.Identifier,
+			Name:                 id.Name,
+			Entitlement:          string(entitlement),
+			Allowed:              err == nil,
+		})
+	}
+
+	return results, nil
+}
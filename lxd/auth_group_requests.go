@@ -0,0 +1,568 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/canonical/lxd/lxd/auth"
+	"github.com/canonical/lxd/lxd/db"
+	dbCluster "github.com/canonical/lxd/lxd/db/cluster"
+	"github.com/canonical/lxd/lxd/db/query"
+	"github.com/canonical/lxd/lxd/identity"
+	"github.com/canonical/lxd/lxd/lifecycle"
+	"github.com/canonical/lxd/lxd/request"
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/lxd/state"
+	"github.com/canonical/lxd/lxd/task"
+	"github.com/canonical/lxd/lxd/util"
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/entity"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/lxd/shared/version"
+)
+
+var authGroupRequestsCmd = APIEndpoint{
+	Name:        "auth_group_requests",
+	Path:        "auth/group-requests",
+	MetricsType: entity.TypeIdentity,
+	Get: APIEndpointAction{
+		Handler:       getAuthGroupRequests,
+		AccessHandler: allowAuthenticated,
+	},
+	Post: APIEndpointAction{
+		Handler:       createAuthGroupRequest,
+		AccessHandler: allowAuthenticated,
+	},
+}
+
+var authGroupRequestCmd = APIEndpoint{
+	Name:        "auth_group_request",
+	Path:        "auth/group-requests/{id}",
+	MetricsType: entity.TypeIdentity,
+	Get: APIEndpointAction{
+		Handler:       getAuthGroupRequest,
+		AccessHandler: allowAuthenticated,
+	},
+	Delete: APIEndpointAction{
+		Handler:       deleteAuthGroupRequest,
+		AccessHandler: allowAuthenticated,
+	},
+}
+
+var authGroupRequestApproveCmd = APIEndpoint{
+	Name:        "auth_group_request_approve",
+	Path:        "auth/group-requests/{id}/approve",
+	MetricsType: entity.TypeIdentity,
+	Post: APIEndpointAction{
+		Handler:       approveAuthGroupRequest,
+		AccessHandler: allowAuthenticated,
+	},
+}
+
+// isAuthGroupRequestor returns true if the caller is the identity that made the request.
+func isAuthGroupRequestor(requestor *request.Requestor, groupRequest dbCluster.AuthGroupRequest) bool {
+	return requestor.CallerProtocol() == string(groupRequest.IdentityAuthMethod) && requestor.CallerUsername() == groupRequest.IdentityIdentifier
+}
+
+// swagger:operation GET /1.0/auth/group-requests auth_groups auth_group_requests_get
+//
+//	Get the group requests
+//
+//	Returns a list of requests of temporary group membership (URLs).
+//	Only the requests of the caller and the requests of identities that the caller can view are returned.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of endpoints
+//	          items:
+//	            type: string
+//	          example: |-
+//	            [
+//	              "/1.0/auth/group-requests/f1e7a5a6-4d5e-4b3c-9c1a-2b8f0a6d7e21",
+//	              "/1.0/auth/group-requests/9b0c3d2e-1f4a-4e6b-8d7c-5a3b2c1d0e9f"
+//	            ]
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+
+// swagger:operation GET /1.0/auth/group-requests?recursion=1 auth_groups auth_group_requests_get_recursion1
+//
+//	Get the group requests
+//
+//	Returns a list of requests of temporary group membership.
+//	Only the requests of the caller and the requests of identities that the caller can view are returned.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of group requests
+//	          items:
+//	            $ref: "#/definitions/AuthGroupRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func getAuthGroupRequests(d *Daemon, r *http.Request) response.Response {
+	recursion, _ := util.IsRecursionRequest(r)
+	s := d.State()
+
+	requestor, err := request.GetRequestor(r.Context())
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	canViewIdentity, err := s.Authorizer.GetPermissionChecker(r.Context(), auth.EntitlementCanView, entity.TypeIdentity)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed getting a permission checker: %w", err))
+	}
+
+	var groupRequests []dbCluster.AuthGroupRequest
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		groupRequests, err = dbCluster.GetAuthGroupRequests(ctx, tx.Tx(), func(groupRequest dbCluster.AuthGroupRequest) bool {
+			return isAuthGroupRequestor(requestor, groupRequest) || canViewIdentity(entity.IdentityURL(string(groupRequest.IdentityAuthMethod), groupRequest.IdentityIdentifier))
+		})
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if recursion == 0 {
+		urls := make([]string, 0, len(groupRequests))
+		for _, groupRequest := range groupRequests {
+			urls = append(urls, api.NewURL().Path(version.APIVersion, "auth", "group-requests", groupRequest.Row.UUID).String())
+		}
+
+		return response.SyncResponse(true, urls)
+	}
+
+	apiGroupRequests := make([]api.AuthGroupRequest, 0, len(groupRequests))
+	for _, groupRequest := range groupRequests {
+		apiGroupRequests = append(apiGroupRequests, *groupRequest.ToAPI())
+	}
+
+	return response.SyncResponse(true, apiGroupRequests)
+}
+
+// swagger:operation POST /1.0/auth/group-requests auth_groups auth_group_requests_post
+//
+//	Request temporary group membership
+//
+//	Requests temporary membership of an authorization group for the caller.
+//	The membership is granted for the requested duration once the request is approved by an identity that can edit
+//	the caller.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: request
+//	    description: Group request
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/AuthGroupRequestsPost"
+//	responses:
+//	  "200":
+//	    description: Group request
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/AuthGroupRequest"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func createAuthGroupRequest(d *Daemon, r *http.Request) response.Response {
+	var req api.AuthGroupRequestsPost
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Invalid request body: %w", err))
+	}
+
+	if req.Group == "" {
+		return response.BadRequest(errors.New("Group name must be provided"))
+	}
+
+	duration, err := time.ParseDuration(req.Duration)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Invalid duration %q: %w", req.Duration, err))
+	}
+
+	if duration <= 0 {
+		return response.BadRequest(errors.New("Duration must be positive"))
+	}
+
+	requestor, err := request.GetRequestor(r.Context())
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Must be a remote API request.
+	err = identity.ValidateAuthenticationMethod(requestor.CallerProtocol())
+	if err != nil {
+		return response.BadRequest(errors.New("Group membership must be requested via the HTTPS API"))
+	}
+
+	s := d.State()
+	var apiGroupRequest *api.AuthGroupRequest
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		id, err := dbCluster.GetIdentityByAuthenticationMethodAndIdentifier(ctx, tx.Tx(), requestor.CallerProtocol(), requestor.CallerUsername())
+		if err != nil {
+			return fmt.Errorf("Failed getting current identity from database: %w", err)
+		}
+
+		identityType, err := identity.New(string(id.Type))
+		if err != nil {
+			return fmt.Errorf("Invalid existing identity type: %w", err)
+		}
+
+		if !identityType.IsFineGrained() {
+			return api.StatusErrorf(http.StatusBadRequest, "Identities of type %q cannot be members of groups", id.Type)
+		}
+
+		group, err := dbCluster.GetAuthGroup(ctx, tx.Tx(), req.Group)
+		if err != nil {
+			return err
+		}
+
+		groups, err := dbCluster.GetIdentityAuthGroupNames(ctx, tx.Tx(), &id.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		groupExpiries, err := dbCluster.GetIdentityAuthGroupExpiries(ctx, tx.Tx(), &id.ID)
+		if err != nil {
+			return err
+		}
+
+		_, isTemporary := groupExpiries[id.ID][group.Name]
+		if slices.Contains(groups[id.ID], group.Name) && !isTemporary {
+			return api.StatusErrorf(http.StatusConflict, "Identity is already a member of group %q", group.Name)
+		}
+
+		groupRequest := dbCluster.AuthGroupRequest{
+			Row: dbCluster.AuthGroupRequestsRow{
+				UUID:         uuid.New().String(),
+				IdentityID:   id.ID,
+				AuthGroupID:  group.ID,
+				Duration:     req.Duration,
+				Reason:       req.Reason,
+				CreationDate: time.Now().UTC(),
+			},
+			IdentityAuthMethod: id.AuthMethod,
+			IdentityIdentifier: id.Identifier,
+			IdentityName:       id.Name,
+			GroupName:          group.Name,
+		}
+
+		_, err = query.Create(ctx, tx.Tx(), groupRequest.Row)
+		if err != nil {
+			return err
+		}
+
+		apiGroupRequest = groupRequest.ToAPI()
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	lc := lifecycle.AuthGroupRequestCreated.Event(apiGroupRequest.ID, request.CreateRequestor(r.Context()), map[string]any{"group": apiGroupRequest.Group, "duration": apiGroupRequest.Duration})
+	s.Events.SendLifecycle("", lc)
+
+	return response.SyncResponseLocation(true, apiGroupRequest, lc.Source)
+}
+
+// swagger:operation GET /1.0/auth/group-requests/{id} auth_groups auth_group_request_get
+//
+//	Get the group request
+//
+//	Gets a specific request of temporary group membership.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: Group request
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/AuthGroupRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func getAuthGroupRequest(d *Daemon, r *http.Request) response.Response {
+	groupRequest, err := loadAuthGroupRequest(d.State(), r, auth.EntitlementCanView)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, groupRequest.ToAPI())
+}
+
+// swagger:operation DELETE /1.0/auth/group-requests/{id} auth_groups auth_group_request_delete
+//
+//	Delete the group request
+//
+//	Cancels or rejects a request of temporary group membership.
+//	Requests can be cancelled by the identity that made them, and rejected by identities that can edit that identity.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func deleteAuthGroupRequest(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+	groupRequest, err := loadAuthGroupRequest(s, r, auth.EntitlementCanEdit)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return query.DeleteByPrimaryKey(ctx, tx.Tx(), groupRequest.Row)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	lc := lifecycle.AuthGroupRequestDeleted.Event(groupRequest.Row.UUID, request.CreateRequestor(r.Context()), map[string]any{"group": groupRequest.GroupName})
+	s.Events.SendLifecycle("", lc)
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation POST /1.0/auth/group-requests/{id}/approve auth_groups auth_group_request_approve_post
+//
+//	Approve the group request
+//
+//	Approves a request of temporary group membership. The identity that made the request is added to the group for the
+//	requested duration, and the request is deleted. The caller must be able to edit the identity that made the request,
+//	and cannot approve their own requests.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func approveAuthGroupRequest(d *Daemon, r *http.Request) response.Response {
+	groupRequest, err := loadAuthGroupRequest(d.State(), r, auth.EntitlementCanEdit)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	requestor, err := request.GetRequestor(r.Context())
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if isAuthGroupRequestor(requestor, *groupRequest) {
+		return response.Forbidden(errors.New("Cannot approve own group request"))
+	}
+
+	duration, err := time.ParseDuration(groupRequest.Row.Duration)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Invalid duration of group request: %w", err))
+	}
+
+	s := d.State()
+	expiry := time.Now().UTC().Add(duration)
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		err := dbCluster.AddIdentityAuthGroupUntil(ctx, tx.Tx(), groupRequest.Row.IdentityID, groupRequest.Row.AuthGroupID, expiry)
+		if err != nil {
+			return err
+		}
+
+		return query.DeleteByPrimaryKey(ctx, tx.Tx(), groupRequest.Row)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	lc := lifecycle.AuthGroupRequestApproved.Event(groupRequest.Row.UUID, request.CreateRequestor(r.Context()), map[string]any{"group": groupRequest.GroupName, "expiry": expiry})
+	s.Events.SendLifecycle("", lc)
+
+	return response.EmptySyncResponse
+}
+
+// loadAuthGroupRequest gets the group request from the request URL. The identity that made the request always has
+// access to it. Other callers must have the given entitlement on that identity.
+func loadAuthGroupRequest(s *state.State, r *http.Request, entitlement auth.Entitlement) (*dbCluster.AuthGroupRequest, error) {
+	requestID, err := url.PathUnescape(mux.Vars(r)["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	requestor, err := request.GetRequestor(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	var groupRequest *dbCluster.AuthGroupRequest
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		groupRequest, err = dbCluster.GetAuthGroupRequest(ctx, tx.Tx(), requestID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if isAuthGroupRequestor(requestor, *groupRequest) {
+		return groupRequest, nil
+	}
+
+	err = s.Authorizer.CheckPermission(r.Context(), entity.IdentityURL(string(groupRequest.IdentityAuthMethod), groupRequest.IdentityIdentifier), entitlement)
+	if err != nil {
+		// Don't reveal that the request exists to callers that cannot view the identity.
+		if auth.IsDeniedError(err) && entitlement == auth.EntitlementCanView {
+			return nil, api.NewGenericStatusError(http.StatusNotFound)
+		}
+
+		return nil, err
+	}
+
+	return groupRequest, nil
+}
+
+// removeExpiredGroupMembershipsTask returns a task that removes temporary group memberships once they expire.
+func removeExpiredGroupMembershipsTask(stateFunc func() *state.State) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := stateFunc()
+
+		// Only the leader removes expired memberships, so that a single event is sent for each of them.
+		leaderInfo, err := s.LeaderInfo()
+		if err != nil {
+			logger.Warn("Failed getting database leader details", logger.Ctx{"err": err})
+			return
+		}
+
+		if !leaderInfo.Leader {
+			return
+		}
+
+		var expired []dbCluster.ExpiredAuthGroupMembership
+		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			expired, err = dbCluster.DeleteExpiredIdentityAuthGroups(ctx, tx.Tx(), time.Now())
+			return err
+		})
+		if err != nil {
+			logger.Error("Failed removing expired group memberships", logger.Ctx{"err": err})
+			return
+		}
+
+		for _, membership := range expired {
+			logger.Info("Removed expired group membership", logger.Ctx{"authMethod": membership.AuthMethod, "identifier": membership.Identifier, "group": membership.GroupName})
+			lc := lifecycle.IdentityGroupExpired.Event(string(membership.AuthMethod), membership.Identifier, nil, map[string]any{"group": membership.GroupName, "expiry": membership.ExpiryDate})
+			s.Events.SendLifecycle("", lc)
+		}
+	}
+
+	return f, task.Every(time.Minute)
+}
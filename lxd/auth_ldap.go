@@ -0,0 +1,266 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/canonical/lxd/lxd/auth/bearer"
+	"github.com/canonical/lxd/lxd/auth/encryption"
+	"github.com/canonical/lxd/lxd/auth/ldap"
+	"github.com/canonical/lxd/lxd/db"
+	dbCluster "github.com/canonical/lxd/lxd/db/cluster"
+	"github.com/canonical/lxd/lxd/db/query"
+	"github.com/canonical/lxd/lxd/lifecycle"
+	"github.com/canonical/lxd/lxd/request"
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/entity"
+	"github.com/canonical/lxd/shared/logger"
+)
+
+var ldapLoginCmd = APIEndpoint{
+	Name:        "ldapLogin",
+	Path:        "auth/ldap/login",
+	MetricsType: entity.TypeIdentity,
+
+	Post: APIEndpointAction{Handler: ldapLoginPost, AllowUntrusted: true},
+}
+
+var ldapLogoutCmd = APIEndpoint{
+	Name:        "ldapLogout",
+	Path:        "auth/ldap/logout",
+	MetricsType: entity.TypeIdentity,
+
+	Post: APIEndpointAction{Handler: ldapLogoutPost, AllowUntrusted: true},
+}
+
+// swagger:operation POST /1.0/auth/ldap/login identities auth_ldap_login_post
+//
+//	Log in with LDAP credentials
+//
+//	Authenticates the user against the configured LDAP server.
+//	On first login, an identity is created for the user. On subsequent logins, the name and identity provider groups
+//	of the identity are updated to match the directory.
+//	The returned session token can be sent as a bearer token. It is also set as a cookie for browser clients.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: credentials
+//	    description: LDAP credentials
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/AuthLDAPLoginPost"
+//	responses:
+//	  "200":
+//	    description: LDAP session
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/AuthLDAPSession"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func ldapLoginPost(d *Daemon, r *http.Request) response.Response {
+	if d.ldapAuthenticator == nil {
+		return response.BadRequest(errors.New("LDAP authentication is not configured"))
+	}
+
+	var req api.AuthLDAPLoginPost
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Failed unmarshaling request body: %w", err))
+	}
+
+	res, err := d.ldapAuthenticator.Login(req.Username, req.Password)
+	if err != nil {
+		if !api.StatusErrorCheck(err, http.StatusForbidden) {
+			logger.Warn("Failed LDAP login", logger.Ctx{"username": req.Username, "err": err})
+
+			// Return a generic error so as not to reveal details of the LDAP server.
+			return response.SmartError(api.NewGenericStatusError(http.StatusInternalServerError))
+		}
+
+		return response.SmartError(err)
+	}
+
+	err = ldapStartSession(d, r, *res)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	expiresAt, err := shared.GetExpiry(time.Now().UTC(), d.globalConfig.LDAPSessionExpiry())
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed getting session expiry: %w", err))
+	}
+
+	secrets, err := d.getCoreAuthSecrets(r.Context())
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed getting session token signing key: %w", err))
+	}
+
+	token, err := encryption.GetLDAPSessionToken(secrets[0].Value, res.Username, d.globalConfig.ClusterUUID(), expiresAt)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed issuing session token: %w", err))
+	}
+
+	return response.ManualResponse(func(w http.ResponseWriter) error {
+		http.SetCookie(w, &http.Cookie{
+			Name:     bearer.CookieNameLDAPSession,
+			Value:    token,
+			Path:     "/",
+			Secure:   true,
+			HttpOnly: true,
+			SameSite: http.SameSiteStrictMode,
+			Expires:  expiresAt,
+		})
+
+		w.Header().Set("Cache-Control", "no-store")
+		return response.SyncResponse(true, api.AuthLDAPSession{Token: token, ExpiresAt: expiresAt}).Render(w, r)
+	})
+}
+
+// ldapStartSession creates the identity of a user on their first login. If the identity already exists, its name
+// and metadata are updated to match the values from the directory.
+func ldapStartSession(d *Daemon, r *http.Request, res ldap.AuthenticationResult) error {
+	newMetadata := dbCluster.LDAPMetadata{
+		DN:                     res.DN,
+		IdentityProviderGroups: res.IdentityProviderGroups,
+	}
+
+	var action lifecycle.IdentityAction
+	err := d.db.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		// Get the identity from the username. If none is found, it's a first time login.
+		var firstTimeLogin bool
+		identity, err := dbCluster.GetIdentityByAuthenticationMethodAndIdentifier(ctx, tx.Tx(), api.AuthenticationMethodLDAP, res.Username)
+		if err != nil {
+			if !api.StatusErrorCheck(err, http.StatusNotFound) {
+				return fmt.Errorf("Failed checking if the identity exists: %w", err)
+			}
+
+			firstTimeLogin = true
+		}
+
+		if !firstTimeLogin {
+			existingMetadata, err := identity.LDAPMetadata()
+			if err != nil {
+				return fmt.Errorf("Failed getting LDAP metadata: %w", err)
+			}
+
+			// As for OIDC subjects, don't allow the user entry of an identity to change. A username may be re-used
+			// for a different person, so conflicts must be resolved by an administrator deleting the identity.
+			if newMetadata.DN != existingMetadata.DN {
+				logger.Warn("Encountered LDAP login with unexpected DN. The existing identity with this username must be deleted to allow the new login", logger.Ctx{"username": res.Username, "dn": res.DN})
+
+				// Return a generic error so as not to reveal that a user with this username already exists.
+				return api.NewGenericStatusError(http.StatusInternalServerError)
+			}
+
+			// Only update the identity name or identity provider groups if they have changed.
+			if res.Name == identity.Name && existingMetadata.Equals(newMetadata) {
+				return nil
+			}
+		}
+
+		metadataJSON, err := json.Marshal(newMetadata)
+		if err != nil {
+			return fmt.Errorf("Failed encoding LDAP metadata: %w", err)
+		}
+
+		if firstTimeLogin {
+			action = lifecycle.IdentityCreated
+			_, err = query.Create(ctx, tx.Tx(), &dbCluster.IdentitiesRow{
+				AuthMethod: api.AuthenticationMethodLDAP,
+				Type:       api.IdentityTypeLDAPUser,
+				Identifier: res.Username,
+				Name:       res.Name,
+				Metadata:   string(metadataJSON),
+			})
+			if err != nil {
+				return fmt.Errorf("Failed creating new identity: %w", err)
+			}
+
+			return nil
+		}
+
+		action = lifecycle.IdentityUpdated
+		identity.Name = res.Name
+		identity.Metadata = string(metadataJSON)
+		err = query.UpdateByPrimaryKey(ctx, tx.Tx(), identity)
+		if err != nil {
+			return fmt.Errorf("Failed updating identity: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Failed starting session: %w", err)
+	}
+
+	// Send lifecycle event.
+	if action != "" {
+		lc := action.Event(api.AuthenticationMethodLDAP, res.Username, request.CreateRequestor(r.Context()), nil)
+		d.events.SendLifecycle("", lc)
+	}
+
+	return nil
+}
+
+// swagger:operation POST /1.0/auth/ldap/logout identities auth_ldap_logout_post
+//
+//	Log out of an LDAP session
+//
+//	Removes the LDAP session cookie.
+//	Session tokens remain valid until they expire or the identity is deleted.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+func ldapLogoutPost(d *Daemon, r *http.Request) response.Response {
+	return response.ManualResponse(func(w http.ResponseWriter) error {
+		http.SetCookie(w, &http.Cookie{
+			Name:     bearer.CookieNameLDAPSession,
+			Value:    "",
+			Path:     "/",
+			Secure:   true,
+			HttpOnly: true,
+			SameSite: http.SameSiteStrictMode,
+
+			// Expire the cookie to instruct the browser to delete it.
+			MaxAge:  -1,
+			Expires: time.Unix(0, 0),
+		})
+
+		w.Header().Set("Cache-Control", "no-store")
+		return response.EmptySyncResponse.Render(w, r)
+	})
+}
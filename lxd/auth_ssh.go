@@ -0,0 +1,386 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/canonical/lxd/lxd/auth/bearer"
+	"github.com/canonical/lxd/lxd/auth/encryption"
+	"github.com/canonical/lxd/lxd/auth/ssh"
+	"github.com/canonical/lxd/lxd/db"
+	dbCluster "github.com/canonical/lxd/lxd/db/cluster"
+	"github.com/canonical/lxd/lxd/db/query"
+	"github.com/canonical/lxd/lxd/lifecycle"
+	"github.com/canonical/lxd/lxd/request"
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/entity"
+)
+
+// sshChallengeExpiry is the time a client has to sign an SSH login challenge.
+const sshChallengeExpiry = time.Minute
+
+var sshChallengeCmd = APIEndpoint{
+	Name:        "sshChallenge",
+	Path:        "auth/ssh/challenge",
+	MetricsType: entity.TypeIdentity,
+
+	Post: APIEndpointAction{Handler: sshChallengePost, AllowUntrusted: true},
+}
+
+var sshLoginCmd = APIEndpoint{
+	Name:        "sshLogin",
+	Path:        "auth/ssh/login",
+	MetricsType: entity.TypeIdentity,
+
+	Post: APIEndpointAction{Handler: sshLoginPost, AllowUntrusted: true},
+}
+
+// validateSSHUsername checks that the given username can be used as the identifier of an SSH identity.
+func validateSSHUsername(username string) error {
+	if username == "" {
+		return errors.New("Username must be provided")
+	}
+
+	if strings.ContainsAny(username, "/\\ \t\r\n") {
+		return fmt.Errorf("Invalid username %q", username)
+	}
+
+	return nil
+}
+
+// swagger:operation POST /1.0/auth/ssh/challenge identities auth_ssh_challenge_post
+//
+//	Get an SSH login challenge
+//
+//	Returns a challenge to sign with an SSH key or certificate to log in as the given user.
+//	The challenge expires after one minute.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: challenge
+//	    description: Challenge request
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/AuthSSHChallengePost"
+//	responses:
+//	  "200":
+//	    description: SSH login challenge
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/AuthSSHChallenge"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func sshChallengePost(d *Daemon, r *http.Request) response.Response {
+	var req api.AuthSSHChallengePost
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Failed unmarshaling request body: %w", err))
+	}
+
+	err = validateSSHUsername(req.Username)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	secrets, err := d.getCoreAuthSecrets(r.Context())
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed getting challenge signing key: %w", err))
+	}
+
+	expiresAt := time.Now().UTC().Add(sshChallengeExpiry)
+	challenge, err := encryption.GetSSHChallengeToken(secrets[0].Value, req.Username, d.globalConfig.ClusterUUID(), expiresAt)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed issuing challenge: %w", err))
+	}
+
+	return response.SyncResponse(true, api.AuthSSHChallenge{Challenge: challenge, ExpiresAt: expiresAt})
+}
+
+// swagger:operation POST /1.0/auth/ssh/login identities auth_ssh_login_post
+//
+//	Log in with an SSH key
+//
+//	Checks the signature of a challenge obtained from `/1.0/auth/ssh/challenge`.
+//	The challenge must be signed either with a user certificate that was signed by one of the keys in `ssh.ca_keys`
+//	and that contains the username as a principal, or with one of the authorized keys of the SSH identity of the user.
+//	The signature must be an armored SSH signature for the `lxd` namespace, as created by `ssh-keygen -Y sign -n lxd`.
+//	On the first login with a certificate, an identity is created for the user. On each login, the identity provider
+//	groups of the identity are set to the other principals of the certificate.
+//	The returned session token can be sent as a bearer token. It expires after `ssh.session.expiry`, or when the
+//	certificate expires if that is sooner.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: login
+//	    description: Signed challenge
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/AuthSSHLoginPost"
+//	responses:
+//	  "200":
+//	    description: SSH session
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/AuthSSHSession"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func sshLoginPost(d *Daemon, r *http.Request) response.Response {
+	if d.sshAuthenticator == nil {
+		return response.BadRequest(errors.New("SSH authentication is not available"))
+	}
+
+	var req api.AuthSSHLoginPost
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Failed unmarshaling request body: %w", err))
+	}
+
+	// Check that the challenge was issued by this cluster for this user and hasn't expired.
+	clusterUUID := d.globalConfig.ClusterUUID()
+	isChallenge, subject, issuedAt := bearer.IsSSHChallenge(req.Challenge, clusterUUID)
+	if !isChallenge || subject != req.Username {
+		return response.Forbidden(errors.New("Invalid SSH challenge"))
+	}
+
+	_, err = d.verifySessionToken(r.Context(), *issuedAt, func(secret []byte) (*time.Time, error) {
+		return bearer.VerifySSHChallengeToken(req.Challenge, secret, req.Username, clusterUUID)
+	})
+	if err != nil {
+		return response.Forbidden(fmt.Errorf("Invalid SSH challenge: %w", err))
+	}
+
+	// Get the authorized keys of the user, if an identity already exists.
+	var authorizedKeys []string
+	err = d.db.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		identity, err := dbCluster.GetIdentityByAuthenticationMethodAndIdentifier(ctx, tx.Tx(), api.AuthenticationMethodSSH, req.Username)
+		if err != nil {
+			if api.StatusErrorCheck(err, http.StatusNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		metadata, err := identity.SSHMetadata()
+		if err != nil {
+			return err
+		}
+
+		authorizedKeys = metadata.AuthorizedKeys
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed getting SSH identity: %w", err))
+	}
+
+	res, err := d.sshAuthenticator.Login(req.Username, req.Challenge, req.PublicKey, req.Signature, authorizedKeys)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = sshStartSession(d, r, *res)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	expiresAt, err := shared.GetExpiry(time.Now().UTC(), d.globalConfig.SSHSessionExpiry())
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed getting session expiry: %w", err))
+	}
+
+	// The session must not outlive the certificate it was started with.
+	if !res.ExpiresAt.IsZero() && res.ExpiresAt.Before(expiresAt) {
+		expiresAt = res.ExpiresAt
+	}
+
+	secrets, err := d.getCoreAuthSecrets(r.Context())
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed getting session token signing key: %w", err))
+	}
+
+	token, err := encryption.GetSSHSessionToken(secrets[0].Value, res.Username, clusterUUID, expiresAt)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed issuing session token: %w", err))
+	}
+
+	return response.ManualResponse(func(w http.ResponseWriter) error {
+		w.Header().Set("Cache-Control", "no-store")
+		return response.SyncResponse(true, api.AuthSSHSession{Token: token, ExpiresAt: expiresAt}).Render(w, r)
+	})
+}
+
+// sshStartSession creates the identity of a user on their first login with a certificate. If the identity already
+// exists, its identity provider groups are updated to match the principals of the certificate.
+func sshStartSession(d *Daemon, r *http.Request, res ssh.AuthenticationResult) error {
+	var action lifecycle.IdentityAction
+	err := d.db.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		identity, err := dbCluster.GetIdentityByAuthenticationMethodAndIdentifier(ctx, tx.Tx(), api.AuthenticationMethodSSH, res.Username)
+		if err != nil {
+			if !api.StatusErrorCheck(err, http.StatusNotFound) {
+				return fmt.Errorf("Failed checking if the identity exists: %w", err)
+			}
+
+			// Only users with a certificate are created on their first login. Other users must be created by an
+			// administrator with their authorized keys.
+			if !res.Certificate {
+				return api.NewGenericStatusError(http.StatusForbidden)
+			}
+
+			metadataJSON, err := json.Marshal(dbCluster.SSHMetadata{IdentityProviderGroups: res.IdentityProviderGroups})
+			if err != nil {
+				return fmt.Errorf("Failed encoding SSH metadata: %w", err)
+			}
+
+			action = lifecycle.IdentityCreated
+			_, err = query.Create(ctx, tx.Tx(), &dbCluster.IdentitiesRow{
+				AuthMethod: api.AuthenticationMethodSSH,
+				Type:       api.IdentityTypeSSHUser,
+				Identifier: res.Username,
+				Name:       res.Username,
+				Metadata:   string(metadataJSON),
+			})
+			if err != nil {
+				return fmt.Errorf("Failed creating new identity: %w", err)
+			}
+
+			return nil
+		}
+
+		metadata, err := identity.SSHMetadata()
+		if err != nil {
+			return fmt.Errorf("Failed getting SSH metadata: %w", err)
+		}
+
+		// Only update the identity provider groups if they have changed. Logging in with an authorized key clears
+		// the groups obtained from a certificate.
+		if slices.Equal(metadata.IdentityProviderGroups, res.IdentityProviderGroups) {
+			return nil
+		}
+
+		metadata.IdentityProviderGroups = res.IdentityProviderGroups
+		metadataJSON, err := json.Marshal(metadata)
+		if err != nil {
+			return fmt.Errorf("Failed encoding SSH metadata: %w", err)
+		}
+
+		action = lifecycle.IdentityUpdated
+		identity.Metadata = string(metadataJSON)
+		err = query.UpdateByPrimaryKey(ctx, tx.Tx(), identity)
+		if err != nil {
+			return fmt.Errorf("Failed updating identity: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Failed starting session: %w", err)
+	}
+
+	// Send lifecycle event.
+	if action != "" {
+		lc := action.Event(api.AuthenticationMethodSSH, res.Username, request.CreateRequestor(r.Context()), nil)
+		d.events.SendLifecycle("", lc)
+	}
+
+	return nil
+}
+
+// checkSSHSession checks that the session of the given user that was issued at the given time hasn't been revoked by
+// removing an authorized key of the user.
+func (d *Daemon) checkSSHSession(ctx context.Context, username string, issuedAt time.Time) error {
+	return d.db.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		identity, err := dbCluster.GetIdentityByAuthenticationMethodAndIdentifier(ctx, tx.Tx(), api.AuthenticationMethodSSH, username)
+		if err != nil {
+			return err
+		}
+
+		metadata, err := identity.SSHMetadata()
+		if err != nil {
+			return err
+		}
+
+		if !metadata.SessionsRevokedAt.IsZero() && !issuedAt.After(metadata.SessionsRevokedAt) {
+			return errors.New("Session was revoked")
+		}
+
+		return nil
+	})
+}
+
+// sshAuthAvailable returns true if users can log in with SSH, which is the case if trusted CA keys are configured
+// or if an SSH identity has authorized keys.
+func (d *Daemon) sshAuthAvailable(ctx context.Context) (bool, error) {
+	if d.sshAuthenticator == nil {
+		return false, nil
+	}
+
+	if d.sshAuthenticator.HasCAKeys() {
+		return true, nil
+	}
+
+	var available bool
+	err := d.db.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+		available, err = dbCluster.HasSSHAuthorizedKeys(ctx, tx.Tx())
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return available, nil
+}
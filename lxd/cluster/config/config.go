@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"net/url"
 	"slices"
 	"strconv"
 	"strings"
@@ -13,10 +14,14 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/zitadel/oidc/v3/pkg/oidc"
 
+	"github.com/canonical/lxd/lxd/auth/ldap"
+	"github.com/canonical/lxd/lxd/auth/ssh"
 	"github.com/canonical/lxd/lxd/config"
 	"github.com/canonical/lxd/lxd/db"
+	"github.com/canonical/lxd/lxd/logexport"
 	"github.com/canonical/lxd/shared"
 	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/units"
 	"github.com/canonical/lxd/shared/validate"
 )
 
@@ -195,6 +200,83 @@ func (c *Config) InstancesMigrationStateful() bool {
 	return c.m.GetBool("instances.migration.stateful")
 }
 
+// InstancesMemoryPressureThresholds returns the host memory pressure percentages above which idle virtual
+// machines are statefully stopped and below which they are started again. A suspend threshold of 0 disables it.
+func (c *Config) InstancesMemoryPressureThresholds() (suspend int64, resume int64) {
+	suspend = c.m.GetInt64("instances.memory_pressure.suspend_threshold")
+	resume = c.m.GetInt64("instances.memory_pressure.resume_threshold")
+	if resume == 0 {
+		resume = suspend / 2
+	}
+
+	return suspend, resume
+}
+
+// Audit returns whether the audit log is enabled, the size (in bytes) above which the log file is rotated and the
+// number of rotated files to keep.
+func (c *Config) Audit() (enabled bool, maxSize int64, maxFiles int) {
+	maxSize, _ = units.ParseByteSizeString(c.m.GetString("audit.max_size"))
+
+	return c.m.GetBool("audit.enabled"), maxSize, int(c.m.GetInt64("audit.max_files"))
+}
+
+// LDAP returns all the LDAP settings needed to authenticate users. LDAP authentication is disabled if the returned URL is empty.
+func (c *Config) LDAP() ldap.Args {
+	return ldap.Args{
+		URL:             c.m.GetString("ldap.url"),
+		StartTLS:        c.m.GetBool("ldap.starttls"),
+		CACert:          c.m.GetString("ldap.ca_cert"),
+		BindDN:          c.m.GetString("ldap.bind.dn"),
+		BindPassword:    c.m.GetString("ldap.bind.password"),
+		UserBaseDN:      c.m.GetString("ldap.user.base_dn"),
+		UserFilter:      c.m.GetString("ldap.user.filter"),
+		GroupsBaseDN:    c.m.GetString("ldap.groups.base_dn"),
+		GroupsFilter:    c.m.GetString("ldap.groups.filter"),
+		GroupsAttribute: c.m.GetString("ldap.groups.attribute"),
+	}
+}
+
+// LDAPSessionExpiry returns the expiry of an LDAP session.
+func (c *Config) LDAPSessionExpiry() (expiry string) {
+	return c.m.GetString("ldap.session.expiry")
+}
+
+// SSHCAKeys returns the SSH CA keys (in the authorized_keys format) trusted to sign the certificates of SSH users.
+func (c *Config) SSHCAKeys() string {
+	return c.m.GetString("ssh.ca_keys")
+}
+
+// SSHSessionExpiry returns the expiry of an SSH session.
+func (c *Config) SSHSessionExpiry() (expiry string) {
+	return c.m.GetString("ssh.session.expiry")
+}
+
+// LogsOTLP returns all the settings needed to export events to an OTLP logs collector.
+func (c *Config) LogsOTLP() (endpoint string, caCert string, instance string, logLevel string, labels []string, types []string) {
+	if c.m.GetString("logs.otlp.types") != "" {
+		types = strings.Split(c.m.GetString("logs.otlp.types"), ",")
+	}
+
+	if c.m.GetString("logs.otlp.labels") != "" {
+		labels = strings.Split(c.m.GetString("logs.otlp.labels"), ",")
+	}
+
+	return c.m.GetString("logs.otlp.endpoint"), c.m.GetString("logs.otlp.ca_cert"), c.m.GetString("logs.otlp.instance"), c.m.GetString("logs.otlp.loglevel"), labels, types
+}
+
+// LogsSyslog returns all the settings needed to export events to a syslog server.
+func (c *Config) LogsSyslog() (address string, caCert string, instance string, logLevel string, labels []string, types []string) {
+	if c.m.GetString("logs.syslog.types") != "" {
+		types = strings.Split(c.m.GetString("logs.syslog.types"), ",")
+	}
+
+	if c.m.GetString("logs.syslog.labels") != "" {
+		labels = strings.Split(c.m.GetString("logs.syslog.labels"), ",")
+	}
+
+	return c.m.GetString("logs.syslog.address"), c.m.GetString("logs.syslog.ca_cert"), c.m.GetString("logs.syslog.instance"), c.m.GetString("logs.syslog.loglevel"), labels, types
+}
+
 // LokiServer returns all the Loki settings needed to connect to a server.
 func (c *Config) LokiServer() (apiURL string, authUsername string, authPassword string, apiCACert string, instance string, logLevel string, labels []string, types []string) {
 	if c.m.GetString("loki.types") != "" {
@@ -208,6 +290,13 @@ func (c *Config) LokiServer() (apiURL string, authUsername string, authPassword
 	return c.m.GetString("loki.api.url"), c.m.GetString("loki.auth.username"), c.m.GetString("loki.auth.password"), c.m.GetString("loki.api.ca_cert"), c.m.GetString("loki.instance"), c.m.GetString("loki.loglevel"), labels, types
 }
 
+// TracingServer returns all the OpenTelemetry settings needed to export traces.
+func (c *Config) TracingServer() (endpoint string, protocol string, sampleRatio float64) {
+	sampleRatio, _ = strconv.ParseFloat(c.m.GetString("tracing.sample_ratio"), 64)
+
+	return c.m.GetString("tracing.otlp.endpoint"), c.m.GetString("tracing.otlp.protocol"), sampleRatio
+}
+
 // ACME returns all ACME settings needed for certificate renewal.
 func (c *Config) ACME() (domain string, email string, caURL string, agreeTOS bool) {
 	return c.m.GetString("acme.domain"), c.m.GetString("acme.email"), c.m.GetString("acme.ca_url"), c.m.GetBool("acme.agree_tos")
@@ -334,6 +423,33 @@ var ConfigSchema = config.Schema{
 		//  shortdesc: Agree to ACME terms of service
 		"acme.agree_tos": {Type: config.Bool, Default: "false"},
 
+		// lxdmeta:generate(entities=server; group=audit; key=audit.enabled)
+		// When enabled, each cluster member records the mutating API requests and the denied API requests it handles in a local, hash-chained audit log.
+		// ---
+		//  type: bool
+		//  scope: global
+		//  defaultdesc: `false`
+		//  shortdesc: Whether to record API requests in the audit log
+		"audit.enabled": {Type: config.Bool, Default: "false"},
+
+		// lxdmeta:generate(entities=server; group=audit; key=audit.max_files)
+		// Specify the number of rotated audit log files to keep on each cluster member.
+		// ---
+		//  type: integer
+		//  scope: global
+		//  defaultdesc: `5`
+		//  shortdesc: Number of rotated audit log files to keep
+		"audit.max_files": {Type: config.Int64, Default: "5", Validator: validate.IsInRange(0, 1000)},
+
+		// lxdmeta:generate(entities=server; group=audit; key=audit.max_size)
+		// Specify the size (in bytes, or with a suffix like `MiB`) above which the audit log file is rotated.
+		// ---
+		//  type: string
+		//  scope: global
+		//  defaultdesc: `10MiB`
+		//  shortdesc: Size above which the audit log file is rotated
+		"audit.max_size": {Default: "10MiB", Validator: validate.IsSize},
+
 		// lxdmeta:generate(entities=server; group=miscellaneous; key=backups.compression_algorithm)
 		// Possible values are `bzip2`, `gzip`, `lzma`, `xz`, or `none`.
 		// ---
@@ -606,6 +722,28 @@ var ConfigSchema = config.Schema{
 		//  shortdesc: Whether to set `migration.stateful` to `true` for the instances
 		"instances.migration.stateful": {Type: config.Bool, Default: "false"},
 
+		// lxdmeta:generate(entities=server; group=miscellaneous; key=instances.memory_pressure.suspend_threshold)
+		// Specify a percentage between 0 and 100 of the host memory pressure (the share of time during which some tasks were stalled waiting for memory over the last 10 seconds).
+		// When the pressure on a cluster member rises above this threshold, LXD statefully stops its idle virtual machines that have {config:option}`instance-resource-limits:limits.memory.suspend.priority` set, starting with the lowest priority.
+		// Set to `0` to disable automatic suspension.
+		// ---
+		//  type: integer
+		//  scope: global
+		//  defaultdesc: `0`
+		//  shortdesc: Memory pressure above which idle VMs are suspended
+		"instances.memory_pressure.suspend_threshold": {Type: config.Int64, Default: "0", Validator: validate.IsInRange(0, 100)},
+
+		// lxdmeta:generate(entities=server; group=miscellaneous; key=instances.memory_pressure.resume_threshold)
+		// Specify a percentage between 0 and 100 of the host memory pressure.
+		// When the pressure on a cluster member falls below this threshold, LXD starts the virtual machines it suspended because of memory pressure again, starting with the highest priority.
+		// If set to `0`, half of {config:option}`server-miscellaneous:instances.memory_pressure.suspend_threshold` is used.
+		// ---
+		//  type: integer
+		//  scope: global
+		//  defaultdesc: `0`
+		//  shortdesc: Memory pressure below which suspended VMs are resumed
+		"instances.memory_pressure.resume_threshold": {Type: config.Int64, Default: "0", Validator: validate.IsInRange(0, 100)},
+
 		// TODO: Remove after sunset period
 		// lxdmeta:generate(entities=server; group=miscellaneous; key=user.instances.placement.scriptlet)
 		// Stores the migrated value from the deprecated `instances.placement.scriptlet` configuration key. LXD ignores this key; changing it has no effect. It exists only to preserve previously stored data and may be removed in a future release.
@@ -615,6 +753,248 @@ var ConfigSchema = config.Schema{
 		//  scope: global
 		//  shortdesc: Legacy storage for `instances.placement.scriptlet` (no effect)
 
+		// lxdmeta:generate(entities=server; group=ldap; key=ldap.url)
+		// Set this option to enable authentication with LDAP credentials (for example, against OpenLDAP or Active Directory).
+		// Use the `ldaps` scheme, or set {config:option}`server-ldap:ldap.starttls`, to encrypt the connection.
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: URL of the LDAP server (`ldap://HOST[:PORT]` or `ldaps://HOST[:PORT]`)
+		"ldap.url": {Validator: validate.Optional(func(value string) error {
+			u, err := url.Parse(value)
+			if err != nil {
+				return err
+			}
+
+			if !slices.Contains([]string{"ldap", "ldaps"}, u.Scheme) || u.Host == "" {
+				return errors.New("Expected ldap://HOST[:PORT] or ldaps://HOST[:PORT]")
+			}
+
+			return nil
+		})},
+
+		// lxdmeta:generate(entities=server; group=ldap; key=ldap.starttls)
+		// This option can only be used with the `ldap` scheme.
+		// ---
+		//  type: bool
+		//  scope: global
+		//  defaultdesc: `false`
+		//  shortdesc: Whether to upgrade the connection to the LDAP server to TLS
+		"ldap.starttls": {Type: config.Bool, Default: "false"},
+
+		// lxdmeta:generate(entities=server; group=ldap; key=ldap.ca_cert)
+		// Specify this option if the LDAP server certificate isn't signed by a trusted CA.
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: CA certificate for the LDAP server
+		"ldap.ca_cert": {},
+
+		// lxdmeta:generate(entities=server; group=ldap; key=ldap.bind.dn)
+		// LXD binds with this DN to search for users and groups.
+		// If not set, the directory is searched anonymously.
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: DN of the account used to search the directory
+		"ldap.bind.dn": {},
+
+		// lxdmeta:generate(entities=server; group=ldap; key=ldap.bind.password)
+		//
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: Password of the account used to search the directory
+		"ldap.bind.password": {},
+
+		// lxdmeta:generate(entities=server; group=ldap; key=ldap.user.base_dn)
+		// This option is required to enable LDAP authentication.
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: Base DN under which users are searched for
+		"ldap.user.base_dn": {},
+
+		// lxdmeta:generate(entities=server; group=ldap; key=ldap.user.filter)
+		// The filter must select exactly one entry for a given user.
+		// `{username}` is replaced by the username entered when logging in.
+		// For Active Directory, use `(sAMAccountName={username})`.
+		// ---
+		//  type: string
+		//  scope: global
+		//  defaultdesc: `(uid={username})`
+		//  shortdesc: Filter used to search for the entry of a user
+		"ldap.user.filter": {Default: "(uid={username})", Validator: func(value string) error {
+			if !strings.Contains(value, ldap.PlaceholderUsername) {
+				return fmt.Errorf("The filter must contain %q", ldap.PlaceholderUsername)
+			}
+
+			return nil
+		}},
+
+		// lxdmeta:generate(entities=server; group=ldap; key=ldap.groups.base_dn)
+		// If set, the groups of the users are retrieved when they log in, and can be mapped to LXD groups using {ref}`identity provider groups <identity-provider-groups>`.
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: Base DN under which groups are searched for
+		"ldap.groups.base_dn": {},
+
+		// lxdmeta:generate(entities=server; group=ldap; key=ldap.groups.filter)
+		// `{dn}` is replaced by the DN of the user entry and `{username}` by the username entered when logging in.
+		// For nested Active Directory groups, use `(member:1.2.840.113556.1.4.1941:={dn})`.
+		// ---
+		//  type: string
+		//  scope: global
+		//  defaultdesc: `(member={dn})`
+		//  shortdesc: Filter used to search for the groups of a user
+		"ldap.groups.filter": {Default: "(member={dn})"},
+
+		// lxdmeta:generate(entities=server; group=ldap; key=ldap.groups.attribute)
+		//
+		// ---
+		//  type: string
+		//  scope: global
+		//  defaultdesc: `cn`
+		//  shortdesc: Attribute of the group entries used as identity provider group name
+		"ldap.groups.attribute": {Default: "cn"},
+
+		// lxdmeta:generate(entities=server; group=ldap; key=ldap.session.expiry)
+		// The duration of an LDAP session. Users must log in again when their session expires.
+		//
+		// This configuration option accepts multiple space-separated values of the form `[0-9]+(S|M|H|d|w|m|y)`,
+		// where `S` is seconds, `M` is minutes, `H` is hours, `d` is days, `w` is weeks, `m` is months, and `y` is years.
+		// For example, `1d 3H` is 1 day and 3 hours.
+		//
+		// The minimum value is `1H` (1 hour).
+		// ---
+		//  type: string
+		//  scope: global
+		//  defaultdesc: `1d`
+		//  shortdesc: The duration of an LDAP session
+		"ldap.session.expiry": {Default: "1d", Validator: func(s string) error {
+			now := time.Now().UTC()
+			exp, err := shared.GetExpiry(now, s)
+			if err != nil {
+				return err
+			}
+
+			if exp.Sub(now) < time.Hour {
+				return errors.New("LDAP session expiry cannot be set to less than one hour")
+			}
+
+			return nil
+		}},
+
+		// lxdmeta:generate(entities=server; group=logs-otlp; key=logs.otlp.ca_cert)
+		//
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: CA certificate for the OTLP logs collector
+		"logs.otlp.ca_cert": {},
+
+		// lxdmeta:generate(entities=server; group=logs-otlp; key=logs.otlp.endpoint)
+		// Specify the protocol, name or IP and port of an OTLP/HTTP collector. For example `https://otel.example.com:4318`. LXD will automatically add the `/v1/logs` suffix if the URL has no path.
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: URL of the OTLP logs collector
+		"logs.otlp.endpoint": {Validator: validate.Optional(validate.IsRequestURL)},
+
+		// lxdmeta:generate(entities=server; group=logs-otlp; key=logs.otlp.instance)
+		// This allows replacing the default instance value (server host name) by a more relevant value like a cluster identifier.
+		// ---
+		//  type: string
+		//  scope: global
+		//  defaultdesc: Local server host name or cluster member name
+		//  shortdesc: Name to use as the `service.instance.id` resource attribute
+		"logs.otlp.instance": {},
+
+		// lxdmeta:generate(entities=server; group=logs-otlp; key=logs.otlp.labels)
+		// Specify a comma-separated list of values that should be used as labels for an OTLP log record.
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: Labels for an OTLP log record
+		"logs.otlp.labels": {},
+
+		// lxdmeta:generate(entities=server; group=logs-otlp; key=logs.otlp.loglevel)
+		//
+		// ---
+		//  type: string
+		//  scope: global
+		//  defaultdesc: `info`
+		//  shortdesc: Minimum log level to send to the OTLP logs collector
+		"logs.otlp.loglevel": {Validator: logLevelValidator, Default: logrus.InfoLevel.String()},
+
+		// lxdmeta:generate(entities=server; group=logs-otlp; key=logs.otlp.types)
+		// Specify a comma-separated list of events to send to the OTLP logs collector.
+		// The events can be any combination of `lifecycle`, `logging`, `network-acl`, `ovn`, and `audit` (entries of the audit log, see {ref}`audit-log`).
+		// ---
+		//  type: string
+		//  scope: global
+		//  defaultdesc: `lifecycle,logging`
+		//  shortdesc: Events to send to the OTLP logs collector
+		"logs.otlp.types": {Validator: validate.Optional(validate.IsListOf(validate.IsOneOf(
+			api.EventTypeLifecycle, api.EventTypeLogging, api.EventTypeNetworkACL, api.EventTypeOVN, logexport.EventTypeAudit,
+		))), Default: "lifecycle,logging"},
+
+		// lxdmeta:generate(entities=server; group=logs-syslog; key=logs.syslog.address)
+		// Specify the protocol, name or IP and port of the syslog server. For example `tls://syslog.example.com:6514`.
+		// The protocol can be `tcp` or `tls`. Messages are sent using the RFC 5424 format with octet counting framing.
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: Address of the syslog server
+		"logs.syslog.address": {Validator: validate.Optional(syslogAddressValidator)},
+
+		// lxdmeta:generate(entities=server; group=logs-syslog; key=logs.syslog.ca_cert)
+		//
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: CA certificate for the syslog server
+		"logs.syslog.ca_cert": {},
+
+		// lxdmeta:generate(entities=server; group=logs-syslog; key=logs.syslog.instance)
+		// This allows replacing the default instance value (server host name) by a more relevant value like a cluster identifier.
+		// ---
+		//  type: string
+		//  scope: global
+		//  defaultdesc: Local server host name or cluster member name
+		//  shortdesc: Name to use as the instance field in syslog messages
+		"logs.syslog.instance": {},
+
+		// lxdmeta:generate(entities=server; group=logs-syslog; key=logs.syslog.labels)
+		// Specify a comma-separated list of values that should be added as labels to the syslog messages.
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: Labels for a syslog message
+		"logs.syslog.labels": {},
+
+		// lxdmeta:generate(entities=server; group=logs-syslog; key=logs.syslog.loglevel)
+		//
+		// ---
+		//  type: string
+		//  scope: global
+		//  defaultdesc: `info`
+		//  shortdesc: Minimum log level to send to the syslog server
+		"logs.syslog.loglevel": {Validator: logLevelValidator, Default: logrus.InfoLevel.String()},
+
+		// lxdmeta:generate(entities=server; group=logs-syslog; key=logs.syslog.types)
+		// Specify a comma-separated list of events to send to the syslog server.
+		// The events can be any combination of `lifecycle`, `logging`, `network-acl`, `ovn`, and `audit` (entries of the audit log, see {ref}`audit-log`).
+		// ---
+		//  type: string
+		//  scope: global
+		//  defaultdesc: `lifecycle,logging`
+		//  shortdesc: Events to send to the syslog server
+		"logs.syslog.types": {Validator: validate.Optional(validate.IsListOf(validate.IsOneOf(
+			api.EventTypeLifecycle, api.EventTypeLogging, api.EventTypeNetworkACL, api.EventTypeOVN, logexport.EventTypeAudit,
+		))), Default: "lifecycle,logging"},
+
 		// lxdmeta:generate(entities=server; group=loki; key=loki.auth.username)
 		//
 		// ---
@@ -674,14 +1054,14 @@ var ConfigSchema = config.Schema{
 
 		// lxdmeta:generate(entities=server; group=loki; key=loki.types)
 		// Specify a comma-separated list of events to send to the Loki server.
-		// The events can be any combination of `lifecycle`, `logging`, and `ovn`.
+		// The events can be any combination of `lifecycle`, `logging`, `network-acl`, `ovn`, and `audit` (entries of the audit log, see {ref}`audit-log`).
 		// ---
 		//  type: string
 		//  scope: global
 		//  defaultdesc: `lifecycle,logging`
 		//  shortdesc: Events to send to the Loki server
 		"loki.types": {Validator: validate.Optional(validate.IsListOf(validate.IsOneOf(
-			api.EventTypeLifecycle, api.EventTypeLogging, api.EventTypeOVN,
+			api.EventTypeLifecycle, api.EventTypeLogging, api.EventTypeNetworkACL, api.EventTypeOVN, logexport.EventTypeAudit,
 		))), Default: "lifecycle,logging"},
 
 		// lxdmeta:generate(entities=server; group=oidc; key=oidc.client.id)
@@ -827,6 +1207,48 @@ var ConfigSchema = config.Schema{
 		//  shortdesc: OVN SSL client key
 		"network.ovn.client_key": {Default: ""},
 
+		// lxdmeta:generate(entities=server; group=ssh; key=ssh.ca_keys)
+		// Specify one public key per line, in the `authorized_keys` format.
+		// Users can authenticate with an SSH user certificate signed by one of these keys.
+		// The first principal of the certificate that matches the requested username identifies the user, and the other
+		// principals can be mapped to LXD groups using {ref}`identity provider groups <identity-provider-groups>`.
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: Trusted SSH certificate authority keys
+		"ssh.ca_keys": {Validator: func(value string) error {
+			_, err := ssh.ParseAuthorizedKeys(value)
+			return err
+		}},
+
+		// lxdmeta:generate(entities=server; group=ssh; key=ssh.session.expiry)
+		// The duration of an SSH session. Users must sign a new challenge when their session expires.
+		// Sessions started with a certificate expire at the latest when the certificate expires.
+		//
+		// This configuration option accepts multiple space-separated values of the form `[0-9]+(S|M|H|d|w|m|y)`,
+		// where `S` is seconds, `M` is minutes, `H` is hours, `d` is days, `w` is weeks, `m` is months, and `y` is years.
+		// For example, `1d 3H` is 1 day and 3 hours.
+		//
+		// The minimum value is `1H` (1 hour).
+		// ---
+		//  type: string
+		//  scope: global
+		//  defaultdesc: `1d`
+		//  shortdesc: The duration of an SSH session
+		"ssh.session.expiry": {Default: "1d", Validator: func(s string) error {
+			now := time.Now().UTC()
+			exp, err := shared.GetExpiry(now, s)
+			if err != nil {
+				return err
+			}
+
+			if exp.Sub(now) < time.Hour {
+				return errors.New("SSH session expiry cannot be set to less than one hour")
+			}
+
+			return nil
+		}},
+
 		// lxdmeta:generate(entities=server; group=miscellaneous; key=volatile.uuid)
 		// This UUID is used as a stable identifier for the cluster. It cannot be changed.
 		// ---
@@ -834,6 +1256,34 @@ var ConfigSchema = config.Schema{
 		//  scope: global
 		//  shortdesc: A random v7 UUID
 		"volatile.uuid": {},
+
+		// lxdmeta:generate(entities=server; group=tracing; key=tracing.otlp.endpoint)
+		// Specify the protocol, name or IP and port of the OpenTelemetry collector. For example `http://otel.example.com:4318`.
+		// When using the `http` protocol, LXD automatically adds the `/v1/traces` suffix if the URL has no path.
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: URL of the OpenTelemetry collector to send traces to
+		"tracing.otlp.endpoint": {Validator: validate.Optional(validate.IsRequestURL)},
+
+		// lxdmeta:generate(entities=server; group=tracing; key=tracing.otlp.protocol)
+		// Possible values are `http` (OTLP over HTTP with protobuf encoding) and `grpc`.
+		// ---
+		//  type: string
+		//  scope: global
+		//  defaultdesc: `http`
+		//  shortdesc: Protocol used to send traces to the OpenTelemetry collector
+		"tracing.otlp.protocol": {Validator: validate.IsOneOf("http", "grpc"), Default: "http"},
+
+		// lxdmeta:generate(entities=server; group=tracing; key=tracing.sample_ratio)
+		// Specify a value between `0` and `1`. Requests that are part of a trace started by the client or by another
+		// cluster member follow the sampling decision of that trace instead.
+		// ---
+		//  type: string
+		//  scope: global
+		//  defaultdesc: `1`
+		//  shortdesc: Ratio of traces to sample
+		"tracing.sample_ratio": {Validator: sampleRatioValidator, Default: "1"},
 	},
 }
 
@@ -918,3 +1368,33 @@ func maxStandByValidator(value string) error {
 
 	return nil
 }
+
+func sampleRatioValidator(value string) error {
+	ratio, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return errors.New("Value is not a number")
+	}
+
+	if ratio < 0 || ratio > 1 {
+		return errors.New("Value must be between 0 and 1")
+	}
+
+	return nil
+}
+
+func syslogAddressValidator(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+
+	if u.Scheme != "tcp" && u.Scheme != "tls" {
+		return errors.New("Scheme must be tcp or tls")
+	}
+
+	if u.Host == "" || u.Port() == "" {
+		return errors.New("Address must include a host and port")
+	}
+
+	return nil
+}
@@ -33,8 +33,9 @@ func connect(ctx context.Context, address string, networkCert *shared.CertInfo,
 		args.Proxy = request.RequestorForwardProxy(requestor)
 	}
 
+	// Keep the values of the context (like the trace context) without tying the client to its cancellation.
 	url := "https://" + address
-	return lxd.ConnectLXD(url, args)
+	return lxd.ConnectLXDWithContext(context.WithoutCancel(ctx), url, args)
 }
 
 // Connect is a convenience around lxd.ConnectLXD that configures the client
@@ -14,6 +14,7 @@ import (
 	"github.com/canonical/lxd/lxd/db"
 	"github.com/canonical/lxd/lxd/db/query"
 	"github.com/canonical/lxd/lxd/db/warningtype"
+	"github.com/canonical/lxd/lxd/metrics"
 	"github.com/canonical/lxd/lxd/response"
 	"github.com/canonical/lxd/lxd/task"
 	"github.com/canonical/lxd/lxd/warnings"
@@ -149,7 +150,7 @@ func (hbState *APIHeartbeat) Update(fullStateList bool, raftNodes []db.RaftNode,
 // Send sends heartbeat requests to the nodes supplied and updates heartbeat state.
 func (hbState *APIHeartbeat) Send(ctx context.Context, networkCert *shared.CertInfo, serverCert *shared.CertInfo, localAddress string, nodes []db.NodeInfo, spreadDuration time.Duration) {
 	heartbeatsWg := sync.WaitGroup{}
-	sendHeartbeat := func(nodeID int64, address string, delay time.Duration, heartbeatData *APIHeartbeat) {
+	sendHeartbeat := func(nodeID int64, name string, address string, delay time.Duration, heartbeatData *APIHeartbeat) {
 		defer heartbeatsWg.Done()
 
 		if delay > 0 {
@@ -163,7 +164,9 @@ func (hbState *APIHeartbeat) Send(ctx context.Context, networkCert *shared.CertI
 		heartbeatData.Time = time.Now().UTC()
 
 		// Don't use ctx here, as we still want to finish off the request if the ctx has been cancelled.
+		startTime := time.Now()
 		err := HeartbeatNode(context.Background(), address, networkCert, serverCert, heartbeatData)
+		metrics.TrackHeartbeat(name, time.Since(startTime), err)
 		if err == nil {
 			heartbeatData.Lock()
 			// Ensure only update nodes that exist in Members already.
@@ -232,7 +235,7 @@ func (hbState *APIHeartbeat) Send(ctx context.Context, networkCert *shared.CertI
 
 		// Parallelize the rest.
 		heartbeatsWg.Add(1)
-		go sendHeartbeat(node.ID, node.Address, delay, hbState)
+		go sendHeartbeat(node.ID, node.Name, node.Address, delay, hbState)
 	}
 
 	heartbeatsWg.Wait()
@@ -448,6 +451,14 @@ func (g *Gateway) heartbeat(ctx context.Context, mode HeartbeatMode) {
 			return
 		}
 
+		// Drop the heartbeat statistics of the members that were removed or renamed.
+		currentMemberNames := make([]string, 0, len(currentMembers))
+		for _, currentMember := range currentMembers {
+			currentMemberNames = append(currentMemberNames, currentMember.Name)
+		}
+
+		metrics.PruneHeartbeats(currentMemberNames)
+
 		newMembers := []db.NodeInfo{}
 		for _, currentMember := range currentMembers {
 			existing := false
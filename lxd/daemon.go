@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"crypto/x509"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"math"
 	"net"
 	"net/http"
@@ -28,10 +30,13 @@ import (
 
 	"github.com/canonical/lxd/lxd/acme"
 	"github.com/canonical/lxd/lxd/apparmor"
+	"github.com/canonical/lxd/lxd/audit"
 	"github.com/canonical/lxd/lxd/auth"
 	"github.com/canonical/lxd/lxd/auth/bearer"
 	authDrivers "github.com/canonical/lxd/lxd/auth/drivers"
+	"github.com/canonical/lxd/lxd/auth/ldap"
 	"github.com/canonical/lxd/lxd/auth/oidc"
+	"github.com/canonical/lxd/lxd/auth/ssh"
 	"github.com/canonical/lxd/lxd/bgp"
 	"github.com/canonical/lxd/lxd/cluster"
 	clusterConfig "github.com/canonical/lxd/lxd/cluster/config"
@@ -53,6 +58,7 @@ import (
 	"github.com/canonical/lxd/lxd/instance"
 	instanceDrivers "github.com/canonical/lxd/lxd/instance/drivers"
 	"github.com/canonical/lxd/lxd/instance/instancetype"
+	"github.com/canonical/lxd/lxd/logexport"
 	"github.com/canonical/lxd/lxd/loki"
 	"github.com/canonical/lxd/lxd/metrics"
 	networkZone "github.com/canonical/lxd/lxd/network/zone"
@@ -67,6 +73,7 @@ import (
 	"github.com/canonical/lxd/lxd/storage/filesystem"
 	"github.com/canonical/lxd/lxd/sys"
 	"github.com/canonical/lxd/lxd/task"
+	"github.com/canonical/lxd/lxd/tracing"
 	"github.com/canonical/lxd/lxd/ubuntupro"
 	"github.com/canonical/lxd/lxd/ucred"
 	"github.com/canonical/lxd/lxd/util"
@@ -117,6 +124,11 @@ type Daemon struct {
 
 	oidcVerifier *oidc.Verifier
 
+	ldapAuthenticator *ldap.Authenticator
+
+	// SSH authentication
+	sshAuthenticator *ssh.Authenticator
+
 	// Stores last heartbeat node information to detect node changes.
 	lastNodeList *cluster.APIHeartbeat
 
@@ -151,7 +163,12 @@ type Daemon struct {
 	serverName      string
 	serverClustered bool
 
-	lokiClient *loki.Client
+	// Event exporters (Loki, OTLP, syslog) keyed by their event handler name.
+	logExporters   map[string]logexport.Exporter
+	logExportersMu sync.Mutex
+
+	// Audit log of the mutating and denied API requests.
+	auditLog *audit.Log
 
 	// HTTP-01 challenge provider for ACME
 	http01Provider acme.HTTP01Provider
@@ -639,11 +656,59 @@ func (d *Daemon) Authenticate(w http.ResponseWriter, r *http.Request) (*request.
 			return nil, fmt.Errorf("Failed verifying bearer token: %w", err)
 		}
 
+		// Deny access if the token may not be used from the caller's address.
+		if bearerRequestor.TokenScope != nil && !bearerRequestor.TokenScope.AllowsAddress(r.RemoteAddr) {
+			return nil, api.StatusErrorf(http.StatusForbidden, "Bearer token may not be used from address %q", r.RemoteAddr)
+		}
+
 		// We successfully authenticated the user via bearer token.
 		// The bearerRequestor contains the identity info (username, protocol=bearer).
 		return bearerRequestor, nil
 	}
 
+	// Check if the caller has an LDAP session token.
+	if d.ldapAuthenticator != nil {
+		isLDAPRequest, token, subject, issuedAt := bearer.IsLDAPRequest(r, d.globalConfig.ClusterUUID())
+		if isLDAPRequest {
+			expiresAt, err := d.verifySessionToken(r.Context(), *issuedAt, func(secret []byte) (*time.Time, error) {
+				return bearer.VerifyLDAPSessionToken(token, secret, subject, d.globalConfig.ClusterUUID())
+			})
+			if err != nil {
+				return nil, fmt.Errorf("Failed LDAP Authentication: %w", err)
+			}
+
+			return &request.RequestorArgs{
+				Trusted:   true,
+				Username:  subject,
+				Protocol:  api.AuthenticationMethodLDAP,
+				ExpiresAt: expiresAt,
+			}, nil
+		}
+	}
+
+	// Check if the caller has an SSH session token.
+	isSSHRequest, token, subject, issuedAt := bearer.IsSSHRequest(r, d.globalConfig.ClusterUUID())
+	if isSSHRequest {
+		expiresAt, err := d.verifySessionToken(r.Context(), *issuedAt, func(secret []byte) (*time.Time, error) {
+			return bearer.VerifySSHSessionToken(token, secret, subject, d.globalConfig.ClusterUUID())
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Failed SSH Authentication: %w", err)
+		}
+
+		err = d.checkSSHSession(r.Context(), subject, *issuedAt)
+		if err != nil {
+			return nil, fmt.Errorf("Failed SSH Authentication: %w", err)
+		}
+
+		return &request.RequestorArgs{
+			Trusted:   true,
+			Username:  subject,
+			Protocol:  api.AuthenticationMethodSSH,
+			ExpiresAt: expiresAt,
+		}, nil
+	}
+
 	// Lastly, check OIDC authentication using the verifier.
 	if d.oidcVerifier != nil && d.oidcVerifier.IsRequest(r) {
 		result, err := d.oidcVerifier.Auth(w, r)
@@ -662,6 +727,32 @@ func (d *Daemon) Authenticate(w http.ResponseWriter, r *http.Request) (*request.
 	return &request.RequestorArgs{Trusted: false}, nil
 }
 
+// verifySessionToken verifies a token whose signing key is derived from a cluster secret (such as LDAP and SSH session
+// tokens), using the cluster secret that was current when the token was issued. For a valid token, an expiration time
+// is returned.
+func (d *Daemon) verifySessionToken(ctx context.Context, issuedAt time.Time, verify func(secret []byte) (*time.Time, error)) (*time.Time, error) {
+	secrets, err := d.getCoreAuthSecrets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting session token signing key: %w", err)
+	}
+
+	// Secrets are ordered from newest to oldest, take the first secret that was created before the token was issued.
+	for _, secret := range secrets {
+		if secret.CreationDate.Unix() > issuedAt.Unix() {
+			continue
+		}
+
+		expiresAt, err := verify(secret.Value)
+		if err != nil {
+			return nil, fmt.Errorf("Session token is not valid: %w", err)
+		}
+
+		return expiresAt, nil
+	}
+
+	return nil, errors.New("Session token signing key has expired")
+}
+
 // getCoreAuthSecrets gets a copy of the current, cluster-wide secrets. The approach can be summarized as follows:
 // 1. Check if the current in-memory value is valid. If valid, return a copy.
 // 2. Check if the current in-database value is valid. If valid, replace in-memory value and return a copy.
@@ -779,6 +870,8 @@ func (d *Daemon) State() *state.State {
 			return nil, fmt.Errorf("Failed getting the address of the cluster leader: %w", err)
 		}
 
+		metrics.TrackLeaderAddress(leaderAddress)
+
 		return &state.LeaderInfo{
 			Clustered: true,
 			Leader:    localClusterAddress == leaderAddress,
@@ -819,6 +912,31 @@ func (d *Daemon) createCmd(restAPI *mux.Router, version string, c APIEndpoint) {
 			metrics.TrackStartedRequest(r, c.MetricsType)
 		}
 
+		// Trace the request, continuing the trace of the client or of the cluster member forwarding it if any.
+		ctx, span := tracing.StartServer(r, uri)
+		defer span.End()
+
+		r = r.WithContext(ctx)
+
+		// Record the mutating and denied requests of the main API in the audit log.
+		if version == "1.0" && d.auditLog.Enabled() {
+			auditWriter := audit.NewResponseWriter(w)
+			auditBody := audit.NewBodyRecorder(r.Body)
+			auditChecks := &audit.Checks{}
+			startTime := time.Now()
+
+			w = auditWriter
+			r.Body = auditBody
+			request.SetContextValue(r, request.CtxAuditChecks, auditChecks)
+
+			defer func() {
+				err := d.auditLog.Record(r, auditWriter, auditBody, auditChecks, d.serverName, startTime)
+				if err != nil {
+					logger.Warn("Failed recording request in audit log", logger.Ctx{"url": r.URL.RequestURI(), "err": err})
+				}
+			}()
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 
 		if r.RemoteAddr != "@" || version != "internal" {
@@ -1067,13 +1185,87 @@ func (d *Daemon) Init() error {
 	return d.init()
 }
 
+// setLogExporter replaces the event exporter registered under the given name.
+// Any existing exporter with that name is stopped. A nil exporter only removes the existing one.
+func (d *Daemon) setLogExporter(name string, exporter logexport.Exporter) {
+	d.logExportersMu.Lock()
+	defer d.logExportersMu.Unlock()
+
+	oldExporter, ok := d.logExporters[name]
+	if ok {
+		d.internalListener.RemoveHandler(name)
+		oldExporter.Stop()
+		delete(d.logExporters, name)
+	}
+
+	if exporter == nil {
+		return
+	}
+
+	if d.logExporters == nil {
+		d.logExporters = map[string]logexport.Exporter{}
+	}
+
+	d.logExporters[name] = exporter
+
+	// Attach the new exporter to the log handler.
+	d.internalListener.AddHandler(name, exporter.HandleEvent)
+}
+
+// forwardAuditEntry sends the audit log entry to the event exporters configured with the audit event type.
+func (d *Daemon) forwardAuditEntry(entry api.AuditEntry) {
+	metadata, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	event := api.Event{
+		Type:      logexport.EventTypeAudit,
+		Timestamp: entry.Timestamp,
+		Location:  entry.Location,
+		Metadata:  metadata,
+	}
+
+	d.logExportersMu.Lock()
+	exporters := slices.Collect(maps.Values(d.logExporters))
+	d.logExportersMu.Unlock()
+
+	for _, exporter := range exporters {
+		exporter.HandleEvent(event)
+	}
+}
+
+// logExportFilter returns the filter used by the event exporters.
+// On standalone systems, the host name is used as location and as default instance name.
+func (d *Daemon) logExportFilter(instanceName string, logLevel string, labels []string, types []string) (logexport.Filter, error) {
+	filter := logexport.Filter{
+		Types:    types,
+		LogLevel: logLevel,
+		Labels:   labels,
+		Instance: instanceName,
+	}
+
+	// Handle standalone systems.
+	if !d.serverClustered {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return logexport.Filter{}, err
+		}
+
+		filter.Location = hostname
+		if filter.Instance == "" {
+			filter.Instance = hostname
+		}
+	} else if filter.Instance == "" {
+		filter.Instance = d.serverName
+	}
+
+	return filter, nil
+}
+
 func (d *Daemon) setupLoki(URL string, cert string, key string, caCert string, instanceName string, logLevel string, labels []string, types []string) error {
 	// Stop any existing loki client.
-	if d.lokiClient != nil {
-		d.internalListener.RemoveHandler("loki")
-		d.lokiClient.Stop()
-		d.lokiClient = nil
-	}
+	d.setLogExporter("loki", nil)
 
 	// Check basic requirements for starting a new client.
 	if URL == "" || logLevel == "" || len(types) == 0 {
@@ -1086,34 +1278,98 @@ func (d *Daemon) setupLoki(URL string, cert string, key string, caCert string, i
 		return err
 	}
 
-	// Handle standalone systems.
-	var location string
-	if !d.serverClustered {
-		hostname, err := os.Hostname()
-		if err != nil {
-			return err
-		}
+	filter, err := d.logExportFilter(instanceName, logLevel, labels, types)
+	if err != nil {
+		return err
+	}
 
-		location = hostname
-		if instanceName == "" {
-			instanceName = hostname
-		}
-	} else if instanceName == "" {
-		instanceName = d.serverName
+	// Start a new client.
+	client, err := loki.NewClient(d.shutdownCtx, u, cert, key, caCert, filter.Instance, filter.Location, logLevel, labels, types)
+	if err != nil {
+		return err
+	}
+
+	d.setLogExporter("loki", client)
+
+	return nil
+}
+
+func (d *Daemon) setupOTLPLogs(endpoint string, caCert string, instanceName string, logLevel string, labels []string, types []string) error {
+	// Stop any existing OTLP client.
+	d.setLogExporter("otlp", nil)
+
+	// Check basic requirements for starting a new client.
+	if endpoint == "" || logLevel == "" || len(types) == 0 {
+		return nil
+	}
+
+	// Validate the URL.
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+
+	filter, err := d.logExportFilter(instanceName, logLevel, labels, types)
+	if err != nil {
+		return err
+	}
+
+	// Start a new client.
+	client, err := logexport.NewOTLPClient(u, caCert, filter)
+	if err != nil {
+		return err
+	}
+
+	d.setLogExporter("otlp", client)
+
+	return nil
+}
+
+func (d *Daemon) setupSyslog(address string, caCert string, instanceName string, logLevel string, labels []string, types []string) error {
+	// Stop any existing syslog client.
+	d.setLogExporter("syslog", nil)
+
+	// Check basic requirements for starting a new client.
+	if address == "" || logLevel == "" || len(types) == 0 {
+		return nil
+	}
+
+	// Validate the URL.
+	u, err := url.Parse(address)
+	if err != nil {
+		return err
+	}
+
+	filter, err := d.logExportFilter(instanceName, logLevel, labels, types)
+	if err != nil {
+		return err
 	}
 
 	// Start a new client.
-	d.lokiClient, err = loki.NewClient(d.shutdownCtx, u, cert, key, caCert, instanceName, location, logLevel, labels, types)
+	client, err := logexport.NewSyslogClient(u, caCert, filter)
 	if err != nil {
 		return err
 	}
 
-	// Attach the new client to the log handler.
-	d.internalListener.AddHandler("loki", d.lokiClient.HandleEvent)
+	d.setLogExporter("syslog", client)
 
 	return nil
 }
 
+func (d *Daemon) setupTracing(endpoint string, protocol string, sampleRatio float64) error {
+	instanceName := d.serverName
+	if !d.serverClustered {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return err
+		}
+
+		instanceName = hostname
+	}
+
+	return tracing.Configure(d.shutdownCtx, endpoint, protocol, sampleRatio, instanceName)
+}
+
 func (d *Daemon) init() error {
 	d.startStopLock.Lock()
 	defer d.startStopLock.Unlock()
@@ -1122,12 +1378,14 @@ func (d *Daemon) init() error {
 
 	var err error
 
-	// Set default authorizer.
-	d.authorizer, err = authDrivers.LoadAuthorizer(d.shutdownCtx, authDrivers.DriverTLS, logger.Log)
+	// Set default authorizer. Permission checks are recorded in the audit log.
+	authorizer, err := authDrivers.LoadAuthorizer(d.shutdownCtx, authDrivers.DriverTLS, logger.Log)
 	if err != nil {
 		return err
 	}
 
+	d.authorizer = audit.NewAuthorizer(authorizer)
+
 	// Setup events
 	d.devLXDEvents = events.NewDevLXDServer(daemon.Debug, daemon.Verbose)
 	d.events, err = events.NewServer(daemon.Debug, daemon.Verbose, cluster.EventHubPush)
@@ -1141,6 +1399,10 @@ func (d *Daemon) init() error {
 	// Setup internal event listener
 	d.internalListener = events.NewInternalListener(d.shutdownCtx, d.events)
 
+	// Setup the audit log, forwarding its entries to the event exporters.
+	d.auditLog = audit.NewLog(shared.VarPath("audit"))
+	d.auditLog.SetForwarder(d.forwardAuditEntry)
+
 	// Lets check if there's an existing LXD running
 	err = endpoints.CheckAlreadyRunning(d.os.GetUnixSocket())
 	if err != nil {
@@ -1328,6 +1590,20 @@ func (d *Daemon) init() error {
 		logger.Info(" - idmapped mounts kernel support: no")
 	}
 
+	// Detect CRIU support for container live migration.
+	d.os.CRIU, d.os.CRIUMemoryTracking = canUseCRIU()
+	if d.os.CRIU {
+		logger.Info(" - container live migration (CRIU): yes")
+	} else {
+		logger.Info(" - container live migration (CRIU): no")
+	}
+
+	if d.os.CRIUMemoryTracking {
+		logger.Info(" - container live migration memory pre-copy: yes")
+	} else {
+		logger.Info(" - container live migration memory pre-copy: no")
+	}
+
 	// Detect and cached available instance types from operational drivers.
 	drivers := instanceDrivers.DriverStatuses()
 	for _, driver := range drivers {
@@ -1397,6 +1673,9 @@ func (d *Daemon) init() error {
 		d.serverCertInt = serverCert
 	}
 
+	// Key the audit log with the server private key, which is specific to this member and kept when joining a cluster.
+	d.auditLog.SetKey(serverCert.PrivateKey())
+
 	// If we're clustered, check for an incoming recovery tarball
 	if d.serverClustered {
 		tarballPath := filepath.Join(d.db.Node.Dir(), cluster.RecoveryTarballName)
@@ -1573,11 +1852,13 @@ func (d *Daemon) init() error {
 
 	// Load the embedded OpenFGA authorizer. This cannot be loaded until after the cluster database is initialised,
 	// so the TLS authorizer must be loaded first to set up clustering.
-	d.authorizer, err = authDrivers.LoadAuthorizer(d.shutdownCtx, authDrivers.DriverEmbeddedOpenFGA, logger.Log, authDrivers.WithOpenFGADatastore(openfga.NewOpenFGAStore(d.db.Cluster)))
+	authorizer, err = authDrivers.LoadAuthorizer(d.shutdownCtx, authDrivers.DriverEmbeddedOpenFGA, logger.Log, authDrivers.WithOpenFGADatastore(openfga.NewOpenFGAStore(d.db.Cluster)))
 	if err != nil {
 		return err
 	}
 
+	d.authorizer = audit.NewAuthorizer(authorizer)
+
 	d.firewall = firewall.New(d.os.KernelVersion)
 	logger.Info("Firewall loaded driver", logger.Ctx{"driver": d.firewall})
 
@@ -1727,12 +2008,21 @@ func (d *Daemon) init() error {
 
 	d.gateway.HeartbeatOfflineThreshold = d.globalConfig.OfflineThreshold()
 	lokiURL, lokiUsername, lokiPassword, lokiCACert, lokiInstance, lokiLoglevel, lokiLabels, lokiTypes := d.globalConfig.LokiServer()
+	otlpEndpoint, otlpCACert, otlpInstance, otlpLoglevel, otlpLabels, otlpTypes := d.globalConfig.LogsOTLP()
+	syslogAddress, syslogCACert, syslogInstance, syslogLoglevel, syslogLabels, syslogTypes := d.globalConfig.LogsSyslog()
+	tracingEndpoint, tracingProtocol, tracingSampleRatio := d.globalConfig.TracingServer()
+	auditEnabled, auditMaxSize, auditMaxFiles := d.globalConfig.Audit()
 	oidcIssuer, oidcClientID, oidcClientSecret, oidcScopes, oidcAudience, oidcGroupsClaim := d.globalConfig.OIDCServer()
+	ldapArgs := d.globalConfig.LDAP()
+	sshCAKeys := d.globalConfig.SSHCAKeys()
 	syslogSocketEnabled := d.localConfig.SyslogSocket()
 
 	d.endpoints.NetworkUpdateTrustedProxy(d.globalConfig.HTTPSTrustedProxy())
 	d.globalConfigMu.Unlock()
 
+	// Setup the audit log.
+	d.auditLog.Configure(auditEnabled, auditMaxSize, auditMaxFiles)
+
 	// Setup Loki logger.
 	if lokiURL != "" {
 		err = d.setupLoki(lokiURL, lokiUsername, lokiPassword, lokiCACert, lokiInstance, lokiLoglevel, lokiLabels, lokiTypes)
@@ -1741,6 +2031,30 @@ func (d *Daemon) init() error {
 		}
 	}
 
+	// Setup OTLP event export.
+	if otlpEndpoint != "" {
+		err = d.setupOTLPLogs(otlpEndpoint, otlpCACert, otlpInstance, otlpLoglevel, otlpLabels, otlpTypes)
+		if err != nil {
+			logger.Warn("Failed setting up OTLP logs", logger.Ctx{"err": err})
+		}
+	}
+
+	// Setup syslog event export.
+	if syslogAddress != "" {
+		err = d.setupSyslog(syslogAddress, syslogCACert, syslogInstance, syslogLoglevel, syslogLabels, syslogTypes)
+		if err != nil {
+			logger.Warn("Failed setting up syslog", logger.Ctx{"err": err})
+		}
+	}
+
+	// Setup OpenTelemetry trace export.
+	if tracingEndpoint != "" {
+		err = d.setupTracing(tracingEndpoint, tracingProtocol, tracingSampleRatio)
+		if err != nil {
+			logger.Warn("Failed setting up tracing", logger.Ctx{"err": err})
+		}
+	}
+
 	if syslogSocketEnabled {
 		err = d.setupSyslogSocket(true)
 		if err != nil {
@@ -1761,6 +2075,20 @@ func (d *Daemon) init() error {
 		}
 	}
 
+	// Setup LDAP authentication.
+	if ldapArgs.URL != "" {
+		d.ldapAuthenticator, err = ldap.NewAuthenticator(ldapArgs)
+		if err != nil {
+			logger.Warn("Failed setting up LDAP authenticator", logger.Ctx{"err": err})
+		}
+	}
+
+	// Setup SSH authentication.
+	d.sshAuthenticator, err = ssh.NewAuthenticator(sshCAKeys)
+	if err != nil {
+		logger.Warn("Failed setting up SSH authenticator", logger.Ctx{"err": err})
+	}
+
 	// Setup BGP listener.
 	d.bgp = bgp.NewServer()
 
@@ -1799,6 +2127,24 @@ func (d *Daemon) init() error {
 		}
 
 		return resp, nil
+	}, func(name string, requestor *api.EventLifecycleRequestor, modify func(records []api.NetworkZoneRecord) ([]api.NetworkZoneRecord, error)) error {
+		// Fetch the zone.
+		zone, err := networkZone.LoadByName(d.shutdownCtx, d.State(), name)
+		if err != nil {
+			return err
+		}
+
+		// Apply the changes to the zone records.
+		changes, err := zone.ModifyRecords(d.shutdownCtx, modify)
+		if err != nil {
+			return err
+		}
+
+		for recordName, action := range changes {
+			d.events.SendLifecycle(zone.Project(), action.Event(zone, recordName, requestor, nil))
+		}
+
+		return nil
 	})
 
 	// Setup the networks.
@@ -1809,6 +2155,9 @@ func (d *Daemon) init() error {
 		return err
 	}
 
+	// Forward the network ACL log entries as events.
+	networkACLLogStartup(d)
+
 	// Setup tertiary listeners that may use managed network addresses and must be started after networks.
 	if bgpAddress != "" && bgpASN != 0 && bgpRouterID != "" {
 		if bgpASN > math.MaxUint32 {
@@ -1964,6 +2313,18 @@ func (d *Daemon) init() error {
 		// Prune expired custom volume snapshots and take snapshots of custom volumes (minutely check of configurable cron expression)
 		d.tasks.Add(pruneExpiredAndAutoCreateCustomVolumeSnapshotsTask(d.State))
 
+		// Start and stop instances on schedule (minutely check of configurable cron expression)
+		d.tasks.Add(instanceScheduledPowerTask(d.State))
+
+		// Suspend and resume virtual machines on host memory pressure (every 10 seconds)
+		d.tasks.Add(instanceMemoryPressureTask(d.State))
+
+		// Run instance health checks (every 5 seconds, configurable interval per instance)
+		d.tasks.Add(instanceHealthCheckTask(d.State))
+
+		// Remove expired session recordings (daily)
+		d.tasks.Add(pruneSessionRecordingsTask(d.State))
+
 		// Remove resolved warnings (daily)
 		d.tasks.Add(pruneResolvedWarningsTask(d.State))
 
@@ -1973,6 +2334,9 @@ func (d *Daemon) init() error {
 		// Remove expired tokens (hourly)
 		d.tasks.Add(autoRemoveExpiredTokensTask(d.State))
 
+		// Remove expired temporary group memberships (minutely)
+		d.tasks.Add(removeExpiredGroupMembershipsTask(d.State))
+
 		// Run scheduled replicators (minutely check of configurable cron expression)
 		d.tasks.Add(runScheduledReplicatorsTask(d.State))
 	}
@@ -1988,6 +2352,9 @@ func (d *Daemon) init() error {
 	// Restore instances
 	instancesStart(d.shutdownCtx, d.State(), instances)
 
+	// Resume pending automatic restarts
+	instancesAutoRestartResume(d.State(), instances)
+
 	// Re-balance in case things changed while LXD was down
 	deviceTaskBalance(d.State())
 
@@ -2060,6 +2427,38 @@ func (d *Daemon) requestorHook(ctx context.Context, authenticationMethod string,
 			}
 		}
 
+		if idType.Name() == api.IdentityTypeLDAPUser {
+			metadata, err := id.LDAPMetadata()
+			if err != nil {
+				return fmt.Errorf("Failed reading LDAP identity metadata: %w", err)
+			}
+
+			if len(metadata.IdentityProviderGroups) > 0 {
+				// If LDAP groups are set, map them to LXD auth groups.
+				res.IdentityProviderGroups = metadata.IdentityProviderGroups
+				res.EffectiveAuthGroups, err = dbCluster.GetDistinctAuthGroupNamesFromIDPGroupNames(ctx, tx.Tx(), metadata.IdentityProviderGroups)
+				if err != nil {
+					return fmt.Errorf("Failed mapping identity provider groups to authorization groups: %w", err)
+				}
+			}
+		}
+
+		if idType.Name() == api.IdentityTypeSSHUser {
+			metadata, err := id.SSHMetadata()
+			if err != nil {
+				return fmt.Errorf("Failed reading SSH identity metadata: %w", err)
+			}
+
+			if len(metadata.IdentityProviderGroups) > 0 {
+				// If certificate principals are set, map them to LXD auth groups.
+				res.IdentityProviderGroups = metadata.IdentityProviderGroups
+				res.EffectiveAuthGroups, err = dbCluster.GetDistinctAuthGroupNamesFromIDPGroupNames(ctx, tx.Tx(), metadata.IdentityProviderGroups)
+				if err != nil {
+					return fmt.Errorf("Failed mapping identity provider groups to authorization groups: %w", err)
+				}
+			}
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -2283,6 +2682,17 @@ func (d *Daemon) Stop(ctx context.Context, sig os.Signal) error {
 		trackError(d.endpoints.Down(), "Shutdown endpoints")
 	}
 
+	trackError(tracing.Shutdown(ctx), "Shutdown tracing")
+
+	if d.auditLog != nil {
+		trackError(d.auditLog.Close(), "Close audit log")
+	}
+
+	// Send any pending events to the configured exporters.
+	for _, name := range []string{"loki", "otlp", "syslog"} {
+		d.setLogExporter(name, nil)
+	}
+
 	if shouldUnmount {
 		logger.Info("Unmounting temporary filesystems")
 
@@ -2552,6 +2962,14 @@ func (d *Daemon) nodeRefreshTask(heartbeatData *cluster.APIHeartbeat, isLeader b
 		cluster.EventsUpdateListeners(d.endpoints, d.db.Cluster, d.serverCert, heartbeatData.Members, d.events.Inject)
 	})
 
+	// Refresh the WireGuard mesh peers on every heartbeat so that members starting their networks are picked up.
+	wg.Go(func() {
+		err := networkUpdateWireGuardMeshTask(s, heartbeatData)
+		if err != nil {
+			logger.Error("Error refreshing WireGuard mesh", logger.Ctx{"err": err, "local": localClusterAddress})
+		}
+	})
+
 	// Only update the node list if there are no state change task failures.
 	// If there are failures, then we leave the old state so that we can re-try the tasks again next heartbeat.
 	if !stateChangeTaskFailure {
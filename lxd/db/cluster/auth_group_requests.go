@@ -0,0 +1,79 @@
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/canonical/lxd/lxd/db/query"
+	"github.com/canonical/lxd/shared/api"
+)
+
+// AuthGroupRequestsRow represents a single row of the auth_group_requests table.
+// db:model auth_group_requests
+type AuthGroupRequestsRow struct {
+	ID           int64     `db:"id"`
+	UUID         string    `db:"uuid"`
+	IdentityID   int64     `db:"identity_id"`
+	AuthGroupID  int64     `db:"auth_group_id"`
+	Duration     string    `db:"duration"`
+	Reason       string    `db:"reason"`
+	CreationDate time.Time `db:"creation_date"`
+}
+
+// APIName implements [query.APINamer] for API friendly error messages.
+func (AuthGroupRequestsRow) APIName() string {
+	return "Group request"
+}
+
+// AuthGroupRequest contains [AuthGroupRequestsRow] with additional joins.
+// db:model auth_group_requests
+type AuthGroupRequest struct {
+	Row AuthGroupRequestsRow
+
+	// db:join JOIN identities ON auth_group_requests.identity_id = identities.id
+	IdentityAuthMethod AuthMethod `db:"identities.auth_method"`
+	IdentityIdentifier string     `db:"identities.identifier"`
+	IdentityName       string     `db:"identities.name"`
+
+	// db:join JOIN auth_groups ON auth_group_requests.auth_group_id = auth_groups.id
+	GroupName string `db:"auth_groups.name"`
+}
+
+// GetAuthGroupRequest gets an [AuthGroupRequest] by UUID.
+func GetAuthGroupRequest(ctx context.Context, tx *sql.Tx, uuid string) (*AuthGroupRequest, error) {
+	return query.SelectOne[AuthGroupRequest](ctx, tx, "WHERE auth_group_requests.uuid = ?", uuid)
+}
+
+// GetAuthGroupRequests gets all requests of temporary group membership, oldest first. The filter must return true to
+// include an entry, and false to reject an entry.
+func GetAuthGroupRequests(ctx context.Context, tx *sql.Tx, filter func(request AuthGroupRequest) bool) ([]AuthGroupRequest, error) {
+	var requests []AuthGroupRequest
+	err := query.SelectFunc[AuthGroupRequest](ctx, tx, "ORDER BY auth_group_requests.creation_date, auth_group_requests.id", func(request AuthGroupRequest) error {
+		if filter != nil && !filter(request) {
+			return nil
+		}
+
+		requests = append(requests, request)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+// ToAPI converts the [AuthGroupRequest] to an [api.AuthGroupRequest].
+func (r *AuthGroupRequest) ToAPI() *api.AuthGroupRequest {
+	return &api.AuthGroupRequest{
+		ID:                   r.Row.UUID,
+		AuthenticationMethod: string(r.IdentityAuthMethod),
+		Identifier:           r.IdentityIdentifier,
+		Name:                 r.IdentityName,
+		Group:                r.GroupName,
+		Duration:             r.Row.Duration,
+		Reason:               r.Row.Reason,
+		CreatedAt:            r.Row.CreationDate,
+	}
+}
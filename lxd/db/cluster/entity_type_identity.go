@@ -100,12 +100,12 @@ func authMethodCaseClause() string {
 }
 
 // onInsertTriggerSQL enforces that newly created identities have a unique name within the authentication method (where
-// method is not OIDC).
+// method is not OIDC or LDAP, as the names of these identities are set by the identity provider).
 func (e entityTypeIdentity) onInsertTriggerSQL() (name string, sql string) {
 	name = "on_identity_insert"
 	sql = `CREATE TRIGGER ` + name + `
 	BEFORE INSERT ON identities
-	WHEN NEW.auth_method != ` + strconv.FormatInt(authMethodOIDC, 10) + `
+	WHEN NEW.auth_method NOT IN (` + strconv.FormatInt(authMethodOIDC, 10) + `, ` + strconv.FormatInt(authMethodLDAP, 10) + `)
 		AND (SELECT COUNT(*) FROM identities WHERE name = NEW.name AND auth_method = NEW.auth_method) > 0
 	BEGIN
 		SELECT RAISE(ABORT, 'An identity with this name and authentication method already exists');
@@ -114,14 +114,14 @@ func (e entityTypeIdentity) onInsertTriggerSQL() (name string, sql string) {
 	return name, sql
 }
 
-// onUpdateTriggerSQL enforces that identities whose authentication method is not OIDC have a unique name (within
+// onUpdateTriggerSQL enforces that identities whose authentication method is not OIDC or LDAP have a unique name (within
 // identities using that authentication method). This trigger only runs if the name of the identity is being changed,
 // this allows pre-existing identities with duplicated names to continue to update normally.
 func (e entityTypeIdentity) onUpdateTriggerSQL() (name string, sql string) {
 	name = "on_identity_update"
 	sql = `CREATE TRIGGER ` + name + `
 	BEFORE UPDATE ON identities
-	WHEN OLD.name != NEW.name AND NEW.auth_method != ` + strconv.FormatInt(authMethodOIDC, 10) + `
+	WHEN OLD.name != NEW.name AND NEW.auth_method NOT IN (` + strconv.FormatInt(authMethodOIDC, 10) + `, ` + strconv.FormatInt(authMethodLDAP, 10) + `)
 		AND (SELECT COUNT(*) FROM identities WHERE name = NEW.name AND auth_method = NEW.auth_method) > 0
 	BEGIN
 		SELECT RAISE(ABORT, 'An identity with this name and authentication method already exists');
@@ -0,0 +1,37 @@
+package cluster
+
+import (
+	"fmt"
+)
+
+// entityTypeInstanceTemplate implements entityTypeDBInfo for an InstanceTemplate.
+type entityTypeInstanceTemplate struct {
+	entityTypeCommon
+}
+
+func (e entityTypeInstanceTemplate) code() int64 {
+	return entityTypeCodeInstanceTemplate
+}
+
+func (e entityTypeInstanceTemplate) allURLsQuery() string {
+	return fmt.Sprintf(`
+SELECT %d, instance_templates.id, projects.name, '', json_array(instance_templates.name)
+FROM instance_templates
+JOIN projects ON projects.id = instance_templates.project_id`, e.code())
+}
+
+func (e entityTypeInstanceTemplate) urlsByProjectQuery() string {
+	return e.allURLsQuery() + " WHERE projects.name = ?"
+}
+
+func (e entityTypeInstanceTemplate) urlByIDQuery() string {
+	return e.allURLsQuery() + " WHERE instance_templates.id = ?"
+}
+
+func (e entityTypeInstanceTemplate) idFromURLQuery() string {
+	return projectEntityIDFromURLQuery("instance_templates")
+}
+
+func (e entityTypeInstanceTemplate) onDeleteTriggerSQL() (name string, sql string) {
+	return standardOnDeleteTriggerSQL("on_instance_template_delete", "instance_templates", e.code())
+}
@@ -4,6 +4,106 @@ package cluster
 
 // Generated by dbgen - DO NOT EDIT
 
+// TableName returns the table name for [AuthGroupRequest] entities.
+func (a AuthGroupRequest) TableName() string {
+	return "auth_group_requests"
+}
+
+// APIName implements [query.APINamer] for API friendly error messages.
+func (a AuthGroupRequest) APIName() string {
+	return a.Row.APIName()
+}
+
+// SelectColumns returns a slice of column names for [AuthGroupRequest] entities.
+func (a AuthGroupRequest) SelectColumns() []string {
+	return []string{
+		"auth_group_requests.id",
+		"auth_group_requests.uuid",
+		"auth_group_requests.identity_id",
+		"auth_group_requests.auth_group_id",
+		"auth_group_requests.duration",
+		"auth_group_requests.reason",
+		"auth_group_requests.creation_date",
+		"identities.auth_method",
+		"identities.identifier",
+		"identities.name",
+		"auth_groups.name",
+	}
+}
+
+// Joins returns a slice of join expressions for [AuthGroupRequest].
+func (a AuthGroupRequest) Joins() []string {
+	return []string{
+		"JOIN identities ON auth_group_requests.identity_id = identities.id",
+		"JOIN auth_groups ON auth_group_requests.auth_group_id = auth_groups.id",
+	}
+}
+
+// ScanArgs implements [query.ScanArger] for [AuthGroupRequest].
+// This returns references to struct fields in definition order.
+func (a *AuthGroupRequest) ScanArgs() []any {
+	return []any{&a.Row.ID, &a.Row.UUID, &a.Row.IdentityID, &a.Row.AuthGroupID, &a.Row.Duration, &a.Row.Reason, &a.Row.CreationDate, &a.IdentityAuthMethod, &a.IdentityIdentifier, &a.IdentityName, &a.GroupName}
+}
+
+// TableName returns the table name for [AuthGroupRequestsRow] entities.
+func (a AuthGroupRequestsRow) TableName() string {
+	return "auth_group_requests"
+}
+
+// SelectColumns returns a slice of column names for [AuthGroupRequestsRow] entities.
+func (a AuthGroupRequestsRow) SelectColumns() []string {
+	return []string{
+		"auth_group_requests.id",
+		"auth_group_requests.uuid",
+		"auth_group_requests.identity_id",
+		"auth_group_requests.auth_group_id",
+		"auth_group_requests.duration",
+		"auth_group_requests.reason",
+		"auth_group_requests.creation_date",
+	}
+}
+
+// Joins returns a slice of join expressions for [AuthGroupRequestsRow].
+func (a AuthGroupRequestsRow) Joins() []string {
+	return []string{}
+}
+
+// ScanArgs implements [query.ScanArger] for [AuthGroupRequestsRow].
+// This returns references to struct fields in definition order.
+func (a *AuthGroupRequestsRow) ScanArgs() []any {
+	return []any{&a.ID, &a.UUID, &a.IdentityID, &a.AuthGroupID, &a.Duration, &a.Reason, &a.CreationDate}
+}
+
+// CreateValues returns a list of values from [AuthGroupRequestsRow] entities matching the bind arguments in [CreateStmt].
+func (a AuthGroupRequestsRow) CreateValues() []any {
+	return []any{a.UUID, a.IdentityID, a.AuthGroupID, a.Duration, a.Reason, a.CreationDate}
+}
+
+// UpdateValues returns a list of values from [AuthGroupRequestsRow] entities matching the columns in [UpdateStmt].
+func (a AuthGroupRequestsRow) UpdateValues() []any {
+	return []any{a.UUID, a.IdentityID, a.AuthGroupID, a.Duration, a.Reason, a.CreationDate}
+}
+
+// PKColumn returns the column name for the primary key of a [AuthGroupRequestsRow] entity used during an update.
+func (a AuthGroupRequestsRow) PKColumn() string {
+	return "id"
+}
+
+// PKValue returns the value for the primary key of a [AuthGroupRequestsRow] entity used during an update.
+func (a AuthGroupRequestsRow) PKValue() any {
+	return a.ID
+}
+
+// CreateStmt returns a query that creates a [AuthGroupRequestsRow] entity.
+func (a AuthGroupRequestsRow) CreateStmt() string {
+	return "INSERT INTO auth_group_requests (uuid, identity_id, auth_group_id, duration, reason, creation_date) VALUES (?, ?, ?, ?, ?, ?)"
+}
+
+// UpdateStmt returns a query that updates a [AuthGroupRequestsRow] by primary key.
+func (a AuthGroupRequestsRow) UpdateStmt() string {
+	return "UPDATE auth_group_requests SET uuid = ?, identity_id = ?, auth_group_id = ?, duration = ?, reason = ?, creation_date = ? "
+}
+
 // TableName returns the table name for [AuthGroupsRow] entities.
 func (a AuthGroupsRow) TableName() string {
 	return "auth_groups"
@@ -264,6 +364,98 @@ func (i *IdentityCertificate) ScanArgs() []any {
 	return []any{&i.Row.ID, &i.Row.Fingerprint, &i.Row.Certificate, &i.Row.CreationDate, &i.IdentityID}
 }
 
+// TableName returns the table name for [InstanceTemplate] entities.
+func (i InstanceTemplate) TableName() string {
+	return "instance_templates"
+}
+
+// APIName implements [query.APINamer] for API friendly error messages.
+func (i InstanceTemplate) APIName() string {
+	return i.Row.APIName()
+}
+
+// SelectColumns returns a slice of column names for [InstanceTemplate] entities.
+func (i InstanceTemplate) SelectColumns() []string {
+	return []string{
+		"instance_templates.id",
+		"instance_templates.name",
+		"instance_templates.description",
+		"instance_templates.project_id",
+		"instance_templates.definition",
+		"projects.name",
+	}
+}
+
+// Joins returns a slice of join expressions for [InstanceTemplate].
+func (i InstanceTemplate) Joins() []string {
+	return []string{
+		"JOIN projects ON instance_templates.project_id = projects.id",
+	}
+}
+
+// ScanArgs implements [query.ScanArger] for [InstanceTemplate].
+// This returns references to struct fields in definition order.
+func (i *InstanceTemplate) ScanArgs() []any {
+	return []any{&i.Row.ID, &i.Row.Name, &i.Row.Description, &i.Row.ProjectID, &i.Row.Definition, &i.ProjectName}
+}
+
+// TableName returns the table name for [InstanceTemplatesRow] entities.
+func (i InstanceTemplatesRow) TableName() string {
+	return "instance_templates"
+}
+
+// SelectColumns returns a slice of column names for [InstanceTemplatesRow] entities.
+func (i InstanceTemplatesRow) SelectColumns() []string {
+	return []string{
+		"instance_templates.id",
+		"instance_templates.name",
+		"instance_templates.description",
+		"instance_templates.project_id",
+		"instance_templates.definition",
+	}
+}
+
+// Joins returns a slice of join expressions for [InstanceTemplatesRow].
+func (i InstanceTemplatesRow) Joins() []string {
+	return []string{}
+}
+
+// ScanArgs implements [query.ScanArger] for [InstanceTemplatesRow].
+// This returns references to struct fields in definition order.
+func (i *InstanceTemplatesRow) ScanArgs() []any {
+	return []any{&i.ID, &i.Name, &i.Description, &i.ProjectID, &i.Definition}
+}
+
+// CreateValues returns a list of values from [InstanceTemplatesRow] entities matching the bind arguments in [CreateStmt].
+func (i InstanceTemplatesRow) CreateValues() []any {
+	return []any{i.Name, i.Description, i.ProjectID, i.Definition}
+}
+
+// UpdateValues returns a list of values from [InstanceTemplatesRow] entities matching the columns in [UpdateStmt].
+func (i InstanceTemplatesRow) UpdateValues() []any {
+	return []any{i.Name, i.Description, i.ProjectID, i.Definition}
+}
+
+// PKColumn returns the column name for the primary key of a [InstanceTemplatesRow] entity used during an update.
+func (i InstanceTemplatesRow) PKColumn() string {
+	return "id"
+}
+
+// PKValue returns the value for the primary key of a [InstanceTemplatesRow] entity used during an update.
+func (i InstanceTemplatesRow) PKValue() any {
+	return i.ID
+}
+
+// CreateStmt returns a query that creates a [InstanceTemplatesRow] entity.
+func (i InstanceTemplatesRow) CreateStmt() string {
+	return "INSERT INTO instance_templates (name, description, project_id, definition) VALUES (?, ?, ?, ?)"
+}
+
+// UpdateStmt returns a query that updates a [InstanceTemplatesRow] by primary key.
+func (i InstanceTemplatesRow) UpdateStmt() string {
+	return "UPDATE instance_templates SET name = ?, description = ?, project_id = ?, definition = ? "
+}
+
 // TableName returns the table name for [PlacementGroup] entities.
 func (p PlacementGroup) TableName() string {
 	return "placement_groups"
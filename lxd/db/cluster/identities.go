@@ -38,6 +38,8 @@ const (
 	authMethodTLS    int64 = 1
 	authMethodOIDC   int64 = 2
 	authMethodBearer int64 = 3
+	authMethodLDAP   int64 = 4
+	authMethodSSH    int64 = 5
 )
 
 // authMethodCodeToText maps the database code for an authentication method to it's string representation.
@@ -45,6 +47,8 @@ var authMethodCodeToText = map[int64]string{
 	authMethodTLS:    api.AuthenticationMethodTLS,
 	authMethodOIDC:   api.AuthenticationMethodOIDC,
 	authMethodBearer: api.AuthenticationMethodBearer,
+	authMethodLDAP:   api.AuthenticationMethodLDAP,
+	authMethodSSH:    api.AuthenticationMethodSSH,
 }
 
 // ScanInteger implements [query.IntegerScanner] for [AuthMethod]. This simplifies the Scan implementation.
@@ -73,6 +77,10 @@ func (a AuthMethod) Value() (driver.Value, error) {
 		return authMethodOIDC, nil
 	case api.AuthenticationMethodBearer:
 		return authMethodBearer, nil
+	case api.AuthenticationMethodLDAP:
+		return authMethodLDAP, nil
+	case api.AuthenticationMethodSSH:
+		return authMethodSSH, nil
 	}
 
 	return nil, fmt.Errorf("Invalid authentication method %q", a)
@@ -238,6 +246,66 @@ func (i IdentitiesRow) OIDCMetadata() (*OIDCMetadata, error) {
 	return &metadata, nil
 }
 
+// LDAPMetadata contains metadata for LDAP identities.
+type LDAPMetadata struct {
+	DN                     string   `json:"dn"`
+	IdentityProviderGroups []string `json:"identity_provider_groups"`
+}
+
+// Equals returns true if the given [LDAPMetadata] is equal to the receiver.
+func (l LDAPMetadata) Equals(m LDAPMetadata) bool {
+	if l.DN != m.DN {
+		return false
+	}
+
+	slices.Sort(l.IdentityProviderGroups)
+	slices.Sort(m.IdentityProviderGroups)
+	return slices.Equal(l.IdentityProviderGroups, m.IdentityProviderGroups)
+}
+
+// LDAPMetadata returns the identity metadata as [LDAPMetadata]. The [AuthMethod] of the [IdentitiesRow] must be [api.AuthenticationMethodLDAP].
+func (i IdentitiesRow) LDAPMetadata() (*LDAPMetadata, error) {
+	if i.AuthMethod != api.AuthenticationMethodLDAP {
+		return nil, fmt.Errorf("Cannot extract LDAP metadata from identity: Identity has authentication method %q (%q required)", i.AuthMethod, api.AuthenticationMethodLDAP)
+	}
+
+	var metadata LDAPMetadata
+	err := json.Unmarshal([]byte(i.Metadata), &metadata)
+	if err != nil {
+		return nil, fmt.Errorf("Failed unmarshaling LDAP metadata: %w", err)
+	}
+
+	return &metadata, nil
+}
+
+// SSHMetadata contains metadata for SSH identities.
+type SSHMetadata struct {
+	AuthorizedKeys         []string `json:"authorized_keys"`
+	IdentityProviderGroups []string `json:"identity_provider_groups"`
+
+	// SessionsRevokedAt is when an authorized key was last removed. Sessions issued until then are no longer valid.
+	SessionsRevokedAt time.Time `json:"sessions_revoked_at,omitzero"`
+}
+
+// SSHMetadata returns the identity metadata as [SSHMetadata]. The [AuthMethod] of the [IdentitiesRow] must be [api.AuthenticationMethodSSH].
+func (i IdentitiesRow) SSHMetadata() (*SSHMetadata, error) {
+	if i.AuthMethod != api.AuthenticationMethodSSH {
+		return nil, fmt.Errorf("Cannot extract SSH metadata from identity: Identity has authentication method %q (%q required)", i.AuthMethod, api.AuthenticationMethodSSH)
+	}
+
+	var metadata SSHMetadata
+	if i.Metadata == "" {
+		return &metadata, nil
+	}
+
+	err := json.Unmarshal([]byte(i.Metadata), &metadata)
+	if err != nil {
+		return nil, fmt.Errorf("Failed unmarshaling SSH metadata: %w", err)
+	}
+
+	return &metadata, nil
+}
+
 // PendingTLSMetadata contains metadata for the pending TLS certificate identity type.
 type PendingTLSMetadata struct {
 	Secret string    `json:"secret"`
@@ -265,7 +333,8 @@ func (i IdentitiesRow) PendingTLSMetadata() (*PendingTLSMetadata, error) {
 }
 
 // ToAPI converts an [IdentitiesRow] to an [api.Identity], executing database queries as necessary.
-func (i *IdentitiesRow) ToAPI(idToGroups map[int64][]string, idToCertificates map[int64][]string) (*api.Identity, error) {
+// The expiry dates of temporary group memberships are optional, and are only set for the given groups.
+func (i *IdentitiesRow) ToAPI(idToGroups map[int64][]string, idToGroupExpiries map[int64]map[string]time.Time, idToCertificates map[int64][]string) (*api.Identity, error) {
 	if idToGroups == nil {
 		return nil, errors.New("Missing required authorization group data")
 	}
@@ -290,18 +359,43 @@ func (i *IdentitiesRow) ToAPI(idToGroups map[int64][]string, idToCertificates ma
 		tlsCertificate = certs[0]
 	}
 
+	var sshAuthorizedKeys []string
+	if i.AuthMethod == api.AuthenticationMethodSSH {
+		metadata, err := i.SSHMetadata()
+		if err != nil {
+			return nil, err
+		}
+
+		sshAuthorizedKeys = metadata.AuthorizedKeys
+	}
+
 	groups, ok := idToGroups[i.ID]
 	if !ok || groups == nil {
 		groups = []string{}
 	}
 
+	var groupExpiry map[string]time.Time
+	for groupName, expiry := range idToGroupExpiries[i.ID] {
+		if !slices.Contains(groups, groupName) {
+			continue
+		}
+
+		if groupExpiry == nil {
+			groupExpiry = make(map[string]time.Time)
+		}
+
+		groupExpiry[groupName] = expiry
+	}
+
 	return &api.Identity{
 		AuthenticationMethod: string(i.AuthMethod),
 		Type:                 string(i.Type),
 		Identifier:           i.Identifier,
 		Name:                 i.Name,
 		Groups:               groups,
+		GroupExpiry:          groupExpiry,
 		TLSCertificate:       tlsCertificate,
+		SSHAuthorizedKeys:    sshAuthorizedKeys,
 	}, nil
 }
 
@@ -411,18 +505,49 @@ func GetPendingTLSIdentityByTokenSecret(ctx context.Context, tx *sql.Tx, secret
 	return ident, nil
 }
 
+// authGroupMembershipExpired returns true if the given expiry date of a group membership is set and is not after the
+// given time. Memberships without an expiry date are permanent.
+func authGroupMembershipExpired(expiryDate sql.NullTime, now time.Time) bool {
+	return expiryDate.Valid && !expiryDate.Time.After(now)
+}
+
 // GetAuthGroupsByIdentityID returns a slice of groups that the identity with the given ID is a member of.
+// Temporary memberships that have expired are omitted.
 func GetAuthGroupsByIdentityID(ctx context.Context, tx *sql.Tx, identityID int64) ([]AuthGroupsRow, error) {
-	clause := `
+	q := `SELECT ` + strings.Join(AuthGroupsRow{}.SelectColumns(), ", ") + `, identities_auth_groups.expiry_date
+FROM auth_groups
 JOIN identities_auth_groups ON auth_groups.id = identities_auth_groups.auth_group_id
 WHERE identities_auth_groups.identity_id = ?
 `
-	return query.Select[AuthGroupsRow](ctx, tx, clause, identityID)
+
+	now := time.Now()
+	var groups []AuthGroupsRow
+	dest := func(scan func(dest ...any) error) error {
+		g := AuthGroupsRow{}
+		var expiryDate sql.NullTime
+		err := scan(append(g.ScanArgs(), &expiryDate)...)
+		if err != nil {
+			return err
+		}
+
+		if !authGroupMembershipExpired(expiryDate, now) {
+			groups = append(groups, g)
+		}
+
+		return nil
+	}
+
+	err := query.Scan(ctx, tx, q, dest, identityID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting identity group membership: %w", err)
+	}
+
+	return groups, nil
 }
 
 // GetIdentityAuthGroupNames returns a map of identity ID to slice of (alphabetically sorted) group names that the
-// identity with that ID is a member of. A filter can be passed in, which should return false to omit entries and true
-// to include them. This is useful for filtering out groups that the caller cannot view. If the filter function returns
+// identity with that ID is a member of. Temporary memberships that have expired are omitted. A filter can be passed in,
+// which should return false to omit entries and true to include them. This is useful for filtering out groups that the caller cannot view. If the filter function returns
 // a non-nil error, this function will abort scanning of rows and return the error unmodified.
 // The optional identity ID field can be used to get authorization group names for only one identity, in which case the
 // output map contains only one key.
@@ -437,7 +562,7 @@ func GetIdentityAuthGroupNames(ctx context.Context, tx *sql.Tx, identityID *int6
 		b.WriteString(col)
 	}
 
-	b.WriteString(` FROM auth_groups
+	b.WriteString(`, identities_auth_groups.expiry_date FROM auth_groups
 JOIN identities_auth_groups ON auth_groups.id = identities_auth_groups.auth_group_id
 `)
 	var args []any
@@ -448,15 +573,21 @@ JOIN identities_auth_groups ON auth_groups.id = identities_auth_groups.auth_grou
 
 	b.WriteString(`ORDER BY identities_auth_groups.identity_id, auth_groups.name`)
 
+	now := time.Now()
 	result := make(map[int64][]string)
 	dest := func(scan func(dest ...any) error) error {
 		var identityID int64
+		var expiryDate sql.NullTime
 		g := AuthGroupsRow{}
-		err := scan(append([]any{&identityID}, g.ScanArgs()...)...)
+		err := scan(append(append([]any{&identityID}, g.ScanArgs()...), &expiryDate)...)
 		if err != nil {
 			return err
 		}
 
+		if authGroupMembershipExpired(expiryDate, now) {
+			return nil
+		}
+
 		if filter != nil {
 			keep, err := filter(g)
 			if err != nil {
@@ -478,6 +609,53 @@ JOIN identities_auth_groups ON auth_groups.id = identities_auth_groups.auth_grou
 	return result, nil
 }
 
+// GetIdentityAuthGroupExpiries returns a map of identity ID to a map of group name to expiry date, for the temporary
+// group memberships of the identity with that ID. Temporary memberships that have expired are omitted.
+// The optional identity ID field can be used to get the expiry dates for only one identity.
+func GetIdentityAuthGroupExpiries(ctx context.Context, tx *sql.Tx, identityID *int64) (map[int64]map[string]time.Time, error) {
+	q := `
+SELECT identities_auth_groups.identity_id, auth_groups.name, identities_auth_groups.expiry_date
+FROM identities_auth_groups
+JOIN auth_groups ON auth_groups.id = identities_auth_groups.auth_group_id
+WHERE identities_auth_groups.expiry_date IS NOT NULL
+`
+	var args []any
+	if identityID != nil {
+		args = []any{*identityID}
+		q += `AND identities_auth_groups.identity_id = ?`
+	}
+
+	now := time.Now()
+	result := make(map[int64]map[string]time.Time)
+	dest := func(scan func(dest ...any) error) error {
+		var identityID int64
+		var groupName string
+		var expiryDate sql.NullTime
+		err := scan(&identityID, &groupName, &expiryDate)
+		if err != nil {
+			return err
+		}
+
+		if authGroupMembershipExpired(expiryDate, now) {
+			return nil
+		}
+
+		if result[identityID] == nil {
+			result[identityID] = make(map[string]time.Time)
+		}
+
+		result[identityID][groupName] = expiryDate.Time
+		return nil
+	}
+
+	err := query.Scan(ctx, tx, q, dest, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting identities group membership expiry: %w", err)
+	}
+
+	return result, nil
+}
+
 // GetIdentityByNameOrIdentifier attempts to get an identity by the authentication method and identifier. If that fails
 // it will try to use the nameOrID argument as a name and will return the result only if the query matches a single [IdentitiesRow].
 // It will return an [api.StatusError] with [http.StatusNotFound] if none are found or [http.StatusBadRequest] if multiple are found.
@@ -552,6 +730,125 @@ WHERE auth_groups.name IN %s
 	return nil
 }
 
+// SetIdentityAuthGroupExpiries sets the expiry dates of the memberships of the identity with the given ID in the
+// groups with the given names. The identity must already be a member of the groups. Memberships of other groups are
+// made permanent.
+func SetIdentityAuthGroupExpiries(ctx context.Context, tx *sql.Tx, identityID int64, expiries map[string]time.Time) error {
+	_, err := tx.ExecContext(ctx, `UPDATE identities_auth_groups SET expiry_date = NULL WHERE identity_id = ?`, identityID)
+	if err != nil {
+		return fmt.Errorf("Failed clearing existing group membership expiry for identity with ID %d: %w", identityID, err)
+	}
+
+	for groupName, expiry := range expiries {
+		res, err := tx.ExecContext(ctx, `
+UPDATE identities_auth_groups SET expiry_date = ?
+WHERE identity_id = ? AND auth_group_id = (SELECT id FROM auth_groups WHERE name = ?)
+`, expiry.UTC(), identityID, groupName)
+		if err != nil {
+			return fmt.Errorf("Failed setting group membership expiry for identity with ID %d: %w", identityID, err)
+		}
+
+		rowsAffected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("Failed checking group membership expiry for identity with ID %d: %w", identityID, err)
+		}
+
+		if rowsAffected != 1 {
+			return api.StatusErrorf(http.StatusBadRequest, "Cannot set an expiry date for group %q: Identity is not a member of the group", groupName)
+		}
+	}
+
+	return nil
+}
+
+// AddIdentityAuthGroupUntil adds the identity with the given ID to the group with the given ID until the given expiry
+// date. If the identity is already a temporary member of the group, the membership is extended if it would expire
+// earlier. Permanent memberships are left unchanged.
+func AddIdentityAuthGroupUntil(ctx context.Context, tx *sql.Tx, identityID int64, groupID int64, expiry time.Time) error {
+	var expiryDate sql.NullTime
+	row := tx.QueryRowContext(ctx, `SELECT expiry_date FROM identities_auth_groups WHERE identity_id = ? AND auth_group_id = ?`, identityID, groupID)
+	err := row.Scan(&expiryDate)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("Failed getting existing group membership for identity with ID %d: %w", identityID, err)
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		_, err = tx.ExecContext(ctx, `INSERT INTO identities_auth_groups (identity_id, auth_group_id, expiry_date) VALUES (?, ?, ?)`, identityID, groupID, expiry.UTC())
+		if err != nil {
+			return fmt.Errorf("Failed adding temporary group membership for identity with ID %d: %w", identityID, err)
+		}
+
+		return nil
+	}
+
+	if !expiryDate.Valid || !expiry.After(expiryDate.Time) {
+		return nil
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE identities_auth_groups SET expiry_date = ? WHERE identity_id = ? AND auth_group_id = ?`, expiry.UTC(), identityID, groupID)
+	if err != nil {
+		return fmt.Errorf("Failed extending temporary group membership for identity with ID %d: %w", identityID, err)
+	}
+
+	return nil
+}
+
+// ExpiredAuthGroupMembership is a temporary membership of an identity in an authorization group that has expired.
+type ExpiredAuthGroupMembership struct {
+	AuthMethod AuthMethod
+	Identifier string
+	GroupName  string
+	ExpiryDate time.Time
+}
+
+// DeleteExpiredIdentityAuthGroups removes the temporary group memberships that expired before the given time, and
+// returns them.
+func DeleteExpiredIdentityAuthGroups(ctx context.Context, tx *sql.Tx, now time.Time) ([]ExpiredAuthGroupMembership, error) {
+	q := `
+SELECT identities_auth_groups.id, identities.auth_method, identities.identifier, auth_groups.name, identities_auth_groups.expiry_date
+FROM identities_auth_groups
+JOIN identities ON identities.id = identities_auth_groups.identity_id
+JOIN auth_groups ON auth_groups.id = identities_auth_groups.auth_group_id
+WHERE identities_auth_groups.expiry_date IS NOT NULL
+`
+	var ids []any
+	var expired []ExpiredAuthGroupMembership
+	dest := func(scan func(dest ...any) error) error {
+		var id int64
+		var membership ExpiredAuthGroupMembership
+		var expiryDate sql.NullTime
+		err := scan(&id, &membership.AuthMethod, &membership.Identifier, &membership.GroupName, &expiryDate)
+		if err != nil {
+			return err
+		}
+
+		if !authGroupMembershipExpired(expiryDate, now) {
+			return nil
+		}
+
+		membership.ExpiryDate = expiryDate.Time
+		ids = append(ids, id)
+		expired = append(expired, membership)
+		return nil
+	}
+
+	err := query.Scan(ctx, tx, q, dest)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting temporary group memberships: %w", err)
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM identities_auth_groups WHERE id IN `+query.Params(len(ids)), ids...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed deleting expired group memberships: %w", err)
+	}
+
+	return expired, nil
+}
+
 // GetIdentityByID gets a single identity with the given ID.
 func GetIdentityByID(ctx context.Context, tx *sql.Tx, id int64) (*IdentitiesRow, error) {
 	return query.SelectOne[IdentitiesRow](ctx, tx, "WHERE id = ?", id)
@@ -600,6 +897,61 @@ func UpdateTLSIdentity(ctx context.Context, tx *sql.Tx, identity IdentitiesRow,
 	return query.UpdateByPrimaryKey(ctx, tx, identity)
 }
 
+// UpdateSSHIdentityAuthorizedKeys replaces the authorized keys of the given SSH identity.
+func UpdateSSHIdentityAuthorizedKeys(ctx context.Context, tx *sql.Tx, identity IdentitiesRow, authorizedKeys []string) error {
+	metadata, err := identity.SSHMetadata()
+	if err != nil {
+		return err
+	}
+
+	// Revoke the sessions that may have been started with a removed key. Session tokens have a precision of one
+	// second, so this includes the sessions issued during the current second.
+	for _, authorizedKey := range metadata.AuthorizedKeys {
+		if !slices.ContainsFunc(authorizedKeys, func(key string) bool { return sameAuthorizedKey(key, authorizedKey) }) {
+			metadata.SessionsRevokedAt = time.Now().UTC().Truncate(time.Second)
+			break
+		}
+	}
+
+	metadata.AuthorizedKeys = authorizedKeys
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("Failed encoding SSH metadata: %w", err)
+	}
+
+	identity.Metadata = string(metadataJSON)
+	return query.UpdateByPrimaryKey(ctx, tx, identity)
+}
+
+// HasSSHAuthorizedKeys returns true if any SSH identity has authorized keys.
+func HasSSHAuthorizedKeys(ctx context.Context, tx *sql.Tx) (bool, error) {
+	identities, err := query.Select[IdentitiesRow](ctx, tx, "WHERE auth_method = ?", AuthMethod(api.AuthenticationMethodSSH))
+	if err != nil {
+		return false, err
+	}
+
+	for _, identity := range identities {
+		metadata, err := identity.SSHMetadata()
+		if err != nil {
+			return false, err
+		}
+
+		if len(metadata.AuthorizedKeys) > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// sameAuthorizedKey returns true if both keys in the authorized_keys format have the same type and key, regardless
+// of their comment.
+func sameAuthorizedKey(a string, b string) bool {
+	aFields := strings.Fields(a)
+	bFields := strings.Fields(b)
+	return len(aFields) >= 2 && len(bFields) >= 2 && slices.Equal(aFields[:2], bFields[:2])
+}
+
 // setIdentityCertificate replaces an identities certificate with the given one.
 func setIdentityCertificate(ctx context.Context, tx *sql.Tx, create bool, identityID int64, fingerprint string, pemCert string) error {
 	// For now, delete all existing certificates for the identity so that we have at most one.
@@ -0,0 +1,141 @@
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/canonical/lxd/lxd/db/query"
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/entity"
+)
+
+// InstanceTemplatesRow represents a single row of the instance_templates table.
+// db:model instance_templates
+type InstanceTemplatesRow struct {
+	ID          int64  `db:"id"`
+	Name        string `db:"name"`
+	Description string `db:"description"`
+	ProjectID   int64  `db:"project_id"`
+	Definition  string `db:"definition"`
+}
+
+// APIName implements [query.APINamer] for API friendly error messages.
+func (InstanceTemplatesRow) APIName() string {
+	return "Instance template"
+}
+
+// InstanceTemplate contains [InstanceTemplatesRow] with additional joins.
+// db:model instance_templates
+type InstanceTemplate struct {
+	Row InstanceTemplatesRow
+
+	// db:join JOIN projects ON instance_templates.project_id = projects.id
+	ProjectName string `db:"projects.name"`
+}
+
+// InstanceTemplateDefinition contains the instance creation arguments of an instance template.
+// It is stored as JSON in the definition column of the instance_templates table.
+type InstanceTemplateDefinition struct {
+	Type         api.InstanceType             `json:"type,omitempty"`
+	InstanceType string                       `json:"instance_type,omitempty"`
+	Source       api.InstanceSource           `json:"source"`
+	Profiles     []string                     `json:"profiles,omitempty"`
+	Config       map[string]string            `json:"config,omitempty"`
+	Devices      map[string]map[string]string `json:"devices,omitempty"`
+}
+
+// SetDefinition sets the definition column of the [InstanceTemplatesRow] from the given [api.InstanceTemplatePut].
+func (t *InstanceTemplatesRow) SetDefinition(put api.InstanceTemplatePut) error {
+	definition, err := json.Marshal(InstanceTemplateDefinition{
+		Type:         put.Type,
+		InstanceType: put.InstanceType,
+		Source:       put.Source,
+		Profiles:     put.Profiles,
+		Config:       put.Config,
+		Devices:      put.Devices,
+	})
+	if err != nil {
+		return fmt.Errorf("Failed marshaling instance template definition: %w", err)
+	}
+
+	t.Description = put.Description
+	t.Definition = string(definition)
+
+	return nil
+}
+
+// GetInstanceTemplate gets an [InstanceTemplate] by name and project.
+func GetInstanceTemplate(ctx context.Context, tx *sql.Tx, name string, projectName string) (*InstanceTemplate, error) {
+	return query.SelectOne[InstanceTemplate](ctx, tx, "WHERE instance_templates.name = ? AND projects.name = ?", name, projectName)
+}
+
+// GetInstanceTemplatesAndURLs queries for all instance templates and then applies the given filter to the result.
+// The filter must return true to include an entry, and false to reject an entry.
+// A slice of (filtered) instance template URLs is also returned for convenience.
+// If the project name argument is non-nil, only instance templates in that project are returned.
+// If the project name is nil, instance templates from all projects are returned.
+func GetInstanceTemplatesAndURLs(ctx context.Context, tx *sql.Tx, projectName *string, filter func(template InstanceTemplate) bool) ([]InstanceTemplate, []string, error) {
+	var args []any
+	var b strings.Builder
+	if projectName == nil {
+		b.WriteString("ORDER BY projects.name, ")
+	} else {
+		b.WriteString("WHERE projects.name = ? ORDER BY ")
+		args = append(args, *projectName)
+	}
+
+	b.WriteString("instance_templates.name")
+
+	var templates []InstanceTemplate
+	var templateURLs []string
+	err := query.SelectFunc[InstanceTemplate](ctx, tx, b.String(), func(template InstanceTemplate) error {
+		if filter != nil && !filter(template) {
+			return nil
+		}
+
+		templates = append(templates, template)
+		templateURLs = append(templateURLs, entity.InstanceTemplateURL(template.ProjectName, template.Row.Name).String())
+		return nil
+	}, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return templates, templateURLs, nil
+}
+
+// ToAPI converts the [InstanceTemplate] to an [api.InstanceTemplate].
+func (t *InstanceTemplate) ToAPI() (*api.InstanceTemplate, error) {
+	definition := InstanceTemplateDefinition{}
+	err := json.Unmarshal([]byte(t.Row.Definition), &definition)
+	if err != nil {
+		return nil, fmt.Errorf("Failed unmarshaling instance template definition: %w", err)
+	}
+
+	if definition.Profiles == nil {
+		definition.Profiles = []string{}
+	}
+
+	if definition.Config == nil {
+		definition.Config = map[string]string{}
+	}
+
+	if definition.Devices == nil {
+		definition.Devices = map[string]map[string]string{}
+	}
+
+	return &api.InstanceTemplate{
+		Name:         t.Row.Name,
+		Description:  t.Row.Description,
+		Project:      t.ProjectName,
+		Type:         definition.Type,
+		InstanceType: definition.InstanceType,
+		Source:       definition.Source,
+		Profiles:     definition.Profiles,
+		Config:       definition.Config,
+		Devices:      definition.Devices,
+	}, nil
+}
@@ -93,6 +93,8 @@ const (
 	requestorProtocolTLS
 	requestorProtocolOIDC
 	requestorProtocolBearer
+	requestorProtocolLDAP
+	requestorProtocolSSH
 )
 
 // requestorProtocolCodeToText maps RequestorProtocol int64 database representation to its string constant.
@@ -105,6 +107,8 @@ var requestorProtocolCodeToText = map[int64]string{
 	requestorProtocolTLS:     api.AuthenticationMethodTLS,
 	requestorProtocolOIDC:    api.AuthenticationMethodOIDC,
 	requestorProtocolBearer:  api.AuthenticationMethodBearer,
+	requestorProtocolLDAP:    api.AuthenticationMethodLDAP,
+	requestorProtocolSSH:     api.AuthenticationMethodSSH,
 }
 
 // ScanInteger implements [query.IntegerScanner] for [RequestorProtocol]. This simplifies the Scan implementation.
@@ -151,6 +155,10 @@ func (r *RequestorProtocol) Value() (driver.Value, error) {
 		return requestorProtocolOIDC, nil
 	case RequestorProtocol(api.AuthenticationMethodBearer):
 		return requestorProtocolBearer, nil
+	case RequestorProtocol(api.AuthenticationMethodLDAP):
+		return requestorProtocolLDAP, nil
+	case RequestorProtocol(api.AuthenticationMethodSSH):
+		return requestorProtocolSSH, nil
 	}
 
 	return nil, fmt.Errorf("Invalid requestor protocol %q", *r)
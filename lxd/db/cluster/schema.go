@@ -6,6 +6,19 @@ package cluster
 // modify the database schema, please add a new schema update to update.go
 // and the run 'make update-schema'.
 const freshSchema = `
+CREATE TABLE auth_group_requests (
+	id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+	uuid TEXT NOT NULL,
+	identity_id INTEGER NOT NULL,
+	auth_group_id INTEGER NOT NULL,
+	duration TEXT NOT NULL,
+	reason TEXT NOT NULL,
+	creation_date DATETIME NOT NULL,
+	UNIQUE (uuid),
+	UNIQUE (identity_id, auth_group_id),
+	FOREIGN KEY (identity_id) REFERENCES identities (id) ON DELETE CASCADE,
+	FOREIGN KEY (auth_group_id) REFERENCES auth_groups (id) ON DELETE CASCADE
+);
 CREATE TABLE auth_groups (
     id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
     name TEXT NOT NULL,
@@ -83,6 +96,7 @@ CREATE TABLE identities_auth_groups (
     id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
     identity_id INTEGER NOT NULL,
     auth_group_id INTEGER NOT NULL,
+    expiry_date DATETIME,
     FOREIGN KEY (identity_id) REFERENCES identities (id) ON DELETE CASCADE,
     FOREIGN KEY (auth_group_id) REFERENCES auth_groups (id) ON DELETE CASCADE,
     UNIQUE (identity_id, auth_group_id)
@@ -180,6 +194,15 @@ CREATE TABLE "images_source" (
     alias TEXT NOT NULL,
     FOREIGN KEY (image_id) REFERENCES "images" (id) ON DELETE CASCADE
 );
+CREATE TABLE instance_templates (
+	id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+	name TEXT NOT NULL,
+	description TEXT NOT NULL,
+	project_id INTEGER NOT NULL,
+	definition TEXT NOT NULL,
+	UNIQUE (project_id, name),
+	FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE
+);
 CREATE TABLE "instances" (
     id INTEGER primary key AUTOINCREMENT NOT NULL,
     node_id INTEGER NOT NULL,
@@ -795,5 +818,5 @@ CREATE TABLE "warnings" (
 );
 CREATE UNIQUE INDEX warnings_unique_node_id_project_id_entity_type_code_entity_id_type_code ON warnings(IFNULL(node_id, -1), IFNULL(project_id, -1), entity_type_code, entity_id, type_code);
 
-INSERT INTO schema (version, updated_at) VALUES (84, strftime("%s"))
+INSERT INTO schema (version, updated_at) VALUES (86, strftime("%s"))
 `
@@ -128,6 +128,46 @@ var updates = map[int]schema.Update{
 	82: updateFromV81,
 	83: updateFromV82,
 	84: updateFromV83,
+	85: updateFromV84,
+	86: updateFromV85,
+}
+
+func updateFromV85(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+ALTER TABLE identities_auth_groups ADD COLUMN expiry_date DATETIME;
+
+CREATE TABLE auth_group_requests (
+	id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+	uuid TEXT NOT NULL,
+	identity_id INTEGER NOT NULL,
+	auth_group_id INTEGER NOT NULL,
+	duration TEXT NOT NULL,
+	reason TEXT NOT NULL,
+	creation_date DATETIME NOT NULL,
+	UNIQUE (uuid),
+	UNIQUE (identity_id, auth_group_id),
+	FOREIGN KEY (identity_id) REFERENCES identities (id) ON DELETE CASCADE,
+	FOREIGN KEY (auth_group_id) REFERENCES auth_groups (id) ON DELETE CASCADE
+);
+`)
+
+	return err
+}
+
+func updateFromV84(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+CREATE TABLE instance_templates (
+	id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+	name TEXT NOT NULL,
+	description TEXT NOT NULL,
+	project_id INTEGER NOT NULL,
+	definition TEXT NOT NULL,
+	UNIQUE (project_id, name),
+	FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE
+);
+`)
+
+	return err
 }
 
 func updateFromV83(ctx context.Context, tx *sql.Tx) error {
@@ -160,7 +160,7 @@ func (c *ClusterTx) GetNetworkACL(ctx context.Context, projectName string, name
 
 // GetNetworkACLNameAndProjectWithID returns the network ACL name and project name for the given ID.
 func (c *ClusterTx) GetNetworkACLNameAndProjectWithID(ctx context.Context, networkACLID int) (networkACLName string, projectName string, err error) {
-	q := `SELECT networks_acls.name, projects.name FROM networks_acls JOIN projects ON projects.id=networks.project_id WHERE networks_acls.id=?`
+	q := `SELECT networks_acls.name, projects.name FROM networks_acls JOIN projects ON projects.id=networks_acls.project_id WHERE networks_acls.id=?`
 
 	err = c.tx.QueryRowContext(ctx, q, networkACLID).Scan(&networkACLName, &projectName)
 	if err != nil {
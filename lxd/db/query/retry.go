@@ -13,6 +13,7 @@ import (
 	"github.com/canonical/go-dqlite/v3/driver"
 	"github.com/mattn/go-sqlite3"
 
+	"github.com/canonical/lxd/lxd/metrics"
 	"github.com/canonical/lxd/shared/api"
 	"github.com/canonical/lxd/shared/logger"
 )
@@ -54,6 +55,7 @@ func Retry(ctx context.Context, f func(ctx context.Context) error) error {
 		}
 
 		logger.Debug("Database error, retrying", logger.Ctx{"attempt": i, "err": err})
+		metrics.TrackDatabaseTransactionRetry()
 		time.Sleep(jitterDeviation(0.8, 100*time.Millisecond))
 	}
 
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/canonical/lxd/lxd/metrics"
+	"github.com/canonical/lxd/lxd/tracing"
 	"github.com/canonical/lxd/shared/logger"
 )
 
@@ -15,6 +17,19 @@ func Transaction(ctx context.Context, db *sql.DB, f func(context.Context, *sql.T
 	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
 	defer cancel()
 
+	ctx, span := tracing.StartChild(ctx, "db.transaction")
+	startTime := time.Now()
+
+	err := transaction(ctx, db, f)
+
+	metrics.TrackDatabaseTransaction(time.Since(startTime))
+	tracing.End(span, err)
+
+	return err
+}
+
+// transaction begins a transaction, calls the given function and commits or rolls back the transaction.
+func transaction(ctx context.Context, db *sql.DB, f func(context.Context, *sql.Tx) error) error {
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		// If there is a leftover transaction let's try to rollback,
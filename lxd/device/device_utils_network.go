@@ -22,6 +22,8 @@ import (
 	"github.com/canonical/lxd/lxd/instance/instancetype"
 	"github.com/canonical/lxd/lxd/ip"
 	"github.com/canonical/lxd/lxd/network"
+	"github.com/canonical/lxd/lxd/network/acl"
+	"github.com/canonical/lxd/lxd/project"
 	"github.com/canonical/lxd/lxd/state"
 	"github.com/canonical/lxd/shared"
 	"github.com/canonical/lxd/shared/logger"
@@ -589,6 +591,55 @@ func networkClearHostVethLimits(d *deviceCommon) error {
 	return nil
 }
 
+// networkSetupACLs applies the network ACLs from the NIC's security.acls setting to the firewall.
+// If parentName is specified then the NIC traffic is filtered on the parent interface by hwAddr, otherwise it is
+// filtered on the host side interface.
+func networkSetupACLs(d *deviceCommon, hostName string, parentName string, hwAddr string) error {
+	if d.config["security.acls"] == "" {
+		return nil
+	}
+
+	networkProjectName, _, err := project.NetworkProject(d.state.DB.Cluster, d.inst.Project().Name)
+	if err != nil {
+		return fmt.Errorf("Failed loading network project name: %w", err)
+	}
+
+	if parentName != "" {
+		err = d.state.Firewall.InstanceSetupParentACLFilter(d.inst.Project().Name, d.inst.Name(), d.name, parentName, hwAddr)
+		if err != nil {
+			return err
+		}
+	}
+
+	return acl.FirewallApplyInstanceNICACLRules(context.TODO(), d.state, networkProjectName, acl.InstanceNICACLUsage{
+		ProjectName:    d.inst.Project().Name,
+		InstanceName:   d.inst.Name(),
+		DeviceName:     d.name,
+		HostName:       hostName,
+		ParentFiltered: parentName != "",
+		Config:         d.config,
+	})
+}
+
+// networkClearACLs removes the network ACL firewall rules for the NIC.
+func networkClearACLs(d *deviceCommon, hostName string) error {
+	return d.state.Firewall.InstanceClearACLRules(d.inst.Project().Name, d.inst.Name(), d.name, hostName)
+}
+
+// networkValidateACLs checks the network ACLs from the NIC's security.acls setting exist.
+func networkValidateACLs(s *state.State, instConf instance.ConfigReader, config deviceConfig.Device) error {
+	if config["security.acls"] == "" {
+		return nil
+	}
+
+	networkProjectName, _, err := project.NetworkProject(s.DB.Cluster, instConf.Project().Name)
+	if err != nil {
+		return fmt.Errorf("Failed loading network project name: %w", err)
+	}
+
+	return acl.Exists(context.TODO(), s, networkProjectName, shared.SplitNTrimSpace(config["security.acls"], ",", -1, true)...)
+}
+
 // networkValidGateway validates the gateway value.
 func networkValidGateway(value string) error {
 	if slices.Contains([]string{"none", "auto"}, value) {
@@ -551,14 +551,14 @@ func nicValidationRules(requiredFields []string, optionalFields []string, instCo
 		//  managed: no
 		//  shortdesc: Parent NIC name to nest this NIC under
 		"nested": validate.IsAny,
-		// lxdmeta:generate(entities=device-nic-ovn; group=device-conf; key=security.acls)
+		// lxdmeta:generate(entities=device-nic-ovn,device-nic-routed,device-nic-macvlan; group=device-conf; key=security.acls)
 		// Specify a comma-separated list
 		// ---
 		//  type: string
 		//  managed: no
 		//  shortdesc: Network ACLs to apply
 		"security.acls": validate.IsAny,
-		// lxdmeta:generate(entities=device-nic-ovn; group=device-conf; key=security.acls.default.ingress.action)
+		// lxdmeta:generate(entities=device-nic-ovn,device-nic-routed,device-nic-macvlan; group=device-conf; key=security.acls.default.ingress.action)
 		// The specified action is used for all ingress traffic that doesn’t match any ACL rule.
 		// ---
 		//  type: string
@@ -566,7 +566,7 @@ func nicValidationRules(requiredFields []string, optionalFields []string, instCo
 		//  managed: no
 		//  shortdesc: Default action to use for ingress traffic
 		"security.acls.default.ingress.action": validate.Optional(validate.IsOneOf(acl.ValidActions...)),
-		// lxdmeta:generate(entities=device-nic-ovn; group=device-conf; key=security.acls.default.egress.action)
+		// lxdmeta:generate(entities=device-nic-ovn,device-nic-routed,device-nic-macvlan; group=device-conf; key=security.acls.default.egress.action)
 		// The specified action is used for all egress traffic that doesn’t match any ACL rule.
 		// ---
 		//  type: string
@@ -574,7 +574,7 @@ func nicValidationRules(requiredFields []string, optionalFields []string, instCo
 		//  managed: no
 		//  shortdesc: Default action to use for egress traffic
 		"security.acls.default.egress.action": validate.Optional(validate.IsOneOf(acl.ValidActions...)),
-		// lxdmeta:generate(entities=device-nic-ovn; group=device-conf; key=security.acls.default.ingress.logged)
+		// lxdmeta:generate(entities=device-nic-ovn,device-nic-routed,device-nic-macvlan; group=device-conf; key=security.acls.default.ingress.logged)
 		//
 		// ---
 		//  type: bool
@@ -582,7 +582,7 @@ func nicValidationRules(requiredFields []string, optionalFields []string, instCo
 		//  managed: no
 		//  shortdesc: Whether to log ingress traffic that doesn’t match any ACL rule
 		"security.acls.default.ingress.logged": validate.Optional(validate.IsBool),
-		// lxdmeta:generate(entities=device-nic-ovn; group=device-conf; key=security.acls.default.egress.logged)
+		// lxdmeta:generate(entities=device-nic-ovn,device-nic-routed,device-nic-macvlan; group=device-conf; key=security.acls.default.egress.logged)
 		//
 		// ---
 		//  type: bool
@@ -137,6 +137,12 @@ func (d *nicBridged) validateConfig(instConf instance.ConfigReader) error {
 			if ip.Equal(net.ParseIP(d.config["ipv4.address"])) {
 				return fmt.Errorf("IP address %q is assigned to parent managed network device %q", d.config["ipv4.address"], d.config["parent"])
 			}
+
+			// IP should not be reserved for another client of the network.
+			reservationName := network.DHCPReservationName(netConfig, net.ParseIP(d.config["ipv4.address"]))
+			if reservationName != "" {
+				return fmt.Errorf("IP address %q is reserved by DHCP reservation %q of network %q", d.config["ipv4.address"], reservationName, n.Name())
+			}
 		}
 
 		if d.config["ipv6.address"] != "" {
@@ -172,6 +178,12 @@ func (d *nicBridged) validateConfig(instConf instance.ConfigReader) error {
 			if ip.Equal(net.ParseIP(d.config["ipv6.address"])) {
 				return fmt.Errorf("IP address %q is assigned to parent managed network device %q", d.config["ipv6.address"], d.config["parent"])
 			}
+
+			// IP should not be reserved for another client of the network.
+			reservationName := network.DHCPReservationName(netConfig, net.ParseIP(d.config["ipv6.address"]))
+			if reservationName != "" {
+				return fmt.Errorf("IP address %q is reserved by DHCP reservation %q of network %q", d.config["ipv6.address"], reservationName, n.Name())
+			}
 		}
 
 		// When we know the parent network is managed, we can validate the NIC's VLAN settings based on
@@ -51,6 +51,11 @@ func (d *nicMACVLAN) validateConfig(instConf instance.ConfigReader) error {
 		"vlan",
 		"boot.priority",
 		"gvrp",
+		"security.acls",
+		"security.acls.default.ingress.action",
+		"security.acls.default.egress.action",
+		"security.acls.default.ingress.logged",
+		"security.acls.default.egress.logged",
 	}
 
 	// Check that if network property is set that conflicting keys are not present.
@@ -103,6 +108,18 @@ func (d *nicMACVLAN) validateConfig(instConf instance.ConfigReader) error {
 		return err
 	}
 
+	if d.config["security.acls"] != "" {
+		// Filtering macvlan traffic requires netdev hooks on the parent interface.
+		if d.state.Firewall.String() != "nftables" {
+			return fmt.Errorf("Network ACLs on macvlan NICs require the nftables firewall driver (currently %q)", d.state.Firewall.String())
+		}
+
+		err = networkValidateACLs(d.state, instConf, d.config)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -237,6 +254,26 @@ func (d *nicMACVLAN) Start() (*deviceConfig.RunConfig, error) {
 		}
 	}
 
+	// Apply network ACLs on the parent interface, matching the traffic using the NIC's MAC address.
+	if d.config["security.acls"] != "" {
+		hwAddr := d.config["hwaddr"]
+		if hwAddr == "" {
+			iface, err := net.InterfaceByName(saveData["host_name"])
+			if err != nil {
+				return nil, fmt.Errorf("Failed getting MAC address of interface %q: %w", saveData["host_name"], err)
+			}
+
+			hwAddr = iface.HardwareAddr.String()
+		}
+
+		revert.Add(func() { _ = networkClearACLs(&d.deviceCommon, "") })
+
+		err = networkSetupACLs(&d.deviceCommon, saveData["host_name"], actualParentName, hwAddr)
+		if err != nil {
+			return nil, fmt.Errorf("Failed applying network ACLs: %w", err)
+		}
+	}
+
 	err = d.volatileSet(saveData)
 	if err != nil {
 		return nil, err
@@ -298,6 +335,14 @@ func (d *nicMACVLAN) postStop() error {
 		}
 	}
 
+	// Remove network ACL rules.
+	if d.config["security.acls"] != "" {
+		err := networkClearACLs(&d.deviceCommon, "")
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	// This will delete the parent interface if we created it for VLAN parent.
 	if shared.IsTrue(v["last_state.created"]) {
 		actualParentName := network.GetHostDevice(d.config["parent"], d.config["vlan"])
@@ -45,7 +45,7 @@ func (d *nicRouted) UpdatableFields(oldDevice Type) []string {
 		return []string{}
 	}
 
-	return []string{"limits.ingress", "limits.egress", "limits.max", "limits.priority"}
+	return []string{"limits.ingress", "limits.egress", "limits.max", "limits.priority", "security.acls", "security.acls.default.ingress.action", "security.acls.default.egress.action", "security.acls.default.ingress.logged", "security.acls.default.egress.logged"}
 }
 
 // validateConfig checks the supplied config for correctness.
@@ -81,6 +81,11 @@ func (d *nicRouted) validateConfig(instConf instance.ConfigReader) error {
 		"ipv4.host_table",
 		"ipv6.host_table",
 		"gvrp",
+		"security.acls",
+		"security.acls.default.ingress.action",
+		"security.acls.default.egress.action",
+		"security.acls.default.ingress.logged",
+		"security.acls.default.egress.logged",
 	}
 
 	rules := nicValidationRules(requiredFields, optionalFields, instConf)
@@ -136,6 +141,11 @@ func (d *nicRouted) validateConfig(instConf instance.ConfigReader) error {
 		return errors.New("The vlan setting can only be used when combined with a parent interface")
 	}
 
+	err = networkValidateACLs(d.state, instConf, d.config)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -384,6 +394,16 @@ func (d *nicRouted) Start() (*deviceConfig.RunConfig, error) {
 		return nil, fmt.Errorf("Error setting up reverse path filter: %w", err)
 	}
 
+	// Apply network ACLs on the host side interface.
+	if d.config["security.acls"] != "" {
+		revert.Add(func() { _ = networkClearACLs(&d.deviceCommon, saveData["host_name"]) })
+
+		err = networkSetupACLs(&d.deviceCommon, saveData["host_name"], "", "")
+		if err != nil {
+			return nil, fmt.Errorf("Failed applying network ACLs: %w", err)
+		}
+	}
+
 	// Perform host-side address configuration.
 	for _, keyPrefix := range []string{"ipv4", "ipv6"} {
 		subnetSize := 32
@@ -596,6 +616,19 @@ func (d *nicRouted) Update(oldDevices deviceConfig.Devices, isRunning bool) erro
 		if err != nil {
 			return err
 		}
+
+		// Apply network ACLs, or remove them if no longer used.
+		if d.config["security.acls"] != "" {
+			err = networkSetupACLs(&d.deviceCommon, d.config["host_name"], "", "")
+			if err != nil {
+				return fmt.Errorf("Failed applying network ACLs: %w", err)
+			}
+		} else if oldDevices[d.name]["security.acls"] != "" {
+			err = networkClearACLs(&d.deviceCommon, d.config["host_name"])
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -674,6 +707,14 @@ func (d *nicRouted) postStop() error {
 		errs = append(errs, err)
 	}
 
+	// Remove network ACL rules.
+	if d.config["security.acls"] != "" {
+		err = networkClearACLs(&d.deviceCommon, d.config["host_name"])
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	if len(errs) > 0 {
 		return fmt.Errorf("%v", errs)
 	}
@@ -94,6 +94,11 @@ func (d *nicSRIOV) validateConfig(instConf instance.ConfigReader) error {
 		requiredFields = append(requiredFields, "parent")
 	}
 
+	// Traffic of a VF doesn't go through the host network stack, so it cannot be filtered by the firewall.
+	if d.config["security.acls"] != "" {
+		return errors.New("Network ACLs are not supported on sriov NICs as their traffic bypasses the host firewall")
+	}
+
 	// For VMs only NIC properties that can be specified on the parent's VF settings are controllable.
 	if instType == instancetype.Container || instType == instancetype.Any {
 		optionalFields = append(optionalFields, "mtu")
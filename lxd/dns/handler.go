@@ -9,6 +9,7 @@ import (
 
 	"github.com/miekg/dns"
 
+	"github.com/canonical/lxd/shared"
 	"github.com/canonical/lxd/shared/api"
 	"github.com/canonical/lxd/shared/logger"
 )
@@ -46,6 +47,12 @@ func (d *dnsHandler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 		return
 	}
 
+	// Dynamic updates are handled separately.
+	if r.Opcode == dns.OpcodeUpdate {
+		d.serveUpdate(w, r)
+		return
+	}
+
 	// Check that it's a supported request type.
 	if r.Question[0].Qtype != dns.TypeAXFR && r.Question[0].Qtype != dns.TypeIXFR && r.Question[0].Qtype != dns.TypeSOA {
 		writeRcode(w, r, dns.RcodeNotImplemented)
@@ -110,14 +117,18 @@ func (d *dnsHandler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 	}
 }
 
-func (d *dnsHandler) isAllowed(zone api.NetworkZone, ip string, tsig *dns.TSIG, tsigStatus bool) bool {
-	type peer struct {
-		address string
-		key     string
-	}
+// zonePeer represents a peer configured on a network zone.
+type zonePeer struct {
+	address     string
+	key         string
+	update      bool
+	updateNames []string
+	updateTypes []string
+}
 
-	// Build a list of peers.
-	peers := map[string]*peer{}
+// zonePeers returns the peers configured on the zone, indexed by peer name.
+func zonePeers(zone api.NetworkZone) map[string]*zonePeer {
+	peers := map[string]*zonePeer{}
 	for k, v := range zone.Config {
 		suffix, found := strings.CutPrefix(k, "peers.")
 		if !found {
@@ -131,22 +142,38 @@ func (d *dnsHandler) isAllowed(zone api.NetworkZone, ip string, tsig *dns.TSIG,
 		}
 
 		if peers[peerName] == nil {
-			peers[peerName] = &peer{}
+			peers[peerName] = &zonePeer{}
 		}
 
-		// Populate peer configuration fields (address, key) based on the last part of the key.
+		// Populate peer configuration fields based on the remaining part of the key.
 		switch field {
 		case "address":
 			peers[peerName].address = v
 		case "key":
 			peers[peerName].key = v
+		case "update":
+			peers[peerName].update = shared.IsTrue(v)
+		case "update.names":
+			peers[peerName].updateNames = shared.SplitNTrimSpace(strings.ToLower(v), ",", -1, true)
+		case "update.types":
+			peers[peerName].updateTypes = shared.SplitNTrimSpace(strings.ToUpper(v), ",", -1, true)
 		}
 	}
 
+	return peers
+}
+
+// isAllowed returns whether the request is from a peer that is allowed to transfer the zone.
+func (d *dnsHandler) isAllowed(zone api.NetworkZone, ip string, tsig *dns.TSIG, tsigStatus bool) bool {
 	// Validate access.
-	for peerName, peer := range peers {
+	for peerName, peer := range zonePeers(zone) {
 		peerKeyName := fmt.Sprintf("%s_%s.", zone.Name, peerName)
 
+		if peer.update {
+			// Peers allowed to update the zone can't transfer it.
+			continue
+		}
+
 		if peer.address != "" && ip != peer.address {
 			// Bad IP address.
 			continue
@@ -315,6 +315,28 @@ func TestIsAllowed(t *testing.T) {
 			tsigStatus: true, // sig verified, but for a different zone's key
 			wantAllow:  false,
 		},
+		{
+			name: "Update peer: valid TSIG with correct key name denied",
+			config: map[string]string{
+				"peers.mypeer.key":    "secret",
+				"peers.mypeer.update": "true",
+			},
+			ip:         "127.0.0.1",
+			tsig:       validTSIG,
+			tsigStatus: true,
+			wantAllow:  false,
+		},
+		{
+			name: "Update disabled peer: valid TSIG with correct key name allowed",
+			config: map[string]string{
+				"peers.mypeer.key":    "secret",
+				"peers.mypeer.update": "false",
+			},
+			ip:         "127.0.0.1",
+			tsig:       validTSIG,
+			tsigStatus: true,
+			wantAllow:  true,
+		},
 		{
 			name:      "Malformed config key (missing field) ignored",
 			config:    map[string]string{"peers.": "value"},
@@ -8,6 +8,7 @@ import (
 
 	"github.com/canonical/lxd/lxd/db"
 	"github.com/canonical/lxd/lxd/util"
+	"github.com/canonical/lxd/shared/api"
 	"github.com/canonical/lxd/shared/logger"
 	"github.com/canonical/lxd/shared/revert"
 )
@@ -15,6 +16,10 @@ import (
 // ZoneRetriever is a function which fetches a DNS zone.
 type ZoneRetriever func(name string, full bool) (*Zone, error)
 
+// ZoneUpdater is a function which applies a dynamic update to the records of a DNS zone.
+// The modify function is called with the current zone records and returns the updated records.
+type ZoneUpdater func(name string, requestor *api.EventLifecycleRequestor, modify func(records []api.NetworkZoneRecord) ([]api.NetworkZoneRecord, error)) error
+
 // Server represents a DNS server instance.
 type Server struct {
 	tcpDNS *dns.Server
@@ -23,6 +28,7 @@ type Server struct {
 	// External dependencies.
 	db            *db.Cluster
 	zoneRetriever ZoneRetriever
+	zoneUpdater   ZoneUpdater
 
 	// Internal state (to handle reconfiguration).
 	address string
@@ -31,9 +37,9 @@ type Server struct {
 }
 
 // NewServer returns a new server instance.
-func NewServer(db *db.Cluster, retriever ZoneRetriever) *Server {
+func NewServer(db *db.Cluster, retriever ZoneRetriever, updater ZoneUpdater) *Server {
 	// Setup new struct.
-	s := &Server{db: db, zoneRetriever: retriever}
+	s := &Server{db: db, zoneRetriever: retriever, zoneUpdater: updater}
 	return s
 }
 
@@ -0,0 +1,431 @@
+package dns
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/logger"
+)
+
+// updateError is returned when a dynamic update must be rejected with a specific response code.
+type updateError struct {
+	rcode int
+}
+
+// Error returns the error message.
+func (e updateError) Error() string {
+	return "Dynamic update rejected: " + dns.RcodeToString[e.rcode]
+}
+
+// serveUpdate handles a dynamic update (RFC 2136) request.
+func (d *dnsHandler) serveUpdate(w dns.ResponseWriter, r *dns.Msg) {
+	// Check that dynamic updates are supported.
+	if d.server.zoneUpdater == nil {
+		writeRcode(w, r, dns.RcodeNotImplemented)
+		return
+	}
+
+	// The zone section must reference the SOA of the zone being updated.
+	if r.Question[0].Qtype != dns.TypeSOA || r.Question[0].Qclass != dns.ClassINET {
+		writeRcode(w, r, dns.RcodeFormatError)
+		return
+	}
+
+	// Extract the request information.
+	name := strings.TrimSuffix(strings.ToLower(r.Question[0].Name), ".")
+	ip, _, err := net.SplitHostPort(w.RemoteAddr().String())
+	if err != nil {
+		writeRcode(w, r, dns.RcodeServerFailure)
+		return
+	}
+
+	// Load the zone.
+	zone, err := d.server.zoneRetriever(name, false)
+	if err != nil {
+		writeRcode(w, r, dns.RcodeNotAuth)
+		return
+	}
+
+	tsig := r.IsTsig()
+	tsigOK := w.TsigStatus() == nil
+
+	// Dynamic updates are only accepted from peers authenticated with a valid TSIG signature.
+	if tsig == nil || !tsigOK {
+		writeRcode(w, r, dns.RcodeNotAuth)
+		return
+	}
+
+	peer := updatePeer(zone.Info, ip, tsig)
+	if peer == nil {
+		writeUpdateResponse(w, r, dns.RcodeRefused, tsig)
+		return
+	}
+
+	// Check the update section before touching the zone.
+	rcode := peer.checkUpdates(name, r.Ns)
+	if rcode != dns.RcodeSuccess {
+		writeUpdateResponse(w, r, rcode, tsig)
+		return
+	}
+
+	requestor := &api.EventLifecycleRequestor{
+		Username: strings.TrimSuffix(tsig.Hdr.Name, "."),
+		Protocol: "dns",
+		Address:  ip,
+	}
+
+	err = d.server.zoneUpdater(name, requestor, func(records []api.NetworkZoneRecord) ([]api.NetworkZoneRecord, error) {
+		rcode := checkPrerequisites(name, records, r.Answer)
+		if rcode != dns.RcodeSuccess {
+			return nil, updateError{rcode: rcode}
+		}
+
+		return peer.applyUpdates(name, records, r.Ns), nil
+	})
+	if err != nil {
+		rcode = dns.RcodeServerFailure
+
+		var updateErr updateError
+		if errors.As(err, &updateErr) {
+			rcode = updateErr.rcode
+		} else {
+			logger.Error("Failed applying dynamic DNS update", logger.Ctx{"zone": name, "err": err})
+		}
+	}
+
+	writeUpdateResponse(w, r, rcode, tsig)
+}
+
+// writeUpdateResponse sends a TSIG signed response to a dynamic update request.
+func writeUpdateResponse(w dns.ResponseWriter, r *dns.Msg, rcode int, tsig *dns.TSIG) {
+	m := new(dns.Msg)
+	m.SetRcode(r, rcode)
+	m.SetTsig(tsig.Hdr.Name, tsig.Algorithm, 300, time.Now().Unix())
+
+	err := w.WriteMsg(m)
+	if err != nil {
+		logger.Error("Cannot write message", logger.Ctx{"err": err})
+	}
+}
+
+// updatePeer returns the zone peer allowed to send dynamic updates using the given TSIG key and address.
+// Returns nil if no such peer exists.
+func updatePeer(zone api.NetworkZone, ip string, tsig *dns.TSIG) *zonePeer {
+	for peerName, peer := range zonePeers(zone) {
+		if !peer.update || peer.key == "" {
+			continue
+		}
+
+		if peer.address != "" && ip != peer.address {
+			// Bad IP address.
+			continue
+		}
+
+		if tsig.Hdr.Name != fmt.Sprintf("%s_%s.", zone.Name, peerName) {
+			// Bad key name (valid TSIG but potentially for another peer or domain).
+			continue
+		}
+
+		return peer
+	}
+
+	return nil
+}
+
+// canUpdate returns whether the peer is allowed to update records of the given type and name (relative to the zone).
+func (p *zonePeer) canUpdate(name string, rrType uint16) bool {
+	// The SOA record is always generated by LXD.
+	if rrType == dns.TypeSOA {
+		return false
+	}
+
+	if len(p.updateTypes) > 0 && rrType != dns.TypeANY && !slices.Contains(p.updateTypes, dns.TypeToString[rrType]) {
+		return false
+	}
+
+	if len(p.updateNames) == 0 {
+		return true
+	}
+
+	for _, pattern := range p.updateNames {
+		match, _ := path.Match(pattern, name)
+		if match {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkUpdates performs the update section prescan (RFC 2136 section 3.4.1) and checks the records against the
+// peer's update policy. Returns the response code to use if the update must be rejected or RcodeSuccess.
+func (p *zonePeer) checkUpdates(zoneName string, updates []dns.RR) int {
+	for _, rr := range updates {
+		hdr := rr.Header()
+
+		name, inZone := relativeName(zoneName, hdr.Name)
+		if !inZone {
+			return dns.RcodeNotZone
+		}
+
+		switch hdr.Class {
+		case dns.ClassINET:
+			if isMetaType(hdr.Rrtype) || hdr.Rrtype == dns.TypeANY {
+				return dns.RcodeFormatError
+			}
+
+		case dns.ClassANY:
+			if hdr.Ttl != 0 || hdr.Rdlength != 0 || isMetaType(hdr.Rrtype) {
+				return dns.RcodeFormatError
+			}
+
+		case dns.ClassNONE:
+			if hdr.Ttl != 0 || isMetaType(hdr.Rrtype) || hdr.Rrtype == dns.TypeANY {
+				return dns.RcodeFormatError
+			}
+
+		default:
+			return dns.RcodeFormatError
+		}
+
+		// Records at the zone apex (SOA and NS) are managed by LXD.
+		if name == "" || !p.canUpdate(name, hdr.Rrtype) {
+			return dns.RcodeRefused
+		}
+	}
+
+	return dns.RcodeSuccess
+}
+
+// applyUpdates applies the update section (RFC 2136 section 3.4.2) to the zone records and returns the new records.
+// Records left without entries by the update are removed.
+func (p *zonePeer) applyUpdates(zoneName string, records []api.NetworkZoneRecord, updates []dns.RR) []api.NetworkZoneRecord {
+	emptied := map[string]bool{}
+
+	findRecord := func(name string) int {
+		return slices.IndexFunc(records, func(record api.NetworkZoneRecord) bool { return record.Name == name })
+	}
+
+	for _, rr := range updates {
+		hdr := rr.Header()
+		name, _ := relativeName(zoneName, hdr.Name)
+		idx := findRecord(name)
+
+		switch hdr.Class {
+		case dns.ClassINET:
+			entry := api.NetworkZoneRecordEntry{
+				Type:  dns.TypeToString[hdr.Rrtype],
+				TTL:   uint64(hdr.Ttl),
+				Value: strings.TrimPrefix(rr.String(), hdr.String()),
+			}
+
+			if idx < 0 {
+				records = append(records, api.NetworkZoneRecord{
+					Name:    name,
+					Entries: []api.NetworkZoneRecordEntry{entry},
+					Config:  map[string]string{},
+				})
+
+				delete(emptied, name)
+				continue
+			}
+
+			record := &records[idx]
+
+			// A CNAME cannot coexist with other records for the same name, ignore conflicting updates.
+			hasCNAME := slices.ContainsFunc(record.Entries, func(e api.NetworkZoneRecordEntry) bool { return e.Type == "CNAME" })
+			hasOther := slices.ContainsFunc(record.Entries, func(e api.NetworkZoneRecordEntry) bool { return e.Type != "CNAME" })
+			if (hdr.Rrtype == dns.TypeCNAME && hasOther) || (hdr.Rrtype != dns.TypeCNAME && hasCNAME) {
+				continue
+			}
+
+			// Duplicate records replace the existing ones (updating the TTL).
+			dupIdx := slices.IndexFunc(record.Entries, func(e api.NetworkZoneRecordEntry) bool { return entryMatches(hdr.Name, e, rr) })
+			if dupIdx >= 0 {
+				record.Entries[dupIdx] = entry
+			} else {
+				record.Entries = append(record.Entries, entry)
+			}
+
+			delete(emptied, name)
+
+		case dns.ClassANY, dns.ClassNONE:
+			if idx < 0 {
+				continue
+			}
+
+			record := &records[idx]
+			if len(record.Entries) == 0 {
+				continue
+			}
+
+			record.Entries = slices.DeleteFunc(record.Entries, func(e api.NetworkZoneRecordEntry) bool {
+				rrType := dns.StringToType[e.Type]
+
+				switch {
+				case hdr.Class == dns.ClassNONE:
+					// Delete a specific record.
+					return entryMatches(hdr.Name, e, rr)
+				case hdr.Rrtype == dns.TypeANY:
+					// Delete all records for the name the peer is allowed to update.
+					return p.canUpdate(name, rrType)
+				default:
+					// Delete a record set.
+					return rrType == hdr.Rrtype
+				}
+			})
+
+			if len(record.Entries) == 0 {
+				emptied[name] = true
+			}
+		}
+	}
+
+	return slices.DeleteFunc(records, func(record api.NetworkZoneRecord) bool { return emptied[record.Name] })
+}
+
+// checkPrerequisites checks the prerequisite section (RFC 2136 section 3.2) against the zone records.
+// Returns the response code to use if a prerequisite isn't satisfied or RcodeSuccess.
+func checkPrerequisites(zoneName string, records []api.NetworkZoneRecord, prereqs []dns.RR) int {
+	// Value dependent record sets, indexed by name and type.
+	rrSets := map[string][]dns.RR{}
+
+	// entries returns the zone record entries for a name.
+	entries := func(name string) []api.NetworkZoneRecordEntry {
+		for _, record := range records {
+			if record.Name == name {
+				return record.Entries
+			}
+		}
+
+		return nil
+	}
+
+	// hasType returns whether any of the entries is of the given type.
+	hasType := func(entries []api.NetworkZoneRecordEntry, rrType uint16) bool {
+		return slices.ContainsFunc(entries, func(e api.NetworkZoneRecordEntry) bool { return dns.StringToType[e.Type] == rrType })
+	}
+
+	for _, rr := range prereqs {
+		hdr := rr.Header()
+		if hdr.Ttl != 0 {
+			return dns.RcodeFormatError
+		}
+
+		name, inZone := relativeName(zoneName, hdr.Name)
+		if !inZone {
+			return dns.RcodeNotZone
+		}
+
+		nameEntries := entries(name)
+
+		switch hdr.Class {
+		case dns.ClassANY:
+			if hdr.Rdlength != 0 {
+				return dns.RcodeFormatError
+			}
+
+			if hdr.Rrtype == dns.TypeANY {
+				// Name is in use.
+				if len(nameEntries) == 0 {
+					return dns.RcodeNameError
+				}
+			} else if !hasType(nameEntries, hdr.Rrtype) {
+				// RRset exists (value independent).
+				return dns.RcodeNXRrset
+			}
+
+		case dns.ClassNONE:
+			if hdr.Rdlength != 0 {
+				return dns.RcodeFormatError
+			}
+
+			if hdr.Rrtype == dns.TypeANY {
+				// Name is not in use.
+				if len(nameEntries) > 0 {
+					return dns.RcodeYXDomain
+				}
+			} else if hasType(nameEntries, hdr.Rrtype) {
+				// RRset does not exist.
+				return dns.RcodeYXRrset
+			}
+
+		case dns.ClassINET:
+			// RRset exists (value dependent), checked once all prerequisites are collected.
+			key := name + "/" + dns.TypeToString[hdr.Rrtype]
+			rrSets[key] = append(rrSets[key], rr)
+
+		default:
+			return dns.RcodeFormatError
+		}
+	}
+
+	for key, rrSet := range rrSets {
+		name, rrTypeName, _ := strings.Cut(key, "/")
+
+		var zoneEntries []api.NetworkZoneRecordEntry
+		for _, e := range entries(name) {
+			if e.Type == rrTypeName {
+				zoneEntries = append(zoneEntries, e)
+			}
+		}
+
+		// The record sets must match exactly.
+		if len(zoneEntries) != len(rrSet) {
+			return dns.RcodeNXRrset
+		}
+
+		for _, e := range zoneEntries {
+			if !slices.ContainsFunc(rrSet, func(rr dns.RR) bool { return entryMatches(rr.Header().Name, e, rr) }) {
+				return dns.RcodeNXRrset
+			}
+		}
+	}
+
+	return dns.RcodeSuccess
+}
+
+// entryMatches returns whether the zone record entry (for the fully qualified name) has the same type and data as rr.
+func entryMatches(fqdn string, entry api.NetworkZoneRecordEntry, rr dns.RR) bool {
+	entryRR, err := dns.NewRR(fmt.Sprintf("%s %d IN %s %s", fqdn, entry.TTL, entry.Type, entry.Value))
+	if err != nil || entryRR == nil {
+		return false
+	}
+
+	// Compare against a copy of the record with the zone class, as deletions use class NONE.
+	rrCopy := dns.Copy(rr)
+	rrCopy.Header().Class = dns.ClassINET
+
+	return dns.IsDuplicate(entryRR, rrCopy)
+}
+
+// relativeName converts a fully qualified name into a name relative to the zone.
+// Returns an empty name for the zone apex and false if the name is outside of the zone.
+func relativeName(zoneName string, fqdn string) (string, bool) {
+	name := strings.TrimSuffix(strings.ToLower(fqdn), ".")
+	zoneName = strings.ToLower(zoneName)
+
+	if name == zoneName {
+		return "", true
+	}
+
+	name, found := strings.CutSuffix(name, "."+zoneName)
+	if !found {
+		return "", false
+	}
+
+	return name, true
+}
+
+// isMetaType returns whether the record type is a query-only meta type which cannot be stored in a zone.
+func isMetaType(rrType uint16) bool {
+	return slices.Contains([]uint16{dns.TypeAXFR, dns.TypeIXFR, dns.TypeMAILA, dns.TypeMAILB, dns.TypeOPT, dns.TypeTSIG}, rrType)
+}
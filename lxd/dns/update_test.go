@@ -0,0 +1,315 @@
+package dns
+
+import (
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/canonical/lxd/shared/api"
+)
+
+// mustRR parses a resource record for use in tests.
+func mustRR(s string) dns.RR {
+	rr, err := dns.NewRR(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return rr
+}
+
+// classRR returns a copy of rr with the given class and a zero TTL, as used in the prerequisite and update sections.
+func classRR(class uint16, rr dns.RR) dns.RR {
+	rr = dns.Copy(rr)
+	rr.Header().Class = class
+	rr.Header().Ttl = 0
+
+	return rr
+}
+
+// rrsetRR returns a record without data for the given name, type and class.
+func rrsetRR(name string, rrType uint16, class uint16) dns.RR {
+	return &dns.ANY{Hdr: dns.RR_Header{Name: name, Rrtype: rrType, Class: class}}
+}
+
+// newUpdateMsg returns a signed dynamic update message for the example.net zone.
+func newUpdateMsg(keyName string, insert ...dns.RR) *dns.Msg {
+	r := new(dns.Msg)
+	r.SetUpdate("example.net.")
+	r.Insert(insert)
+	r.SetTsig(keyName, dns.HmacSHA256, 300, 0)
+
+	return r
+}
+
+func TestServeUpdate(t *testing.T) {
+	t.Parallel()
+
+	zone := &Zone{
+		Info: api.NetworkZone{
+			Name: "example.net",
+			Config: map[string]string{
+				"peers.acme.key":          "secret",
+				"peers.acme.update":       "true",
+				"peers.acme.update.names": "_acme-challenge*",
+				"peers.acme.update.types": "TXT",
+				"peers.xfer.key":          "secret",
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		keyName    string
+		tsigStatus error
+		rr         string
+		wantRcode  int
+		wantUpdate bool
+	}{
+		{
+			name:       "Invalid TSIG signature",
+			keyName:    "example.net_acme.",
+			tsigStatus: dns.ErrSig,
+			rr:         "_acme-challenge.example.net. 60 IN TXT \"token\"",
+			wantRcode:  dns.RcodeNotAuth,
+		},
+		{
+			name:      "Peer without updates enabled",
+			keyName:   "example.net_xfer.",
+			rr:        "_acme-challenge.example.net. 60 IN TXT \"token\"",
+			wantRcode: dns.RcodeRefused,
+		},
+		{
+			name:      "Disallowed type",
+			keyName:   "example.net_acme.",
+			rr:        "_acme-challenge.example.net. 60 IN A 192.0.2.1",
+			wantRcode: dns.RcodeRefused,
+		},
+		{
+			name:      "Disallowed name",
+			keyName:   "example.net_acme.",
+			rr:        "www.example.net. 60 IN TXT \"token\"",
+			wantRcode: dns.RcodeRefused,
+		},
+		{
+			name:      "Name outside of zone",
+			keyName:   "example.net_acme.",
+			rr:        "_acme-challenge.example.com. 60 IN TXT \"token\"",
+			wantRcode: dns.RcodeNotZone,
+		},
+		{
+			name:       "Allowed update",
+			keyName:    "example.net_acme.",
+			rr:         "_acme-challenge.example.net. 60 IN TXT \"token\"",
+			wantRcode:  dns.RcodeSuccess,
+			wantUpdate: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var updated []api.NetworkZoneRecord
+			s := &Server{
+				zoneRetriever: func(name string, full bool) (*Zone, error) {
+					return zone, nil
+				},
+				zoneUpdater: func(name string, requestor *api.EventLifecycleRequestor, modify func(records []api.NetworkZoneRecord) ([]api.NetworkZoneRecord, error)) error {
+					var err error
+					updated, err = modify(nil)
+					return err
+				},
+			}
+
+			h := &dnsHandler{server: s}
+			w := newMockWriter("127.0.0.1:12345", tt.tsigStatus)
+
+			h.ServeDNS(w, newUpdateMsg(tt.keyName, mustRR(tt.rr)))
+
+			require.NotNil(t, w.written)
+			assert.Equal(t, tt.wantRcode, w.written.Rcode)
+
+			if tt.wantUpdate {
+				require.Len(t, updated, 1)
+				assert.Equal(t, "_acme-challenge", updated[0].Name)
+				assert.Equal(t, []api.NetworkZoneRecordEntry{{Type: "TXT", TTL: 60, Value: "\"token\""}}, updated[0].Entries)
+			} else {
+				assert.Nil(t, updated)
+			}
+		})
+	}
+}
+
+func TestApplyUpdates(t *testing.T) {
+	t.Parallel()
+
+	records := func() []api.NetworkZoneRecord {
+		return []api.NetworkZoneRecord{
+			{
+				Name: "www",
+				Entries: []api.NetworkZoneRecordEntry{
+					{Type: "A", Value: "192.0.2.1"},
+					{Type: "A", Value: "192.0.2.2"},
+					{Type: "TXT", Value: "\"hello\""},
+				},
+			},
+			{
+				Name:    "alias",
+				Entries: []api.NetworkZoneRecordEntry{{Type: "CNAME", Value: "www.example.net."}},
+			},
+			{
+				Name: "empty",
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		updates []dns.RR
+		want    map[string][]api.NetworkZoneRecordEntry
+	}{
+		{
+			name:    "Add to existing record",
+			updates: []dns.RR{mustRR("www.example.net. 60 IN AAAA 2001:db8::1")},
+			want: map[string][]api.NetworkZoneRecordEntry{
+				"www": {
+					{Type: "A", Value: "192.0.2.1"},
+					{Type: "A", Value: "192.0.2.2"},
+					{Type: "TXT", Value: "\"hello\""},
+					{Type: "AAAA", TTL: 60, Value: "2001:db8::1"},
+				},
+				"alias": {{Type: "CNAME", Value: "www.example.net."}},
+				"empty": nil,
+			},
+		},
+		{
+			name:    "Duplicate replaces TTL",
+			updates: []dns.RR{mustRR("www.example.net. 60 IN A 192.0.2.1")},
+			want: map[string][]api.NetworkZoneRecordEntry{
+				"www": {
+					{Type: "A", TTL: 60, Value: "192.0.2.1"},
+					{Type: "A", Value: "192.0.2.2"},
+					{Type: "TXT", Value: "\"hello\""},
+				},
+				"alias": {{Type: "CNAME", Value: "www.example.net."}},
+				"empty": nil,
+			},
+		},
+		{
+			name:    "CNAME conflict ignored",
+			updates: []dns.RR{mustRR("alias.example.net. 60 IN A 192.0.2.1")},
+			want: map[string][]api.NetworkZoneRecordEntry{
+				"www": {
+					{Type: "A", Value: "192.0.2.1"},
+					{Type: "A", Value: "192.0.2.2"},
+					{Type: "TXT", Value: "\"hello\""},
+				},
+				"alias": {{Type: "CNAME", Value: "www.example.net."}},
+				"empty": nil,
+			},
+		},
+		{
+			name:    "Delete single record",
+			updates: []dns.RR{classRR(dns.ClassNONE, mustRR("www.example.net. IN A 192.0.2.2"))},
+			want: map[string][]api.NetworkZoneRecordEntry{
+				"www": {
+					{Type: "A", Value: "192.0.2.1"},
+					{Type: "TXT", Value: "\"hello\""},
+				},
+				"alias": {{Type: "CNAME", Value: "www.example.net."}},
+				"empty": nil,
+			},
+		},
+		{
+			name:    "Delete record set",
+			updates: []dns.RR{rrsetRR("www.example.net.", dns.TypeA, dns.ClassANY)},
+			want: map[string][]api.NetworkZoneRecordEntry{
+				"www":   {{Type: "TXT", Value: "\"hello\""}},
+				"alias": {{Type: "CNAME", Value: "www.example.net."}},
+				"empty": nil,
+			},
+		},
+		{
+			name:    "Delete all record sets removes the record",
+			updates: []dns.RR{rrsetRR("alias.example.net.", dns.TypeANY, dns.ClassANY)},
+			want: map[string][]api.NetworkZoneRecordEntry{
+				"www": {
+					{Type: "A", Value: "192.0.2.1"},
+					{Type: "A", Value: "192.0.2.2"},
+					{Type: "TXT", Value: "\"hello\""},
+				},
+				"empty": nil,
+			},
+		},
+		{
+			name:    "Create new record",
+			updates: []dns.RR{mustRR("new.example.net. 300 IN TXT \"new\"")},
+			want: map[string][]api.NetworkZoneRecordEntry{
+				"www": {
+					{Type: "A", Value: "192.0.2.1"},
+					{Type: "A", Value: "192.0.2.2"},
+					{Type: "TXT", Value: "\"hello\""},
+				},
+				"alias": {{Type: "CNAME", Value: "www.example.net."}},
+				"empty": nil,
+				"new":   {{Type: "TXT", TTL: 300, Value: "\"new\""}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			peer := &zonePeer{update: true}
+			got := map[string][]api.NetworkZoneRecordEntry{}
+			for _, record := range peer.applyUpdates("example.net", records(), tt.updates) {
+				got[record.Name] = record.Entries
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCheckPrerequisites(t *testing.T) {
+	t.Parallel()
+
+	records := []api.NetworkZoneRecord{
+		{
+			Name: "www",
+			Entries: []api.NetworkZoneRecordEntry{
+				{Type: "A", Value: "192.0.2.1"},
+				{Type: "A", Value: "192.0.2.2"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		prereqs   []dns.RR
+		wantRcode int
+	}{
+		{"Name in use", []dns.RR{rrsetRR("www.example.net.", dns.TypeANY, dns.ClassANY)}, dns.RcodeSuccess},
+		{"Name not in use", []dns.RR{rrsetRR("other.example.net.", dns.TypeANY, dns.ClassANY)}, dns.RcodeNameError},
+		{"Name expected unused", []dns.RR{rrsetRR("www.example.net.", dns.TypeANY, dns.ClassNONE)}, dns.RcodeYXDomain},
+		{"RRset exists", []dns.RR{rrsetRR("www.example.net.", dns.TypeA, dns.ClassANY)}, dns.RcodeSuccess},
+		{"RRset missing", []dns.RR{rrsetRR("www.example.net.", dns.TypeAAAA, dns.ClassANY)}, dns.RcodeNXRrset},
+		{"RRset expected missing", []dns.RR{rrsetRR("www.example.net.", dns.TypeA, dns.ClassNONE)}, dns.RcodeYXRrset},
+		{"RRset matches", []dns.RR{mustRR("www.example.net. 0 IN A 192.0.2.1"), mustRR("www.example.net. 0 IN A 192.0.2.2")}, dns.RcodeSuccess},
+		{"RRset differs", []dns.RR{mustRR("www.example.net. 0 IN A 192.0.2.1")}, dns.RcodeNXRrset},
+		{"Outside of zone", []dns.RR{rrsetRR("www.example.com.", dns.TypeANY, dns.ClassANY)}, dns.RcodeNotZone},
+		{"Non-zero TTL", []dns.RR{mustRR("www.example.net. 60 IN A 192.0.2.1")}, dns.RcodeFormatError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.wantRcode, checkPrerequisites("example.net", records, tt.prereqs))
+		})
+	}
+}
@@ -233,6 +233,20 @@ func (d placementGroupDeleter) Delete(ctx context.Context, clientType request.Cl
 	return nil
 }
 
+type instanceTemplateDeleter struct{}
+
+// Delete deletes an instance template.
+func (d instanceTemplateDeleter) Delete(ctx context.Context, clientType request.ClientType, op *operations.Operation, s *state.State, ref entity.Reference) error {
+	name := ref.Name()
+
+	err := doInstanceTemplateDelete(ctx, s, name, ref.ProjectName)
+	if err != nil {
+		return fmt.Errorf("Failed deleting instance template %q: %w", name, err)
+	}
+
+	return nil
+}
+
 // getEntityDeleter returns a deleter implementation for the given entity type.
 func getEntityDeleter(t entity.Type) (entityDeleter, error) {
 	switch t {
@@ -254,6 +268,8 @@ func getEntityDeleter(t entity.Type) (entityDeleter, error) {
 		return profileDeleter{}, nil
 	case entity.TypePlacementGroup:
 		return placementGroupDeleter{}, nil
+	case entity.TypeInstanceTemplate:
+		return instanceTemplateDeleter{}, nil
 	default:
 		return nil, fmt.Errorf("Unsupported entity type %q", t)
 	}
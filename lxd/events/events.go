@@ -121,6 +121,21 @@ func (s *Server) AddListener(projectName string, allProjects bool, filter func(l
 	return listener, nil
 }
 
+// ListenerCount returns the number of connected event listeners.
+func (s *Server) ListenerCount() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	count := 0
+	for _, listener := range s.listeners {
+		if !listener.IsClosed() {
+			count++
+		}
+	}
+
+	return count
+}
+
 // SendLifecycle broadcasts a lifecycle event.
 func (s *Server) SendLifecycle(projectName string, event api.EventLifecycle) {
 	_ = s.Send(projectName, api.EventTypeLifecycle, event)
@@ -39,6 +39,13 @@ type ACLRule struct {
 	DestinationPort string
 	ICMPType        string
 	ICMPCode        string
+	CounterName     string // Name used to report the rule's hit counters (no counters if empty).
+}
+
+// ACLRuleCounters represents the hit counters of an ACL rule.
+type ACLRuleCounters struct {
+	Packets uint64
+	Bytes   uint64
 }
 
 // AddressForward represents a NAT address forward.
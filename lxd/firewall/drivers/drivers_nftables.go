@@ -93,10 +93,13 @@ func (d Nftables) Compat() (bool, error) {
 // nftGenericItem represents some common fields amongst the different nftables types.
 type nftGenericItem struct {
 	itemType string // Type of item (table, chain or rule). Populated by LXD.
-	Family   string `json:"family"` // Family of item (ip, ip6, bridge etc).
-	Table    string `json:"table"`  // Table the item belongs to (for chains and rules).
-	Chain    string `json:"chain"`  // Chain the item belongs to (for rules).
-	Name     string `json:"name"`   // Name of item (for tables and chains).
+	Family   string `json:"family"`  // Family of item (ip, ip6, bridge etc).
+	Table    string `json:"table"`   // Table the item belongs to (for chains and rules).
+	Chain    string `json:"chain"`   // Chain the item belongs to (for rules).
+	Name     string `json:"name"`    // Name of item (for tables and chains).
+	Comment  string `json:"comment"` // Comment of item (for rules).
+
+	Expr []map[string]json.RawMessage `json:"expr"` // Statements of item (for rules).
 }
 
 // nftParseRuleset parses the ruleset and returns the generic parts as a slice of items.
@@ -651,38 +654,144 @@ func (d Nftables) InstanceClearNetPrio(projectName string, instanceName string,
 	return nil
 }
 
-// NetworkApplyACLRules applies ACL rules to the existing firewall chains.
-func (d Nftables) NetworkApplyACLRules(networkName string, rules []ACLRule) error {
-	nftRules := make([]string, 0)
+// InstanceSetupACLRules sets up the ACL chains and applies the ACL rules for the specified instance device on
+// the host interface. It is safe to call this again to replace the existing rules.
+func (d Nftables) InstanceSetupACLRules(projectName string, instanceName string, deviceName string, hostName string, rules []ACLRule) error {
+	// The host interface acts as a single port network so the network ACL chains can be reused.
+	err := d.networkSetupACLChainAndJumpRules(hostName)
+	if err != nil {
+		return fmt.Errorf("Failed adding ACL chains for instance device %q: %w", d.instanceDeviceLabel(projectName, instanceName, deviceName), err)
+	}
+
+	err = d.NetworkApplyACLRules(hostName, rules)
+	if err != nil {
+		return fmt.Errorf("Failed applying ACL rules for instance device %q: %w", d.instanceDeviceLabel(projectName, instanceName, deviceName), err)
+	}
+
+	return nil
+}
+
+// InstanceSetupParentACLFilter sets up the netdev hooks on the parent interface that send the traffic of the
+// specified instance device (identified by its MAC address) through its ACL chains.
+func (d Nftables) InstanceSetupParentACLFilter(projectName string, instanceName string, deviceName string, parentName string, hwAddr string) error {
+	deviceLabel := d.instanceDeviceLabel(projectName, instanceName, deviceName)
+	tplFields := map[string]any{
+		"namespace":      nftablesNamespace,
+		"chainSeparator": nftablesChainSeparator,
+		"deviceLabel":    deviceLabel,
+		"parentName":     parentName,
+		"hwAddr":         hwAddr,
+		"family":         "netdev",
+	}
+
+	config := &strings.Builder{}
+	err := nftablesInstanceParentACLSetup.Execute(config, tplFields)
+	if err != nil {
+		return fmt.Errorf("Failed running %q template: %w", nftablesInstanceParentACLSetup.Name(), err)
+	}
+
+	err = shared.RunCommandWithFds(context.TODO(), strings.NewReader(config.String()), nil, "nft", "-f", "-")
+	if err != nil {
+		return fmt.Errorf("Failed adding ACL filter rules for instance device %q: %w", deviceLabel, err)
+	}
+
+	return nil
+}
+
+// InstanceApplyParentACLRules applies ACL rules to the existing ACL chains of the specified instance device set
+// up by InstanceSetupParentACLFilter. There is no connection tracking available at that stage, so the rules are
+// stateless and reject actions are turned into drop actions.
+func (d Nftables) InstanceApplyParentACLRules(projectName string, instanceName string, deviceName string, rules []ACLRule) error {
+	deviceLabel := d.instanceDeviceLabel(projectName, instanceName, deviceName)
+
+	ingressRules := make([]ACLRule, 0, len(rules))
+	egressRules := make([]ACLRule, 0, len(rules))
 	for _, rule := range rules {
-		// First try generating rules with IPv4 or IP agnostic criteria.
-		nftRule, partial, err := d.aclRuleCriteriaToRules(networkName, 4, &rule)
-		if err != nil {
-			return err
+		if rule.Action == "reject" {
+			rule.Action = "drop"
 		}
 
-		if nftRule != "" {
-			nftRules = append(nftRules, nftRule)
+		if rule.Direction == "ingress" {
+			ingressRules = append(ingressRules, rule)
+		} else {
+			egressRules = append(egressRules, rule)
 		}
+	}
 
-		if partial {
-			// If we couldn't fully generate the ruleset with only IPv4 or IP agnostic criteria, then
-			// fill in the remaining parts using IPv6 criteria.
-			nftRule, _, err = d.aclRuleCriteriaToRules(networkName, 6, &rule)
-			if err != nil {
-				return err
-			}
+	// The chains are per direction already, so no extra matching is needed.
+	noDirectionArgs := func(_ *ACLRule) []string { return nil }
 
-			if nftRule == "" {
-				return errors.New("Invalid empty rule generated")
-			}
+	nftIngressRules, err := d.aclRulesToNftRules(ingressRules, noDirectionArgs)
+	if err != nil {
+		return err
+	}
 
-			nftRules = append(nftRules, nftRule)
-		} else if nftRule == "" {
-			return errors.New("Invalid empty rule generated")
+	nftEgressRules, err := d.aclRulesToNftRules(egressRules, noDirectionArgs)
+	if err != nil {
+		return err
+	}
+
+	tplFields := map[string]any{
+		"namespace":      nftablesNamespace,
+		"chainSeparator": nftablesChainSeparator,
+		"deviceLabel":    deviceLabel,
+		"family":         "netdev",
+		"ingressRules":   nftIngressRules,
+		"egressRules":    nftEgressRules,
+	}
+
+	config := &strings.Builder{}
+	err = nftablesInstanceParentACLRules.Execute(config, tplFields)
+	if err != nil {
+		return fmt.Errorf("Failed running %q template: %w", nftablesInstanceParentACLRules.Name(), err)
+	}
+
+	err = shared.RunCommandWithFds(context.TODO(), strings.NewReader(config.String()), nil, "nft", "-f", "-")
+	if err != nil {
+		return fmt.Errorf("Failed applying ACL rules for instance device %q: %w", deviceLabel, err)
+	}
+
+	return nil
+}
+
+// InstanceClearACLRules removes the ACL chains for the specified instance device.
+func (d Nftables) InstanceClearACLRules(projectName string, instanceName string, deviceName string, hostName string) error {
+	deviceLabel := d.instanceDeviceLabel(projectName, instanceName, deviceName)
+
+	// Remove the hook chains before the chains they jump to.
+	err := d.removeChains([]string{"netdev"}, "acl"+nftablesChainSeparator+deviceLabel, "ingress", "egress")
+	if err != nil {
+		return fmt.Errorf("Failed clearing ACL rules for instance device %q: %w", deviceLabel, err)
+	}
+
+	err = d.removeChains([]string{"netdev"}, deviceLabel, "aclin", "aclout")
+	if err != nil {
+		return fmt.Errorf("Failed clearing ACL rules for instance device %q: %w", deviceLabel, err)
+	}
+
+	if hostName != "" {
+		err = d.removeChains([]string{"inet"}, hostName, "aclin", "aclout", "aclfwd", "acl")
+		if err != nil {
+			return fmt.Errorf("Failed clearing ACL rules for instance device %q: %w", deviceLabel, err)
 		}
 	}
 
+	return nil
+}
+
+// NetworkApplyACLRules applies ACL rules to the existing firewall chains.
+func (d Nftables) NetworkApplyACLRules(networkName string, rules []ACLRule) error {
+	nftRules, err := d.aclRulesToNftRules(rules, func(rule *ACLRule) []string {
+		if rule.Direction == "ingress" {
+			return []string{"oifname", networkName} // Coming from host into network's interface.
+		}
+
+		return []string{"iifname", networkName} // Coming from network's interface into host.
+	})
+	if err != nil {
+		return err
+	}
+
 	tplFields := map[string]any{
 		"namespace":      nftablesNamespace,
 		"chainSeparator": nftablesChainSeparator,
@@ -692,7 +801,7 @@ func (d Nftables) NetworkApplyACLRules(networkName string, rules []ACLRule) erro
 	}
 
 	config := &strings.Builder{}
-	err := nftablesNetACLRules.Execute(config, tplFields)
+	err = nftablesNetACLRules.Execute(config, tplFields)
 	if err != nil {
 		return fmt.Errorf("Failed running %q template: %w", nftablesNetACLRules.Name(), err)
 	}
@@ -705,16 +814,83 @@ func (d Nftables) NetworkApplyACLRules(networkName string, rules []ACLRule) erro
 	return nil
 }
 
-// aclRuleCriteriaToRules converts an ACL rule into 1 or more nftables rules.
-func (d Nftables) aclRuleCriteriaToRules(networkName string, ipVersion uint, rule *ACLRule) (string, bool, error) {
-	var args []string
+// NetworkACLRuleCounters returns the hit counters of the ACL rules that have a counter name, summed up across
+// all the networks and instance devices the rules are applied to.
+func (d Nftables) NetworkACLRuleCounters() (map[string]ACLRuleCounters, error) {
+	ruleset, err := d.nftParseRuleset()
+	if err != nil {
+		return nil, err
+	}
 
-	if rule.Direction == "ingress" {
-		args = append(args, "oifname", networkName) // Coming from host into network's interface.
-	} else {
-		args = append(args, "iifname", networkName) // Coming from network's interface into host.
+	counters := make(map[string]ACLRuleCounters)
+	for _, item := range ruleset {
+		if item.itemType != "rule" || item.Table != nftablesNamespace || item.Comment == "" {
+			continue
+		}
+
+		for _, expr := range item.Expr {
+			rawCounter, found := expr["counter"]
+			if !found {
+				continue
+			}
+
+			counter := ACLRuleCounters{}
+			err = json.Unmarshal(rawCounter, &counter)
+			if err != nil {
+				return nil, fmt.Errorf("Failed parsing counter of nftables rule %q: %w", item.Comment, err)
+			}
+
+			total := counters[item.Comment]
+			total.Packets += counter.Packets
+			total.Bytes += counter.Bytes
+			counters[item.Comment] = total
+		}
 	}
 
+	return counters, nil
+}
+
+// aclRulesToNftRules converts a list of ACL rules into nftables rules.
+// The directionArgs function returns the match arguments to prepend to each rule based on its direction.
+func (d Nftables) aclRulesToNftRules(rules []ACLRule, directionArgs func(rule *ACLRule) []string) ([]string, error) {
+	nftRules := make([]string, 0)
+	for _, rule := range rules {
+		// First try generating rules with IPv4 or IP agnostic criteria.
+		nftRule, partial, err := d.aclRuleCriteriaToRules(directionArgs(&rule), 4, &rule)
+		if err != nil {
+			return nil, err
+		}
+
+		if nftRule != "" {
+			nftRules = append(nftRules, nftRule)
+		}
+
+		if partial {
+			// If we couldn't fully generate the ruleset with only IPv4 or IP agnostic criteria, then
+			// fill in the remaining parts using IPv6 criteria.
+			nftRule, _, err = d.aclRuleCriteriaToRules(directionArgs(&rule), 6, &rule)
+			if err != nil {
+				return nil, err
+			}
+
+			if nftRule == "" {
+				return nil, errors.New("Invalid empty rule generated")
+			}
+
+			nftRules = append(nftRules, nftRule)
+		} else if nftRule == "" {
+			return nil, errors.New("Invalid empty rule generated")
+		}
+	}
+
+	return nftRules, nil
+}
+
+// aclRuleCriteriaToRules converts an ACL rule into 1 or more nftables rules.
+// The directionArgs are prepended to the generated rule.
+func (d Nftables) aclRuleCriteriaToRules(directionArgs []string, ipVersion uint, rule *ACLRule) (string, bool, error) {
+	args := slices.Clone(directionArgs)
+
 	// Add subject filters.
 	isPartialRule := false
 
@@ -801,6 +977,11 @@ func (d Nftables) aclRuleCriteriaToRules(networkName string, ipVersion uint, rul
 		}
 	}
 
+	// Handle counters.
+	if rule.CounterName != "" {
+		args = append(args, "counter")
+	}
+
 	// Handle logging.
 	if rule.Log {
 		args = append(args, "log")
@@ -819,6 +1000,11 @@ func (d Nftables) aclRuleCriteriaToRules(networkName string, ipVersion uint, rul
 
 	args = append(args, action)
 
+	// The comment identifies the rule when reading its counters.
+	if rule.CounterName != "" {
+		args = append(args, "comment", `"`+rule.CounterName+`"`)
+	}
+
 	return strings.Join(args, " "), isPartialRule, nil
 }
 
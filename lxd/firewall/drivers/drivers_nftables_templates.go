@@ -186,6 +186,58 @@ table {{.family}} {{.namespace}} {
 }
 `))
 
+// nftablesInstanceParentACLSetup defines the netdev hooks on the parent interface that send the traffic of an
+// instance device (identified by its MAC address) through its ACL chains.
+var nftablesInstanceParentACLSetup = template.Must(template.New("nftablesInstanceParentACLSetup").Parse(`
+add table {{.family}} {{.namespace}}
+add chain {{.family}} {{.namespace}} aclin{{.chainSeparator}}{{.deviceLabel}}
+add chain {{.family}} {{.namespace}} aclout{{.chainSeparator}}{{.deviceLabel}}
+add chain {{.family}} {{.namespace}} ingress{{.chainSeparator}}acl{{.chainSeparator}}{{.deviceLabel}} {type filter hook ingress device "{{.parentName}}" priority filter; policy accept;}
+add chain {{.family}} {{.namespace}} egress{{.chainSeparator}}acl{{.chainSeparator}}{{.deviceLabel}} {type filter hook egress device "{{.parentName}}" priority filter; policy accept;}
+flush chain {{.family}} {{.namespace}} ingress{{.chainSeparator}}acl{{.chainSeparator}}{{.deviceLabel}}
+flush chain {{.family}} {{.namespace}} egress{{.chainSeparator}}acl{{.chainSeparator}}{{.deviceLabel}}
+
+table {{.family}} {{.namespace}} {
+	chain ingress{{.chainSeparator}}acl{{.chainSeparator}}{{.deviceLabel}} {
+		ether daddr {{.hwAddr}} jump aclin{{.chainSeparator}}{{.deviceLabel}}
+	}
+
+	chain egress{{.chainSeparator}}acl{{.chainSeparator}}{{.deviceLabel}} {
+		ether saddr {{.hwAddr}} jump aclout{{.chainSeparator}}{{.deviceLabel}}
+	}
+}
+`))
+
+// nftablesInstanceParentACLRules defines the ACL rules for an instance device filtered on its parent interface.
+// There is no connection tracking available in the netdev family, so ARP, neighbour discovery and core ICMP are
+// always allowed to keep the instance reachable.
+var nftablesInstanceParentACLRules = template.Must(template.New("nftablesInstanceParentACLRules").Parse(`
+flush chain {{.family}} {{.namespace}} aclin{{.chainSeparator}}{{.deviceLabel}}
+flush chain {{.family}} {{.namespace}} aclout{{.chainSeparator}}{{.deviceLabel}}
+
+table {{.family}} {{.namespace}} {
+	chain aclin{{.chainSeparator}}{{.deviceLabel}} {
+		ether type arp accept
+		icmp type {3, 11, 12} accept
+		icmpv6 type {1, 2, 3, 4, 133, 134, 135, 136, 143} accept
+
+		{{- range .ingressRules}}
+		{{.}}
+		{{- end}}
+	}
+
+	chain aclout{{.chainSeparator}}{{.deviceLabel}} {
+		ether type arp accept
+		icmp type {3, 11, 12} accept
+		icmpv6 type {1, 2, 3, 4, 133, 135, 136, 143} accept
+
+		{{- range .egressRules}}
+		{{.}}
+		{{- end}}
+	}
+}
+`))
+
 // nftablesInstanceBridgeFilter defines the rules needed for MAC, IPv4 and IPv6 bridge security filtering.
 // To prevent instances from using IPs that are different from their assigned IPs we use ARP and NDP filtering
 // to prevent neighbour advertisements that are not allowed. However in order for DHCPv4 & DHCPv6 to work back to
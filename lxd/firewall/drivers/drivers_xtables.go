@@ -589,6 +589,66 @@ func (d Xtables) NetworkApplyACLRules(networkName string, rules []ACLRule) error
 	return nil
 }
 
+// NetworkACLRuleCounters returns the packet and byte counters of all ACL rules that have a counter name, keyed
+// by counter name. Counters of rules with the same name (such as the IPv4 and IPv6 variant) are summed.
+func (d Xtables) NetworkACLRuleCounters() (map[string]ACLRuleCounters, error) {
+	counters := make(map[string]ACLRuleCounters)
+
+	for _, cmd := range []string{"iptables-save", "ip6tables-save"} {
+		_, err := exec.LookPath(cmd)
+		if err != nil {
+			continue
+		}
+
+		output, err := shared.RunCommand(context.TODO(), cmd, "-c", "-t", "filter")
+		if err != nil {
+			return nil, fmt.Errorf("Failed getting rule counters using %q: %w", cmd, err)
+		}
+
+		for line := range strings.SplitSeq(output, "\n") {
+			// Counted rules look like: [<packets>:<bytes>] -A lxd_acl_<name> ... --comment <counter name> ...
+			if !strings.HasPrefix(line, "[") || !strings.Contains(line, "-A "+iptablesChainACLFilterPrefix+"_") {
+				continue
+			}
+
+			countersField, ruleFields, found := strings.Cut(line, " ")
+			if !found {
+				continue
+			}
+
+			packetsStr, bytesStr, found := strings.Cut(strings.Trim(countersField, "[]"), ":")
+			if !found {
+				continue
+			}
+
+			fields := strings.Fields(ruleFields)
+			idx := slices.Index(fields, "--comment")
+			if idx < 0 || idx+1 >= len(fields) {
+				continue
+			}
+
+			counterName := strings.Trim(fields[idx+1], `"`)
+
+			packets, err := strconv.ParseUint(packetsStr, 10, 64)
+			if err != nil {
+				continue
+			}
+
+			bytes, err := strconv.ParseUint(bytesStr, 10, 64)
+			if err != nil {
+				continue
+			}
+
+			ruleCounters := counters[counterName]
+			ruleCounters.Packets += packets
+			ruleCounters.Bytes += bytes
+			counters[counterName] = ruleCounters
+		}
+	}
+
+	return counters, nil
+}
+
 // aclRuleCriteriaToArgs converts an ACL rule into an set of arguments for an xtables rule.
 // Returns the arguments to use for the action command and separately the arguments for logging if enabled.
 // Returns nil arguments if the rule is not appropriate for the ipVersion.
@@ -692,7 +752,14 @@ func (d Xtables) aclRuleCriteriaToArgs(networkName string, ipVersion uint, rule
 		action = "accept"
 	}
 
-	actionArgs = append(args, "-j", strings.ToUpper(action))
+	actionArgs = slices.Clone(args)
+
+	// Tag the action rule so its hit counters can be found later.
+	if rule.CounterName != "" {
+		actionArgs = append(actionArgs, "-m", "comment", "--comment", rule.CounterName)
+	}
+
+	actionArgs = append(actionArgs, "-j", strings.ToUpper(action))
 
 	// Handle logging.
 	if rule.Log {
@@ -1437,6 +1504,50 @@ func (d Xtables) InstanceClearNetPrio(projectName string, instanceName string, d
 	return nil
 }
 
+// InstanceSetupACLRules sets up the ACL chains and applies the ACL rules for the specified instance device on
+// the host interface. It is safe to call this again to replace the existing rules.
+func (d Xtables) InstanceSetupACLRules(projectName string, instanceName string, deviceName string, hostName string, rules []ACLRule) error {
+	// The host interface acts as a single port network so the network ACL chains can be reused.
+	// Only add the jump rules if the chain doesn't exist yet, as they would otherwise be duplicated.
+	exists, _, err := d.iptablesChainExists(4, "filter", iptablesChainACLFilterPrefix+"_"+hostName)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		err = d.networkSetupACLFilteringChains(hostName)
+		if err != nil {
+			return fmt.Errorf("Failed adding ACL chains for instance device %q: %w", deviceName, err)
+		}
+	}
+
+	err = d.NetworkApplyACLRules(hostName, rules)
+	if err != nil {
+		return fmt.Errorf("Failed applying ACL rules for instance device %q: %w", deviceName, err)
+	}
+
+	return nil
+}
+
+// InstanceSetupParentACLFilter is not supported by the xtables driver.
+func (d Xtables) InstanceSetupParentACLFilter(projectName string, instanceName string, deviceName string, parentName string, hwAddr string) error {
+	return errors.New("Filtering ACL rules on the parent interface is not supported by the xtables firewall driver")
+}
+
+// InstanceApplyParentACLRules is not supported by the xtables driver.
+func (d Xtables) InstanceApplyParentACLRules(projectName string, instanceName string, deviceName string, rules []ACLRule) error {
+	return errors.New("Filtering ACL rules on the parent interface is not supported by the xtables firewall driver")
+}
+
+// InstanceClearACLRules removes the ACL chain and rules for the specified instance device.
+func (d Xtables) InstanceClearACLRules(projectName string, instanceName string, deviceName string, hostName string) error {
+	if hostName == "" {
+		return nil
+	}
+
+	return d.NetworkClear(hostName, false, []uint{4, 6})
+}
+
 // iptablesChainExists checks whether a chain exists in a table, and whether it has any rules.
 func (d Xtables) iptablesChainExists(ipVersion uint, table string, chain string) (exists, hasRules bool, err error) {
 	var cmd string
@@ -14,6 +14,7 @@ type Firewall interface {
 	NetworkSetup(networkName string, ip4Address net.IP, ip6Address net.IP, opts drivers.Opts) error
 	NetworkClear(networkName string, remove bool, ipVersions []uint) error
 	NetworkApplyACLRules(networkName string, rules []drivers.ACLRule) error
+	NetworkACLRuleCounters() (map[string]drivers.ACLRuleCounters, error)
 	NetworkApplyForwards(networkName string, rules []drivers.AddressForward) error
 
 	InstanceSetupBridgeFilter(projectName string, instanceName string, deviceName string, parentName string, hostName string, hwAddr string, IPv4Nets []*net.IPNet, IPv6Nets []*net.IPNet, parentManaged bool) error
@@ -27,4 +28,9 @@ type Firewall interface {
 
 	InstanceSetupNetPrio(projectName string, instanceName string, deviceName string, netPrio uint32) error
 	InstanceClearNetPrio(projectName string, instanceName string, deviceName string) error
+
+	InstanceSetupACLRules(projectName string, instanceName string, deviceName string, hostName string, rules []drivers.ACLRule) error
+	InstanceSetupParentACLFilter(projectName string, instanceName string, deviceName string, parentName string, hwAddr string) error
+	InstanceApplyParentACLRules(projectName string, instanceName string, deviceName string, rules []drivers.ACLRule) error
+	InstanceClearACLRules(projectName string, instanceName string, deviceName string, hostName string) error
 }
@@ -7,6 +7,8 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"maps"
+	"net"
 	"net/http"
 	"net/url"
 	"slices"
@@ -18,6 +20,7 @@ import (
 	"github.com/canonical/lxd/client"
 	"github.com/canonical/lxd/lxd/auth"
 	"github.com/canonical/lxd/lxd/auth/encryption"
+	"github.com/canonical/lxd/lxd/auth/ssh"
 	"github.com/canonical/lxd/lxd/certificate"
 	"github.com/canonical/lxd/lxd/cluster"
 	"github.com/canonical/lxd/lxd/db"
@@ -106,6 +109,32 @@ var bearerIdentitiesCmd = APIEndpoint{
 	},
 }
 
+var ldapIdentitiesCmd = APIEndpoint{
+	Name:        "identities",
+	Path:        "auth/identities/ldap",
+	MetricsType: entity.TypeIdentity,
+
+	Get: APIEndpointAction{
+		Handler:       identitiesGet(api.AuthenticationMethodLDAP),
+		AccessHandler: allowAuthenticated,
+	},
+}
+
+var sshIdentitiesCmd = APIEndpoint{
+	Name:        "identities",
+	Path:        "auth/identities/ssh",
+	MetricsType: entity.TypeIdentity,
+
+	Get: APIEndpointAction{
+		Handler:       identitiesGet(api.AuthenticationMethodSSH),
+		AccessHandler: allowAuthenticated,
+	},
+	Post: APIEndpointAction{
+		Handler:       identitiesSSHPost,
+		AccessHandler: allowPermission(entity.TypeServer, auth.EntitlementCanCreateIdentities),
+	},
+}
+
 var tlsIdentityCmd = APIEndpoint{
 	Name:        "identity",
 	Path:        "auth/identities/tls/{nameOrIdentifier}",
@@ -175,6 +204,52 @@ var bearerIdentityCmd = APIEndpoint{
 	},
 }
 
+var ldapIdentityCmd = APIEndpoint{
+	Name:        "identity",
+	Path:        "auth/identities/ldap/{nameOrIdentifier}",
+	MetricsType: entity.TypeIdentity,
+
+	Get: APIEndpointAction{
+		Handler:       identityGet,
+		AccessHandler: identityAccessHandler(api.AuthenticationMethodLDAP, auth.EntitlementCanView),
+	},
+	Put: APIEndpointAction{
+		Handler:       identityPut(api.AuthenticationMethodLDAP),
+		AccessHandler: identityAccessHandler(api.AuthenticationMethodLDAP, auth.EntitlementCanEdit),
+	},
+	Patch: APIEndpointAction{
+		Handler:       identityPatch(api.AuthenticationMethodLDAP),
+		AccessHandler: identityAccessHandler(api.AuthenticationMethodLDAP, auth.EntitlementCanEdit),
+	},
+	Delete: APIEndpointAction{
+		Handler:       identityDelete,
+		AccessHandler: identityAccessHandler(api.AuthenticationMethodLDAP, auth.EntitlementCanDelete),
+	},
+}
+
+var sshIdentityCmd = APIEndpoint{
+	Name:        "identity",
+	Path:        "auth/identities/ssh/{nameOrIdentifier}",
+	MetricsType: entity.TypeIdentity,
+
+	Get: APIEndpointAction{
+		Handler:       identityGet,
+		AccessHandler: identityAccessHandler(api.AuthenticationMethodSSH, auth.EntitlementCanView),
+	},
+	Put: APIEndpointAction{
+		Handler:       identityPut(api.AuthenticationMethodSSH),
+		AccessHandler: identityAccessHandler(api.AuthenticationMethodSSH, auth.EntitlementCanEdit),
+	},
+	Patch: APIEndpointAction{
+		Handler:       identityPatch(api.AuthenticationMethodSSH),
+		AccessHandler: identityAccessHandler(api.AuthenticationMethodSSH, auth.EntitlementCanEdit),
+	},
+	Delete: APIEndpointAction{
+		Handler:       identityDelete,
+		AccessHandler: identityAccessHandler(api.AuthenticationMethodSSH, auth.EntitlementCanDelete),
+	},
+}
+
 var bearerIdentityTokenCmd = APIEndpoint{
 	Path:        "auth/identities/bearer/{nameOrIdentifier}/token",
 	MetricsType: entity.TypeIdentity,
@@ -462,6 +537,91 @@ func identitiesBearerPost(d *Daemon, r *http.Request) response.Response {
 	return response.SyncResponseLocation(true, nil, lc.Source)
 }
 
+// swagger:operation POST /1.0/auth/identities/ssh identities identities_post_ssh
+//
+//	Add an SSH identity.
+//
+//	Creates a new SSH identity. The identity can log in by signing a challenge with one of its authorized keys.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: SSH identity
+//	    description: SSH Identity
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/IdentitiesSSHPost"
+//	responses:
+//	  "201":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func identitiesSSHPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	req := api.IdentitiesSSHPost{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = validateSSHUsername(req.Name)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	authorizedKeys, err := ssh.NormalizeAuthorizedKeys(req.SSHAuthorizedKeys)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	metadataJSON, err := json.Marshal(dbCluster.SSHMetadata{AuthorizedKeys: authorizedKeys})
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed encoding SSH metadata: %w", err))
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		// Create the identity.
+		id, err := query.Create(ctx, tx.Tx(), dbCluster.IdentitiesRow{
+			AuthMethod: api.AuthenticationMethodSSH,
+			Type:       api.IdentityTypeSSHUser,
+			Identifier: req.Name,
+			Name:       req.Name,
+			Metadata:   string(metadataJSON),
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(req.Groups) > 0 {
+			return dbCluster.SetIdentityAuthGroups(ctx, tx.Tx(), id, req.Groups)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Send lifecycle event for identity creation.
+	// No need to update the cache because SSH identities are not cached.
+	notify := newIdentityNotificationFunc(s, r, s.Endpoints.NetworkCert(), s.ServerCert())
+	lc, err := notify(lifecycle.IdentityCreated, api.AuthenticationMethodSSH, req.Name, false)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponseLocation(true, nil, lc.Source)
+}
+
 // swagger:operation POST /1.0/auth/identities/bearer/{nameOrID}/token identities identity_post_bearer_token
 //
 //	Issue a token for a bearer identity.
@@ -520,6 +680,13 @@ func identityBearerTokenPost(d *Daemon, r *http.Request) response.Response {
 		req.Expiry = "1d"
 	}
 
+	s := d.State()
+
+	tokenScope, err := validateBearerTokenScope(r.Context(), s, id.Type, req)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
 	expiry := req.Expiry
 	if expiry == "" {
 		expiry = defaultBearerTokenExpiry
@@ -530,8 +697,6 @@ func identityBearerTokenPost(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
-	s := d.State()
-
 	var secret []byte
 	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 		secret, err = dbCluster.RotateBearerIdentitySigningKey(ctx, tx.Tx(), id.ID)
@@ -556,7 +721,7 @@ func identityBearerTokenPost(d *Daemon, r *http.Request) response.Response {
 			return response.SmartError(fmt.Errorf("Failed parsing server certificate fingerprint: %w", err))
 		}
 
-		token, err = encryption.GetClientBearerToken(secret, id.Identifier, s.GlobalConfig.ClusterUUID(), expiresAt, serverCertFingerprint)
+		token, err = encryption.GetClientBearerToken(secret, id.Identifier, s.GlobalConfig.ClusterUUID(), expiresAt, serverCertFingerprint, tokenScope)
 	case api.IdentityTypeBearerTokenDevLXD:
 		token, err = encryption.GetDevLXDBearerToken(secret, id.Identifier, s.GlobalConfig.ClusterUUID(), expiresAt)
 	default:
@@ -1115,6 +1280,92 @@ func tlsIdentityTokenValidate(ctx context.Context, s *state.State, token api.Cer
 //	  "500":
 //	    $ref: "#/responses/InternalServerError"
 
+// swagger:operation GET /1.0/auth/identities/ldap identities identities_get_ldap
+//
+//	Get the LDAP identities
+//
+//	Returns a list of LDAP identities (URLs).
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of endpoints
+//	          items:
+//	            type: string
+//	          example: |-
+//	            [
+//	              "/1.0/auth/identities/ldap/jane.doe",
+//	              "/1.0/auth/identities/ldap/joe.bloggs"
+//	            ]
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+
+// swagger:operation GET /1.0/auth/identities/ssh identities identities_get_ssh
+//
+//	Get the SSH identities
+//
+//	Returns a list of SSH identities (URLs).
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of endpoints
+//	          items:
+//	            type: string
+//	          example: |-
+//	            [
+//	              "/1.0/auth/identities/ssh/jane.doe",
+//	              "/1.0/auth/identities/ssh/joe.bloggs"
+//	            ]
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+
 // swagger:operation GET /1.0/auth/identities/bearer?recursion=1 identities identities_get_bearer_recursion1
 //
 //	Get the bearer identities
@@ -1271,6 +1522,7 @@ func identitiesGet(authenticationMethod string) func(d *Daemon, r *http.Request)
 		var identities []dbCluster.IdentitiesRow
 		var identityURLs []string
 		var groupsByIdentityID map[int64][]string
+		var groupExpiriesByIdentityID map[int64]map[string]time.Time
 		var certificatesByIdentityID map[int64][]string
 		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 			var authMethodFilter *string
@@ -1306,6 +1558,11 @@ func identitiesGet(authenticationMethod string) func(d *Daemon, r *http.Request)
 				return err
 			}
 
+			groupExpiriesByIdentityID, err = dbCluster.GetIdentityAuthGroupExpiries(ctx, tx.Tx(), identityIDFilter)
+			if err != nil {
+				return err
+			}
+
 			return nil
 		})
 		if err != nil {
@@ -1320,7 +1577,7 @@ func identitiesGet(authenticationMethod string) func(d *Daemon, r *http.Request)
 		apiIdentities := make([]*api.Identity, 0, len(identities))
 		urlToIdentity := make(map[*api.URL]auth.EntitlementReporter, len(identities))
 		for _, id := range identities {
-			apiIdentity, err := id.ToAPI(groupsByIdentityID, certificatesByIdentityID)
+			apiIdentity, err := id.ToAPI(groupsByIdentityID, groupExpiriesByIdentityID, certificatesByIdentityID)
 			if err != nil {
 				return response.SmartError(err)
 			}
@@ -1340,11 +1597,11 @@ func identitiesGet(authenticationMethod string) func(d *Daemon, r *http.Request)
 	}
 }
 
-// swagger:operation GET /1.0/auth/identities/bearer/{nameOrIdentifier} identities identity_get_bearer
+// swagger:operation GET /1.0/auth/identities/ldap?recursion=1 identities identities_get_ldap_recursion1
 //
-//	Get the bearer identity
+//	Get the LDAP identities
 //
-//	Gets a specific bearer identity.
+//	Returns a list of LDAP identities.
 //
 //	---
 //	produces:
@@ -1369,17 +1626,20 @@ func identitiesGet(authenticationMethod string) func(d *Daemon, r *http.Request)
 //	          description: Status code
 //	          example: 200
 //	        metadata:
-//	          $ref: "#/definitions/Identity"
+//	          type: array
+//	          description: List of identities
+//	          items:
+//	            $ref: "#/definitions/Identity"
 //	  "403":
 //	    $ref: "#/responses/Forbidden"
 //	  "500":
 //	    $ref: "#/responses/InternalServerError"
 
-// swagger:operation GET /1.0/auth/identities/tls/{nameOrIdentifier} identities identity_get_tls
+// swagger:operation GET /1.0/auth/identities/ssh?recursion=1 identities identities_get_ssh_recursion1
 //
-//	Get the TLS identity
+//	Get the SSH identities
 //
-//	Gets a specific TLS identity.
+//	Returns a list of SSH identities.
 //
 //	---
 //	produces:
@@ -1404,17 +1664,20 @@ func identitiesGet(authenticationMethod string) func(d *Daemon, r *http.Request)
 //	          description: Status code
 //	          example: 200
 //	        metadata:
-//	          $ref: "#/definitions/Identity"
+//	          type: array
+//	          description: List of identities
+//	          items:
+//	            $ref: "#/definitions/Identity"
 //	  "403":
 //	    $ref: "#/responses/Forbidden"
 //	  "500":
 //	    $ref: "#/responses/InternalServerError"
 
-// swagger:operation GET /1.0/auth/identities/oidc/{nameOrIdentifier} identities identity_get_oidc
+// swagger:operation GET /1.0/auth/identities/bearer/{nameOrIdentifier} identities identity_get_bearer
 //
-//	Get the OIDC identity
+//	Get the bearer identity
 //
-//	Gets a specific OIDC identity.
+//	Gets a specific bearer identity.
 //
 //	---
 //	produces:
@@ -1444,36 +1707,112 @@ func identitiesGet(authenticationMethod string) func(d *Daemon, r *http.Request)
 //	    $ref: "#/responses/Forbidden"
 //	  "500":
 //	    $ref: "#/responses/InternalServerError"
-func identityGet(d *Daemon, r *http.Request) response.Response {
-	id, err := request.GetContextValue[*dbCluster.IdentitiesRow](r.Context(), ctxClusterDBIdentity)
-	if err != nil {
-		return response.SmartError(err)
-	}
-
-	withEntitlements, err := extractEntitlementsFromQuery(r, entity.TypeIdentity, false)
-	if err != nil {
-		return response.SmartError(err)
-	}
-
-	s := d.State()
-	canViewGroup, err := s.Authorizer.GetPermissionChecker(r.Context(), auth.EntitlementCanView, entity.TypeAuthGroup)
-	if err != nil {
-		return response.SmartError(err)
-	}
-
-	idType, err := identity.New(string(id.Type))
-	if err != nil {
-		return response.SmartError(fmt.Errorf("Invalid existing identity type: %w", err))
-	}
 
-	var groups map[int64][]string
-	var certificates map[int64][]string
-	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
-		var err error
-		groups, err = dbCluster.GetIdentityAuthGroupNames(ctx, tx.Tx(), &id.ID, func(row dbCluster.AuthGroupsRow) (bool, error) {
-			return canViewGroup(entity.AuthGroupURL(row.Name)), nil
-		})
-		if err != nil {
+// swagger:operation GET /1.0/auth/identities/tls/{nameOrIdentifier} identities identity_get_tls
+//
+//	Get the TLS identity
+//
+//	Gets a specific TLS identity.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/Identity"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+
+// swagger:operation GET /1.0/auth/identities/oidc/{nameOrIdentifier} identities identity_get_oidc
+//
+//	Get the OIDC identity
+//
+//	Gets a specific OIDC identity.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/Identity"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func identityGet(d *Daemon, r *http.Request) response.Response {
+	id, err := request.GetContextValue[*dbCluster.IdentitiesRow](r.Context(), ctxClusterDBIdentity)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	withEntitlements, err := extractEntitlementsFromQuery(r, entity.TypeIdentity, false)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	s := d.State()
+	canViewGroup, err := s.Authorizer.GetPermissionChecker(r.Context(), auth.EntitlementCanView, entity.TypeAuthGroup)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	idType, err := identity.New(string(id.Type))
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Invalid existing identity type: %w", err))
+	}
+
+	var groups map[int64][]string
+	var groupExpiries map[int64]map[string]time.Time
+	var certificates map[int64][]string
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+		groups, err = dbCluster.GetIdentityAuthGroupNames(ctx, tx.Tx(), &id.ID, func(row dbCluster.AuthGroupsRow) (bool, error) {
+			return canViewGroup(entity.AuthGroupURL(row.Name)), nil
+		})
+		if err != nil {
+			return err
+		}
+
+		groupExpiries, err = dbCluster.GetIdentityAuthGroupExpiries(ctx, tx.Tx(), &id.ID)
+		if err != nil {
 			return err
 		}
 
@@ -1490,7 +1829,7 @@ func identityGet(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
-	apiIdentity, err := id.ToAPI(groups, certificates)
+	apiIdentity, err := id.ToAPI(groups, groupExpiries, certificates)
 	if err != nil {
 		return response.SmartError(err)
 	}
@@ -1505,6 +1844,76 @@ func identityGet(d *Daemon, r *http.Request) response.Response {
 	return response.SyncResponseETag(true, apiIdentity, apiIdentity)
 }
 
+// swagger:operation GET /1.0/auth/identities/ldap/{nameOrIdentifier} identities identity_get_ldap
+//
+//	Get the LDAP identity
+//
+//	Gets a specific LDAP identity.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/Identity"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+
+// swagger:operation GET /1.0/auth/identities/ssh/{nameOrIdentifier} identities identity_get_ssh
+//
+//	Get the SSH identity
+//
+//	Gets a specific SSH identity.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/Identity"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+
 // swagger:operation GET /1.0/auth/identities/current identities identity_get_current
 //
 //	Get the current identity
@@ -1565,6 +1974,7 @@ func identityGetCurrent(d *Daemon, r *http.Request) response.Response {
 	var identityType identity.Type
 	var effectiveGroups []string
 	var permissions []dbCluster.Permission
+	var groupExpiries map[int64]map[string]time.Time
 	var certificates map[int64][]string
 	var entityURLs map[entity.Type]map[int]*api.URL
 	var id *dbCluster.IdentitiesRow
@@ -1586,6 +1996,11 @@ func identityGetCurrent(d *Daemon, r *http.Request) response.Response {
 			}
 		}
 
+		groupExpiries, err = dbCluster.GetIdentityAuthGroupExpiries(ctx, tx.Tx(), &id.ID)
+		if err != nil {
+			return err
+		}
+
 		effectiveGroups = requestor.CallerEffectiveAuthorizationGroupNames()
 		permissions, err = dbCluster.GetDistinctPermissionsByGroupNames(ctx, tx.Tx(), effectiveGroups)
 		if err != nil {
@@ -1603,7 +2018,7 @@ func identityGetCurrent(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
-	apiIdentity, err := id.ToAPI(map[int64][]string{id.ID: requestor.CallerAuthorizationGroupNames()}, certificates)
+	apiIdentity, err := id.ToAPI(map[int64][]string{id.ID: requestor.CallerAuthorizationGroupNames()}, groupExpiries, certificates)
 	if err != nil {
 		return response.SmartError(err)
 	}
@@ -1745,6 +2160,15 @@ func identityPut(authenticationMethod string) func(d *Daemon, r *http.Request) r
 			return response.BadRequest(fmt.Errorf("Cannot update certificate for identities of type %q", id.Type))
 		}
 
+		if identityPut.SSHAuthorizedKeys != nil && identityType.AuthenticationMethod() != api.AuthenticationMethodSSH {
+			return response.BadRequest(fmt.Errorf("Cannot update SSH authorized keys for identities of type %q", id.Type))
+		}
+
+		err = validateGroupExpiry(identityPut.GroupExpiry)
+		if err != nil {
+			return response.BadRequest(err)
+		}
+
 		err = s.Authorizer.CheckPermission(r.Context(), entity.IdentityURL(authenticationMethod, id.Identifier), auth.EntitlementCanEdit)
 		if err == nil {
 			return updateIdentityPrivileged(s, r, *id, identityPut)
@@ -1766,6 +2190,68 @@ func identityPut(authenticationMethod string) func(d *Daemon, r *http.Request) r
 	}
 }
 
+// swagger:operation PUT /1.0/auth/identities/ldap/{nameOrIdentifier} identities identity_put_ldap
+//
+//	Update the LDAP identity
+//
+//	Replaces the editable fields of an LDAP identity
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: identity
+//	    description: Update request
+//	    schema:
+//	      $ref: "#/definitions/IdentityPut"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "412":
+//	    $ref: "#/responses/PreconditionFailed"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+//	  "501":
+//	    $ref: "#/responses/NotImplemented"
+
+// swagger:operation PUT /1.0/auth/identities/ssh/{nameOrIdentifier} identities identity_put_ssh
+//
+//	Update the SSH identity
+//
+//	Replaces the editable fields of an SSH identity
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: identity
+//	    description: Update request
+//	    schema:
+//	      $ref: "#/definitions/IdentityPut"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "412":
+//	    $ref: "#/responses/PreconditionFailed"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+//	  "501":
+//	    $ref: "#/responses/NotImplemented"
+
 // updateSelfIdentityUnprivileged is only invoked when an identity of type api.IdentityTypeClientCertificate updates their
 // own identity and does not have permission to change their own groups.
 func updateSelfIdentityUnprivileged(s *state.State, r *http.Request, id dbCluster.IdentitiesRow, identityPut api.IdentityPut) response.Response {
@@ -1784,12 +2270,17 @@ func updateSelfIdentityUnprivileged(s *state.State, r *http.Request, id dbCluste
 			return err
 		}
 
+		groupExpiries, err := dbCluster.GetIdentityAuthGroupExpiries(ctx, tx.Tx(), &id.ID)
+		if err != nil {
+			return err
+		}
+
 		certs, err := dbCluster.GetIdentitiesPEMCertificates(ctx, tx.Tx(), &id.ID)
 		if err != nil {
 			return err
 		}
 
-		apiIdentity, err := id.ToAPI(groups, certs)
+		apiIdentity, err := id.ToAPI(groups, groupExpiries, certs)
 		if err != nil {
 			return err
 		}
@@ -1800,7 +2291,7 @@ func updateSelfIdentityUnprivileged(s *state.State, r *http.Request, id dbCluste
 		}
 
 		// Return an error if the caller tries to update their own groups.
-		if !slices.Equal(identityPut.Groups, apiIdentity.Groups) {
+		if !slices.Equal(identityPut.Groups, apiIdentity.Groups) || !maps.EqualFunc(identityPut.GroupExpiry, apiIdentity.GroupExpiry, time.Time.Equal) {
 			return api.NewStatusError(http.StatusForbidden, "Only the certificate may be changed")
 		}
 
@@ -1840,6 +2331,16 @@ func updateIdentityPrivileged(s *state.State, r *http.Request, id dbCluster.Iden
 		}
 	}
 
+	// Validate the authorized keys of SSH identities.
+	var authorizedKeys []string
+	if id.AuthMethod == api.AuthenticationMethodSSH {
+		var err error
+		authorizedKeys, err = ssh.NormalizeAuthorizedKeys(identityPut.SSHAuthorizedKeys)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
 	// Get a permission checker for use in the group filtering function below.
 	canViewGroup, err := s.Authorizer.GetPermissionChecker(r.Context(), auth.EntitlementCanView, entity.TypeAuthGroup)
 	if err != nil {
@@ -1865,12 +2366,17 @@ func updateIdentityPrivileged(s *state.State, r *http.Request, id dbCluster.Iden
 			return err
 		}
 
+		groupExpiries, err := dbCluster.GetIdentityAuthGroupExpiries(ctx, tx.Tx(), &id.ID)
+		if err != nil {
+			return err
+		}
+
 		certs, err := dbCluster.GetIdentitiesPEMCertificates(ctx, tx.Tx(), &id.ID)
 		if err != nil {
 			return err
 		}
 
-		apiIdentity, err := id.ToAPI(groups, certs)
+		apiIdentity, err := id.ToAPI(groups, groupExpiries, certs)
 		if err != nil {
 			return err
 		}
@@ -1886,6 +2392,28 @@ func updateIdentityPrivileged(s *state.State, r *http.Request, id dbCluster.Iden
 			return err
 		}
 
+		// Set the expiry of temporary group memberships, keeping the expiry of groups that remain if no expiry is
+		// given (e.g. by clients that are unaware of it).
+		groupExpiry := identityPut.GroupExpiry
+		if groupExpiry == nil {
+			groupExpiry = make(map[string]time.Time)
+			for groupName, expiry := range apiIdentity.GroupExpiry {
+				if slices.Contains(identityPut.Groups, groupName) {
+					groupExpiry[groupName] = expiry
+				}
+			}
+		}
+
+		err = dbCluster.SetIdentityAuthGroupExpiries(ctx, tx.Tx(), id.ID, groupExpiry)
+		if err != nil {
+			return err
+		}
+
+		// Replace the authorized keys of SSH identities.
+		if id.AuthMethod == api.AuthenticationMethodSSH {
+			return dbCluster.UpdateSSHIdentityAuthorizedKeys(ctx, tx.Tx(), id, authorizedKeys)
+		}
+
 		if identityPut.TLSCertificate == "" || fingerprint == id.Identifier {
 			return nil
 		}
@@ -2026,7 +2554,16 @@ func identityPatch(authenticationMethod string) func(d *Daemon, r *http.Request)
 			return response.BadRequest(fmt.Errorf("Cannot update certificate for identities of type %q", id.Type))
 		}
 
-		if len(identityPut.Groups) == 0 && identityPut.TLSCertificate == "" {
+		if identityPut.SSHAuthorizedKeys != nil && identityType.AuthenticationMethod() != api.AuthenticationMethodSSH {
+			return response.BadRequest(fmt.Errorf("Cannot update SSH authorized keys for identities of type %q", id.Type))
+		}
+
+		err = validateGroupExpiry(identityPut.GroupExpiry)
+		if err != nil {
+			return response.BadRequest(err)
+		}
+
+		if len(identityPut.Groups) == 0 && identityPut.GroupExpiry == nil && identityPut.TLSCertificate == "" && identityPut.SSHAuthorizedKeys == nil {
 			// Nothing to do
 			return response.EmptySyncResponse
 		}
@@ -2052,6 +2589,68 @@ func identityPatch(authenticationMethod string) func(d *Daemon, r *http.Request)
 	}
 }
 
+// swagger:operation PATCH /1.0/auth/identities/ldap/{nameOrIdentifier} identities identity_patch_ldap
+//
+//	Partially update the LDAP identity
+//
+//	Updates the editable fields of an LDAP identity
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: identity
+//	    description: Update request
+//	    schema:
+//	      $ref: "#/definitions/IdentityPut"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "412":
+//	    $ref: "#/responses/PreconditionFailed"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+//	  "501":
+//	    $ref: "#/responses/NotImplemented"
+
+// swagger:operation PATCH /1.0/auth/identities/ssh/{nameOrIdentifier} identities identity_patch_ssh
+//
+//	Partially update the SSH identity
+//
+//	Updates the editable fields of an SSH identity
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: identity
+//	    description: Update request
+//	    schema:
+//	      $ref: "#/definitions/IdentityPut"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "412":
+//	    $ref: "#/responses/PreconditionFailed"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+//	  "501":
+//	    $ref: "#/responses/NotImplemented"
+
 // patchIdentityPrivileged is invoked when the caller has `can_edit` on the identity. It must handle both OIDC and TLS identities.
 func patchIdentityPrivileged(s *state.State, r *http.Request, id dbCluster.IdentitiesRow, identityPut api.IdentityPut) response.Response {
 	// Parse the certificate if given.
@@ -2064,6 +2663,16 @@ func patchIdentityPrivileged(s *state.State, r *http.Request, id dbCluster.Ident
 		}
 	}
 
+	// Validate the authorized keys if given.
+	var authorizedKeys []string
+	if identityPut.SSHAuthorizedKeys != nil {
+		var err error
+		authorizedKeys, err = ssh.NormalizeAuthorizedKeys(identityPut.SSHAuthorizedKeys)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
 	// Get a permission checker for use in the group filtering function below.
 	canViewGroup, err := s.Authorizer.GetPermissionChecker(r.Context(), auth.EntitlementCanView, entity.TypeAuthGroup)
 	if err != nil {
@@ -2089,12 +2698,17 @@ func patchIdentityPrivileged(s *state.State, r *http.Request, id dbCluster.Ident
 			return err
 		}
 
+		groupExpiries, err := dbCluster.GetIdentityAuthGroupExpiries(ctx, tx.Tx(), &id.ID)
+		if err != nil {
+			return err
+		}
+
 		certs, err := dbCluster.GetIdentitiesPEMCertificates(ctx, tx.Tx(), &id.ID)
 		if err != nil {
 			return err
 		}
 
-		apiIdentity, err := id.ToAPI(groups, certs)
+		apiIdentity, err := id.ToAPI(groups, groupExpiries, certs)
 		if err != nil {
 			return err
 		}
@@ -2105,11 +2719,35 @@ func patchIdentityPrivileged(s *state.State, r *http.Request, id dbCluster.Ident
 		}
 
 		// Set groups if provided.
+		groupExpiry := identityPut.GroupExpiry
 		if len(identityPut.Groups) > 0 {
 			err = dbCluster.SetIdentityAuthGroups(ctx, tx.Tx(), id.ID, identityPut.Groups)
 			if err != nil {
 				return err
 			}
+
+			// Keep the expiry of the temporary memberships of groups that remain if no expiry is given.
+			if groupExpiry == nil {
+				groupExpiry = make(map[string]time.Time)
+				for groupName, expiry := range apiIdentity.GroupExpiry {
+					if slices.Contains(identityPut.Groups, groupName) {
+						groupExpiry[groupName] = expiry
+					}
+				}
+			}
+		}
+
+		// Set the expiry of temporary group memberships if groups or expiry dates are provided.
+		if groupExpiry != nil {
+			err = dbCluster.SetIdentityAuthGroupExpiries(ctx, tx.Tx(), id.ID, groupExpiry)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Only update the authorized keys if they are given.
+		if identityPut.SSHAuthorizedKeys != nil {
+			return dbCluster.UpdateSSHIdentityAuthorizedKeys(ctx, tx.Tx(), id, authorizedKeys)
 		}
 
 		// Only update the certificate if it is given. Additionally, we don't need to update it if it's the same as the
@@ -2137,7 +2775,7 @@ func patchIdentityPrivileged(s *state.State, r *http.Request, id dbCluster.Ident
 // patchSelfIdentityUnprivileged is only invoked when an identity of type api.IdentityTypeClientCertificate updates their
 // own identity and does not have permission to change their own groups.
 func patchSelfIdentityUnprivileged(s *state.State, r *http.Request, id dbCluster.IdentitiesRow, identityPut api.IdentityPut) response.Response {
-	if len(identityPut.Groups) > 0 {
+	if len(identityPut.Groups) > 0 || identityPut.GroupExpiry != nil {
 		return response.Forbidden(errors.New("Only the certificate may be changed"))
 	}
 
@@ -2167,12 +2805,17 @@ func patchSelfIdentityUnprivileged(s *state.State, r *http.Request, id dbCluster
 			return err
 		}
 
+		groupExpiries, err := dbCluster.GetIdentityAuthGroupExpiries(ctx, tx.Tx(), &id.ID)
+		if err != nil {
+			return err
+		}
+
 		certs, err := dbCluster.GetIdentitiesPEMCertificates(ctx, tx.Tx(), &id.ID)
 		if err != nil {
 			return err
 		}
 
-		apiIdentity, err := id.ToAPI(groups, certs)
+		apiIdentity, err := id.ToAPI(groups, groupExpiries, certs)
 		if err != nil {
 			return err
 		}
@@ -2293,6 +2936,48 @@ func identityDelete(d *Daemon, r *http.Request) response.Response {
 	return response.EmptySyncResponse
 }
 
+// swagger:operation DELETE /1.0/auth/identities/ldap/{nameOrIdentifier} identities identity_delete_ldap
+//
+//	Delete the LDAP identity
+//
+//	Removes the LDAP identity.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+//	  "501":
+//	    $ref: "#/responses/NotImplemented"
+
+// swagger:operation DELETE /1.0/auth/identities/ssh/{nameOrIdentifier} identities identity_delete_ssh
+//
+//	Delete the SSH identity
+//
+//	Removes the SSH identity.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+//	  "501":
+//	    $ref: "#/responses/NotImplemented"
+
 // newIdentityNotificationFunc returns a function that creates and sends a lifecycle event for the identity.
 // If updateCache is true, the local identity cache is updated and a notification is sent to other members to do the same.
 func newIdentityNotificationFunc(s *state.State, r *http.Request, networkCert *shared.CertInfo, serverCert *shared.CertInfo) identityNotificationFunc {
@@ -2323,6 +3008,75 @@ func newIdentityNotificationFunc(s *state.State, r *http.Request, networkCert *s
 	}
 }
 
+// validateGroupExpiry checks that the expiry dates of temporary group memberships are in the future.
+func validateGroupExpiry(groupExpiry map[string]time.Time) error {
+	now := time.Now()
+	for group, expiry := range groupExpiry {
+		if !expiry.After(now) {
+			return fmt.Errorf("Expiry of membership of group %q must be in the future", group)
+		}
+	}
+
+	return nil
+}
+
+// validateBearerTokenScope validates the scope requested when issuing a token for a bearer identity of the given type.
+// The entity references of the permissions are standardised so that they can be compared during permission checks.
+// It returns nil if no scope was requested.
+func validateBearerTokenScope(ctx context.Context, s *state.State, identityType dbCluster.IdentityType, req api.IdentityBearerTokenPost) (*auth.TokenScope, error) {
+	if len(req.Permissions) == 0 && len(req.AllowedCIDRs) == 0 {
+		return nil, nil
+	}
+
+	if identityType != api.IdentityTypeBearerTokenClient {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "Token scopes can only be set for identities of type %q", api.IdentityTypeBearerTokenClient)
+	}
+
+	for _, cidr := range req.AllowedCIDRs {
+		_, _, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, api.StatusErrorf(http.StatusBadRequest, "Invalid allowed CIDR %q: %w", cidr, err)
+		}
+	}
+
+	permissions := make([]api.Permission, 0, len(req.Permissions))
+	for _, permission := range req.Permissions {
+		u, err := url.Parse(permission.EntityReference)
+		if err != nil {
+			return nil, api.StatusErrorf(http.StatusBadRequest, "Failed parsing permission with entity reference %q and entitlement %q: %w", permission.EntityReference, permission.Entitlement, err)
+		}
+
+		entityType, projectName, location, pathArguments, err := entity.ParseURL(*u)
+		if err != nil {
+			return nil, api.StatusErrorf(http.StatusBadRequest, "Failed parsing permission with entity reference %q and entitlement %q: %w", permission.EntityReference, permission.Entitlement, err)
+		}
+
+		entityURL, err := entityType.URL(projectName, location, pathArguments...)
+		if err != nil {
+			return nil, api.StatusErrorf(http.StatusBadRequest, "Failed parsing permission with entity reference %q and entitlement %q: %w", permission.EntityReference, permission.Entitlement, err)
+		}
+
+		permission.EntityReference = entityURL.String()
+		if !slices.Contains(permissions, permission) {
+			permissions = append(permissions, permission)
+		}
+	}
+
+	// The permissions of a token scope are validated in the same way as the permissions of a group, including that
+	// the referenced entities exist.
+	if len(permissions) > 0 {
+		_, err := validatePermissions(ctx, s, permissions)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &auth.TokenScope{
+		Permissions:  permissions,
+		AllowedCIDRs: req.AllowedCIDRs,
+	}, nil
+}
+
 // validateIdentityCert validates the certificate and returns its fingerprint.
 func validateIdentityCert(networkCert *shared.CertInfo, cert string) (fingerprint string, err error) {
 	if cert == "" {
@@ -0,0 +1,31 @@
+package identity
+
+import (
+	"github.com/canonical/lxd/shared/api"
+)
+
+// LDAPUser represents an identity that authenticates with LDAP credentials, in exchange for a session token issued by LXD.
+// It supports fine-grained permissions but is not an admin by default.
+type LDAPUser struct {
+	typeInfoCommon
+}
+
+// AuthenticationMethod indicates that LDAP users authenticate using LDAP.
+func (LDAPUser) AuthenticationMethod() string {
+	return api.AuthenticationMethodLDAP
+}
+
+// Code returns the identity type code for this identity type.
+func (LDAPUser) Code() int64 {
+	return identityTypeLDAPUser
+}
+
+// IsFineGrained indicates that this identity uses fine-grained permissions.
+func (LDAPUser) IsFineGrained() bool {
+	return true
+}
+
+// Name returns the API name of this identity type.
+func (LDAPUser) Name() string {
+	return api.IdentityTypeLDAPUser
+}
@@ -0,0 +1,32 @@
+package identity
+
+import (
+	"github.com/canonical/lxd/shared/api"
+)
+
+// SSHUser represents an identity that authenticates by signing a challenge with an SSH key or certificate, in exchange for a
+// session token issued by LXD.
+// It supports fine-grained permissions but is not an admin by default.
+type SSHUser struct {
+	typeInfoCommon
+}
+
+// AuthenticationMethod indicates that SSH users authenticate using SSH keys.
+func (SSHUser) AuthenticationMethod() string {
+	return api.AuthenticationMethodSSH
+}
+
+// Code returns the identity type code for this identity type.
+func (SSHUser) Code() int64 {
+	return identityTypeSSHUser
+}
+
+// IsFineGrained indicates that this identity uses fine-grained permissions.
+func (SSHUser) IsFineGrained() bool {
+	return true
+}
+
+// Name returns the API name of this identity type.
+func (SSHUser) Name() string {
+	return api.IdentityTypeSSHUser
+}
@@ -83,6 +83,12 @@ const (
 
 	// identityTypeCertificateClusterLinkPending represents cluster links for which a token has been issued but who have not yet authenticated with a linked LXD cluster.
 	identityTypeCertificateClusterLinkPending int64 = 13
+
+	// identityTypeLDAPUser represents an identity that authenticates with LDAP credentials.
+	identityTypeLDAPUser int64 = 14
+
+	// identityTypeSSHUser represents an identity that authenticates with an SSH key or certificate.
+	identityTypeSSHUser int64 = 15
 )
 
 // types is a slice of all identity types that implement the [Type] interface.
@@ -100,6 +106,8 @@ var types = []Type{
 	TokenBearerDevLXD{},
 	TokenBearerClient{},
 	TokenBearerInitialUI{},
+	LDAPUser{},
+	SSHUser{},
 }
 
 var nameToType = make(map[string]Type, len(types))
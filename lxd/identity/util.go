@@ -10,7 +10,7 @@ import (
 // ValidateAuthenticationMethod returns an api.StatusError with http.StatusBadRequest if the given authentication
 // method is not recognised.
 func ValidateAuthenticationMethod(authenticationMethod string) error {
-	if !slices.Contains([]string{api.AuthenticationMethodTLS, api.AuthenticationMethodOIDC, api.AuthenticationMethodBearer}, authenticationMethod) {
+	if !slices.Contains([]string{api.AuthenticationMethodTLS, api.AuthenticationMethodOIDC, api.AuthenticationMethodBearer, api.AuthenticationMethodLDAP, api.AuthenticationMethodSSH}, authenticationMethod) {
 		return api.StatusErrorf(http.StatusBadRequest, "Unrecognized authentication method %q", authenticationMethod)
 	}
 
@@ -26,6 +26,7 @@ import (
 	"github.com/canonical/lxd/lxd/device/filters"
 	"github.com/canonical/lxd/lxd/device/nictype"
 	"github.com/canonical/lxd/lxd/instance"
+	"github.com/canonical/lxd/lxd/instance/healthcheck"
 	"github.com/canonical/lxd/lxd/instance/instancetype"
 	"github.com/canonical/lxd/lxd/instance/operationlock"
 	"github.com/canonical/lxd/lxd/lifecycle"
@@ -2325,3 +2326,207 @@ func (d *common) validateConfig(allUpdatedDeviceKeys []string, addDevices device
 
 	return nil
 }
+
+// autoRestartResetPeriod is how long the instance needs to stay up after an automatic restart for its restart
+// count to be reset.
+const autoRestartResetPeriod = 10 * time.Minute
+
+// autoRestartMaxDelay is the maximum delay to wait before automatically restarting an instance.
+const autoRestartMaxDelay = 5 * time.Minute
+
+// autoRestartPolicy returns the maximum number of consecutive automatic restarts (0 for unlimited) and the delay to
+// wait before the automatic restart following the given number of consecutive restarts.
+func autoRestartPolicy(config map[string]string, restartCount int64) (int64, time.Duration) {
+	maxRetries := int64(10)
+	if config["boot.autorestart.max_retries"] != "" {
+		maxRetries, _ = strconv.ParseInt(config["boot.autorestart.max_retries"], 10, 64)
+	}
+
+	delay := time.Second
+	if config["boot.autorestart.delay"] != "" {
+		delaySeconds, _ := strconv.ParseInt(config["boot.autorestart.delay"], 10, 64)
+		delay = time.Duration(delaySeconds) * time.Second
+	}
+
+	// Double the delay for every consecutive restart.
+	for range restartCount {
+		delay *= 2
+		if delay >= autoRestartMaxDelay {
+			delay = autoRestartMaxDelay
+			break
+		}
+	}
+
+	return maxRetries, delay
+}
+
+// autoRestartSchedule checks whether the instance should be automatically restarted following an unexpected stop.
+// If so, it records the pending restart in the instance's volatile config and starts the instance again in the
+// background once the stop operation has completed and the backoff delay has elapsed.
+// Returns true if a restart has been scheduled.
+func (d *common) autoRestartSchedule(op *operationlock.InstanceOperation) bool {
+	if shared.IsFalseOrEmpty(d.expandedConfig["boot.autorestart"]) {
+		return false
+	}
+
+	// Reset the restart count if the instance has been up for long enough since its last automatic restart.
+	restartCount, _ := strconv.ParseInt(d.localConfig["volatile.autorestart.count"], 10, 64)
+	lastRestart, err := time.Parse(time.RFC3339, d.localConfig["volatile.autorestart.last"])
+	if err != nil || time.Since(lastRestart) > autoRestartResetPeriod {
+		restartCount = 0
+	}
+
+	maxRetries, delay := autoRestartPolicy(d.expandedConfig, restartCount)
+	if maxRetries > 0 && restartCount >= maxRetries {
+		d.logger.Warn("Instance stopped unexpectedly too many times, not restarting it", logger.Ctx{"restarts": restartCount})
+		return false
+	}
+
+	restartCount++
+	restartAt := time.Now().Add(delay)
+	err = d.VolatileSet(map[string]string{
+		"volatile.autorestart.count":   strconv.FormatInt(restartCount, 10),
+		"volatile.autorestart.last":    restartAt.UTC().Format(time.RFC3339),
+		"volatile.autorestart.pending": "true",
+	})
+	if err != nil {
+		d.logger.Error("Failed recording automatic restart", logger.Ctx{"err": err})
+		return false
+	}
+
+	d.logger.Info("Instance stopped unexpectedly, scheduling restart", logger.Ctx{"restarts": restartCount, "delay": delay})
+
+	go autoRestart(d.state, d.project.Name, d.name, restartCount, restartAt, op)
+
+	return true
+}
+
+// AutoRestartResume resumes the pending automatic restart of the instance, if any.
+// It is used on startup to resume the restarts that were pending when LXD was stopped.
+func AutoRestartResume(s *state.State, inst instance.Instance) {
+	if shared.IsFalseOrEmpty(inst.LocalConfig()["volatile.autorestart.pending"]) {
+		return
+	}
+
+	restartCount, _ := strconv.ParseInt(inst.LocalConfig()["volatile.autorestart.count"], 10, 64)
+
+	// Restart immediately if the restart time is unknown.
+	restartAt, _ := time.Parse(time.RFC3339, inst.LocalConfig()["volatile.autorestart.last"])
+
+	go autoRestart(s, inst.Project().Name, inst.Name(), restartCount, restartAt, nil)
+}
+
+// autoRestart starts the instance once the given operation (if any) has completed and the restart time has been
+// reached. Failed starts are retried following the instance's backoff policy. The pending restart is cleared once the
+// instance has been started or the restart is abandoned, and is superseded by any start or stop requested in the
+// meantime.
+func autoRestart(s *state.State, projectName string, instanceName string, restartCount int64, restartAt time.Time, op *operationlock.InstanceOperation) {
+	l := logger.AddContext(logger.Ctx{"project": projectName, "instance": instanceName})
+
+	// Wait for the stop to be fully processed.
+	if op != nil {
+		err := op.Wait(s.ShutdownCtx)
+		if err != nil {
+			return
+		}
+	}
+
+	for {
+		// The pending restart is resumed on the next startup if LXD is stopped in the meantime.
+		select {
+		case <-time.After(time.Until(restartAt)):
+		case <-s.ShutdownCtx.Done():
+			return
+		}
+
+		inst, err := instance.LoadByProjectAndName(s, projectName, instanceName)
+		if err != nil {
+			l.Error("Failed loading instance for automatic restart", logger.Ctx{"err": err})
+			return
+		}
+
+		// Skip the restart if it has been superseded by a more recent one, or cancelled by a start or stop
+		// requested through LXD.
+		if inst.LocalConfig()["volatile.autorestart.count"] != strconv.FormatInt(restartCount, 10) || shared.IsFalseOrEmpty(inst.LocalConfig()["volatile.autorestart.pending"]) {
+			return
+		}
+
+		// Abandon the restart if it's no longer wanted or the instance was started in the meantime.
+		if shared.IsFalseOrEmpty(inst.ExpandedConfig()["boot.autorestart"]) || inst.IsRunning() {
+			autoRestartAbandon(inst, l)
+			return
+		}
+
+		// Starting the instance clears the pending restart.
+		err = inst.Start(context.Background(), nil, false)
+		if err == nil {
+			s.Events.SendLifecycle(projectName, lifecycle.InstanceAutoRestarted.Event(context.Background(), inst, map[string]any{"restarts": restartCount}))
+			return
+		}
+
+		l.Error("Failed automatically restarting instance", logger.Ctx{"restarts": restartCount, "err": err})
+
+		// Retry following the backoff policy.
+		maxRetries, delay := autoRestartPolicy(inst.ExpandedConfig(), restartCount)
+		if maxRetries > 0 && restartCount >= maxRetries {
+			l.Warn("Instance failed to restart too many times, not restarting it", logger.Ctx{"restarts": restartCount})
+			autoRestartAbandon(inst, l)
+			return
+		}
+
+		restartCount++
+		restartAt = time.Now().Add(delay)
+		err = inst.VolatileSet(map[string]string{
+			"volatile.autorestart.count":   strconv.FormatInt(restartCount, 10),
+			"volatile.autorestart.last":    restartAt.UTC().Format(time.RFC3339),
+			"volatile.autorestart.pending": "true",
+		})
+		if err != nil {
+			l.Error("Failed recording automatic restart", logger.Ctx{"err": err})
+			return
+		}
+	}
+}
+
+// autoRestartCancel cancels the pending automatic restart of the instance, if any.
+// It is called when the instance is started or stopped through LXD, as this supersedes the automatic restart.
+func (d *common) autoRestartCancel() {
+	if shared.IsFalseOrEmpty(d.localConfig["volatile.autorestart.pending"]) {
+		return
+	}
+
+	err := d.VolatileSet(map[string]string{"volatile.autorestart.pending": ""})
+	if err != nil {
+		d.logger.Warn("Failed cancelling pending automatic restart", logger.Ctx{"err": err})
+	}
+}
+
+// autoRestartAbandon clears the pending automatic restart of the instance.
+// Stopped ephemeral instances, which were kept for the restart, are deleted.
+func autoRestartAbandon(inst instance.Instance, l logger.Logger) {
+	if inst.IsEphemeral() && !inst.IsRunning() {
+		err := inst.Delete(context.Background(), true, "", nil)
+		if err != nil {
+			l.Error("Failed deleting ephemeral instance", logger.Ctx{"err": err})
+		}
+
+		return
+	}
+
+	err := inst.VolatileSet(map[string]string{"volatile.autorestart.pending": ""})
+	if err != nil {
+		l.Warn("Failed clearing pending automatic restart", logger.Ctx{"err": err})
+	}
+}
+
+// healthState returns the health of the running instance with the given init PID.
+// Returns nil if no health check is configured.
+func (d *common) healthState(pid int) *api.InstanceStateHealth {
+	if d.expandedConfig["healthcheck.type"] == "" {
+		return nil
+	}
+
+	health := healthcheck.Get(d.project.Name, d.name, pid)
+
+	return &health
+}
@@ -22,6 +22,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -57,6 +58,7 @@ import (
 	"github.com/canonical/lxd/lxd/operations"
 	"github.com/canonical/lxd/lxd/project"
 	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/lxd/rsync"
 	"github.com/canonical/lxd/lxd/seccomp"
 	"github.com/canonical/lxd/lxd/state"
 	storagePools "github.com/canonical/lxd/lxd/storage"
@@ -2257,6 +2259,8 @@ func (d *lxc) Start(ctx context.Context, progressReporter ioprogress.ProgressRep
 	d.logger.Debug("Start started", logger.Ctx{"stateful": stateful})
 	defer d.logger.Debug("Start finished", logger.Ctx{"stateful": stateful})
 
+	d.autoRestartCancel()
+
 	// Check that we are startable before creating an operation lock.
 	// Must happen before creating operation Start lock to avoid the status check returning Stopped due to the
 	// existence of a Start operation lock.
@@ -2582,6 +2586,8 @@ func (d *lxc) Stop(ctx context.Context, stateful bool) error {
 	d.logger.Debug("Stop started", logger.Ctx{"stateful": stateful})
 	defer d.logger.Debug("Stop finished", logger.Ctx{"stateful": stateful})
 
+	d.autoRestartCancel()
+
 	// Must be run prior to creating the operation lock.
 	if !d.IsRunning() {
 		return ErrInstanceIsStopped
@@ -2716,6 +2722,8 @@ func (d *lxc) Shutdown(ctx context.Context, timeout time.Duration) error {
 	d.logger.Debug("Shutdown started", logger.Ctx{"timeout": timeout})
 	defer d.logger.Debug("Shutdown finished", logger.Ctx{"timeout": timeout})
 
+	d.autoRestartCancel()
+
 	// Must be run prior to creating the operation lock.
 	statusCode := d.statusCode()
 	if !d.isRunningStatusCode(statusCode) {
@@ -2966,6 +2974,9 @@ func (d *lxc) onStop(ctx context.Context, args map[string]string) error {
 			d.state.Events.SendLifecycle(d.project.Name, lifecycle.InstanceShutdown.Event(ctx, d, nil))
 		}
 
+		// Restart the container if it stopped unexpectedly and automatic restarts are enabled.
+		autoRestart := target == "stop" && op.GetInstanceInitiated() && d.autoRestartSchedule(op)
+
 		// Reboot the container
 		if target == "reboot" {
 			// Start the container again
@@ -2983,7 +2994,7 @@ func (d *lxc) onStop(ctx context.Context, args map[string]string) error {
 		}
 
 		// Destroy ephemeral containers
-		if d.ephemeral {
+		if d.ephemeral && !autoRestart {
 			err = d.delete(ctx, true)
 			if err != nil {
 				op.Done(fmt.Errorf("Failed deleting ephemeral instance: %w", err))
@@ -3311,6 +3322,8 @@ func (d *lxc) renderState(statusCode api.StatusCode, hostInterfaces []net.Interf
 		StatusCode: statusCode,
 	}
 
+	status.AutoRestarts, _ = strconv.ParseInt(d.localConfig["volatile.autorestart.count"], 10, 64)
+
 	pid := d.InitPID()
 	processesState, _ := d.processesState(pid)
 
@@ -3318,6 +3331,7 @@ func (d *lxc) renderState(statusCode api.StatusCode, hostInterfaces []net.Interf
 		// CPU and Memory are always included (can't be nil)
 		status.CPU = d.cpuState()
 		status.Memory = d.memoryState()
+		status.Health = d.healthState(pid)
 
 		// Network - conditionally fetch
 		if options.IncludeNetwork {
@@ -4738,8 +4752,8 @@ func (d *lxc) MigrateSend(ctx context.Context, args instance.MigrateSendArgs, pr
 		return err
 	}
 
-	if args.Live {
-		err := api.StatusErrorf(http.StatusBadRequest, "Live migration is not supported for containers")
+	if args.Live && !d.state.OS.CRIU {
+		err := migration.ErrNoLiveMigrationSource
 		op.Done(err)
 		return err
 	}
@@ -4755,6 +4769,16 @@ func (d *lxc) MigrateSend(ctx context.Context, args instance.MigrateSendArgs, pr
 	// The migration source/sender doesn't need to care whether or not it's doing a refresh as the migration
 	// sink/receiver will know this, and adjust the migration types accordingly.
 	poolMigrationTypes := pool.MigrationTypes(storagePools.InstanceContentType(d), false, args.Snapshots)
+
+	// Live migrations transfer the root filesystem while the container is running and perform a final sync
+	// once it has been stopped by the final dump, which only the rsync based migration types support.
+	// Remote cluster moves are the exception as the root filesystem doesn't need transferring at all.
+	if args.Live && (args.ClusterMoveSourceName == "" || !pool.Driver().Info().Remote) {
+		poolMigrationTypes = slices.DeleteFunc(poolMigrationTypes, func(migrationType migration.Type) bool {
+			return !slices.Contains([]migration.MigrationFSType{migration.MigrationFSType_RSYNC, migration.MigrationFSType_BLOCK_AND_RSYNC, migration.MigrationFSType_RBD_AND_RSYNC}, migrationType.FSType)
+		})
+	}
+
 	if len(poolMigrationTypes) == 0 {
 		err := errors.New("No source migration types available")
 		op.Done(err)
@@ -4808,6 +4832,13 @@ func (d *lxc) MigrateSend(ctx context.Context, args instance.MigrateSendArgs, pr
 		}
 	}
 
+	// Offer to transfer the container state using CRIU, pre-copying the memory if incremental memory
+	// transfer is enabled.
+	if args.Live {
+		offerHeader.Criu = migration.CRIUType_CRIU_RSYNC.Enum()
+		offerHeader.Predump = new(d.migrationPreDumpEnabled())
+	}
+
 	// Send offer to target.
 	d.logger.Debug("Sending migration offer to target")
 	err = args.ControlSend(offerHeader)
@@ -4869,7 +4900,8 @@ func (d *lxc) MigrateSend(ctx context.Context, args instance.MigrateSendArgs, pr
 		}
 	}
 
-	// For containers, only stateless migration is currently supported; do not use CRIU signals.
+	// For live migration the container keeps running while its root filesystem is first transferred, and a
+	// final sync is performed once CRIU has checkpointed it and it has been frozen.
 	instanceRunning := args.Live
 	nonOptimizedMigration := volSourceArgs.MigrationType.FSType == migration.MigrationFSType_RSYNC || slices.Contains([]migration.MigrationFSType{migration.MigrationFSType_BLOCK_AND_RSYNC, migration.MigrationFSType_RBD_AND_RSYNC}, volSourceArgs.MigrationType.FSType)
 	if instanceRunning && nonOptimizedMigration {
@@ -4877,6 +4909,38 @@ func (d *lxc) MigrateSend(ctx context.Context, args instance.MigrateSendArgs, pr
 		volSourceArgs.MultiSync = true
 	}
 
+	// Wait for the state transfer connection if the target accepted the CRIU state transfer.
+	var stateConn io.ReadWriteCloser
+	if args.Live {
+		if respHeader.Criu == nil || *respHeader.Criu != migration.CRIUType_CRIU_RSYNC {
+			err := migration.ErrNoLiveMigrationTarget
+			op.Done(err)
+			return err
+		}
+
+		stateConn, err = args.StateConn(connectionsCtx)
+		if err != nil {
+			op.Done(err)
+			return err
+		}
+	}
+
+	// Setup a temporary directory for the CRIU images.
+	var checkpointDir string
+	if args.Live {
+		checkpointDir, err = os.MkdirTemp("", "lxd_checkpoint_")
+		if err != nil {
+			op.Done(err)
+			return err
+		}
+
+		defer func() { _ = os.RemoveAll(checkpointDir) }()
+	}
+
+	// Records whether the container has been frozen for the final dump, in which case it needs stopping once
+	// migrated or unfreezing on failure.
+	var dumped atomic.Bool
+
 	g, ctx := errgroup.WithContext(context.Background())
 
 	// Start control connection monitor.
@@ -4923,14 +4987,42 @@ func (d *lxc) MigrateSend(ctx context.Context, args instance.MigrateSendArgs, pr
 
 		var err error
 
-		d.logger.Debug("Starting storage migration phase")
+		// Live migrations transfer the root filesystem before the final CRIU dump, except for remote cluster
+		// moves whose volume is only handed over once the container has been stopped by the final dump.
+		if !args.Live || volSourceArgs.MultiSync {
+			d.logger.Debug("Starting storage migration phase")
 
-		err = pool.MigrateInstance(ctx, d, filesystemConn, volSourceArgs, progressReporter)
-		if err != nil {
-			return err
+			err = pool.MigrateInstance(ctx, d, filesystemConn, volSourceArgs, progressReporter)
+			if err != nil {
+				return err
+			}
+
+			d.logger.Debug("Finished storage migration phase")
 		}
 
-		d.logger.Debug("Finished storage migration phase")
+		if args.Live {
+			d.logger.Debug("Starting state migration phase")
+
+			rsyncBwlimit := pool.Driver().Config()["rsync.bwlimit"]
+			err = d.migrateSendState(stateConn, checkpointDir, offerHeader.GetPredump() && respHeader.GetPredump(), respHeader.GetRsyncFeaturesSlice(), rsyncBwlimit, &dumped)
+			if err != nil {
+				return err
+			}
+
+			d.logger.Debug("Finished state migration phase")
+
+			// Remote cluster moves hand over the root volume once the container is frozen.
+			if !volSourceArgs.MultiSync {
+				d.logger.Debug("Starting storage migration phase")
+
+				err = pool.MigrateInstance(ctx, d, filesystemConn, volSourceArgs, progressReporter)
+				if err != nil {
+					return err
+				}
+
+				d.logger.Debug("Finished storage migration phase")
+			}
+		}
 
 		// Perform final sync if in multi sync mode.
 		if volSourceArgs.MultiSync {
@@ -4957,6 +5049,15 @@ func (d *lxc) MigrateSend(ctx context.Context, args instance.MigrateSendArgs, pr
 		// Wait for routines to finish and collect first error.
 		err := g.Wait()
 		if err == nil {
+			// The container now runs on the target, so stop the frozen source container.
+			// The stop inherits the migration operation and completes it once the container is stopped.
+			if dumped.Load() {
+				stopErr := d.Stop(context.Background(), false)
+				if stopErr != nil {
+					d.logger.Error("Failed stopping container on source after live migration", logger.Ctx{"err": stopErr})
+				}
+			}
+
 			postMigrateSendErr := d.postMigrateSendCommon(d, args.ClusterMoveSourceName)
 			if postMigrateSendErr != nil {
 				d.logger.Error("Post-migration steps failed on source", logger.Ctx{"err": postMigrateSendErr})
@@ -4964,6 +5065,16 @@ func (d *lxc) MigrateSend(ctx context.Context, args instance.MigrateSendArgs, pr
 		}
 
 		if err != nil {
+			// The memory pages of the final dump were sent to the target, so resume the frozen container.
+			if dumped.Load() {
+				d.logger.Warn("Resuming container on source after failed live migration", logger.Ctx{"err": err})
+
+				unfreezeErr := d.Unfreeze(context.Background())
+				if unfreezeErr != nil {
+					d.logger.Error("Failed resuming container on source after failed live migration", logger.Ctx{"err": unfreezeErr})
+				}
+			}
+
 			op.Done(err)
 			return err
 		}
@@ -4974,6 +5085,159 @@ func (d *lxc) MigrateSend(ctx context.Context, args instance.MigrateSendArgs, pr
 	}
 }
 
+// migrationPreDumpEnabled returns whether the container memory should be pre-copied during live migration.
+// This requires migration.incremental.memory to be enabled and CRIU to support dirty memory tracking.
+func (d *lxc) migrationPreDumpEnabled() bool {
+	if shared.IsFalseOrEmpty(d.expandedConfig["migration.incremental.memory"]) || !d.state.OS.CRIUMemoryTracking {
+		return false
+	}
+
+	err := d.migrate(&instance.CriuMigrationArgs{
+		Cmd:      liblxc.MIGRATE_FEATURE_CHECK,
+		Function: "feature-check",
+		Features: liblxc.FEATURE_MEM_TRACK,
+	})
+	if err != nil {
+		d.logger.Warn("CRIU memory tracking not supported for container, disabling memory pre-copy", logger.Ctx{"err": err})
+		return false
+	}
+
+	return true
+}
+
+// migrateSendState transfers the container state to the target using CRIU.
+// The memory pages of each dump are streamed over the state connection to a CRIU page server on the target,
+// and the remaining CRIU images are then sent with rsync.
+// When preDump is enabled, the memory is pre-copied over several pre-dump rounds while the container keeps
+// running, until either migration.incremental.memory.goal percent of the memory was unchanged since the
+// previous round or migration.incremental.memory.iterations rounds have been done. The final dump is done with
+// the container frozen, so that it can be resumed if the migration fails, and only the memory modified since the
+// last round needs to be transferred.
+func (d *lxc) migrateSendState(stateConn io.ReadWriteCloser, checkpointDir string, preDump bool, rsyncFeatures []string, rsyncBwlimit string, dumped *atomic.Bool) error {
+	rsyncFeatures = criuRsyncFeatures(rsyncFeatures)
+
+	var preDumpDir string
+	if preDump {
+		maxIterations := 10
+		if d.expandedConfig["migration.incremental.memory.iterations"] != "" {
+			maxIterations, _ = strconv.Atoi(d.expandedConfig["migration.incremental.memory.iterations"])
+		}
+
+		goal := 70
+		if d.expandedConfig["migration.incremental.memory.goal"] != "" {
+			goal, _ = strconv.Atoi(d.expandedConfig["migration.incremental.memory.goal"])
+		}
+
+		for i := range maxIterations {
+			dumpDir := strconv.Itoa(i)
+
+			err := d.migrateDumpToPageServer(stateConn, &instance.CriuMigrationArgs{
+				Cmd:        liblxc.MIGRATE_PRE_DUMP,
+				StateDir:   checkpointDir,
+				Function:   "migration",
+				DumpDir:    dumpDir,
+				PreDumpDir: preDumpDir,
+			})
+			if err != nil {
+				return err
+			}
+
+			// Stop pre-copying once enough of the memory is unchanged since the previous round.
+			final := i == maxIterations-1
+			stats, err := migration.ReadCRIUDumpStats(filepath.Join(checkpointDir, dumpDir))
+			if err != nil {
+				d.logger.Warn("Failed reading CRIU pre-dump statistics", logger.Ctx{"err": err})
+				final = true
+			} else {
+				d.logger.Debug("Memory pre-dump round finished", logger.Ctx{"round": i + 1, "pagesWritten": stats.PagesWritten, "pagesSkipped": stats.PagesSkippedParent})
+				if preDumpDir != "" && stats.SkippedPercentage() >= goal {
+					final = true
+				}
+			}
+
+			err = rsync.Send(d.Name(), filepath.Join(checkpointDir, dumpDir), stateConn, nil, rsyncFeatures, rsyncBwlimit, d.state.OS.ExecPath)
+			if err != nil {
+				return err
+			}
+
+			err = migration.ProtoSendStream(stateConn, &migration.MigrationSync{FinalPreDump: new(final)})
+			if err != nil {
+				return fmt.Errorf("Failed sending pre-dump sync to target: %w", err)
+			}
+
+			preDumpDir = "../" + dumpDir
+			if final {
+				break
+			}
+		}
+	}
+
+	// Freeze the container for the final dump, CRIU leaves it frozen.
+	err := d.Freeze(context.Background())
+	if err != nil {
+		return fmt.Errorf("Failed freezing container for final dump: %w", err)
+	}
+
+	if !d.IsFrozen() {
+		return errors.New("Live migration requires the freezer cgroup")
+	}
+
+	dumped.Store(true)
+
+	// Perform the final dump.
+	err = d.migrateDumpToPageServer(stateConn, &instance.CriuMigrationArgs{
+		Cmd:        liblxc.MIGRATE_DUMP,
+		StateDir:   checkpointDir,
+		Function:   "migration",
+		DumpDir:    "final",
+		PreDumpDir: preDumpDir,
+	})
+	if err != nil {
+		return err
+	}
+
+	return rsync.Send(d.Name(), filepath.Join(checkpointDir, "final"), stateConn, nil, rsyncFeatures, rsyncBwlimit, d.state.OS.ExecPath)
+}
+
+// migrateDumpToPageServer performs a CRIU dump or pre-dump whose memory pages are sent to the CRIU page server
+// of the target through the state connection.
+func (d *lxc) migrateDumpToPageServer(stateConn io.ReadWriteCloser, args *instance.CriuMigrationArgs) error {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fmt.Errorf("Failed listening for CRIU page server connection: %w", err)
+	}
+
+	proxyDone := make(chan error, 1)
+	go func() {
+		conn, err := migration.AcceptCRIUPageServerConn(listener)
+		if err != nil {
+			proxyDone <- fmt.Errorf("Failed accepting CRIU page server connection: %w", err)
+			return
+		}
+
+		defer func() { _ = conn.Close() }()
+
+		proxyDone <- migration.CRIUPageServerProxy(conn, stateConn)
+	}()
+
+	args.PageServer = listener.Addr().String()
+	err = d.migrate(args)
+	_ = listener.Close()
+	if err != nil {
+		return err
+	}
+
+	return <-proxyDone
+}
+
+// criuRsyncFeatures returns the rsync features used to transfer CRIU images. Deletion is disabled as the memory
+// pages were already received by the CRIU page server of the target.
+func criuRsyncFeatures(rsyncFeatures []string) []string {
+	return slices.DeleteFunc(slices.Clone(rsyncFeatures), func(feature string) bool {
+		return feature == "delete"
+	})
+}
+
 func (d *lxc) resetContainerDiskIdmap(srcIdmap *idmap.IdmapSet) error {
 	dstIdmap, err := d.DiskIdmap()
 	if err != nil {
@@ -5031,15 +5295,20 @@ func (d *lxc) MigrateReceive(ctx context.Context, args instance.MigrateReceiveAr
 		return fmt.Errorf("Failed receiving migration offer from source: %w", err)
 	}
 
-	// Reject live migration attempts.
+	// Live migration transfers the container state using CRIU.
 	// Older LXD versions (5.21 and earlier) send Criu=NONE for stateless migrations of running
 	// containers, so we must allow NONE for backward compatibility while rejecting other CRIU types.
-	if args.Live || (offerHeader.Criu != nil && *offerHeader.Criu != migration.CRIUType_NONE) {
-		return api.StatusErrorf(http.StatusBadRequest, "Live migration is not supported for containers")
-	}
+	useCRIU := offerHeader.Criu != nil && *offerHeader.Criu == migration.CRIUType_CRIU_RSYNC
+	if args.Live {
+		if !useCRIU {
+			return api.StatusErrorf(http.StatusBadRequest, "Source server doesn't support container live migration")
+		}
 
-	if offerHeader.GetPredump() {
-		return api.StatusErrorf(http.StatusBadRequest, "Pre-dump is not supported for containers")
+		if !d.state.OS.CRIU {
+			return migration.ErrNoLiveMigrationTarget
+		}
+	} else if (offerHeader.Criu != nil && *offerHeader.Criu != migration.CRIUType_NONE) || offerHeader.GetPredump() {
+		return api.StatusErrorf(http.StatusBadRequest, "Unexpected container state transfer for stateless migration")
 	}
 
 	// When doing a cluster same-name move we cannot load the storage pool using the instance's volume DB
@@ -5092,6 +5361,11 @@ func (d *lxc) MigrateReceive(ctx context.Context, args instance.MigrateReceiveAr
 	respHeader.Snapshots = offerHeader.Snapshots
 	respHeader.Refresh = &args.Refresh
 
+	if useCRIU {
+		respHeader.Criu = migration.CRIUType_CRIU_RSYNC.Enum()
+		respHeader.Predump = new(offerHeader.GetPredump())
+	}
+
 	if args.Refresh {
 		// Get the remote snapshots on the source.
 		sourceSnapshots := offerHeader.GetSnapshots()
@@ -5153,6 +5427,23 @@ func (d *lxc) MigrateReceive(ctx context.Context, args instance.MigrateReceiveAr
 
 	d.logger.Debug("Sent migration response to source")
 
+	// Establish state transfer connection and setup a temporary directory for the CRIU images if needed.
+	var stateConn io.ReadWriteCloser
+	var checkpointDir string
+	if useCRIU {
+		stateConn, err = args.StateConn(connectionsCtx)
+		if err != nil {
+			return err
+		}
+
+		checkpointDir, err = os.MkdirTemp("", "lxd_restore_")
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = os.RemoveAll(checkpointDir) }()
+	}
+
 	srcIdmap := new(idmap.IdmapSet)
 	for _, idmapSet := range offerHeader.Idmap {
 		e := idmap.IdmapEntry{
@@ -5258,7 +5549,8 @@ func (d *lxc) MigrateReceive(ctx context.Context, args instance.MigrateReceiveAr
 			snapshots = offerHeader.Snapshots
 		}
 
-		// For containers, expect a final delta only when args.Live (not currently used as CRIU is not supported) is true.
+		// For containers, expect a final delta when live migrating as the root filesystem is synced again
+		// after CRIU has stopped the container on the source.
 		sendFinalFsDelta := args.Live
 
 		volTargetArgs := migration.VolumeTargetArgs{
@@ -5368,9 +5660,25 @@ func (d *lxc) MigrateReceive(ctx context.Context, args instance.MigrateReceiveAr
 		return nil
 	})
 
+	// Start state transfer routine and initialise a channel that is closed when the routine finishes.
+	stateTransferDone := make(chan struct{})
+	if useCRIU {
+		g.Go(func() error {
+			defer close(stateTransferDone)
+
+			d.logger.Debug("Migrate receive state transfer started")
+			defer d.logger.Debug("Migrate receive state transfer finished")
+
+			return d.migrateReceiveState(stateConn, checkpointDir, respHeader.GetPredump(), respHeader.GetRsyncFeaturesSlice())
+		})
+	} else {
+		close(stateTransferDone)
+	}
+
 	{
 		// Wait until the filesystem transfer and state transfer routines have finished.
 		<-fsTransferDone
+		<-stateTransferDone
 
 		// If context is cancelled by this stage, then an error has occurred.
 		// Wait for all routines to finish and collect the first error that occurred.
@@ -5395,6 +5703,32 @@ func (d *lxc) MigrateReceive(ctx context.Context, args instance.MigrateReceiveAr
 			return err
 		}
 
+		// Restore the container from the transferred state now that its root filesystem is in place.
+		if useCRIU {
+			err := d.migrate(&instance.CriuMigrationArgs{
+				Cmd:      liblxc.MIGRATE_RESTORE,
+				StateDir: checkpointDir,
+				DumpDir:  "final",
+				Function: "migration",
+			})
+			if err != nil {
+				msg := migration.MigrationControl{
+					Success: new(false),
+					Message: new(err.Error()),
+				}
+
+				d.logger.Debug("Sending migration failure response to source", logger.Ctx{"err": err})
+				sendErr := args.ControlSend(&msg)
+				if sendErr != nil {
+					d.logger.Warn("Failed sending migration failure to source", logger.Ctx{"err": sendErr})
+				}
+
+				return err
+			}
+
+			revert.Add(func() { _ = d.Stop(context.Background(), false) })
+		}
+
 		// Send success response to source to control as nothing has gone wrong so far.
 		msg := migration.MigrationControl{
 			Success: new(true),
@@ -5416,6 +5750,269 @@ func (d *lxc) MigrateReceive(ctx context.Context, args instance.MigrateReceiveAr
 	}
 }
 
+// migrateReceiveState receives the CRIU images sent by migrateSendState into checkpointDir.
+// When preDump is enabled, the pre-dump rounds are received until the source indicates the final one.
+func (d *lxc) migrateReceiveState(stateConn io.ReadWriteCloser, checkpointDir string, preDump bool, rsyncFeatures []string) error {
+	rsyncFeatures = criuRsyncFeatures(rsyncFeatures)
+
+	var preDumpDir string
+	if preDump {
+		for i := 0; ; i++ {
+			dumpDir := strconv.Itoa(i)
+
+			err := d.migrateReceivePages(stateConn, checkpointDir, dumpDir, preDumpDir)
+			if err != nil {
+				return err
+			}
+
+			err = rsync.Recv(shared.AddSlash(checkpointDir), stateConn, nil, rsyncFeatures)
+			if err != nil {
+				return err
+			}
+
+			syncMsg := migration.MigrationSync{}
+			err = migration.ProtoRecvStream(stateConn, &syncMsg)
+			if err != nil {
+				return fmt.Errorf("Failed receiving pre-dump sync from source: %w", err)
+			}
+
+			d.logger.Debug("Received memory pre-dump round", logger.Ctx{"round": i + 1, "final": syncMsg.GetFinalPreDump()})
+
+			preDumpDir = "../" + dumpDir
+			if syncMsg.GetFinalPreDump() {
+				break
+			}
+		}
+	}
+
+	// Receive the final dump.
+	err := d.migrateReceivePages(stateConn, checkpointDir, "final", preDumpDir)
+	if err != nil {
+		return err
+	}
+
+	return rsync.Recv(shared.AddSlash(checkpointDir), stateConn, nil, rsyncFeatures)
+}
+
+// migrateReceivePages runs a CRIU page server that receives the memory pages of a dump into the dumpDir
+// subdirectory of checkpointDir, fed by the page server traffic forwarded over the state connection.
+func (d *lxc) migrateReceivePages(stateConn io.ReadWriteCloser, checkpointDir string, dumpDir string, preDumpDir string) error {
+	imagesDir := filepath.Join(checkpointDir, dumpDir)
+	err := os.MkdirAll(imagesDir, 0700)
+	if err != nil {
+		return err
+	}
+
+	fds, err := unix.Socketpair(unix.AF_UNIX, unix.SOCK_STREAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return fmt.Errorf("Failed creating CRIU page server socket: %w", err)
+	}
+
+	pageServerFile := os.NewFile(uintptr(fds[0]), "criu-page-server")
+	proxyFile := os.NewFile(uintptr(fds[1]), "criu-page-server-proxy")
+	conn, err := net.FileConn(proxyFile)
+	_ = proxyFile.Close()
+	if err != nil {
+		_ = pageServerFile.Close()
+		return fmt.Errorf("Failed creating CRIU page server socket: %w", err)
+	}
+
+	defer func() { _ = conn.Close() }()
+
+	criuArgs := []string{"page-server", "--images-dir", imagesDir, "--ps-socket", "3"}
+	if preDumpDir != "" {
+		criuArgs = append(criuArgs, "--prev-images-dir", preDumpDir)
+	}
+
+	// The page server exits once the source has sent all the pages, which ends the forwarded traffic.
+	pageServerDone := make(chan error, 1)
+	go func() {
+		_, err := shared.RunCommandInheritFds(context.TODO(), []*os.File{pageServerFile}, "criu", criuArgs...)
+		_ = pageServerFile.Close()
+		pageServerDone <- err
+	}()
+
+	proxyErr := migration.CRIUPageServerProxy(conn, stateConn)
+	err = <-pageServerDone
+	if err != nil {
+		return fmt.Errorf("Failed running CRIU page server: %w", err)
+	}
+
+	return proxyErr
+}
+
+// migrate performs a CRIU operation on the container.
+// Dumps and pre-dumps are written to the DumpDir subdirectory of StateDir, and restores are done from it.
+func (d *lxc) migrate(args *instance.CriuMigrationArgs) error {
+	ctxMap := logger.Ctx{
+		"created":    d.creationDate,
+		"ephemeral":  d.ephemeral,
+		"used":       d.lastUsedDate,
+		"statedir":   args.StateDir,
+		"dumpdir":    args.DumpDir,
+		"predumpdir": args.PreDumpDir,
+		"function":   args.Function,
+		"stop":       args.Stop,
+	}
+
+	pool, err := d.getStoragePool()
+	if err != nil {
+		return err
+	}
+
+	preservesInodes := pool.Driver().Info().PreservesInodes
+	imagesDir := args.StateDir
+	if args.DumpDir != "" {
+		imagesDir = filepath.Join(args.StateDir, args.DumpDir)
+	}
+
+	d.logger.Info("Migrating container", ctxMap)
+
+	var migrateErr error
+	switch args.Cmd {
+	case liblxc.MIGRATE_PRE_DUMP, liblxc.MIGRATE_DUMP:
+		if args.PageServer != "" {
+			migrateErr = d.migrateDumpProcess(args, imagesDir, preservesInodes)
+			break
+		}
+
+		cc, err := d.initLXC(true)
+		if err != nil {
+			return err
+		}
+
+		migrateErr = cc.Migrate(args.Cmd, liblxc.MigrateOptions{
+			Directory:       imagesDir,
+			PredumpDir:      args.PreDumpDir,
+			Stop:            args.Stop,
+			Verbose:         true,
+			PreservesInodes: preservesInodes,
+		})
+	case liblxc.MIGRATE_RESTORE:
+		// Run the shared start code.
+		cleanupInstanceDevices, configPath, postStartHooks, err := d.startCommon(context.Background(), nil)
+		if err != nil {
+			return err
+		}
+
+		// Restore the container in a separate process so it isn't a child of LXD.
+		_, migrateErr = shared.RunCommand(
+			context.TODO(),
+			d.state.OS.ExecPath,
+			"forkmigrate",
+			project.Instance(d.Project().Name, d.name),
+			d.state.OS.LxcPath,
+			configPath,
+			imagesDir,
+			strconv.FormatBool(preservesInodes))
+		if migrateErr != nil {
+			if !d.IsRunning() {
+				cleanupInstanceDevices()
+			}
+
+			break
+		}
+
+		err = d.runHooks(postStartHooks)
+		if err != nil {
+			_ = d.Stop(context.Background(), false)
+			return err
+		}
+
+		err = d.VolatileSet(map[string]string{"volatile.last_state.power": instance.PowerStateRunning})
+		if err != nil {
+			d.logger.Warn("Failed recording last power state", logger.Ctx{"err": err})
+		}
+
+	case liblxc.MIGRATE_FEATURE_CHECK:
+		cc, err := d.initLXC(true)
+		if err != nil {
+			return err
+		}
+
+		migrateErr = cc.Migrate(args.Cmd, liblxc.MigrateOptions{
+			FeaturesToCheck: args.Features,
+		})
+	default:
+		return fmt.Errorf("Unknown CRIU operation %d", args.Cmd)
+	}
+
+	if migrateErr != nil {
+		d.logger.Error("Failed migrating container", ctxMap)
+
+		criuErrors := criuLogErrors(imagesDir)
+		if criuErrors != "" {
+			return fmt.Errorf("Failed %s: %w\n%s", args.Function, migrateErr, criuErrors)
+		}
+
+		return fmt.Errorf("Failed %s: %w", args.Function, migrateErr)
+	}
+
+	d.logger.Info("Migrated container", ctxMap)
+
+	return nil
+}
+
+// migrateDumpProcess performs a CRIU dump or pre-dump in a separate process whose CRIU configuration sends the
+// memory pages to the page server at args.PageServer instead of writing them to imagesDir.
+func (d *lxc) migrateDumpProcess(args *instance.CriuMigrationArgs, imagesDir string, preservesInodes bool) error {
+	host, port, err := net.SplitHostPort(args.PageServer)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(imagesDir, 0700)
+	if err != nil {
+		return err
+	}
+
+	configFile := imagesDir + ".conf"
+	err = os.WriteFile(configFile, fmt.Appendf(nil, "page-server\naddress %s\nport %s\n", host, port), 0600)
+	if err != nil {
+		return fmt.Errorf("Failed writing CRIU configuration: %w", err)
+	}
+
+	defer func() { _ = os.Remove(configFile) }()
+
+	_, _, err = shared.RunCommandSplit(
+		context.TODO(),
+		append(os.Environ(), "CRIU_CONFIG_FILE="+configFile),
+		nil,
+		d.state.OS.ExecPath,
+		"forkdump",
+		project.Instance(d.Project().Name, d.name),
+		d.state.OS.LxcPath,
+		filepath.Join(d.LogPath(), "lxc.conf"),
+		imagesDir,
+		args.PreDumpDir,
+		strconv.FormatBool(args.Cmd == liblxc.MIGRATE_PRE_DUMP),
+		strconv.FormatBool(args.Stop),
+		strconv.FormatBool(preservesInodes))
+
+	return err
+}
+
+// criuLogErrors returns the error lines of the CRIU log files in imagesDir.
+func criuLogErrors(imagesDir string) string {
+	logFiles, _ := filepath.Glob(filepath.Join(imagesDir, "*.log"))
+
+	var errorLines []string
+	for _, logFile := range logFiles {
+		content, err := os.ReadFile(logFile)
+		if err != nil {
+			continue
+		}
+
+		for line := range strings.SplitSeq(string(content), "\n") {
+			if strings.Contains(line, "Error") {
+				errorLines = append(errorLines, "  "+strings.TrimSpace(line))
+			}
+		}
+	}
+
+	return strings.Join(errorLines, "\n")
+}
+
 // ConversionReceive establishes the filesystem connection, transfers the filesystem / block volume,
 // and creates an instance from it.
 func (d *lxc) ConversionReceive(args instance.ConversionReceiveArgs, progressReporter ioprogress.ProgressReporter) error {
@@ -121,6 +121,12 @@ const qemuSparseUSBPorts = 8
 // qemuBusModePersistent is the volatile.bus.mode for persistent bus allocation mode.
 const qemuBusModePersistent = "persistent"
 
+// qemuMemoryHotplugDeviceID is the ID of the virtio-mem device used for memory hotplug.
+const qemuMemoryHotplugDeviceID = "dev-qemu_mem_hotplug"
+
+// qemuMemoryHotplugBlockSizeMB is the granularity in MiB at which memory is hotplugged through virtio-mem.
+const qemuMemoryHotplugBlockSizeMB = 2
+
 var errQemuAgentOffline = errors.New("LXD VM agent is not currently running")
 
 type monitorHook func(m *qmp.Monitor) error
@@ -663,6 +669,9 @@ func (d *qemu) onStop(ctx context.Context, target string) error {
 		d.state.Events.SendLifecycle(d.project.Name, lifecycle.InstanceStopped.Event(ctx, d, nil))
 	}
 
+	// Restart the instance if it stopped unexpectedly and automatic restarts are enabled.
+	autoRestart := target == "stop" && op.GetInstanceInitiated() && d.autoRestartSchedule(op)
+
 	// Reboot the instance.
 	if target == "reboot" {
 		// Progress tracking here is not useful. We are in the on stop hook, which is called via lxc hook, so progress
@@ -674,7 +683,7 @@ func (d *qemu) onStop(ctx context.Context, target string) error {
 		}
 
 		d.state.Events.SendLifecycle(d.project.Name, lifecycle.InstanceRestarted.Event(ctx, d, nil))
-	} else if d.ephemeral {
+	} else if d.ephemeral && !autoRestart {
 		// Destroy ephemeral virtual machines.
 		err = d.delete(ctx, true)
 		if err != nil {
@@ -691,6 +700,8 @@ func (d *qemu) Shutdown(ctx context.Context, timeout time.Duration) error {
 	d.logger.Debug("Shutdown started", logger.Ctx{"timeout": timeout})
 	defer d.logger.Debug("Shutdown finished", logger.Ctx{"timeout": timeout})
 
+	d.autoRestartCancel()
+
 	// Must be run prior to creating the operation lock.
 	statusCode := d.statusCode()
 	if !d.isRunningStatusCode(statusCode) {
@@ -1101,6 +1112,8 @@ func (d *qemu) Start(ctx context.Context, progressReporter ioprogress.ProgressRe
 
 	defer unlock()
 
+	d.autoRestartCancel()
+
 	return d.start(ctx, stateful, nil, progressReporter)
 }
 
@@ -1546,6 +1559,7 @@ func (d *qemu) start(ctx context.Context, stateful bool, op *operationlock.Insta
 		"-sandbox", "on,obsolete=deny,elevateprivileges=allow,spawn=allow,resourcecontrol=deny",
 		"-readconfig", confFile,
 		"-spice", d.spiceCmdlineConfig(),
+		"-vnc", d.vncCmdlineConfig(),
 		"-pidfile", d.pidFilePath(),
 		"-D", d.LogFilePath(),
 	}
@@ -2945,6 +2959,14 @@ func (d *qemu) spiceCmdlineConfig() string {
 	return "unix=on,disable-ticketing=on,addr=" + d.spicePath()
 }
 
+func (d *qemu) vncPath() string {
+	return filepath.Join(d.LogPath(), "qemu.vnc")
+}
+
+func (d *qemu) vncCmdlineConfig() string {
+	return "unix:" + d.vncPath()
+}
+
 // generateConfigShare generates the config share directory that will be exported to the VM via
 // a 9P share. Due to the unknown size of templates inside the images this directory is created
 // inside the VM's config volume so that it can be restricted by quota.
@@ -3653,6 +3675,34 @@ func (d *qemu) generateQemuConfigFile(cpuInfo *cpuTopology, mountInfo *storagePo
 		cfg = append(cfg, qemuUSB(&usbOpts)...)
 	}
 
+	// Add the virtio-mem device used to grow the memory beyond its boot size.
+	// It takes the last function of the generic multi-function group, so it doesn't change the PCI layout.
+	_, hotplugSizeMB, err := d.memorySizesMB()
+	if err != nil {
+		return "", nil, err
+	}
+
+	if hotplugSizeMB > 0 {
+		devBus, devAddr, multi = bus.allocate(busFunctionGroupGeneric)
+		memoryHotplugOpts := qemuMemoryHotplugOpts{
+			dev: qemuDevOpts{
+				busName:       bus.name,
+				devBus:        devBus,
+				devAddr:       devAddr,
+				multifunction: multi,
+			},
+			sizeMB:      hotplugSizeMB,
+			blockSizeMB: qemuMemoryHotplugBlockSizeMB,
+		}
+
+		// Virtual machines on x86_64 always have a NUMA node 0.
+		if d.architecture == osarch.ARCH_64BIT_INTEL_X86 {
+			memoryHotplugOpts.numaNode = "0"
+		}
+
+		cfg = append(cfg, qemuMemoryHotplug(&memoryHotplugOpts)...)
+	}
+
 	// Allocate a regular entry to keep things aligned normally (avoid NICs getting a different name).
 	devBus, devAddr, multi = bus.allocate(busFunctionGroupNone)
 	bootMode := d.effectiveBootMode()
@@ -4041,14 +4091,9 @@ func (d *qemu) addCPUMemoryConfig(cfg *[]cfgSection, cpuInfo *cpuTopology) error
 	}
 
 	// Configure memory limit.
-	memSize := d.expandedConfig["limits.memory"]
-	if memSize == "" {
-		memSize = QEMUDefaultMemSize // Default if no memory limit specified.
-	}
-
-	memSizeBytes, err := parseMemoryStr(memSize)
+	memSizeMB, hotplugSizeMB, err := d.memorySizesMB()
 	if err != nil {
-		return fmt.Errorf("limits.memory invalid: %w", err)
+		return err
 	}
 
 	cpuOpts.hugepages = ""
@@ -4062,18 +4107,61 @@ func (d *qemu) addCPUMemoryConfig(cfg *[]cfgSection, cpuInfo *cpuTopology) error
 	}
 
 	// Determine per-node memory limit.
-	memSizeMB := memSizeBytes / 1024 / 1024
 	nodeMemory := int64(memSizeMB / int64(len(hostNodes)))
 	cpuOpts.memory = nodeMemory
 
 	if cfg != nil {
-		*cfg = append(*cfg, qemuMemory(&qemuMemoryOpts{memSizeMB})...)
+		*cfg = append(*cfg, qemuMemory(&qemuMemoryOpts{memSizeMB: memSizeMB, maxMemSizeMB: memSizeMB + hotplugSizeMB})...)
 		*cfg = append(*cfg, qemuCPU(&cpuOpts, cpuPinning)...)
 	}
 
 	return nil
 }
 
+// memorySizesMB returns the boot memory size and the size of the memory hotplug region in MiB.
+// The hotplug region size is 0 if memory hotplug isn't enabled.
+func (d *qemu) memorySizesMB() (memSizeMB int64, hotplugSizeMB int64, err error) {
+	memSize := d.expandedConfig["limits.memory"]
+	if memSize == "" {
+		memSize = QEMUDefaultMemSize // Default if no memory limit specified.
+	}
+
+	memSizeBytes, err := parseMemoryStr(memSize)
+	if err != nil {
+		return -1, -1, fmt.Errorf("limits.memory invalid: %w", err)
+	}
+
+	memSizeMB = memSizeBytes / 1024 / 1024
+
+	if d.expandedConfig["limits.memory.hotplug"] == "" {
+		return memSizeMB, 0, nil
+	}
+
+	if !slices.Contains([]int{osarch.ARCH_64BIT_INTEL_X86, osarch.ARCH_64BIT_ARMV8_LITTLE_ENDIAN}, d.architecture) {
+		return -1, -1, errors.New("Memory hotplug is only supported on x86_64 and aarch64")
+	}
+
+	if shared.IsTrue(d.expandedConfig["limits.memory.hugepages"]) {
+		return -1, -1, errors.New("Memory hotplug cannot be used with huge pages")
+	}
+
+	maxSizeBytes, err := parseMemoryStr(d.expandedConfig["limits.memory.hotplug"])
+	if err != nil {
+		return -1, -1, fmt.Errorf("limits.memory.hotplug invalid: %w", err)
+	}
+
+	maxSizeMB := maxSizeBytes / 1024 / 1024
+	if maxSizeMB < memSizeMB {
+		return -1, -1, fmt.Errorf("limits.memory.hotplug (%dMiB) cannot be lower than limits.memory (%dMiB)", maxSizeMB, memSizeMB)
+	}
+
+	// The hotplug region must be a multiple of the hotplug block size.
+	hotplugSizeMB = maxSizeMB - memSizeMB
+	hotplugSizeMB -= hotplugSizeMB % qemuMemoryHotplugBlockSizeMB
+
+	return memSizeMB, hotplugSizeMB, nil
+}
+
 // addRootDriveConfig adds the qemu config required for adding the root drive.
 func (d *qemu) addRootDriveConfig(busAllocate busAllocator, mountInfo *storagePools.MountInfo, bootIndexes map[string]int, rootDriveConf deviceConfig.MountEntryItem) (monitorHook, error) {
 	if rootDriveConf.TargetPath != "/" {
@@ -5279,6 +5367,8 @@ func (d *qemu) Stop(ctx context.Context, stateful bool) error {
 	d.logger.Debug("Stop started", logger.Ctx{"stateful": stateful})
 	defer d.logger.Debug("Stop finished", logger.Ctx{"stateful": stateful})
 
+	d.autoRestartCancel()
+
 	// Must be run prior to creating the operation lock.
 	// Allow to proceed if statusCode is Error or Frozen as we may need to forcefully kill the QEMU process.
 	// Also Stop() is called from migrateSendLive in some cases, and instance status will be Frozen then.
@@ -5977,6 +6067,15 @@ func (d *qemu) Update(ctx context.Context, args db.InstanceArgs, actionType inst
 		}
 	}
 
+	// Validate the memory hotplug configuration against the other memory limits (even if not running).
+	memoryKeys := []string{"limits.memory", "limits.memory.hotplug", "limits.memory.hugepages"}
+	if d.expandedConfig["limits.memory.hotplug"] != "" && slices.ContainsFunc(changedConfig, func(key string) bool { return slices.Contains(memoryKeys, key) }) {
+		_, _, err = d.memorySizesMB()
+		if err != nil {
+			return err
+		}
+	}
+
 	isRunning := d.IsRunning()
 
 	// Use the device interface to apply update changes.
@@ -6267,6 +6366,16 @@ func (d *qemu) updateMemoryLimit(newLimit string) error {
 		return err
 	}
 
+	// Use the virtio-mem device if memory hotplug is enabled.
+	memoryHotplug, err := monitor.GetVirtioMemDevice(qemuMemoryHotplugDeviceID)
+	if err != nil {
+		return err
+	}
+
+	if memoryHotplug != nil {
+		return d.updateMemoryHotplug(monitor, memoryHotplug, baseSizeBytes, newSizeBytes)
+	}
+
 	baseSizeMB := baseSizeBytes / 1024 / 1024
 
 	curSizeBytes, err := monitor.GetMemoryBalloonSizeBytes()
@@ -6282,12 +6391,122 @@ func (d *qemu) updateMemoryLimit(newLimit string) error {
 		return fmt.Errorf("Cannot increase memory size beyond boot time size when VM is running (Boot time size %dMiB, new size %dMiB)", baseSizeMB, newSizeMB)
 	}
 
+	return d.updateMemoryBalloon(monitor, newSizeBytes)
+}
+
+// updateMemoryHotplug updates the memory size of a running VM which has memory hotplug enabled.
+// Memory above the boot time size is plugged through the virtio-mem device, while memory below it is reclaimed
+// using the balloon.
+func (d *qemu) updateMemoryHotplug(monitor *qmp.Monitor, memoryHotplug *qmp.VirtioMemDevice, baseSizeBytes int64, newSizeBytes int64) error {
+	maxSizeBytes := baseSizeBytes + memoryHotplug.MaxSize
+	if newSizeBytes > maxSizeBytes {
+		return fmt.Errorf("Cannot increase memory size beyond limits.memory.hotplug when VM is running (Maximum size %dMiB, new size %dMiB)", maxSizeBytes/1024/1024, newSizeBytes/1024/1024)
+	}
+
+	requestedSizeBytes := int64(0)
+	if newSizeBytes > baseSizeBytes {
+		// Round up to the hotplug block size.
+		requestedSizeBytes = newSizeBytes - baseSizeBytes
+		if memoryHotplug.BlockSize > 0 && requestedSizeBytes%memoryHotplug.BlockSize != 0 {
+			requestedSizeBytes += memoryHotplug.BlockSize - requestedSizeBytes%memoryHotplug.BlockSize
+		}
+
+		requestedSizeBytes = min(requestedSizeBytes, memoryHotplug.MaxSize)
+
+		// Release any memory held by the balloon before plugging more memory.
+		err := monitor.SetMemoryBalloonSizeBytes(maxSizeBytes)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := monitor.SetVirtioMemRequestedSizeBytes(qemuMemoryHotplugDeviceID, requestedSizeBytes)
+	if err != nil {
+		return err
+	}
+
+	// Wait for the guest to plug or unplug the memory.
+	pluggedSizeBytes := memoryHotplug.Size
+	for range 20 {
+		memoryHotplug, err = monitor.GetVirtioMemDevice(qemuMemoryHotplugDeviceID)
+		if err != nil {
+			return err
+		}
+
+		if memoryHotplug == nil {
+			return errors.New("Memory hotplug device is gone")
+		}
+
+		pluggedSizeBytes = memoryHotplug.Size
+		if pluggedSizeBytes == requestedSizeBytes {
+			break
+		}
+
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	if pluggedSizeBytes != requestedSizeBytes {
+		// Don't leave a pending request behind, so the hotplug state reflects the configured memory size.
+		_ = monitor.SetVirtioMemRequestedSizeBytes(qemuMemoryHotplugDeviceID, pluggedSizeBytes)
+
+		return fmt.Errorf("Failed setting hotplugged memory to %dMiB (currently %dMiB), the guest may not support virtio-mem", requestedSizeBytes/1024/1024, pluggedSizeBytes/1024/1024)
+	}
+
+	if requestedSizeBytes > 0 {
+		return nil
+	}
+
+	return d.updateMemoryBalloon(monitor, newSizeBytes)
+}
+
+// memoryHotplugState returns the memory hotplug state of the running VM.
+// The guest support reported by the lxd-agent (if any) is used when available.
+func (d *qemu) memoryHotplugState(agentHotplug *api.InstanceStateMemoryHotplug) *api.InstanceStateMemoryHotplug {
+	monitor, err := qmp.Connect(d.monitorPath(), qemuSerialChardevName, d.getMonitorEventHandler())
+	if err != nil {
+		return nil
+	}
+
+	baseSizeBytes, err := monitor.GetMemorySizeBytes()
+	if err != nil {
+		return nil
+	}
+
+	memoryHotplug, err := monitor.GetVirtioMemDevice(qemuMemoryHotplugDeviceID)
+	if err != nil || memoryHotplug == nil {
+		return nil
+	}
+
+	hotplug := &api.InstanceStateMemoryHotplug{
+		Max:          baseSizeBytes + memoryHotplug.MaxSize,
+		Requested:    memoryHotplug.RequestedSize,
+		Plugged:      memoryHotplug.Size,
+		GuestSupport: "unknown",
+	}
+
+	if agentHotplug != nil && agentHotplug.GuestSupport != "" {
+		hotplug.GuestSupport = agentHotplug.GuestSupport
+	} else if memoryHotplug.Size > 0 {
+		// Memory can only be plugged by a guest driving the device.
+		hotplug.GuestSupport = "supported"
+	}
+
+	return hotplug
+}
+
+// updateMemoryBalloon sets the effective memory size of a running VM using the balloon.
+func (d *qemu) updateMemoryBalloon(monitor *qmp.Monitor, newSizeBytes int64) error {
+	newSizeMB := newSizeBytes / 1024 / 1024
+
 	// Set effective memory size.
-	err = monitor.SetMemoryBalloonSizeBytes(newSizeBytes)
+	err := monitor.SetMemoryBalloonSizeBytes(newSizeBytes)
 	if err != nil {
 		return err
 	}
 
+	var curSizeBytes int64
+	var curSizeMB int64
+
 	// Changing the memory balloon can take time, so poll the effectice size to check it has shrunk within 1%
 	// of the target size, which we then take as success (it may still continue to shrink closer to target).
 	for range 10 {
@@ -8034,6 +8253,14 @@ func (d *qemu) Console(ctx context.Context, protocol string) (*os.File, chan err
 		path = d.consolePath()
 	case instance.ConsoleTypeVGA:
 		path = d.spicePath()
+	case instance.ConsoleTypeVNC:
+		path = d.vncPath()
+
+		// VMs started before VNC support was added don't have a VNC server.
+		if !shared.PathExists(path) {
+			return nil, nil, errors.New("VNC console isn't available, the instance needs to be restarted")
+		}
+
 	default:
 		return nil, nil, fmt.Errorf("Unknown protocol %q", protocol)
 	}
@@ -8357,6 +8584,15 @@ func (d *qemu) renderState(statusCode api.StatusCode, opts ...instance.StateRend
 	status.Pid = int64(pid)
 	status.Status = statusCode.String()
 	status.StatusCode = statusCode
+	status.AutoRestarts, _ = strconv.ParseInt(d.localConfig["volatile.autorestart.count"], 10, 64)
+
+	if d.isRunningStatusCode(statusCode) {
+		status.Health = d.healthState(pid)
+
+		if d.expandedConfig["limits.memory.hotplug"] != "" {
+			status.Memory.Hotplug = d.memoryHotplugState(status.Memory.Hotplug)
+		}
+	}
 
 	// Disk - conditionally fetch (expensive operation)
 	if options.IncludeDisk {
@@ -98,15 +98,21 @@ func TestQemuConfigTemplates(t *testing.T) {
 			opts     qemuMemoryOpts
 			expected string
 		}{{
-			qemuMemoryOpts{4096},
+			qemuMemoryOpts{4096, 0},
 			`# Memory
 			[memory]
 			size = "4096M"`,
 		}, {
-			qemuMemoryOpts{8192},
+			qemuMemoryOpts{8192, 0},
 			`# Memory
 			[memory]
 			size = "8192M"`,
+		}, {
+			qemuMemoryOpts{4096, 16384},
+			`# Memory
+			[memory]
+			size = "4096M"
+			maxmem = "16384M"`,
 		}}
 		for _, tc := range testCases {
 			runTest(tc.expected, qemuMemory(&tc.opts))
@@ -246,6 +252,57 @@ func TestQemuConfigTemplates(t *testing.T) {
 		}
 	})
 
+	t.Run("qemu_memory_hotplug", func(t *testing.T) {
+		testCases := []struct {
+			opts     qemuMemoryHotplugOpts
+			expected string
+		}{{
+			qemuMemoryHotplugOpts{
+				dev:         qemuDevOpts{"pcie", "qemu_pcie0", "00.7", true},
+				sizeMB:      12288,
+				blockSizeMB: 2,
+				numaNode:    "0",
+			},
+			`# Memory hotplug
+			[object "qemu_mem_hotplug"]
+			qom-type = "memory-backend-ram"
+			size = "12288M"
+
+			[device "dev-qemu_mem_hotplug"]
+			driver = "virtio-mem-pci"
+			bus = "qemu_pcie0"
+			addr = "00.7"
+			multifunction = "on"
+			memdev = "qemu_mem_hotplug"
+			block-size = "2M"
+			requested-size = "0"
+			node = "0"
+			`,
+		}, {
+			qemuMemoryHotplugOpts{
+				dev:         qemuDevOpts{"pci", "qemu_pci0", "00.7", false},
+				sizeMB:      4096,
+				blockSizeMB: 2,
+			},
+			`# Memory hotplug
+			[object "qemu_mem_hotplug"]
+			qom-type = "memory-backend-ram"
+			size = "4096M"
+
+			[device "dev-qemu_mem_hotplug"]
+			driver = "virtio-mem-pci"
+			bus = "qemu_pci0"
+			addr = "00.7"
+			memdev = "qemu_mem_hotplug"
+			block-size = "2M"
+			requested-size = "0"
+			`,
+		}}
+		for _, tc := range testCases {
+			runTest(tc.expected, qemuMemoryHotplug(&tc.opts))
+		}
+	})
+
 	t.Run("qemu_rng", func(t *testing.T) {
 		testCases := []struct {
 			opts     qemuDevOpts
@@ -142,14 +142,58 @@ func qemuBase(opts *qemuBaseOpts) []cfgSection {
 }
 
 type qemuMemoryOpts struct {
-	memSizeMB int64
+	memSizeMB    int64
+	maxMemSizeMB int64
 }
 
 func qemuMemory(opts *qemuMemoryOpts) []cfgSection {
+	entries := []cfgEntry{{key: "size", value: fmt.Sprintf("%dM", opts.memSizeMB)}}
+
+	// Reserve address space for memory devices, such as virtio-mem.
+	if opts.maxMemSizeMB > opts.memSizeMB {
+		entries = append(entries, cfgEntry{key: "maxmem", value: fmt.Sprintf("%dM", opts.maxMemSizeMB)})
+	}
+
 	return []cfgSection{{
 		name:    "memory",
 		comment: "Memory",
-		entries: []cfgEntry{{key: "size", value: fmt.Sprintf("%dM", opts.memSizeMB)}},
+		entries: entries,
+	}}
+}
+
+type qemuMemoryHotplugOpts struct {
+	dev         qemuDevOpts
+	sizeMB      int64
+	blockSizeMB int64
+	numaNode    string
+}
+
+func qemuMemoryHotplug(opts *qemuMemoryHotplugOpts) []cfgSection {
+	entriesOpts := qemuDevEntriesOpts{
+		dev:     opts.dev,
+		pciName: "virtio-mem-pci",
+	}
+
+	deviceEntries := append(qemuDeviceEntries(&entriesOpts),
+		cfgEntry{key: "memdev", value: "qemu_mem_hotplug"},
+		cfgEntry{key: "block-size", value: fmt.Sprintf("%dM", opts.blockSizeMB)},
+		cfgEntry{key: "requested-size", value: "0"},
+	)
+
+	if opts.numaNode != "" {
+		deviceEntries = append(deviceEntries, cfgEntry{key: "node", value: opts.numaNode})
+	}
+
+	return []cfgSection{{
+		name:    `object "qemu_mem_hotplug"`,
+		comment: "Memory hotplug",
+		entries: []cfgEntry{
+			{key: "qom-type", value: "memory-backend-ram"},
+			{key: "size", value: fmt.Sprintf("%dM", opts.sizeMB)},
+		},
+	}, {
+		name:    `device "` + qemuMemoryHotplugDeviceID + `"`,
+		entries: deviceEntries,
 	}}
 }
 
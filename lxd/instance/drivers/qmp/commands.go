@@ -462,6 +462,56 @@ func (m *Monitor) SetMemoryBalloonSizeBytes(sizeBytes int64) error {
 	return m.run("balloon", args, nil)
 }
 
+// VirtioMemDevice contains information about a virtio-mem device.
+type VirtioMemDevice struct {
+	ID            string `json:"id"`
+	Size          int64  `json:"size"`
+	MaxSize       int64  `json:"max-size"`
+	RequestedSize int64  `json:"requested-size"`
+	BlockSize     int64  `json:"block-size"`
+}
+
+// GetVirtioMemDevice returns the virtio-mem device with the specified ID.
+// Returns nil if no such device exists.
+func (m *Monitor) GetVirtioMemDevice(deviceID string) (*VirtioMemDevice, error) {
+	// Prepare the response.
+	var resp struct {
+		Return []struct {
+			Type string          `json:"type"`
+			Data VirtioMemDevice `json:"data"`
+		} `json:"return"`
+	}
+
+	err := m.run("query-memory-devices", nil, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("Failed querying memory devices: %w", err)
+	}
+
+	for _, device := range resp.Return {
+		if device.Type == "virtio-mem" && device.Data.ID == deviceID {
+			return &device.Data, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// SetVirtioMemRequestedSizeBytes sets the amount of memory the guest is asked to plug from a virtio-mem device.
+func (m *Monitor) SetVirtioMemRequestedSizeBytes(deviceID string, sizeBytes int64) error {
+	args := map[string]any{
+		"path":     "/machine/peripheral/" + deviceID,
+		"property": "requested-size",
+		"value":    sizeBytes,
+	}
+
+	err := m.run("qom-set", args, nil)
+	if err != nil {
+		return fmt.Errorf("Failed setting requested size of memory device %q: %w", deviceID, err)
+	}
+
+	return nil
+}
+
 // AddBlockDevice adds a block device.
 func (m *Monitor) AddBlockDevice(blockDev map[string]any, device map[string]any) error {
 	revert := revert.New()
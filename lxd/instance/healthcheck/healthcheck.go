@@ -0,0 +1,88 @@
+package healthcheck
+
+import (
+	"sync"
+	"time"
+
+	"github.com/canonical/lxd/lxd/project"
+	"github.com/canonical/lxd/shared/api"
+)
+
+// StatusStarting indicates that no health check has succeeded yet since the instance started.
+const StatusStarting = "starting"
+
+// StatusHealthy indicates that the last health check succeeded.
+const StatusHealthy = "healthy"
+
+// StatusUnhealthy indicates that the health check failed for the configured number of consecutive times.
+const StatusUnhealthy = "unhealthy"
+
+// health represents the health of a running instance.
+type health struct {
+	// Init PID of the instance when the results were recorded, used to detect restarts.
+	pid       int
+	status    string
+	failures  int
+	lastCheck time.Time
+}
+
+var healthLock sync.Mutex
+var healths = make(map[string]*health)
+
+// Get returns the health of a running instance with the given init PID.
+// The health is reported as starting until a check result has been recorded for the instance's current run.
+func Get(projectName string, instanceName string, pid int) api.InstanceStateHealth {
+	healthLock.Lock()
+	defer healthLock.Unlock()
+
+	h := healths[project.Instance(projectName, instanceName)]
+	if h == nil || h.pid != pid {
+		return api.InstanceStateHealth{Status: StatusStarting}
+	}
+
+	return api.InstanceStateHealth{
+		Status:    h.status,
+		Failures:  h.failures,
+		LastCheck: h.lastCheck,
+	}
+}
+
+// Record records the result of a health check of a running instance with the given init PID.
+// The instance becomes unhealthy once the check has failed retries consecutive times. Failures are not
+// counted while the instance is in its start period and hasn't been healthy yet.
+// Returns the health status before and after recording the result.
+func Record(projectName string, instanceName string, pid int, success bool, retries int, inStartPeriod bool) (oldStatus string, newStatus string) {
+	healthLock.Lock()
+	defer healthLock.Unlock()
+
+	key := project.Instance(projectName, instanceName)
+
+	h := healths[key]
+	if h == nil || h.pid != pid {
+		h = &health{pid: pid, status: StatusStarting}
+		healths[key] = h
+	}
+
+	oldStatus = h.status
+	h.lastCheck = time.Now()
+
+	if success {
+		h.status = StatusHealthy
+		h.failures = 0
+	} else if !inStartPeriod || h.status != StatusStarting {
+		h.failures++
+		if h.failures >= retries {
+			h.status = StatusUnhealthy
+		}
+	}
+
+	return oldStatus, h.status
+}
+
+// Forget removes the recorded health of an instance.
+func Forget(projectName string, instanceName string) {
+	healthLock.Lock()
+	defer healthLock.Unlock()
+
+	delete(healths, project.Instance(projectName, instanceName))
+}
@@ -0,0 +1,46 @@
+package healthcheck
+
+import (
+	"testing"
+)
+
+func TestRecord(t *testing.T) {
+	defer Forget("default", "c1")
+
+	// Nothing recorded yet.
+	if Get("default", "c1", 100).Status != StatusStarting {
+		t.Fatal("Expected instance to be starting")
+	}
+
+	// Failures in the start period aren't counted.
+	_, status := Record("default", "c1", 100, false, 2, true)
+	if status != StatusStarting || Get("default", "c1", 100).Failures != 0 {
+		t.Fatalf("Expected failure in start period to be ignored, got %q", status)
+	}
+
+	oldStatus, status := Record("default", "c1", 100, true, 2, true)
+	if oldStatus != StatusStarting || status != StatusHealthy {
+		t.Fatalf("Expected transition from starting to healthy, got %q to %q", oldStatus, status)
+	}
+
+	// Once healthy, failures are counted even in the start period.
+	_, status = Record("default", "c1", 100, false, 2, true)
+	if status != StatusHealthy || Get("default", "c1", 100).Failures != 1 {
+		t.Fatalf("Expected instance to stay healthy after one failure, got %q", status)
+	}
+
+	oldStatus, status = Record("default", "c1", 100, false, 2, false)
+	if oldStatus != StatusHealthy || status != StatusUnhealthy {
+		t.Fatalf("Expected transition from healthy to unhealthy, got %q to %q", oldStatus, status)
+	}
+
+	// A different init PID means the instance was restarted.
+	if Get("default", "c1", 200).Status != StatusStarting {
+		t.Fatal("Expected restarted instance to be starting")
+	}
+
+	oldStatus, status = Record("default", "c1", 200, true, 2, false)
+	if oldStatus != StatusStarting || status != StatusHealthy {
+		t.Fatalf("Expected transition from starting to healthy after restart, got %q to %q", oldStatus, status)
+	}
+}
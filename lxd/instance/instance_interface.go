@@ -41,6 +41,7 @@ const HookStop = "onstop"
 const (
 	ConsoleTypeConsole = "console"
 	ConsoleTypeVGA     = "vga"
+	ConsoleTypeVNC     = "vnc"
 )
 
 // TemplateTrigger trigger name.
@@ -224,6 +225,7 @@ type CriuMigrationArgs struct {
 	ActionScript bool
 	DumpDir      string
 	PreDumpDir   string
+	PageServer   string // Address of the CRIU page server the memory pages are sent to when dumping.
 	Features     liblxc.CriuFeatures
 	Op           *operationlock.InstanceOperation
 }
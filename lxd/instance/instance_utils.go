@@ -124,6 +124,21 @@ func ValidConfig(sysOS *sys.OS, config map[string]string, expanded bool, instanc
 		return errors.New(`CPU pinning specified, but pinning strategy is set to "auto"`)
 	}
 
+	// Validate that the health check has what it needs once the configuration is fully expanded.
+	if expanded {
+		switch config["healthcheck.type"] {
+		case "exec":
+			if config["healthcheck.command"] == "" {
+				return errors.New(`healthcheck.command must be set when healthcheck.type is "exec"`)
+			}
+
+		case "tcp", "http":
+			if config["healthcheck.port"] == "" {
+				return fmt.Errorf("healthcheck.port must be set when healthcheck.type is %q", config["healthcheck.type"])
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -93,6 +93,39 @@ var HugePageSizeSuffix = [...]string{"64KB", "1MB", "2MB", "1GB"}
 
 // InstanceConfigKeysAny is a map of config key to validator. (keys applying to containers AND virtual machines).
 var InstanceConfigKeysAny = map[string]func(value string) error{
+	// lxdmeta:generate(entities=instance; group=boot; key=boot.autorestart)
+	// If set to `true`, the instance is automatically restarted when it stops without being requested to through LXD,
+	// for example when the init process of a container or the QEMU process of a virtual machine exits.
+	// This includes a shutdown initiated from within the instance.
+	// See {config:option}`instance-boot:boot.autorestart.max_retries` and {config:option}`instance-boot:boot.autorestart.delay` to control how often the instance is restarted.
+	// ---
+	//  type: bool
+	//  defaultdesc: `false`
+	//  liveupdate: yes
+	//  shortdesc: Whether to restart the instance when it stops unexpectedly
+	"boot.autorestart": validate.Optional(validate.IsBool),
+
+	// lxdmeta:generate(entities=instance; group=boot; key=boot.autorestart.delay)
+	// The number of seconds to wait before the first automatic restart.
+	// The delay doubles with every consecutive restart, up to a maximum of 5 minutes.
+	// ---
+	//  type: integer
+	//  defaultdesc: `1`
+	//  liveupdate: yes
+	//  shortdesc: Initial delay before automatically restarting the instance
+	"boot.autorestart.delay": validate.Optional(validate.IsUint32),
+
+	// lxdmeta:generate(entities=instance; group=boot; key=boot.autorestart.max_retries)
+	// The maximum number of consecutive automatic restarts, after which the instance is left stopped.
+	// The count is reset once the instance has been running for 10 minutes after its last automatic restart.
+	// Set to `0` to restart the instance indefinitely.
+	// ---
+	//  type: integer
+	//  defaultdesc: `10`
+	//  liveupdate: yes
+	//  shortdesc: Maximum number of consecutive automatic restarts
+	"boot.autorestart.max_retries": validate.Optional(validate.IsUint32),
+
 	// lxdmeta:generate(entities=instance; group=boot; key=boot.autostart)
 	// If set to `true`, the instance will always be auto-started, unless `security.protection.start` is also enabled.
 	// If set to `false`, the instance will not be started on LXD start up.
@@ -139,6 +172,27 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: How long to wait for the instance to shut down
 	"boot.host_shutdown_timeout": validate.Optional(validate.IsInt64),
 
+	// lxdmeta:generate(entities=instance; group=boot; key=boot.schedule.start)
+	// Specify a cron expression (`<minute> <hour> <dom> <month> <dow>`), or a comma-separated list of cron expressions, at which the instance is started if it is stopped.
+	// See {ref}`instances-manage-schedule`.
+	// ---
+	//  type: string
+	//  defaultdesc: empty
+	//  liveupdate: yes
+	//  shortdesc: Schedule for automatically starting the instance
+	"boot.schedule.start": validate.Optional(validate.IsCron(nil)),
+
+	// lxdmeta:generate(entities=instance; group=boot; key=boot.schedule.stop)
+	// Specify a cron expression (`<minute> <hour> <dom> <month> <dow>`), or a comma-separated list of cron expressions, at which the instance is stopped if it is running.
+	// The instance is shut down cleanly, and is forcefully stopped if it doesn't shut down within {config:option}`instance-boot:boot.host_shutdown_timeout`.
+	// See {ref}`instances-manage-schedule`.
+	// ---
+	//  type: string
+	//  defaultdesc: empty
+	//  liveupdate: yes
+	//  shortdesc: Schedule for automatically stopping the instance
+	"boot.schedule.stop": validate.Optional(validate.IsCron(nil)),
+
 	// lxdmeta:generate(entities=instance; group=cloud-init; key=cloud-init.network-config)
 	// The content is used as seed value for `cloud-init`.
 	// ---
@@ -218,6 +272,101 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: What to do when evacuating the instance
 	"cluster.evacuate": validate.Optional(validate.IsOneOf(api.ClusterEvacuateModeAuto, api.ClusterEvacuateModeMigrate, api.ClusterEvacuateModeLiveMigrate, api.ClusterEvacuateModeStop)),
 
+	// lxdmeta:generate(entities=instance; group=healthcheck; key=healthcheck.action)
+	// Possible values are `none` (only report the health status) and `restart` (restart the instance when it becomes unhealthy).
+	// ---
+	//  type: string
+	//  defaultdesc: `none`
+	//  liveupdate: yes
+	//  shortdesc: What to do when the instance becomes unhealthy
+	"healthcheck.action": validate.Optional(validate.IsOneOf("none", "restart")),
+
+	// lxdmeta:generate(entities=instance; group=healthcheck; key=healthcheck.command)
+	// The command to run inside the instance when {config:option}`instance-healthcheck:healthcheck.type` is set to `exec`.
+	// The check succeeds if the command exits with status `0`.
+	// For virtual machines, this requires the `lxd-agent` to be running.
+	// ---
+	//  type: string
+	//  liveupdate: yes
+	//  shortdesc: Command to run for the health check
+	"healthcheck.command": validate.IsAny,
+
+	// lxdmeta:generate(entities=instance; group=healthcheck; key=healthcheck.interval)
+	// The number of seconds between two health checks.
+	// ---
+	//  type: integer
+	//  defaultdesc: `30`
+	//  liveupdate: yes
+	//  shortdesc: Interval between health checks
+	"healthcheck.interval": validate.Optional(validate.IsUint32),
+
+	// lxdmeta:generate(entities=instance; group=healthcheck; key=healthcheck.path)
+	// The path to request when {config:option}`instance-healthcheck:healthcheck.type` is set to `http`.
+	// The check succeeds if the response has a `2xx` or `3xx` status code.
+	// ---
+	//  type: string
+	//  defaultdesc: `/`
+	//  liveupdate: yes
+	//  shortdesc: HTTP path for the health check
+	"healthcheck.path": func(value string) error {
+		if value == "" {
+			return nil
+		}
+
+		if !strings.HasPrefix(value, "/") {
+			return errors.New("Path must start with /")
+		}
+
+		return nil
+	},
+
+	// lxdmeta:generate(entities=instance; group=healthcheck; key=healthcheck.port)
+	// The port to connect to on the instance's address when {config:option}`instance-healthcheck:healthcheck.type` is set to `tcp` or `http`.
+	// ---
+	//  type: integer
+	//  liveupdate: yes
+	//  shortdesc: Port for the health check
+	"healthcheck.port": validate.Optional(validate.IsNetworkPort),
+
+	// lxdmeta:generate(entities=instance; group=healthcheck; key=healthcheck.retries)
+	// The number of consecutive failed health checks after which the instance is considered unhealthy.
+	// ---
+	//  type: integer
+	//  defaultdesc: `3`
+	//  liveupdate: yes
+	//  shortdesc: Number of failed checks before the instance is unhealthy
+	"healthcheck.retries": validate.Optional(validate.IsUint32),
+
+	// lxdmeta:generate(entities=instance; group=healthcheck; key=healthcheck.start_period)
+	// The number of seconds after the instance starts during which failed health checks are not counted,
+	// unless a check has already succeeded.
+	// ---
+	//  type: integer
+	//  defaultdesc: `0`
+	//  liveupdate: yes
+	//  shortdesc: Grace period after the instance starts
+	"healthcheck.start_period": validate.Optional(validate.IsUint32),
+
+	// lxdmeta:generate(entities=instance; group=healthcheck; key=healthcheck.timeout)
+	// The number of seconds after which a health check is considered failed.
+	// ---
+	//  type: integer
+	//  defaultdesc: `5`
+	//  liveupdate: yes
+	//  shortdesc: Timeout of a health check
+	"healthcheck.timeout": validate.Optional(validate.IsUint32),
+
+	// lxdmeta:generate(entities=instance; group=healthcheck; key=healthcheck.type)
+	// Possible values are `exec` (run {config:option}`instance-healthcheck:healthcheck.command` inside the instance),
+	// `tcp` (connect to {config:option}`instance-healthcheck:healthcheck.port`) and
+	// `http` (request {config:option}`instance-healthcheck:healthcheck.path` on {config:option}`instance-healthcheck:healthcheck.port`).
+	// Health checks are disabled if this option is not set.
+	// ---
+	//  type: string
+	//  liveupdate: yes
+	//  shortdesc: Type of health check
+	"healthcheck.type": validate.Optional(validate.IsOneOf("exec", "tcp", "http")),
+
 	// lxdmeta:generate(entities=instance; group=resource-limits; key=limits.cpu)
 	// A number or a specific range of CPUs to expose to the instance.
 	//
@@ -436,6 +585,27 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//   condition: snapshot
 	"volatile.attached_volumes": validate.IsAny,
 
+	// lxdmeta:generate(entities=instance; group=volatile; key=volatile.autorestart.count)
+	// The number of consecutive automatic restarts (see {config:option}`instance-boot:boot.autorestart`).
+	// ---
+	//  type: integer
+	//  shortdesc: Number of consecutive automatic restarts
+	"volatile.autorestart.count": validate.Optional(validate.IsUint32),
+
+	// lxdmeta:generate(entities=instance; group=volatile; key=volatile.autorestart.last)
+	// The time of the last (or next, if pending) automatic restart, in RFC3339 format.
+	// ---
+	//  type: string
+	//  shortdesc: Time of the last automatic restart
+	"volatile.autorestart.last": validate.IsAny,
+
+	// lxdmeta:generate(entities=instance; group=volatile; key=volatile.autorestart.pending)
+	// Set while an automatic restart is pending, so that it can be resumed if LXD is restarted in the meantime.
+	// ---
+	//  type: bool
+	//  shortdesc: Whether an automatic restart is pending
+	"volatile.autorestart.pending": validate.Optional(validate.IsBool),
+
 	// lxdmeta:generate(entities=instance; group=volatile; key=volatile.base_image)
 	// The hash of the image that the instance was created from (empty if the instance was not created from an image).
 	// ---
@@ -662,6 +832,8 @@ var InstanceConfigKeysContainer = map[string]func(value string) error{
 
 	// lxdmeta:generate(entities=instance; group=migration; key=migration.incremental.memory)
 	// Using incremental memory transfer of the instance's memory can reduce downtime.
+	// During live migration, the memory is pre-copied to the target while the container keeps running, and only the memory that changed since is transferred once the container is stopped.
+	// This requires CRIU support for dirty memory tracking on the source server.
 	// ---
 	//  type: bool
 	//  defaultdesc: `false`
@@ -671,7 +843,7 @@ var InstanceConfigKeysContainer = map[string]func(value string) error{
 	"migration.incremental.memory": validate.Optional(validate.IsBool),
 
 	// lxdmeta:generate(entities=instance; group=migration; key=migration.incremental.memory.iterations)
-	//
+	// Each iteration transfers the memory that changed since the previous one.
 	// ---
 	//  type: integer
 	//  defaultdesc: `10`
@@ -681,7 +853,7 @@ var InstanceConfigKeysContainer = map[string]func(value string) error{
 	"migration.incremental.memory.iterations": validate.Optional(validate.IsUint32),
 
 	// lxdmeta:generate(entities=instance; group=migration; key=migration.incremental.memory.goal)
-	//
+	// The container is stopped for the final transfer once this percentage of its memory was unchanged since the previous iteration.
 	// ---
 	//  type: integer
 	//  defaultdesc: `70`
@@ -1060,6 +1232,20 @@ var InstanceConfigKeysVM = map[string]func(value string) error{
 	//  shortdesc: Whether to back the instance using huge pages
 	"limits.memory.hugepages": validate.Optional(validate.IsBool),
 
+	// lxdmeta:generate(entities=instance; group=resource-limits; key=limits.memory.hotplug)
+	// Setting this option enables memory hotplug through a `virtio-mem` device, so that {config:option}`instance-resource-limits:limits.memory`
+	// can be raised up to this size while the virtual machine is running.
+	// Memory above the boot time size is plugged by the guest, which requires the guest kernel to support `virtio-mem`.
+	// Memory hotplug is available on `x86_64` and `aarch64`, and cannot be combined with {config:option}`instance-resource-limits:limits.memory.hugepages`.
+	//
+	// See {ref}`instance-options-limits-memory-hotplug` for more information.
+	// ---
+	//  type: string
+	//  liveupdate: no
+	//  condition: virtual machine
+	//  shortdesc: Maximum memory size to allow growing to while running
+	"limits.memory.hotplug": validate.Optional(validate.IsSize),
+
 	// lxdmeta:generate(entities=instance; group=resource-limits; key=limits.cpu.pin_strategy)
 	// Specify the strategy for VM CPU auto pinning.
 	// Possible values: `none` (disables CPU auto pinning) and `auto` (enables CPU auto pinning).
@@ -1083,6 +1269,18 @@ var InstanceConfigKeysVM = map[string]func(value string) error{
 	//  shortdesc: Limit of allowed PCI/PCIe devices
 	"limits.max_bus_ports": validate.Optional(validate.IsUint8),
 
+	// lxdmeta:generate(entities=instance; group=resource-limits; key=limits.memory.suspend.priority)
+	// Specify an integer between 0 and 10.
+	// If set, the virtual machine is statefully stopped when it is idle and the host memory pressure rises above {config:option}`server-miscellaneous:instances.memory_pressure.suspend_threshold`, and started again when the pressure drops or when it is accessed.
+	// The higher the value, the less likely the instance is to be suspended.
+	// This requires {config:option}`instance-migration:migration.stateful` to be enabled.
+	// ---
+	//  type: integer
+	//  liveupdate: yes
+	//  condition: virtual machine
+	//  shortdesc: Priority of the instance for suspension on host memory pressure
+	"limits.memory.suspend.priority": validate.Optional(validate.IsPriority),
+
 	// lxdmeta:generate(entities=instance; group=migration; key=migration.stateful)
 	// Enabling this option prevents the use of some features that are incompatible with it.
 	// ---
@@ -1206,6 +1404,13 @@ var InstanceConfigKeysVM = map[string]func(value string) error{
 	//  shortdesc: Device bus allocation mode
 	"volatile.bus.mode": validate.Optional(validate.IsOneOf("persistent")),
 
+	// lxdmeta:generate(entities=instance; group=volatile; key=volatile.memory_pressure.suspended)
+	// Set when the instance was statefully stopped because of host memory pressure.
+	// ---
+	//  type: bool
+	//  shortdesc: Whether the instance was suspended on memory pressure
+	"volatile.memory_pressure.suspended": validate.Optional(validate.IsBool),
+
 	// lxdmeta:generate(entities=instance; group=volatile; key=volatile.vsock_id)
 	//
 	// ---
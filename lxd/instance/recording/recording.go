@@ -0,0 +1,239 @@
+// Package recording records interactive instance sessions using the asciicast v2 format.
+// The resulting files can be replayed with asciinema compatible players.
+package recording
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileExt is the file extension used for session recordings.
+const FileExt = ".cast"
+
+// Default terminal size used when the client didn't provide one.
+const (
+	defaultWidth  = 80
+	defaultHeight = 24
+)
+
+// Header represents the header line of an asciicast v2 file.
+type Header struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Command   string            `json:"command,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Recorder records the events of a session to an asciicast v2 file.
+type Recorder struct {
+	mu     sync.Mutex
+	file   *os.File
+	start  time.Time
+	closed bool
+}
+
+// New creates a new recording file in the directory and writes its header.
+// The file name is derived from the current time and the session type.
+func New(dir string, sessionType string, header Header) (*Recorder, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("Failed creating recording directory %q: %w", dir, err)
+	}
+
+	start := time.Now()
+	name := start.UTC().Format("20060102T150405.000000000Z") + "-" + sessionType + FileExt
+
+	file, err := os.OpenFile(filepath.Join(dir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("Failed creating recording file: %w", err)
+	}
+
+	header.Version = 2
+	header.Timestamp = start.Unix()
+
+	if header.Width <= 0 || header.Height <= 0 {
+		header.Width = defaultWidth
+		header.Height = defaultHeight
+	}
+
+	line, err := json.Marshal(header)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	_, err = file.Write(append(line, '\n'))
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("Failed writing recording header: %w", err)
+	}
+
+	return &Recorder{file: file, start: start}, nil
+}
+
+// Path returns the path of the recording file.
+func (r *Recorder) Path() string {
+	return r.file.Name()
+}
+
+// Duration returns the time elapsed since the recording started.
+func (r *Recorder) Duration() time.Duration {
+	return time.Since(r.start)
+}
+
+// event appends an event of the given type to the recording.
+// Write errors are ignored so that a failing recording doesn't interrupt the session.
+func (r *Recorder) event(eventType string, data string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return
+	}
+
+	line, err := json.Marshal([]any{time.Since(r.start).Seconds(), eventType, data})
+	if err != nil {
+		return
+	}
+
+	_, _ = r.file.Write(append(line, '\n'))
+}
+
+// Output records data sent by the instance to the client.
+func (r *Recorder) Output(p []byte) {
+	r.event("o", string(p))
+}
+
+// Input records data sent by the client to the instance.
+func (r *Recorder) Input(p []byte) {
+	r.event("i", string(p))
+}
+
+// Resize records a change of the terminal size.
+func (r *Recorder) Resize(width int, height int) {
+	r.event("r", strconv.Itoa(width)+"x"+strconv.Itoa(height))
+}
+
+// Close closes the recording file.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil
+	}
+
+	r.closed = true
+
+	return r.file.Close()
+}
+
+// Reader returns a reader which records the data read from rd as output.
+func (r *Recorder) Reader(rd io.Reader) io.Reader {
+	return &reader{rd: rd, recorder: r}
+}
+
+// Writer returns a writer which records the data written to wr as input.
+func (r *Recorder) Writer(wr io.Writer) io.Writer {
+	return &writer{wr: wr, recorder: r}
+}
+
+// ReadWriteCloser returns a ReadWriteCloser which records the data read from rwc as output and the data
+// written to it as input.
+func (r *Recorder) ReadWriteCloser(rwc io.ReadWriteCloser) io.ReadWriteCloser {
+	return &readWriteCloser{
+		reader: reader{rd: rwc, recorder: r},
+		writer: writer{wr: rwc, recorder: r},
+		closer: rwc,
+	}
+}
+
+type reader struct {
+	rd       io.Reader
+	recorder *Recorder
+}
+
+// Read reads from the underlying reader and records the data read.
+func (r *reader) Read(p []byte) (int, error) {
+	n, err := r.rd.Read(p)
+	if n > 0 {
+		r.recorder.Output(p[:n])
+	}
+
+	return n, err
+}
+
+type writer struct {
+	wr       io.Writer
+	recorder *Recorder
+}
+
+// Write records the data and writes it to the underlying writer.
+func (w *writer) Write(p []byte) (int, error) {
+	n, err := w.wr.Write(p)
+	if n > 0 {
+		w.recorder.Input(p[:n])
+	}
+
+	return n, err
+}
+
+type readWriteCloser struct {
+	reader
+	writer
+	closer io.Closer
+}
+
+// Close closes the underlying ReadWriteCloser.
+func (rwc *readWriteCloser) Close() error {
+	return rwc.closer.Close()
+}
+
+// Prune removes the recordings in the directory which were last modified before the given time.
+// It returns the number of removed recordings.
+func Prune(dir string, before time.Time) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	removed := 0
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), FileExt) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		if !info.ModTime().Before(before) {
+			continue
+		}
+
+		err = os.Remove(filepath.Join(dir, entry.Name()))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return removed, fmt.Errorf("Failed removing recording %q: %w", entry.Name(), err)
+		}
+
+		removed++
+	}
+
+	return removed, nil
+}
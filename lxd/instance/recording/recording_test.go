@@ -0,0 +1,140 @@
+package recording
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type nopCloser struct {
+	io.ReadWriter
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+func TestRecorder(t *testing.T) {
+	dir := t.TempDir()
+
+	recorder, err := New(dir, "exec", Header{Command: "bash"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := bytes.NewBufferString("hello\r\n")
+	rwc := recorder.ReadWriteCloser(nopCloser{buf})
+
+	_, err = rwc.Write([]byte("ls\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = io.ReadAll(rwc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorder.Resize(120, 40)
+
+	err = recorder.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Events after closing are ignored.
+	recorder.Output([]byte("ignored"))
+
+	file, err := os.Open(recorder.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+
+	if !scanner.Scan() {
+		t.Fatal("Expected a header line")
+	}
+
+	header := Header{}
+	err = json.Unmarshal(scanner.Bytes(), &header)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if header.Version != 2 || header.Width != defaultWidth || header.Height != defaultHeight || header.Command != "bash" {
+		t.Fatalf("Unexpected header %+v", header)
+	}
+
+	var events [][]any
+	for scanner.Scan() {
+		var event []any
+		err = json.Unmarshal(scanner.Bytes(), &event)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		events = append(events, event)
+	}
+
+	expected := [][2]string{{"i", "ls\n"}, {"o", "hello\r\nls\n"}, {"r", "120x40"}}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d", len(expected), len(events))
+	}
+
+	for i, event := range events {
+		if event[1] != expected[i][0] || event[2] != expected[i][1] {
+			t.Fatalf("Unexpected event %d: %v", i, event)
+		}
+	}
+}
+
+func TestPrune(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"old" + FileExt, "new" + FileExt, "other.log"} {
+		err := os.WriteFile(filepath.Join(dir, name), nil, 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	old := time.Now().Add(-48 * time.Hour)
+	for _, name := range []string{"old" + FileExt, "other.log"} {
+		err := os.Chtimes(filepath.Join(dir, name), old, old)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	removed, err := Prune(dir, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if removed != 1 {
+		t.Fatalf("Expected 1 removed recording, got %d", removed)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 remaining files, got %d", len(entries))
+	}
+
+	// A missing directory has nothing to prune.
+	_, err = Prune(filepath.Join(dir, "missing"), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/canonical/lxd/lxd/operations"
 	"github.com/canonical/lxd/lxd/request"
 	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/lxd/state"
 	"github.com/canonical/lxd/shared"
 	"github.com/canonical/lxd/shared/api"
 	"github.com/canonical/lxd/shared/cancel"
@@ -37,6 +38,9 @@ import (
 )
 
 type consoleWs struct {
+	// daemon state
+	state *state.State
+
 	// instance currently worked on
 	instance instance.Instance
 
@@ -64,7 +68,7 @@ type consoleWs struct {
 	// terminal height
 	height int
 
-	// channel type (either console or vga)
+	// channel type (either console, vga or vnc)
 	protocol string
 
 	// track either server or client disconnected
@@ -95,7 +99,7 @@ func (s *consoleWs) Connect(op *operations.Operation, r *http.Request, w http.Re
 	switch s.protocol {
 	case instance.ConsoleTypeConsole:
 		return s.connectConsole(r, w)
-	case instance.ConsoleTypeVGA:
+	case instance.ConsoleTypeVGA, instance.ConsoleTypeVNC:
 		return s.connectVGA(r, w)
 	default:
 		return fmt.Errorf("Unknown protocol %q", s.protocol)
@@ -180,7 +184,7 @@ func (s *consoleWs) connectVGA(r *http.Request, w http.ResponseWriter) error {
 
 		logger.Debug("VGA dynamic websocket connected")
 
-		console, _, err := s.instance.Console(r.Context(), "vga")
+		console, _, err := s.instance.Console(r.Context(), s.protocol)
 		if err != nil {
 			_ = conn.Close()
 			return err
@@ -226,7 +230,7 @@ func (s *consoleWs) Do(ctx context.Context, _ *operations.Operation) error {
 	switch s.protocol {
 	case instance.ConsoleTypeConsole:
 		return s.doConsole(ctx)
-	case instance.ConsoleTypeVGA:
+	case instance.ConsoleTypeVGA, instance.ConsoleTypeVNC:
 		return s.doVGA(ctx)
 	default:
 		return fmt.Errorf("Unknown protocol %q", s.protocol)
@@ -237,6 +241,16 @@ func (s *consoleWs) doConsole(ctx context.Context) error {
 	defer logger.Debug("Console websocket finished")
 	<-s.allConnected
 
+	// Record the session if enabled in the project.
+	recorder, err := sessionRecordingStart(ctx, s.state, s.instance, sessionTypeConsole, s.width, s.height, nil)
+	if err != nil {
+		return fmt.Errorf("Failed starting session recording: %w", err)
+	}
+
+	if recorder != nil {
+		defer recorder.Stop(ctx)
+	}
+
 	// Get console from instance.
 	console, consoleDisconnectCh, err := s.instance.Console(ctx, s.protocol)
 	if err != nil {
@@ -304,6 +318,10 @@ func (s *consoleWs) doConsole(ctx context.Context) error {
 				}
 
 				logger.Debugf("Set window size to: %dx%d", winchWidth, winchHeight)
+
+				if recorder != nil {
+					recorder.Resize(winchWidth, winchHeight)
+				}
 			}
 		}
 	}()
@@ -318,8 +336,13 @@ func (s *consoleWs) doConsole(ctx context.Context) error {
 		l := logger.AddContext(logger.Ctx{"address": conn.RemoteAddr().String()})
 		defer l.Debug("Finished mirroring websocket to console")
 
+		var rwc io.ReadWriteCloser = console
+		if recorder != nil {
+			rwc = recorder.ReadWriteCloser(console)
+		}
+
 		l.Debug("Started mirroring websocket")
-		readDone, writeDone := ws.Mirror(conn, console)
+		readDone, writeDone := ws.Mirror(conn, rwc)
 
 		<-readDone
 		l.Debug("Finished mirroring console to websocket")
@@ -503,7 +526,7 @@ func instanceConsolePost(d *Daemon, r *http.Request) response.Response {
 	}
 
 	// Basic parameter validation.
-	if !slices.Contains([]string{instance.ConsoleTypeConsole, instance.ConsoleTypeVGA}, post.Type) {
+	if !slices.Contains([]string{instance.ConsoleTypeConsole, instance.ConsoleTypeVGA, instance.ConsoleTypeVNC}, post.Type) {
 		return response.BadRequest(fmt.Errorf("Unknown console type %q", post.Type))
 	}
 
@@ -516,6 +539,16 @@ func instanceConsolePost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(errors.New("VGA console is only supported by virtual machines"))
 	}
 
+	if post.Type == instance.ConsoleTypeVNC && inst.Type() != instancetype.VM {
+		return response.BadRequest(errors.New("VNC console is only supported by virtual machines"))
+	}
+
+	// Resume the instance if it was suspended because of host memory pressure.
+	err = instanceResumeOnDemand(r.Context(), inst)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
 	if !inst.IsRunning() {
 		return response.BadRequest(errors.New("Instance is not running"))
 	}
@@ -540,6 +573,7 @@ func instanceConsolePost(d *Daemon, r *http.Request) response.Response {
 
 	ws.allConnected = make(chan bool, 1)
 	ws.controlConnected = make(chan bool, 1)
+	ws.state = s
 	ws.instance = inst
 	ws.width = post.Width
 	ws.height = post.Height
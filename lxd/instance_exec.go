@@ -294,6 +294,19 @@ func (s *execWs) Do(ctx context.Context, op *operations.Operation) error {
 		return cmdErr
 	}
 
+	// Record interactive sessions if enabled in the project.
+	var recorder *sessionRecording
+	if s.req.Interactive {
+		recorder, err = sessionRecordingStart(ctx, s.s, s.instance, sessionTypeExec, s.req.Width, s.req.Height, s.req.Command)
+		if err != nil {
+			return finisher(-1, fmt.Errorf("Failed starting session recording: %w", err))
+		}
+
+		if recorder != nil {
+			defer recorder.Stop(ctx)
+		}
+	}
+
 	cmd, err := s.instance.Exec(ctx, s.req, stdin, stdout, stderr)
 	if err != nil {
 		return finisher(-1, err)
@@ -398,6 +411,10 @@ func (s *execWs) Do(ctx context.Context, op *operations.Operation) error {
 					l.Debug("Failed setting window size", logger.Ctx{"err": err, "width": winchWidth, "height": winchHeight})
 					continue
 				}
+
+				if recorder != nil {
+					recorder.Resize(winchWidth, winchHeight)
+				}
 			} else if command.Command == "signal" {
 				err := cmd.Signal(unix.Signal(command.Signal))
 				if err != nil {
@@ -425,10 +442,22 @@ func (s *execWs) Do(ctx context.Context, op *operations.Operation) error {
 			if s.instance.Type() == instancetype.Container {
 				// For containers, we are running the command via the local LXD managed PTY and so
 				// need to use the same PTY handle for both read and write.
-				readDone, writeDone = ws.Mirror(conn, shared.NewExecWrapper(waitAttachedChildIsDead, ptys[0]))
+				var rwc io.ReadWriteCloser = shared.NewExecWrapper(waitAttachedChildIsDead, ptys[0])
+				if recorder != nil {
+					rwc = recorder.ReadWriteCloser(rwc)
+				}
+
+				readDone, writeDone = ws.Mirror(conn, rwc)
 			} else {
-				readDone = ws.MirrorRead(conn, ptys[execWSStdout])
-				writeDone = ws.MirrorWrite(conn, ttys[execWSStdin])
+				var reader io.Reader = ptys[execWSStdout]
+				var writer io.Writer = ttys[execWSStdin]
+				if recorder != nil {
+					reader = recorder.Reader(reader)
+					writer = recorder.Writer(writer)
+				}
+
+				readDone = ws.MirrorRead(conn, reader)
+				writeDone = ws.MirrorWrite(conn, writer)
 			}
 
 			readErr = <-readDone
@@ -601,6 +630,12 @@ func instanceExecPost(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	// Resume the instance if it was suspended because of host memory pressure.
+	err = instanceResumeOnDemand(r.Context(), inst)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
 	if !inst.IsRunning() {
 		return response.BadRequest(errors.New("Instance is not running"))
 	}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/kballard/go-shellquote"
+
+	"github.com/canonical/lxd/lxd/db"
+	dbCluster "github.com/canonical/lxd/lxd/db/cluster"
+	"github.com/canonical/lxd/lxd/instance"
+	"github.com/canonical/lxd/lxd/instance/instancetype"
+	"github.com/canonical/lxd/lxd/instance/recording"
+	"github.com/canonical/lxd/lxd/lifecycle"
+	"github.com/canonical/lxd/lxd/project"
+	"github.com/canonical/lxd/lxd/request"
+	"github.com/canonical/lxd/lxd/state"
+	storagePools "github.com/canonical/lxd/lxd/storage"
+	storageDrivers "github.com/canonical/lxd/lxd/storage/drivers"
+	"github.com/canonical/lxd/lxd/task"
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/logger"
+)
+
+// Types of recorded sessions.
+const (
+	sessionTypeConsole = "console"
+	sessionTypeExec    = "exec"
+)
+
+// sessionRecording is an in-progress recording of an interactive instance session.
+type sessionRecording struct {
+	*recording.Recorder
+
+	s           *state.State
+	inst        instance.Instance
+	sessionType string
+	release     func()
+}
+
+// sessionRecordingLoadProject loads the project with its configuration.
+func sessionRecordingLoadProject(ctx context.Context, s *state.State, projectName string) (*api.Project, error) {
+	var p *api.Project
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		dbProject, err := dbCluster.GetProject(ctx, tx.Tx(), projectName)
+		if err != nil {
+			return err
+		}
+
+		p, err = dbProject.ToAPI(ctx, tx.Tx())
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading project %q: %w", projectName, err)
+	}
+
+	return p, nil
+}
+
+// sessionRecordingDir returns the directory the session recordings of the instance are stored in.
+// If the project stores recordings on a custom volume, the volume is mounted and the returned function must be
+// called to release it once done.
+func sessionRecordingDir(s *state.State, p *api.Project, inst instance.Instance) (string, func(), error) {
+	target := p.Config["sessions.recording.volume"]
+	if target == "" {
+		return filepath.Join(inst.LogPath(), "recordings"), func() {}, nil
+	}
+
+	poolName, volumeName, err := daemonStorageSplitVolume(target)
+	if err != nil {
+		return "", nil, err
+	}
+
+	pool, err := storagePools.LoadByName(s, poolName)
+	if err != nil {
+		return "", nil, fmt.Errorf("Failed loading storage pool %q: %w", poolName, err)
+	}
+
+	volumeProject := project.StorageVolumeProjectFromRecord(p, dbCluster.StoragePoolVolumeTypeCustom)
+
+	_, err = pool.MountCustomVolume(volumeProject, volumeName, nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("Failed mounting session recording volume %q: %w", target, err)
+	}
+
+	release := func() {
+		_, err := pool.UnmountCustomVolume(volumeProject, volumeName, nil)
+		if err != nil {
+			logger.Warn("Failed unmounting session recording volume", logger.Ctx{"volume": target, "err": err})
+		}
+	}
+
+	mountPath := storageDrivers.GetVolumeMountPath(poolName, storageDrivers.VolumeTypeCustom, project.StorageVolume(volumeProject, volumeName))
+
+	return filepath.Join(mountPath, project.Instance(inst.Project().Name, inst.Name())), release, nil
+}
+
+// sessionRecordingCutoff returns the time before which recordings are expired according to the expiry expression.
+func sessionRecordingCutoff(expiry string) (time.Time, error) {
+	now := time.Now()
+
+	expiresAt, err := shared.GetExpiry(now, expiry)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return now.Add(-expiresAt.Sub(now)), nil
+}
+
+// sessionRecordingStart starts recording an interactive session of the instance if session recording is enabled
+// in the instance's project. It returns nil if session recording isn't enabled.
+// The context is expected to carry the requestor of the session.
+func sessionRecordingStart(ctx context.Context, s *state.State, inst instance.Instance, sessionType string, width int, height int, command []string) (*sessionRecording, error) {
+	p, err := sessionRecordingLoadProject(ctx, s, inst.Project().Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if shared.IsFalseOrEmpty(p.Config["sessions.recording"]) {
+		return nil, nil
+	}
+
+	dir, release, err := sessionRecordingDir(s, p, inst)
+	if err != nil {
+		return nil, err
+	}
+
+	// Remove the expired recordings of the instance.
+	if p.Config["sessions.recording.expiry"] != "" {
+		cutoff, err := sessionRecordingCutoff(p.Config["sessions.recording.expiry"])
+		if err == nil {
+			_, err = recording.Prune(dir, cutoff)
+		}
+
+		if err != nil {
+			logger.Warn("Failed pruning expired session recordings", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "err": err})
+		}
+	}
+
+	requestor := request.CreateRequestor(ctx)
+
+	header := recording.Header{
+		Width:   width,
+		Height:  height,
+		Command: shellquote.Join(command...),
+		Title:   fmt.Sprintf("%s session of instance %q in project %q opened by %q (%s)", sessionType, inst.Name(), inst.Project().Name, requestor.Username, requestor.Protocol),
+	}
+
+	recorder, err := recording.New(dir, sessionType, header)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	s.Events.SendLifecycle(inst.Project().Name, lifecycle.InstanceSessionStarted.Event(ctx, inst, map[string]any{"type": sessionType, "path": recorder.Path()}))
+
+	return &sessionRecording{
+		Recorder:    recorder,
+		s:           s,
+		inst:        inst,
+		sessionType: sessionType,
+		release:     release,
+	}, nil
+}
+
+// Stop finalizes the recording and releases its storage.
+func (r *sessionRecording) Stop(ctx context.Context) {
+	err := r.Close()
+	if err != nil {
+		logger.Warn("Failed closing session recording", logger.Ctx{"project": r.inst.Project().Name, "instance": r.inst.Name(), "path": r.Path(), "err": err})
+	}
+
+	r.release()
+
+	r.s.Events.SendLifecycle(r.inst.Project().Name, lifecycle.InstanceSessionFinished.Event(ctx, r.inst, map[string]any{"type": r.sessionType, "path": r.Path(), "duration": r.Duration().Round(time.Second).String()}))
+}
+
+// pruneSessionRecordingsTask returns a task that removes the expired session recordings of the local instances.
+func pruneSessionRecordingsTask(stateFunc func() *state.State) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		err := pruneSessionRecordings(ctx, stateFunc())
+		if err != nil {
+			logger.Error("Failed pruning expired session recordings", logger.Ctx{"err": err})
+		}
+	}
+
+	return f, task.Daily()
+}
+
+// pruneSessionRecordings removes the expired session recordings of the local instances.
+func pruneSessionRecordings(ctx context.Context, s *state.State) error {
+	instances, err := instance.LoadNodeAll(s, instancetype.Any)
+	if err != nil {
+		return fmt.Errorf("Failed loading instances: %w", err)
+	}
+
+	projects := map[string]*api.Project{}
+
+	for _, inst := range instances {
+		p, ok := projects[inst.Project().Name]
+		if !ok {
+			p, err = sessionRecordingLoadProject(ctx, s, inst.Project().Name)
+			if err != nil {
+				return err
+			}
+
+			projects[inst.Project().Name] = p
+		}
+
+		if p.Config["sessions.recording.expiry"] == "" {
+			continue
+		}
+
+		cutoff, err := sessionRecordingCutoff(p.Config["sessions.recording.expiry"])
+		if err != nil {
+			continue
+		}
+
+		dir, release, err := sessionRecordingDir(s, p, inst)
+		if err != nil {
+			logger.Warn("Failed accessing session recordings", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "err": err})
+			continue
+		}
+
+		removed, err := recording.Prune(dir, cutoff)
+		release()
+		if err != nil {
+			logger.Warn("Failed pruning expired session recordings", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "err": err})
+			continue
+		}
+
+		if removed > 0 {
+			logger.Debug("Pruned expired session recordings", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "count": removed})
+		}
+	}
+
+	return nil
+}
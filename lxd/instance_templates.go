@@ -0,0 +1,782 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+
+	"github.com/canonical/lxd/lxd/auth"
+	"github.com/canonical/lxd/lxd/db"
+	"github.com/canonical/lxd/lxd/db/cluster"
+	"github.com/canonical/lxd/lxd/db/query"
+	deviceConfig "github.com/canonical/lxd/lxd/device/config"
+	"github.com/canonical/lxd/lxd/instance"
+	"github.com/canonical/lxd/lxd/instance/instancetype"
+	"github.com/canonical/lxd/lxd/lifecycle"
+	"github.com/canonical/lxd/lxd/request"
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/lxd/state"
+	"github.com/canonical/lxd/lxd/util"
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/entity"
+	"github.com/canonical/lxd/shared/validate"
+)
+
+var instanceTemplatesCmd = APIEndpoint{
+	Path:        "instance-templates",
+	MetricsType: entity.TypeInstanceTemplate,
+
+	Get:  APIEndpointAction{Handler: instanceTemplatesGet, AccessHandler: allowProjectResourceList(false)},
+	Post: APIEndpointAction{Handler: instanceTemplatesPost, AccessHandler: allowPermission(entity.TypeProject, auth.EntitlementCanCreateInstanceTemplates)},
+}
+
+var instanceTemplateCmd = APIEndpoint{
+	Path:        "instance-templates/{name}",
+	MetricsType: entity.TypeInstanceTemplate,
+
+	Delete: APIEndpointAction{Handler: instanceTemplateDelete, AccessHandler: allowPermission(entity.TypeInstanceTemplate, auth.EntitlementCanDelete, "name")},
+	Get:    APIEndpointAction{Handler: instanceTemplateGet, AccessHandler: allowPermission(entity.TypeInstanceTemplate, auth.EntitlementCanView, "name")},
+	Put:    APIEndpointAction{Handler: instanceTemplatePut, AccessHandler: allowPermission(entity.TypeInstanceTemplate, auth.EntitlementCanEdit, "name")},
+	Patch:  APIEndpointAction{Handler: instanceTemplatePut, AccessHandler: allowPermission(entity.TypeInstanceTemplate, auth.EntitlementCanEdit, "name")},
+	Post:   APIEndpointAction{Handler: instanceTemplatePost, AccessHandler: allowPermission(entity.TypeInstanceTemplate, auth.EntitlementCanEdit, "name")},
+}
+
+// API endpoints.
+
+// swagger:operation GET /1.0/instance-templates instance-templates instance_templates_get
+//
+//	Get the instance templates
+//
+//	Returns a list of instance templates (URLs).
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: query
+//	    name: all-projects
+//	    description: Retrieve instance templates from all projects
+//	    type: boolean
+//	    example: true
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of endpoints
+//	          items:
+//	            type: string
+//	          example: |-
+//	            [
+//	              "/1.0/instance-templates/web",
+//	              "/1.0/instance-templates/db"
+//	            ]
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+
+// swagger:operation GET /1.0/instance-templates?recursion=1 instance-templates instance_templates_get_recursion1
+//
+//	Get the instance templates
+//
+//	Returns a list of instance templates (structs).
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: query
+//	    name: all-projects
+//	    description: Retrieve instance templates from all projects
+//	    type: boolean
+//	    example: true
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of instance templates
+//	          items:
+//	            $ref: "#/definitions/InstanceTemplate"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceTemplatesGet(d *Daemon, r *http.Request) response.Response {
+	projectName, allProjects, err := request.ProjectParams(r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	recursion, _ := util.IsRecursionRequest(r)
+	withEntitlements, err := extractEntitlementsFromQuery(r, entity.TypeInstanceTemplate, true)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	s := d.State()
+
+	canViewInstanceTemplate, err := s.Authorizer.GetPermissionChecker(r.Context(), auth.EntitlementCanView, entity.TypeInstanceTemplate)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	var projectNameFilter *string
+	if !allProjects {
+		projectNameFilter = &projectName
+	}
+
+	var templates []cluster.InstanceTemplate
+	var templateURLs []string
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		templates, templateURLs, err = cluster.GetInstanceTemplatesAndURLs(ctx, tx.Tx(), projectNameFilter, func(template cluster.InstanceTemplate) bool {
+			return canViewInstanceTemplate(entity.InstanceTemplateURL(template.ProjectName, template.Row.Name))
+		})
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if recursion == 0 {
+		return response.SyncResponse(true, templateURLs)
+	}
+
+	apiTemplates := make([]*api.InstanceTemplate, 0, len(templates))
+	entitlementReportingMap := make(map[*api.URL]auth.EntitlementReporter, len(templates))
+	for _, template := range templates {
+		apiTemplate, err := template.ToAPI()
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		apiTemplates = append(apiTemplates, apiTemplate)
+		entitlementReportingMap[entity.InstanceTemplateURL(template.ProjectName, template.Row.Name)] = apiTemplate
+	}
+
+	if len(withEntitlements) > 0 {
+		err = reportEntitlements(r.Context(), s.Authorizer, entity.TypeInstanceTemplate, withEntitlements, entitlementReportingMap)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
+	return response.SyncResponse(true, apiTemplates)
+}
+
+// swagger:operation POST /1.0/instance-templates instance-templates instance_templates_post
+//
+//	Add an instance template
+//
+//	Creates a new instance template.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: instanceTemplate
+//	    description: The new instance template
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/InstanceTemplatesPost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceTemplatesPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	req := api.InstanceTemplatesPost{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = validate.IsDeviceName(req.Name)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	projectName := request.ProjectParam(r)
+
+	p, err := instanceTemplateLoadProject(r.Context(), s, projectName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = instanceTemplateValidate(s, *p, req.InstanceTemplatePut)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	newTemplate := cluster.InstanceTemplatesRow{Name: req.Name}
+	err = newTemplate.SetDefinition(req.InstanceTemplatePut)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		newTemplate.ProjectID, err = cluster.GetProjectID(ctx, tx.Tx(), projectName)
+		if err != nil {
+			return fmt.Errorf("Failed getting project ID: %w", err)
+		}
+
+		_, err = query.Create(ctx, tx.Tx(), newTemplate)
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	lc := lifecycle.InstanceTemplateCreated.Event(projectName, req.Name, request.CreateRequestor(r.Context()), nil)
+	s.Events.SendLifecycle(projectName, lc)
+
+	return response.SyncResponseLocation(true, nil, lc.Source)
+}
+
+// swagger:operation DELETE /1.0/instance-templates/{name} instance-templates instance_template_delete
+//
+//	Delete the instance template
+//
+//	Removes the instance template.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceTemplateDelete(d *Daemon, r *http.Request) response.Response {
+	projectName := request.ProjectParam(r)
+	templateName, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = doInstanceTemplateDelete(r.Context(), d.State(), templateName, projectName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
+
+func doInstanceTemplateDelete(ctx context.Context, s *state.State, name string, projectName string) error {
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		template, err := cluster.GetInstanceTemplate(ctx, tx.Tx(), name, projectName)
+		if err != nil {
+			return err
+		}
+
+		return query.DeleteByPrimaryKey(ctx, tx.Tx(), template.Row)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.Events.SendLifecycle(projectName, lifecycle.InstanceTemplateDeleted.Event(projectName, name, request.CreateRequestor(ctx), nil))
+
+	return nil
+}
+
+// swagger:operation GET /1.0/instance-templates/{name} instance-templates instance_template_get
+//
+//	Get the instance template
+//
+//	Gets a specific instance template.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: Instance template
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/InstanceTemplate"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceTemplateGet(d *Daemon, r *http.Request) response.Response {
+	projectName := request.ProjectParam(r)
+	templateName, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	withEntitlements, err := extractEntitlementsFromQuery(r, entity.TypeInstanceTemplate, false)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	s := d.State()
+
+	template, err := instanceTemplateLoad(r.Context(), s, templateName, projectName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	etag := *template
+	if len(withEntitlements) > 0 {
+		err = reportEntitlements(r.Context(), s.Authorizer, entity.TypeInstanceTemplate, withEntitlements, map[*api.URL]auth.EntitlementReporter{entity.InstanceTemplateURL(projectName, templateName): template})
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
+	return response.SyncResponseETag(true, template, etag)
+}
+
+// swagger:operation PATCH /1.0/instance-templates/{name} instance-templates instance_template_patch
+//
+//	Partially update the instance template
+//
+//	Updates a subset of the instance template configuration.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: instanceTemplate
+//	    description: Instance template configuration
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/InstanceTemplatePut"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "412":
+//	    $ref: "#/responses/PreconditionFailed"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+
+// swagger:operation PUT /1.0/instance-templates/{name} instance-templates instance_template_put
+//
+//	Update the instance template
+//
+//	Updates the entire instance template.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: instanceTemplate
+//	    description: Instance template configuration
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/InstanceTemplatePut"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "412":
+//	    $ref: "#/responses/PreconditionFailed"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceTemplatePut(d *Daemon, r *http.Request) response.Response {
+	projectName := request.ProjectParam(r)
+	templateName, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	req := api.InstanceTemplatePut{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	s := d.State()
+
+	template, err := instanceTemplateLoad(r.Context(), s, templateName, projectName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = util.EtagCheck(r, template)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if r.Method == http.MethodPatch {
+		req = instanceTemplateMerge(template.Writable(), req)
+	}
+
+	p, err := instanceTemplateLoadProject(r.Context(), s, projectName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = instanceTemplateValidate(s, *p, req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		dbTemplate, err := cluster.GetInstanceTemplate(ctx, tx.Tx(), templateName, projectName)
+		if err != nil {
+			return err
+		}
+
+		err = dbTemplate.Row.SetDefinition(req)
+		if err != nil {
+			return err
+		}
+
+		return query.UpdateByPrimaryKey(ctx, tx.Tx(), dbTemplate.Row)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	s.Events.SendLifecycle(projectName, lifecycle.InstanceTemplateUpdated.Event(projectName, templateName, request.CreateRequestor(r.Context()), nil))
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation POST /1.0/instance-templates/{name} instance-templates instance_template_post
+//
+//	Rename the instance template
+//
+//	Renames the instance template.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: instanceTemplate
+//	    description: Instance template rename request
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/InstanceTemplatePost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceTemplatePost(d *Daemon, r *http.Request) response.Response {
+	projectName := request.ProjectParam(r)
+	templateName, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	req := api.InstanceTemplatePost{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = validate.IsDeviceName(req.Name)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	s := d.State()
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		template, err := cluster.GetInstanceTemplate(ctx, tx.Tx(), templateName, projectName)
+		if err != nil {
+			return err
+		}
+
+		template.Row.Name = req.Name
+		return query.UpdateByPrimaryKey(ctx, tx.Tx(), template.Row)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	lc := lifecycle.InstanceTemplateRenamed.Event(projectName, req.Name, request.CreateRequestor(r.Context()), map[string]any{"old_name": templateName})
+	s.Events.SendLifecycle(projectName, lc)
+
+	return response.SyncResponseLocation(true, nil, lc.Source)
+}
+
+// instanceTemplateLoad loads the instance template from the database.
+func instanceTemplateLoad(ctx context.Context, s *state.State, name string, projectName string) (*api.InstanceTemplate, error) {
+	var template *api.InstanceTemplate
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		dbTemplate, err := cluster.GetInstanceTemplate(ctx, tx.Tx(), name, projectName)
+		if err != nil {
+			return err
+		}
+
+		template, err = dbTemplate.ToAPI()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// instanceTemplateLoadProject loads the project the instance template belongs to.
+func instanceTemplateLoadProject(ctx context.Context, s *state.State, projectName string) (*api.Project, error) {
+	var p *api.Project
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		dbProject, err := cluster.GetProject(ctx, tx.Tx(), projectName)
+		if err != nil {
+			return err
+		}
+
+		p, err = dbProject.ToAPI(ctx, tx.Tx())
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading project %q: %w", projectName, err)
+	}
+
+	return p, nil
+}
+
+// instanceTemplateValidate validates the instance creation arguments of an instance template.
+func instanceTemplateValidate(s *state.State, p api.Project, req api.InstanceTemplatePut) error {
+	switch req.Type {
+	case "", api.InstanceTypeContainer, api.InstanceTypeVM:
+	default:
+		return fmt.Errorf("Invalid instance type %q", req.Type)
+	}
+
+	switch req.Source.Type {
+	case "", api.SourceTypeImage, api.SourceTypeNone:
+	default:
+		return fmt.Errorf("Invalid source type %q, instance templates only support %q and %q sources", req.Source.Type, api.SourceTypeImage, api.SourceTypeNone)
+	}
+
+	if req.InstanceType != "" {
+		_, err := instanceParseType(req.InstanceType)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Templates can be used for any instance type, like profiles, so instance type specific checks are
+	// performed when the instance is created.
+	err := instance.ValidConfig(s.OS, req.Config, false, instancetype.Any)
+	if err != nil {
+		return err
+	}
+
+	return instance.ValidDevices(s, p, instancetype.Any, deviceConfig.NewDevices(req.Devices), nil)
+}
+
+// instanceTemplateMerge merges a partial instance template update into the current instance template.
+// Config keys set to an empty value are removed.
+func instanceTemplateMerge(current api.InstanceTemplatePut, req api.InstanceTemplatePut) api.InstanceTemplatePut {
+	if req.Description != "" {
+		current.Description = req.Description
+	}
+
+	if req.Type != "" {
+		current.Type = req.Type
+	}
+
+	if req.InstanceType != "" {
+		current.InstanceType = req.InstanceType
+	}
+
+	if req.Source.Type != "" {
+		current.Source = req.Source
+	}
+
+	if req.Profiles != nil {
+		current.Profiles = req.Profiles
+	}
+
+	config := maps.Clone(current.Config)
+	for k, v := range req.Config {
+		if v == "" {
+			delete(config, k)
+			continue
+		}
+
+		config[k] = v
+	}
+
+	current.Config = config
+
+	devices := maps.Clone(current.Devices)
+	maps.Copy(devices, req.Devices)
+	current.Devices = devices
+
+	return current
+}
+
+// instanceTemplateExpand fills the instance creation request with the arguments of the requested instance template.
+// Arguments set in the request take precedence over the ones of the template. Config keys and devices are merged,
+// with the request's entries overriding those of the template with the same name.
+func instanceTemplateExpand(ctx context.Context, s *state.State, projectName string, req *api.InstancesPost) error {
+	err := s.Authorizer.CheckPermission(ctx, entity.InstanceTemplateURL(projectName, req.Template), auth.EntitlementCanView)
+	if err != nil {
+		return err
+	}
+
+	template, err := instanceTemplateLoad(ctx, s, req.Template, projectName)
+	if err != nil {
+		return fmt.Errorf("Failed loading instance template %q: %w", req.Template, err)
+	}
+
+	if req.Type == "" {
+		req.Type = template.Type
+	}
+
+	if req.InstanceType == "" {
+		req.InstanceType = template.InstanceType
+	}
+
+	if req.Source.Type == "" {
+		req.Source = template.Source
+	}
+
+	if req.Profiles == nil && len(template.Profiles) > 0 {
+		req.Profiles = template.Profiles
+	}
+
+	config := maps.Clone(template.Config)
+	maps.Copy(config, req.Config)
+	req.Config = config
+
+	devices := maps.Clone(template.Devices)
+	maps.Copy(devices, req.Devices)
+	req.Devices = devices
+
+	// The request now carries the expanded arguments so it can be forwarded to other members as is.
+	req.Template = ""
+
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/canonical/lxd/lxd/db"
 	"github.com/canonical/lxd/lxd/db/warningtype"
 	"github.com/canonical/lxd/lxd/instance"
+	"github.com/canonical/lxd/lxd/instance/drivers"
 	"github.com/canonical/lxd/lxd/instance/instancetype"
 	"github.com/canonical/lxd/lxd/operations"
 	"github.com/canonical/lxd/lxd/project"
@@ -300,6 +301,18 @@ func instancesStart(ctx context.Context, s *state.State, instances []instance.In
 	}
 }
 
+// instancesAutoRestartResume resumes the automatic restarts that were pending when LXD was stopped.
+func instancesAutoRestartResume(s *state.State, instances []instance.Instance) {
+	// Check if the cluster is currently evacuated.
+	if s.DB.Cluster.LocalNodeIsEvacuated() {
+		return
+	}
+
+	for _, inst := range instances {
+		drivers.AutoRestartResume(s, inst)
+	}
+}
+
 type instanceStopList []instance.Instance
 
 func (slice instanceStopList) Len() int {
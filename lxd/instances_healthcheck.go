@@ -0,0 +1,327 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kballard/go-shellquote"
+	"golang.org/x/sys/unix"
+
+	"github.com/canonical/lxd/lxd/instance"
+	"github.com/canonical/lxd/lxd/instance/healthcheck"
+	"github.com/canonical/lxd/lxd/instance/instancetype"
+	"github.com/canonical/lxd/lxd/lifecycle"
+	"github.com/canonical/lxd/lxd/project"
+	"github.com/canonical/lxd/lxd/state"
+	"github.com/canonical/lxd/lxd/task"
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/logger"
+)
+
+// instanceHealthCheckTaskInterval is how often instances are looked at for due health checks.
+const instanceHealthCheckTaskInterval = 5 * time.Second
+
+// instanceHealthCheckRestartTimeout is how long an unhealthy instance is given to shut down cleanly when restarted.
+const instanceHealthCheckRestartTimeout = 30 * time.Second
+
+// instanceHealthCheck tracks the health check schedule of a local instance.
+type instanceHealthCheck struct {
+	next    time.Time
+	running bool
+}
+
+var instanceHealthChecksMu sync.Mutex
+var instanceHealthChecks = map[string]*instanceHealthCheck{}
+
+// instanceHealthCheckTask returns a task that runs the configured health checks of the local instances.
+func instanceHealthCheckTask(stateFunc func() *state.State) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		err := instanceHealthCheckRun(ctx, stateFunc())
+		if err != nil {
+			logger.Error("Failed running instance health checks", logger.Ctx{"err": err})
+		}
+	}
+
+	return f, task.Every(instanceHealthCheckTaskInterval)
+}
+
+// instanceHealthCheckRun starts the health checks which are due on the local instances.
+// Each check runs in the background so that a slow check doesn't delay the others.
+func instanceHealthCheckRun(ctx context.Context, s *state.State) error {
+	instances, err := instance.LoadNodeAll(s, instancetype.Any)
+	if err != nil {
+		return fmt.Errorf("Failed loading instances: %w", err)
+	}
+
+	instanceHealthChecksMu.Lock()
+	defer instanceHealthChecksMu.Unlock()
+
+	seen := make(map[string]bool, len(instances))
+
+	for _, inst := range instances {
+		key := project.Instance(inst.Project().Name, inst.Name())
+
+		if inst.ExpandedConfig()["healthcheck.type"] == "" || !inst.IsRunning() || inst.IsFrozen() {
+			continue
+		}
+
+		seen[key] = true
+
+		check := instanceHealthChecks[key]
+		if check == nil {
+			check = &instanceHealthCheck{}
+			instanceHealthChecks[key] = check
+		}
+
+		if check.running || time.Now().Before(check.next) {
+			continue
+		}
+
+		interval := instanceHealthCheckConfigSeconds(inst, "healthcheck.interval", 30)
+		check.next = time.Now().Add(interval)
+		check.running = true
+
+		go func(inst instance.Instance, check *instanceHealthCheck) {
+			instanceHealthCheckInstance(ctx, s, inst)
+
+			instanceHealthChecksMu.Lock()
+			check.running = false
+			instanceHealthChecksMu.Unlock()
+		}(inst, check)
+	}
+
+	// Forget about the instances which are gone or no longer have a health check.
+	for key, check := range instanceHealthChecks {
+		if seen[key] || check.running {
+			continue
+		}
+
+		delete(instanceHealthChecks, key)
+
+		projectName, instanceName := project.InstanceParts(key)
+		healthcheck.Forget(projectName, instanceName)
+	}
+
+	return nil
+}
+
+// instanceHealthCheckConfigSeconds returns the duration in seconds from the specified instance config key, or the
+// default if not set.
+func instanceHealthCheckConfigSeconds(inst instance.Instance, key string, defaultSeconds int) time.Duration {
+	value := inst.ExpandedConfig()[key]
+	if value == "" {
+		return time.Duration(defaultSeconds) * time.Second
+	}
+
+	seconds, _ := strconv.Atoi(value)
+
+	return time.Duration(seconds) * time.Second
+}
+
+// instanceHealthCheckInstance runs the health check of an instance, records its result and acts on status changes.
+func instanceHealthCheckInstance(ctx context.Context, s *state.State, inst instance.Instance) {
+	config := inst.ExpandedConfig()
+
+	// The init PID identifies the current run of the instance, so that results of a previous run are discarded.
+	pid := inst.InitPID()
+	if pid <= 0 {
+		return
+	}
+
+	timeout := instanceHealthCheckConfigSeconds(inst, "healthcheck.timeout", 5)
+	startPeriod := instanceHealthCheckConfigSeconds(inst, "healthcheck.start_period", 0)
+
+	retries := 3
+	if config["healthcheck.retries"] != "" {
+		retries, _ = strconv.Atoi(config["healthcheck.retries"])
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var err error
+	switch config["healthcheck.type"] {
+	case "exec":
+		err = instanceHealthCheckExec(checkCtx, inst, config["healthcheck.command"])
+	case "tcp":
+		err = instanceHealthCheckTCP(checkCtx, inst, config["healthcheck.port"])
+	case "http":
+		err = instanceHealthCheckHTTP(checkCtx, inst, config["healthcheck.port"], config["healthcheck.path"])
+	default:
+		return
+	}
+
+	// Skip recording the result if the instance stopped or restarted while being checked.
+	if inst.InitPID() != pid {
+		return
+	}
+
+	// The last used date is updated every time the instance is started.
+	inStartPeriod := time.Since(inst.LastUsedDate()) < startPeriod
+
+	if err != nil {
+		logger.Debug("Instance health check failed", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "err": err})
+	}
+
+	oldStatus, newStatus := healthcheck.Record(inst.Project().Name, inst.Name(), pid, err == nil, retries, inStartPeriod)
+	if oldStatus == newStatus {
+		return
+	}
+
+	s.Events.SendLifecycle(inst.Project().Name, lifecycle.InstanceHealthChanged.Event(context.Background(), inst, map[string]any{"status": newStatus, "old_status": oldStatus}))
+
+	if newStatus == healthcheck.StatusUnhealthy && config["healthcheck.action"] == "restart" {
+		logger.Warn("Restarting unhealthy instance", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
+
+		err = inst.Restart(ctx, instanceHealthCheckRestartTimeout, nil)
+		if err != nil {
+			logger.Error("Failed restarting unhealthy instance", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "err": err})
+		}
+	}
+}
+
+// instanceHealthCheckExec runs the command inside the instance and checks that it exits successfully.
+func instanceHealthCheckExec(ctx context.Context, inst instance.Instance, command string) error {
+	args, err := shellquote.Split(command)
+	if err != nil {
+		return fmt.Errorf("Failed parsing health check command: %w", err)
+	}
+
+	if len(args) == 0 {
+		return errors.New("No health check command set")
+	}
+
+	devNull, err := os.OpenFile(os.DevNull, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = devNull.Close() }()
+
+	req := api.InstanceExecPost{
+		Command:     args,
+		Environment: map[string]string{},
+		WaitForWS:   false,
+	}
+
+	cmd, err := inst.Exec(ctx, req, devNull, devNull, devNull)
+	if err != nil {
+		return fmt.Errorf("Failed running health check command: %w", err)
+	}
+
+	type result struct {
+		exitCode int
+		err      error
+	}
+
+	resultCh := make(chan result, 1)
+	go func() {
+		exitCode, err := cmd.Wait()
+		resultCh <- result{exitCode: exitCode, err: err}
+	}()
+
+	var res result
+	select {
+	case res = <-resultCh:
+	case <-ctx.Done():
+		_ = cmd.Signal(unix.SIGKILL)
+		return fmt.Errorf("Health check command timed out: %w", ctx.Err())
+	}
+
+	if res.err != nil {
+		return fmt.Errorf("Failed waiting for health check command: %w", res.err)
+	}
+
+	if res.exitCode != 0 {
+		return fmt.Errorf("Health check command exited with status %d", res.exitCode)
+	}
+
+	return nil
+}
+
+// instanceHealthCheckAddress returns the address of the instance to probe on the specified port.
+// The first global address of the instance's network interfaces is used.
+func instanceHealthCheckAddress(inst instance.Instance, port string) (string, error) {
+	hostInterfaces, _ := net.Interfaces()
+
+	instState, err := inst.RenderState(hostInterfaces, instance.StateRenderOptions{IncludeNetwork: true})
+	if err != nil {
+		return "", fmt.Errorf("Failed getting instance state: %w", err)
+	}
+
+	for _, family := range []string{"inet", "inet6"} {
+		for netName, network := range instState.Network {
+			if netName == "lo" {
+				continue
+			}
+
+			for _, address := range network.Addresses {
+				if address.Family == family && address.Scope == "global" {
+					return net.JoinHostPort(address.Address, port), nil
+				}
+			}
+		}
+	}
+
+	return "", errors.New("Instance has no global address")
+}
+
+// instanceHealthCheckTCP checks that a TCP connection can be established to the port on the instance.
+func instanceHealthCheckTCP(ctx context.Context, inst instance.Instance, port string) error {
+	address, err := instanceHealthCheckAddress(inst, port)
+	if err != nil {
+		return err
+	}
+
+	dialer := net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+// instanceHealthCheckHTTP checks that an HTTP request to the path on the instance's port returns a 2xx or 3xx status code.
+func instanceHealthCheckHTTP(ctx context.Context, inst instance.Instance, port string, path string) error {
+	address, err := instanceHealthCheckAddress(inst, port)
+	if err != nil {
+		return err
+	}
+
+	if path == "" {
+		path = "/"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+address+path, nil)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		// Don't follow redirects, a redirect response is considered healthy.
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	_ = resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("Health check request returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/canonical/lxd/lxd/instance"
+	"github.com/canonical/lxd/lxd/instance/instancetype"
+	"github.com/canonical/lxd/lxd/instance/operationlock"
+	"github.com/canonical/lxd/lxd/resources"
+	"github.com/canonical/lxd/lxd/state"
+	"github.com/canonical/lxd/lxd/task"
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/lxd/shared/logger"
+)
+
+// memoryPressureInterval is the interval at which the host memory pressure is checked.
+// At most one instance is suspended or resumed per interval so that the effect on the pressure can be observed.
+const memoryPressureInterval = 10 * time.Second
+
+// memoryPressureIdleCPU is the share of a single CPU below which a virtual machine is considered idle.
+const memoryPressureIdleCPU = 0.05
+
+// memoryPressureSuspendedKey records that an instance was statefully stopped because of host memory pressure.
+const memoryPressureSuspendedKey = "volatile.memory_pressure.suspended"
+
+// memoryPressureCPUTimes records the CPU time (in clock ticks) of the local virtual machines at the previous check.
+// It is only accessed from the memory pressure task.
+var memoryPressureCPUTimes = map[int]uint64{}
+
+// instanceMemoryPressureTask returns a task that statefully stops idle virtual machines when the host memory
+// pressure rises above instances.memory_pressure.suspend_threshold and starts them again when it drops.
+func instanceMemoryPressureTask(stateFunc func() *state.State) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		err := instanceMemoryPressure(ctx, stateFunc())
+		if err != nil {
+			logger.Error("Failed handling host memory pressure", logger.Ctx{"err": err})
+		}
+	}
+
+	return f, task.Every(memoryPressureInterval, task.SkipFirst)
+}
+
+// instanceMemoryPressure suspends or resumes a local virtual machine depending on the host memory pressure.
+func instanceMemoryPressure(ctx context.Context, s *state.State) error {
+	suspendThreshold, resumeThreshold := s.GlobalConfig.InstancesMemoryPressureThresholds()
+	if suspendThreshold == 0 {
+		clear(memoryPressureCPUTimes)
+		return nil
+	}
+
+	pressure, err := resources.GetMemoryPressure()
+	if err != nil {
+		return fmt.Errorf("Failed getting host memory pressure: %w", err)
+	}
+
+	instances, err := instance.LoadNodeAll(s, instancetype.VM)
+	if err != nil {
+		return fmt.Errorf("Failed loading instances: %w", err)
+	}
+
+	// Clear the suspension flag of instances that were started since.
+	for _, inst := range instances {
+		if inst.IsRunning() && shared.IsTrue(inst.LocalConfig()[memoryPressureSuspendedKey]) {
+			err := inst.VolatileSet(map[string]string{memoryPressureSuspendedKey: ""})
+			if err != nil {
+				logger.Warn("Failed clearing instance memory pressure suspension flag", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "err": err})
+			}
+		}
+	}
+
+	idle := memoryPressureIdleInstances(instances)
+
+	if pressure.SomeAvg10 >= float64(suspendThreshold) {
+		candidates := memoryPressureSuspendCandidates(instances, idle)
+		if len(candidates) == 0 {
+			logger.Debug("Host memory pressure is high but no instance can be suspended", logger.Ctx{"pressure": pressure.SomeAvg10})
+			return nil
+		}
+
+		inst := candidates[0]
+		l := logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "pressure": pressure.SomeAvg10})
+
+		l.Info("Suspending instance because of host memory pressure")
+		err := inst.Stop(ctx, true)
+		if err != nil {
+			return fmt.Errorf("Failed suspending instance %q in project %q: %w", inst.Name(), inst.Project().Name, err)
+		}
+
+		err = inst.VolatileSet(map[string]string{memoryPressureSuspendedKey: "true"})
+		if err != nil {
+			return fmt.Errorf("Failed recording suspension of instance %q in project %q: %w", inst.Name(), inst.Project().Name, err)
+		}
+
+		return nil
+	}
+
+	if pressure.SomeAvg10 >= float64(resumeThreshold) || s.DB.Cluster.LocalNodeIsEvacuated() {
+		return nil
+	}
+
+	candidates := memoryPressureResumeCandidates(instances)
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	inst := candidates[0]
+	logger.Info("Resuming instance suspended because of host memory pressure", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "pressure": pressure.SomeAvg10})
+
+	return instanceResumeOnDemand(ctx, inst)
+}
+
+// memoryPressureIdleInstances returns the IDs of the running instances whose CPU usage since the previous check
+// is below memoryPressureIdleCPU, and records their current CPU time for the next check.
+func memoryPressureIdleInstances(instances []instance.Instance) map[int]bool {
+	idle := map[int]bool{}
+	cpuTimes := make(map[int]uint64, len(instances))
+
+	for _, inst := range instances {
+		if !inst.IsRunning() || inst.IsFrozen() {
+			continue
+		}
+
+		cpuTime, err := processCPUTime(inst.InitPID())
+		if err != nil {
+			continue
+		}
+
+		cpuTimes[inst.ID()] = cpuTime
+
+		previous, ok := memoryPressureCPUTimes[inst.ID()]
+		if ok && cpuTime >= previous {
+			// Clock ticks are exposed in USER_HZ, which is always 100 per second.
+			usage := float64(cpuTime-previous) / (memoryPressureInterval.Seconds() * 100)
+			idle[inst.ID()] = usage < memoryPressureIdleCPU
+		}
+	}
+
+	memoryPressureCPUTimes = cpuTimes
+
+	return idle
+}
+
+// memoryPressureSuspendCandidates returns the running virtual machines that can be suspended, sorted by
+// ascending limits.memory.suspend.priority.
+func memoryPressureSuspendCandidates(instances []instance.Instance, idle map[int]bool) []instance.Instance {
+	candidates := []instance.Instance{}
+	for _, inst := range instances {
+		config := inst.ExpandedConfig()
+		if config["limits.memory.suspend.priority"] == "" || shared.IsFalseOrEmpty(config["migration.stateful"]) {
+			continue
+		}
+
+		if !idle[inst.ID()] || !inst.IsRunning() || operationlock.Get(inst.Project().Name, inst.Name()) != nil {
+			continue
+		}
+
+		candidates = append(candidates, inst)
+	}
+
+	slices.SortStableFunc(candidates, func(a instance.Instance, b instance.Instance) int {
+		return memoryPressureSuspendPriority(a) - memoryPressureSuspendPriority(b)
+	})
+
+	return candidates
+}
+
+// memoryPressureResumeCandidates returns the virtual machines suspended because of memory pressure, sorted by
+// descending limits.memory.suspend.priority.
+func memoryPressureResumeCandidates(instances []instance.Instance) []instance.Instance {
+	candidates := []instance.Instance{}
+	for _, inst := range instances {
+		if shared.IsFalseOrEmpty(inst.LocalConfig()[memoryPressureSuspendedKey]) || inst.IsRunning() {
+			continue
+		}
+
+		candidates = append(candidates, inst)
+	}
+
+	slices.SortStableFunc(candidates, func(a instance.Instance, b instance.Instance) int {
+		return memoryPressureSuspendPriority(b) - memoryPressureSuspendPriority(a)
+	})
+
+	return candidates
+}
+
+// memoryPressureSuspendPriority returns the limits.memory.suspend.priority of an instance.
+func memoryPressureSuspendPriority(inst instance.Instance) int {
+	priority, _ := strconv.Atoi(inst.ExpandedConfig()["limits.memory.suspend.priority"])
+	return priority
+}
+
+// instanceResumeOnDemand statefully starts an instance that was suspended because of host memory pressure.
+// It does nothing if the instance isn't suspended.
+func instanceResumeOnDemand(ctx context.Context, inst instance.Instance) error {
+	if shared.IsFalseOrEmpty(inst.LocalConfig()[memoryPressureSuspendedKey]) {
+		return nil
+	}
+
+	if !inst.IsRunning() {
+		err := inst.Start(ctx, nil, inst.IsStateful())
+		if err != nil {
+			return fmt.Errorf("Failed resuming instance %q in project %q: %w", inst.Name(), inst.Project().Name, err)
+		}
+	}
+
+	return inst.VolatileSet(map[string]string{memoryPressureSuspendedKey: ""})
+}
+
+// processCPUTime returns the CPU time (user and system, in clock ticks) consumed by a process.
+func processCPUTime(pid int) (uint64, error) {
+	if pid <= 0 {
+		return 0, errors.New("Invalid PID")
+	}
+
+	content, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0, err
+	}
+
+	// Skip the command name as it may contain spaces, the remaining fields start with the process state.
+	idx := strings.LastIndex(string(content), ")")
+	if idx < 0 {
+		return 0, errors.New("Invalid process stat")
+	}
+
+	values := strings.Fields(string(content)[idx+1:])
+	if len(values) < 13 {
+		return 0, errors.New("Invalid process stat")
+	}
+
+	utime, err := strconv.ParseUint(values[11], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	stime, err := strconv.ParseUint(values[12], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return utime + stime, nil
+}
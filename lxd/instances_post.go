@@ -11,10 +11,14 @@ import (
 	"os"
 	"slices"
 	"strconv"
+	"strings"
+	"sync"
 
 	petname "github.com/dustinkirkland/golang-petname"
 	"github.com/google/uuid"
+	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/canonical/lxd/client"
 	"github.com/canonical/lxd/lxd/archive"
@@ -28,6 +32,7 @@ import (
 	"github.com/canonical/lxd/lxd/instance"
 	"github.com/canonical/lxd/lxd/instance/instancetype"
 	"github.com/canonical/lxd/lxd/instance/operationlock"
+	"github.com/canonical/lxd/lxd/metrics"
 	"github.com/canonical/lxd/lxd/operations"
 	"github.com/canonical/lxd/lxd/placement"
 	"github.com/canonical/lxd/lxd/project"
@@ -1263,6 +1268,14 @@ func instancesPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
+	// Expand the instance template if requested.
+	if req.Template != "" {
+		err = instanceTemplateExpand(r.Context(), s, targetProjectName, &req)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
 	// Set type from URL if missing
 	urlType, err := urlInstanceTypeDetect(r)
 	if err != nil {
@@ -1321,6 +1334,11 @@ func instancesPost(d *Daemon, r *http.Request) response.Response {
 		}
 	}
 
+	// Create multiple instances from the same source if requested.
+	if req.Count > 0 {
+		return instancesPostBulk(d, r, targetProjectName, req)
+	}
+
 	var targetProject *api.Project
 	var profiles []api.Profile
 	var sourceInst *dbCluster.Instance
@@ -1915,3 +1933,282 @@ func instanceCreateFinish(ctx context.Context, s *state.State, req *api.Instance
 
 	return inst.Start(ctx, op, false)
 }
+
+// instanceBulkCreateMax is the maximum number of instances that can be created by a single request.
+const instanceBulkCreateMax = 100
+
+// instanceBulkCreateParallelism is the number of instances that are created concurrently by a bulk request.
+const instanceBulkCreateParallelism = 4
+
+// instancesPostBulkNames returns the names of the instances to create for a bulk request.
+// Each occurrence of "{n}" in the pattern is replaced by the index of the instance (starting at 1). If the pattern
+// doesn't contain it, "-{n}" is appended. Random names are generated if the pattern is empty.
+func instancesPostBulkNames(pattern string, count int, existingNames []string) ([]string, error) {
+	names := make([]string, 0, count)
+
+	if pattern == "" {
+		for len(names) < count {
+			i := 0
+			for {
+				i++
+				name := petname.Generate(2, "-")
+				if !slices.Contains(existingNames, name) && !slices.Contains(names, name) {
+					names = append(names, name)
+					break
+				}
+
+				if i > 100 {
+					return nil, errors.New("Could not generate a new unique name after 100 tries")
+				}
+			}
+		}
+
+		return names, nil
+	}
+
+	if !strings.Contains(pattern, "{n}") {
+		pattern += "-{n}"
+	}
+
+	for i := 1; i <= count; i++ {
+		name := strings.ReplaceAll(pattern, "{n}", strconv.Itoa(i))
+
+		err := instancetype.ValidName(name, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if slices.Contains(existingNames, name) {
+			return nil, api.StatusErrorf(http.StatusConflict, "Instance %q already exists", name)
+		}
+
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+// instancesPostBulk creates req.Count instances from the same source in a single operation.
+// Each instance is created in-process through the regular instance creation handler, using the context of the
+// original request. This way each instance is subject to the same placement, permission checks, project limits and
+// storage handling (including clones of the image or source volume) as instances created one by one, and is
+// attributed to the original caller. When the cluster scheduler picks the members, the instances are created one
+// after the other so that each placement decision accounts for the previously created instances.
+func instancesPostBulk(d *Daemon, r *http.Request, projectName string, req api.InstancesPost) response.Response {
+	s := d.State()
+
+	if req.Count > instanceBulkCreateMax {
+		return response.BadRequest(fmt.Errorf("Cannot create more than %d instances at once", instanceBulkCreateMax))
+	}
+
+	switch req.Source.Type {
+	case api.SourceTypeImage, api.SourceTypeNone:
+	case api.SourceTypeCopy:
+		if req.Source.Refresh {
+			return response.BadRequest(errors.New("Refresh isn't supported when creating multiple instances"))
+		}
+
+	default:
+		return response.BadRequest(fmt.Errorf("Source type %q isn't supported when creating multiple instances", req.Source.Type))
+	}
+
+	target := request.QueryParam(r, "target")
+	if !s.ServerClustered && target != "" {
+		return response.BadRequest(errors.New("Target only allowed when clustered"))
+	}
+
+	// Check the request as a whole before scheduling the operation, so that it fails early rather than after
+	// creating some of the instances.
+	var names []string
+	err := s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		dbProject, err := dbCluster.GetProject(ctx, tx.Tx(), projectName)
+		if err != nil {
+			return fmt.Errorf("Failed loading project %q: %w", projectName, err)
+		}
+
+		targetProject, err := dbProject.ToAPI(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		if s.ServerClustered {
+			allMembers, err := tx.GetNodes(ctx)
+			if err != nil {
+				return fmt.Errorf("Failed getting cluster members: %w", err)
+			}
+
+			_, _, err = limits.CheckTarget(ctx, s.Authorizer, tx, targetProject, target, allMembers)
+			if err != nil {
+				return err
+			}
+		}
+
+		if req.Source.Type == api.SourceTypeImage {
+			var sourceImageRef string
+			_, err := resolveSourceImageFromCache(r, s, tx, projectName, req.Source, &sourceImageRef, string(req.Type))
+			if err != nil {
+				return err
+			}
+		}
+
+		existingNames, err := tx.GetInstanceNames(ctx, projectName)
+		if err != nil {
+			return err
+		}
+
+		names, err = instancesPostBulkNames(req.Name, req.Count, existingNames)
+		if err != nil {
+			return err
+		}
+
+		if req.Source.Type != api.SourceTypeCopy {
+			err = limits.AllowInstancesCreation(ctx, s.GlobalConfig, tx, projectName, req, names)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	instanceURLs := make([]string, 0, len(names))
+	for _, name := range names {
+		instanceURLs = append(instanceURLs, entity.InstanceURL(projectName, name).String())
+	}
+
+	// Keep the values of the request context (like the requestor) without tying the creation to its cancellation.
+	requestCtx := context.WithoutCancel(r.Context())
+
+	// Only report the API metrics of the request once the bulk operation completes.
+	requestCtx = context.WithValue(requestCtx, request.CtxMetricsCallbackFunc, func(metrics.RequestResult) {})
+
+	run := func(ctx context.Context, op *operations.Operation) error {
+		// Stop creating instances if the bulk operation is cancelled.
+		callerCtx, cancel := context.WithCancel(requestCtx)
+		defer cancel()
+
+		stop := context.AfterFunc(ctx, cancel)
+		defer stop()
+
+		reverter := revert.New()
+		defer reverter.Fail()
+
+		g, gctx := errgroup.WithContext(callerCtx)
+
+		// Let the scheduler see the previously created instances when it picks the cluster members.
+		if s.ServerClustered && (target == "" || strings.HasPrefix(target, "@")) {
+			g.SetLimit(1)
+		} else {
+			g.SetLimit(instanceBulkCreateParallelism)
+		}
+
+		var createdLock sync.Mutex
+		created := 0
+
+		for _, name := range names {
+			g.Go(func() error {
+				instReq := req
+				instReq.Name = name
+				instReq.Count = 0
+				instReq.Template = ""
+
+				reqURL := api.NewURL().Path(version.APIVersion, "instances").Project(projectName)
+				if target != "" {
+					reqURL = reqURL.WithQuery("target", target)
+				}
+
+				createReq, err := lxd.NewRequestWithContext(gctx, http.MethodPost, reqURL.String(), instReq, "")
+				if err != nil {
+					return err
+				}
+
+				createOp, err := response.NewResponseCapture(createReq).RenderToOperation(instancesPost(d, createReq))
+				if err != nil {
+					return fmt.Errorf("Failed creating instance %q: %w", name, err)
+				}
+
+				err = instancesPostBulkWait(gctx, d, projectName, createOp.ID)
+				if err != nil {
+					return fmt.Errorf("Failed creating instance %q: %w", name, err)
+				}
+
+				createdLock.Lock()
+				defer createdLock.Unlock()
+
+				reverter.Add(func() {
+					deleteURL := api.NewURL().Path(version.APIVersion, "instances", name).Project(projectName).WithQuery("force", "1")
+					deleteReq, err := lxd.NewRequestWithContext(requestCtx, http.MethodDelete, deleteURL.String(), nil, "")
+					if err != nil {
+						return
+					}
+
+					deleteReq = mux.SetURLVars(deleteReq, map[string]string{"name": name})
+					deleteOp, err := response.NewResponseCapture(deleteReq).RenderToOperation(instanceDelete(d, deleteReq))
+					if err == nil {
+						_ = instancesPostBulkWait(requestCtx, d, projectName, deleteOp.ID)
+					}
+				})
+
+				created++
+				_ = op.UpdateMetadata(map[string]any{
+					"instances":         instanceURLs,
+					"instances_created": created,
+				})
+
+				return nil
+			})
+		}
+
+		err := g.Wait()
+		if err != nil {
+			return err
+		}
+
+		reverter.Success()
+		return nil
+	}
+
+	args := operations.OperationArgs{
+		ProjectName: projectName,
+		EntityURL:   api.NewURL().Path(version.APIVersion, "projects", projectName),
+		Type:        operationtype.InstanceCreateBulk,
+		Class:       operations.OperationClassTask,
+		RunHook:     run,
+		Metadata: map[string]any{
+			"instances":         instanceURLs,
+			"instances_created": 0,
+		},
+	}
+
+	op, err := operations.ScheduleUserOperationFromRequest(s, r, args)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return operations.OperationResponse(op)
+}
+
+// instancesPostBulkWait waits for an operation started on behalf of a bulk request to complete.
+// The operation may be running on another cluster member, in which case the wait is forwarded to it.
+func instancesPostBulkWait(ctx context.Context, d *Daemon, projectName string, opID string) error {
+	waitURL := api.NewURL().Path(version.APIVersion, "operations", opID).Project(projectName)
+	waitReq, err := lxd.NewRequestWithContext(ctx, http.MethodGet, waitURL.String(), nil, "")
+	if err != nil {
+		return err
+	}
+
+	waitReq = mux.SetURLVars(waitReq, map[string]string{"id": opID})
+	op, err := response.NewResponseCapture(waitReq).RenderToOperation(operationWaitGet(d, waitReq))
+	if err != nil {
+		return err
+	}
+
+	if op.StatusCode != api.Success {
+		return errors.New(op.Err)
+	}
+
+	return nil
+}
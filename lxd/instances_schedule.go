@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/canonical/lxd/lxd/instance"
+	"github.com/canonical/lxd/lxd/instance/instancetype"
+	"github.com/canonical/lxd/lxd/state"
+	"github.com/canonical/lxd/lxd/task"
+	"github.com/canonical/lxd/shared/logger"
+)
+
+// instanceScheduledPowerTask returns a task that starts and stops the local instances according to their
+// boot.schedule.start and boot.schedule.stop settings.
+func instanceScheduledPowerTask(stateFunc func() *state.State) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		err := instanceScheduledPower(ctx, stateFunc())
+		if err != nil {
+			logger.Error("Failed running scheduled instance power actions", logger.Ctx{"err": err})
+		}
+	}
+
+	first := true
+	schedule := func() (time.Duration, error) {
+		interval := time.Minute
+
+		if first {
+			first = false
+			return interval, task.ErrSkip
+		}
+
+		return interval, nil
+	}
+
+	return f, schedule
+}
+
+// instanceScheduledPower starts and stops the local instances which are scheduled to do so at the current minute.
+func instanceScheduledPower(ctx context.Context, s *state.State) error {
+	instances, err := instance.LoadNodeAll(s, instancetype.Any)
+	if err != nil {
+		return fmt.Errorf("Failed loading instances: %w", err)
+	}
+
+	// Don't start instances on an evacuated cluster member.
+	evacuated := s.DB.Cluster.LocalNodeIsEvacuated()
+
+	wg := sync.WaitGroup{}
+	for _, inst := range instances {
+		config := inst.ExpandedConfig()
+
+		start := config["boot.schedule.start"] != "" && snapshotIsScheduledNow(config["boot.schedule.start"], int64(inst.ID()))
+		stop := config["boot.schedule.stop"] != "" && snapshotIsScheduledNow(config["boot.schedule.stop"], int64(inst.ID()))
+
+		if start && stop {
+			logger.Warn("Instance is scheduled to both start and stop, skipping", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
+			continue
+		}
+
+		if start && !inst.IsRunning() && !evacuated {
+			wg.Go(func() { instanceScheduledStart(ctx, inst) })
+		} else if stop && inst.IsRunning() {
+			wg.Go(func() { instanceScheduledStop(ctx, inst) })
+		}
+	}
+
+	wg.Wait()
+
+	return nil
+}
+
+// instanceScheduledStart starts an instance as scheduled by boot.schedule.start.
+func instanceScheduledStart(ctx context.Context, inst instance.Instance) {
+	l := logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
+
+	l.Info("Starting scheduled instance")
+	err := inst.Start(ctx, nil, false)
+	if err != nil {
+		l.Error("Failed starting scheduled instance", logger.Ctx{"err": err})
+	}
+}
+
+// instanceScheduledStop stops an instance as scheduled by boot.schedule.stop.
+// The instance is given boot.host_shutdown_timeout seconds to shut down cleanly before being forcefully stopped.
+func instanceScheduledStop(ctx context.Context, inst instance.Instance) {
+	l := logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
+
+	timeoutSeconds := 30
+	value, ok := inst.ExpandedConfig()["boot.host_shutdown_timeout"]
+	if ok {
+		timeoutSeconds, _ = strconv.Atoi(value)
+	}
+
+	l.Info("Stopping scheduled instance")
+	err := inst.Shutdown(ctx, time.Second*time.Duration(timeoutSeconds))
+	if err != nil {
+		l.Warn("Failed shutting down scheduled instance, forcefully stopping", logger.Ctx{"err": err})
+		err = inst.Stop(ctx, false)
+		if err != nil {
+			l.Error("Failed forcefully stopping scheduled instance", logger.Ctx{"err": err})
+		}
+	}
+}
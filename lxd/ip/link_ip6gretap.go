@@ -0,0 +1,18 @@
+package ip
+
+// IP6Gretap represents arguments for link of type ip6gretap.
+type IP6Gretap struct {
+	Link
+	Local  string
+	Remote string
+}
+
+// additionalArgs generates ip6gretap specific arguments.
+func (g *IP6Gretap) additionalArgs() []string {
+	return []string{"local", g.Local, "remote", g.Remote}
+}
+
+// Add adds new virtual link.
+func (g *IP6Gretap) Add() error {
+	return g.add("ip6gretap", g.additionalArgs())
+}
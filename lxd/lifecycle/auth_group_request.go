@@ -0,0 +1,28 @@
+package lifecycle
+
+import (
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/version"
+)
+
+// AuthGroupRequestAction represents a lifecycle event action for requests of temporary group membership.
+type AuthGroupRequestAction string
+
+// All supported lifecycle events for requests of temporary group membership.
+const (
+	AuthGroupRequestCreated  = AuthGroupRequestAction(api.EventLifecycleAuthGroupRequestCreated)
+	AuthGroupRequestApproved = AuthGroupRequestAction(api.EventLifecycleAuthGroupRequestApproved)
+	AuthGroupRequestDeleted  = AuthGroupRequestAction(api.EventLifecycleAuthGroupRequestDeleted)
+)
+
+// Event creates the lifecycle event for an action on a request of temporary group membership.
+func (a AuthGroupRequestAction) Event(id string, requestor *api.EventLifecycleRequestor, ctx map[string]any) api.EventLifecycle {
+	u := api.NewURL().Path(version.APIVersion, "auth", "group-requests", id)
+
+	return api.EventLifecycle{
+		Action:    string(a),
+		Source:    u.String(),
+		Context:   ctx,
+		Requestor: requestor,
+	}
+}
@@ -10,9 +10,10 @@ type IdentityAction string
 
 // All supported lifecycle events for identities.
 const (
-	IdentityCreated = IdentityAction(api.EventLifecycleIdentityCreated)
-	IdentityUpdated = IdentityAction(api.EventLifecycleIdentityUpdated)
-	IdentityDeleted = IdentityAction(api.EventLifecycleIdentityDeleted)
+	IdentityCreated      = IdentityAction(api.EventLifecycleIdentityCreated)
+	IdentityUpdated      = IdentityAction(api.EventLifecycleIdentityUpdated)
+	IdentityDeleted      = IdentityAction(api.EventLifecycleIdentityDeleted)
+	IdentityGroupExpired = IdentityAction(api.EventLifecycleIdentityGroupExpired)
 )
 
 // Event creates the lifecycle event for an action on an Identity.
@@ -24,6 +24,7 @@ const (
 	InstanceStopped          = InstanceAction(api.EventLifecycleInstanceStopped)
 	InstanceShutdown         = InstanceAction(api.EventLifecycleInstanceShutdown)
 	InstanceRestarted        = InstanceAction(api.EventLifecycleInstanceRestarted)
+	InstanceAutoRestarted    = InstanceAction(api.EventLifecycleInstanceAutoRestarted)
 	InstancePaused           = InstanceAction(api.EventLifecycleInstancePaused)
 	InstanceReady            = InstanceAction(api.EventLifecycleInstanceReady)
 	InstanceResumed          = InstanceAction(api.EventLifecycleInstanceResumed)
@@ -39,6 +40,9 @@ const (
 	InstanceFileRetrieved    = InstanceAction(api.EventLifecycleInstanceFileRetrieved)
 	InstanceFilePushed       = InstanceAction(api.EventLifecycleInstanceFilePushed)
 	InstanceFileDeleted      = InstanceAction(api.EventLifecycleInstanceFileDeleted)
+	InstanceHealthChanged    = InstanceAction(api.EventLifecycleInstanceHealthChanged)
+	InstanceSessionStarted   = InstanceAction(api.EventLifecycleInstanceSessionStarted)
+	InstanceSessionFinished  = InstanceAction(api.EventLifecycleInstanceSessionFinished)
 )
 
 // Event creates the lifecycle event for an action on an instance.
@@ -0,0 +1,29 @@
+package lifecycle
+
+import (
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/entity"
+)
+
+// InstanceTemplateAction represents a lifecycle event action for instance templates.
+type InstanceTemplateAction string
+
+// All supported lifecycle events for instance templates.
+const (
+	InstanceTemplateCreated = InstanceTemplateAction(api.EventLifecycleInstanceTemplateCreated)
+	InstanceTemplateDeleted = InstanceTemplateAction(api.EventLifecycleInstanceTemplateDeleted)
+	InstanceTemplateRenamed = InstanceTemplateAction(api.EventLifecycleInstanceTemplateRenamed)
+	InstanceTemplateUpdated = InstanceTemplateAction(api.EventLifecycleInstanceTemplateUpdated)
+)
+
+// Event creates the lifecycle event for an action on an instance template.
+func (a InstanceTemplateAction) Event(projectName string, instanceTemplateName string, requestor *api.EventLifecycleRequestor, ctx map[string]any) api.EventLifecycle {
+	u := entity.InstanceTemplateURL(projectName, instanceTemplateName)
+
+	return api.EventLifecycle{
+		Action:    string(a),
+		Source:    u.String(),
+		Context:   ctx,
+		Requestor: requestor,
+	}
+}
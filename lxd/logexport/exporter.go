@@ -0,0 +1,15 @@
+package logexport
+
+import (
+	"github.com/canonical/lxd/shared/api"
+)
+
+// Exporter represents a destination that LXD events are exported to.
+// Several exporters can run at the same time, each receiving all the events from the internal event listener.
+type Exporter interface {
+	// HandleEvent exports the event if it matches the exporter filter.
+	HandleEvent(event api.Event)
+
+	// Stop sends the pending events and stops the exporter.
+	Stop()
+}
@@ -0,0 +1,242 @@
+package logexport
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/canonical/lxd/shared/api"
+)
+
+// EventTypeAudit is the type of the events created from the audit log entries.
+// These events are only sent to the exporters, not to the event listeners of the API.
+const EventTypeAudit = "audit"
+
+// Filter selects the events sent to an exporter and converts them to records.
+type Filter struct {
+	// Types is the list of event types to export.
+	Types []string
+
+	// LogLevel is the minimum level of the logging events to export.
+	LogLevel string
+
+	// Labels is the list of event context keys to move to the record labels.
+	Labels []string
+
+	// Instance is the value of the "instance" label.
+	Instance string
+
+	// Location overrides the location of the events if set (used on standalone systems).
+	Location string
+}
+
+// Record represents an event converted for export.
+type Record struct {
+	// Timestamp is the time of the event.
+	Timestamp time.Time
+
+	// Type is the event type.
+	Type string
+
+	// Level is the log level of logging events. It is empty for lifecycle events.
+	Level string
+
+	// Labels are the indexed key/value pairs of the record.
+	Labels map[string]string
+
+	// Context holds the remaining key/value pairs of the event.
+	Context map[string]string
+
+	// Message is the log message for logging events and the action for lifecycle events.
+	Message string
+}
+
+// Line returns the record as a single line with the context as sorted key="value" pairs followed by the message.
+func (r *Record) Line() string {
+	keys := slices.Sorted(maps.Keys(r.Context))
+
+	var line strings.Builder
+	for _, k := range keys {
+		line.WriteString(k + `="` + r.Context[k] + `" `)
+	}
+
+	line.WriteString(r.Message)
+
+	return line.String()
+}
+
+// Record converts the event to a record. It returns false if the event should not be exported.
+func (f *Filter) Record(event api.Event) (*Record, bool) {
+	if !slices.Contains(f.Types, event.Type) {
+		return nil, false
+	}
+
+	location := event.Location
+	if f.Location != "" {
+		location = f.Location
+	}
+
+	record := &Record{
+		Timestamp: event.Timestamp,
+		Type:      event.Type,
+		Labels: map[string]string{
+			"app":      "lxd",
+			"type":     event.Type,
+			"location": location,
+			"instance": f.Instance,
+		},
+		Context: map[string]string{},
+	}
+
+	switch event.Type {
+	case api.EventTypeLifecycle:
+		lifecycleEvent := api.EventLifecycle{}
+
+		err := json.Unmarshal(event.Metadata, &lifecycleEvent)
+		if err != nil {
+			return nil, false
+		}
+
+		if lifecycleEvent.Name != "" {
+			record.Labels["name"] = lifecycleEvent.Name
+		}
+
+		if lifecycleEvent.Project != "" {
+			record.Labels["project"] = lifecycleEvent.Project
+		}
+
+		// Build map. These key-value pairs will either be added as labels, or be part of the
+		// log message itself.
+		record.Context["action"] = lifecycleEvent.Action
+		record.Context["source"] = lifecycleEvent.Source
+
+		maps.Copy(record.Context, buildNestedContext("context", lifecycleEvent.Context))
+
+		if lifecycleEvent.Requestor != nil {
+			record.Context["requester-address"] = lifecycleEvent.Requestor.Address
+			record.Context["requester-protocol"] = lifecycleEvent.Requestor.Protocol
+			record.Context["requester-username"] = lifecycleEvent.Requestor.Username
+		}
+
+		record.Message = lifecycleEvent.Action
+	case api.EventTypeLogging, api.EventTypeOVN, api.EventTypeNetworkACL:
+		logEvent := api.EventLogging{}
+
+		err := json.Unmarshal(event.Metadata, &logEvent)
+		if err != nil {
+			return nil, false
+		}
+
+		// The errors can be ignored as the values are validated elsewhere.
+		l1, _ := logrus.ParseLevel(logEvent.Level)
+		l2, _ := logrus.ParseLevel(f.LogLevel)
+
+		// Only consider log messages with a certain log level.
+		if l2 < l1 {
+			return nil, false
+		}
+
+		tmpContext := map[string]any{}
+
+		// Convert map[string]string to map[string]any as buildNestedContext takes the latter type.
+		for k, v := range logEvent.Context {
+			tmpContext[k] = v
+		}
+
+		record.Level = logEvent.Level
+		record.Context["level"] = logEvent.Level
+
+		maps.Copy(record.Context, buildNestedContext("context", tmpContext))
+
+		record.Message = logEvent.Message
+	case EventTypeAudit:
+		auditEntry := api.AuditEntry{}
+
+		err := json.Unmarshal(event.Metadata, &auditEntry)
+		if err != nil {
+			return nil, false
+		}
+
+		record.Context["username"] = auditEntry.Username
+		record.Context["protocol"] = auditEntry.Protocol
+		record.Context["source-address"] = auditEntry.SourceAddress
+		record.Context["status-code"] = strconv.Itoa(auditEntry.StatusCode)
+		record.Context["result"] = auditEntry.Result
+		record.Context["sequence"] = strconv.FormatInt(auditEntry.Sequence, 10)
+		record.Context["hash"] = auditEntry.Hash
+
+		if len(auditEntry.Groups) > 0 {
+			record.Context["groups"] = strings.Join(auditEntry.Groups, ",")
+		}
+
+		if auditEntry.Operation != "" {
+			record.Context["operation"] = auditEntry.Operation
+		}
+
+		if auditEntry.Body != nil {
+			body, err := json.Marshal(auditEntry.Body)
+			if err == nil {
+				record.Context["body"] = string(body)
+			}
+		}
+
+		for i, check := range auditEntry.Authorization {
+			prefix := "authorization-" + strconv.Itoa(i)
+			record.Context[prefix+"-entity-url"] = check.EntityURL
+			record.Context[prefix+"-entitlement"] = check.Entitlement
+			record.Context[prefix+"-allowed"] = strconv.FormatBool(check.Allowed)
+		}
+
+		record.Message = auditEntry.Method + " " + auditEntry.URL
+	default:
+		return nil, false
+	}
+
+	// Add key-value pairs as labels but don't override any labels.
+	for k, v := range record.Context {
+		if !slices.Contains(f.Labels, k) {
+			continue
+		}
+
+		_, ok := record.Labels[k]
+		if !ok {
+			record.Labels[k] = v
+			delete(record.Context, k)
+		}
+	}
+
+	return record, true
+}
+
+func buildNestedContext(prefix string, m map[string]any) map[string]string {
+	labels := map[string]string{}
+
+	for k, v := range m {
+		t := reflect.TypeOf(v)
+
+		if t != nil && t.Kind() == reflect.Map {
+			for k, v := range buildNestedContext(k, v.(map[string]any)) {
+				if prefix == "" {
+					labels[k] = v
+				} else {
+					labels[prefix+"-"+k] = v
+				}
+			}
+		} else {
+			if prefix == "" {
+				labels[k] = fmt.Sprint(v)
+			} else {
+				labels[prefix+"-"+k] = fmt.Sprint(v)
+			}
+		}
+	}
+
+	return labels
+}
@@ -0,0 +1,163 @@
+package logexport
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/canonical/lxd/shared/api"
+)
+
+func newEvent(t *testing.T, eventType string, metadata any) api.Event {
+	t.Helper()
+
+	data, err := json.Marshal(metadata)
+	require.NoError(t, err)
+
+	return api.Event{
+		Type:      eventType,
+		Timestamp: time.Date(2024, 2, 14, 21, 31, 20, 0, time.UTC),
+		Location:  "node1",
+		Metadata:  data,
+	}
+}
+
+func TestFilterRecord(t *testing.T) {
+	filter := Filter{
+		Types:    []string{api.EventTypeLifecycle, api.EventTypeLogging},
+		LogLevel: "info",
+		Labels:   []string{"requester-username"},
+		Instance: "cluster1",
+	}
+
+	lifecycle := newEvent(t, api.EventTypeLifecycle, api.EventLifecycle{
+		Action:    "instance-started",
+		Source:    "/1.0/instances/c1",
+		Name:      "c1",
+		Project:   "default",
+		Requestor: &api.EventLifecycleRequestor{Username: "ubuntu", Protocol: "unix", Address: "@"},
+	})
+
+	record, ok := filter.Record(lifecycle)
+	require.True(t, ok)
+	require.Equal(t, map[string]string{
+		"app":                "lxd",
+		"type":               api.EventTypeLifecycle,
+		"location":           "node1",
+		"instance":           "cluster1",
+		"name":               "c1",
+		"project":            "default",
+		"requester-username": "ubuntu",
+	}, record.Labels)
+	require.Equal(t, `action="instance-started" requester-address="@" requester-protocol="unix" source="/1.0/instances/c1" instance-started`, record.Line())
+
+	// Logging events more verbose than the configured level are skipped.
+	_, ok = filter.Record(newEvent(t, api.EventTypeLogging, api.EventLogging{Level: "debug", Message: "Debug message"}))
+	require.False(t, ok)
+
+	record, ok = filter.Record(newEvent(t, api.EventTypeLogging, api.EventLogging{Level: "warning", Message: "Warning message", Context: map[string]string{"err": "Failure"}}))
+	require.True(t, ok)
+	require.Equal(t, "warning", record.Level)
+	require.Equal(t, `context-err="Failure" level="warning" Warning message`, record.Line())
+
+	// Event types that aren't selected are skipped.
+	_, ok = filter.Record(newEvent(t, api.EventTypeOVN, api.EventLogging{Level: "error", Message: "OVN message"}))
+	require.False(t, ok)
+
+	// Audit log entries are only exported if selected.
+	audit := newEvent(t, EventTypeAudit, api.AuditEntry{
+		Sequence:   3,
+		Username:   "ubuntu",
+		Protocol:   "unix",
+		Method:     "PATCH",
+		URL:        "/1.0/instances/c1",
+		Body:       map[string]any{"config": map[string]any{"user.foo": "bar"}},
+		StatusCode: 202,
+		Result:     api.AuditResultSuccess,
+		Hash:       "abcd",
+	})
+
+	_, ok = filter.Record(audit)
+	require.False(t, ok)
+
+	filter.Types = append(filter.Types, EventTypeAudit)
+	filter.Labels = []string{"result"}
+	record, ok = filter.Record(audit)
+	require.True(t, ok)
+	require.Equal(t, api.AuditResultSuccess, record.Labels["result"])
+	require.Equal(t, `body="{"config":{"user.foo":"bar"}}" hash="abcd" protocol="unix" sequence="3" source-address="" status-code="202" username="ubuntu" PATCH /1.0/instances/c1`, record.Line())
+
+	// The location can be overridden.
+	filter.Location = "host1"
+	record, ok = filter.Record(lifecycle)
+	require.True(t, ok)
+	require.Equal(t, "host1", record.Labels["location"])
+}
+
+func TestSyslogMessage(t *testing.T) {
+	record := &Record{
+		Timestamp: time.Date(2024, 2, 14, 21, 31, 20, 0, time.UTC),
+		Type:      api.EventTypeLogging,
+		Level:     "error",
+		Labels:    map[string]string{"app": "lxd", "type": api.EventTypeLogging, "location": "node 1", "instance": "cluster1"},
+		Context:   map[string]string{"level": "error"},
+		Message:   "Failed starting instance",
+	}
+
+	require.Equal(t, `<27>1 2024-02-14T21:31:20Z node1 lxd 1234 logging - instance="cluster1" level="error" Failed starting instance`, syslogMessage(record, "1234"))
+
+	// Lifecycle events are informational and missing header fields use the nil value.
+	record.Level = ""
+	record.Labels["location"] = ""
+	require.Equal(t, `<30>1 2024-02-14T21:31:20Z - lxd 1234 logging - instance="cluster1" level="error" Failed starting instance`, syslogMessage(record, "1234"))
+}
+
+func TestOTLPClient(t *testing.T) {
+	requests := make(chan *collogspb.ExportLogsServiceRequest, 1)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != otlpLogsPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		req := &collogspb.ExportLogsServiceRequest{}
+		require.NoError(t, proto.Unmarshal(body, req))
+
+		requests <- req
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	defer collector.Close()
+
+	u, err := url.Parse(collector.URL)
+	require.NoError(t, err)
+
+	client, err := NewOTLPClient(u, "", Filter{Types: []string{api.EventTypeLogging}, LogLevel: "info", Instance: "cluster1"})
+	require.NoError(t, err)
+
+	client.HandleEvent(newEvent(t, api.EventTypeLogging, api.EventLogging{Level: "warning", Message: "Warning message"}))
+	client.Stop()
+
+	req := <-requests
+	require.Len(t, req.GetResourceLogs(), 1)
+	require.Equal(t, "cluster1", req.GetResourceLogs()[0].GetResource().GetAttributes()[1].GetValue().GetStringValue())
+
+	logRecords := req.GetResourceLogs()[0].GetScopeLogs()[0].GetLogRecords()
+	require.Len(t, logRecords, 1)
+	require.Equal(t, "Warning message", logRecords[0].GetBody().GetStringValue())
+	require.Equal(t, "WARN", logRecords[0].GetSeverityText())
+
+	_, err = NewOTLPClient(&url.URL{Scheme: "ftp", Host: "127.0.0.1"}, "", Filter{})
+	require.Error(t, err)
+}
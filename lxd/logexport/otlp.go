@@ -0,0 +1,258 @@
+package logexport
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/cancel"
+)
+
+const (
+	// otlpLogsPath is the default OTLP/HTTP path used when the URL doesn't specify one.
+	otlpLogsPath = "/v1/logs"
+
+	otlpBatchSize = 100
+	otlpBatchWait = 1 * time.Second
+	otlpTimeout   = 10 * time.Second
+	otlpRetries   = 3
+	maxErrMsgLen  = 1024
+)
+
+// OTLPClient exports events as OpenTelemetry log records using OTLP over HTTP.
+type OTLPClient struct {
+	filter  Filter
+	url     *url.URL
+	client  *http.Client
+	records chan *Record
+	cancel  cancel.Canceller
+	wg      sync.WaitGroup
+}
+
+// NewOTLPClient returns a client sending the events matching the filter to the OTLP collector at the given URL.
+// The "/v1/logs" path is used if the URL doesn't have one.
+func NewOTLPClient(u *url.URL, caCert string, filter Filter) (*OTLPClient, error) {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("Invalid OTLP URL %q: Scheme must be http or https", u.String())
+	}
+
+	endpoint := *u
+	if endpoint.Path == "" || endpoint.Path == "/" {
+		endpoint.Path = otlpLogsPath
+	}
+
+	client := &OTLPClient{
+		filter:  filter,
+		url:     &endpoint,
+		client:  http.DefaultClient,
+		records: make(chan *Record),
+		cancel:  cancel.New(),
+	}
+
+	if caCert != "" {
+		tlsConfig, err := shared.GetTLSConfigMem("", "", caCert, "", false)
+		if err != nil {
+			return nil, err
+		}
+
+		client.client = &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig:     tlsConfig,
+				TLSHandshakeTimeout: 10 * time.Second,
+			},
+		}
+	}
+
+	client.wg.Add(1)
+	go client.run()
+
+	return client, nil
+}
+
+// HandleEvent handles the event received from the internal event listener.
+func (c *OTLPClient) HandleEvent(event api.Event) {
+	record, ok := c.filter.Record(event)
+	if !ok {
+		return
+	}
+
+	// Drop the record if the client is stopped.
+	select {
+	case c.records <- record:
+	case <-c.cancel.Done():
+	}
+}
+
+// Stop sends the pending records and stops the client.
+func (c *OTLPClient) Stop() {
+	c.cancel.Cancel()
+	c.wg.Wait()
+}
+
+func (c *OTLPClient) run() {
+	defer c.wg.Done()
+
+	var batch []*Record
+
+	ticker := time.NewTicker(otlpBatchWait)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.cancel.Done():
+			c.sendBatch(batch)
+			return
+
+		case record := <-c.records:
+			batch = append(batch, record)
+			if len(batch) >= otlpBatchSize {
+				c.sendBatch(batch)
+				batch = nil
+			}
+
+		case <-ticker.C:
+			c.sendBatch(batch)
+			batch = nil
+		}
+	}
+}
+
+func (c *OTLPClient) sendBatch(batch []*Record) {
+	if len(batch) == 0 {
+		return
+	}
+
+	buf, err := proto.Marshal(otlpRequest(c.filter.Instance, batch))
+	if err != nil {
+		return
+	}
+
+	for range otlpRetries {
+		ctx, cancel := context.WithTimeout(context.Background(), otlpTimeout)
+		status, err := c.send(ctx, buf)
+		cancel()
+
+		// Only retry 429s, 500s and connection-level errors.
+		if err == nil || (status > 0 && status != http.StatusTooManyRequests && status/100 != 5) {
+			return
+		}
+
+		// Retry after a while, but exit if Stop() is called.
+		select {
+		case <-c.cancel.Done():
+			return
+		case <-time.After(otlpTimeout):
+		}
+	}
+}
+
+func (c *OTLPClient) send(ctx context.Context, buf []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url.String(), bytes.NewReader(buf))
+	if err != nil {
+		return -1, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-protobuf")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return -1, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		scanner := bufio.NewScanner(io.LimitReader(resp.Body, maxErrMsgLen))
+		line := ""
+
+		if scanner.Scan() {
+			line = scanner.Text()
+		}
+
+		return resp.StatusCode, fmt.Errorf("server returned HTTP status %s (%d): %s", resp.Status, resp.StatusCode, line)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// otlpRequest converts the records to an OTLP logs export request.
+func otlpRequest(instance string, records []*Record) *collogspb.ExportLogsServiceRequest {
+	logRecords := make([]*logspb.LogRecord, 0, len(records))
+	for _, record := range records {
+		attributes := make([]*commonpb.KeyValue, 0, len(record.Labels)+len(record.Context))
+		for _, values := range []map[string]string{record.Labels, record.Context} {
+			for _, k := range slices.Sorted(maps.Keys(values)) {
+				attributes = append(attributes, otlpStringAttribute(k, values[k]))
+			}
+		}
+
+		severityNumber, severityText := otlpSeverity(record.Level)
+		logRecords = append(logRecords, &logspb.LogRecord{
+			TimeUnixNano:         uint64(record.Timestamp.UnixNano()),
+			ObservedTimeUnixNano: uint64(time.Now().UnixNano()),
+			SeverityNumber:       severityNumber,
+			SeverityText:         severityText,
+			Body:                 &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: record.Message}},
+			Attributes:           attributes,
+		})
+	}
+
+	return &collogspb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{{
+			Resource: &resourcepb.Resource{
+				Attributes: []*commonpb.KeyValue{
+					otlpStringAttribute("service.name", "lxd"),
+					otlpStringAttribute("service.instance.id", instance),
+				},
+			},
+			ScopeLogs: []*logspb.ScopeLogs{{
+				Scope:      &commonpb.InstrumentationScope{Name: "github.com/canonical/lxd/lxd"},
+				LogRecords: logRecords,
+			}},
+		}},
+	}
+}
+
+func otlpStringAttribute(key string, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}}}
+}
+
+// otlpSeverity returns the OTLP severity of a log level. Lifecycle events (without level) are informational.
+func otlpSeverity(level string) (logspb.SeverityNumber, string) {
+	l, err := logrus.ParseLevel(level)
+	if err != nil {
+		return logspb.SeverityNumber_SEVERITY_NUMBER_INFO, "INFO"
+	}
+
+	switch l {
+	case logrus.TraceLevel:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_TRACE, "TRACE"
+	case logrus.DebugLevel:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_DEBUG, "DEBUG"
+	case logrus.WarnLevel:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_WARN, "WARN"
+	case logrus.ErrorLevel:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_ERROR, "ERROR"
+	case logrus.FatalLevel, logrus.PanicLevel:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_FATAL, "FATAL"
+	default:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_INFO, "INFO"
+	}
+}
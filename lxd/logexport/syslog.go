@@ -0,0 +1,221 @@
+package logexport
+
+import (
+	"crypto/tls"
+	"fmt"
+	"maps"
+	"net"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/cancel"
+)
+
+const (
+	// syslogFacilityDaemon is the syslog facility used for all messages (system daemons).
+	syslogFacilityDaemon = 3
+
+	syslogTimeout = 10 * time.Second
+)
+
+// SyslogClient exports events as RFC 5424 syslog messages over TCP or TLS.
+// Messages are framed using octet counting as described in RFC 6587.
+type SyslogClient struct {
+	filter    Filter
+	address   string
+	tlsConfig *tls.Config
+	pid       string
+	records   chan *Record
+	cancel    cancel.Canceller
+	wg        sync.WaitGroup
+
+	// conn is only accessed from the run goroutine.
+	conn net.Conn
+}
+
+// NewSyslogClient returns a client sending the events matching the filter to the syslog server at the given URL.
+// The URL scheme must be either "tcp" or "tls".
+func NewSyslogClient(u *url.URL, caCert string, filter Filter) (*SyslogClient, error) {
+	if u.Host == "" {
+		return nil, fmt.Errorf("Invalid syslog URL %q: Missing address", u.String())
+	}
+
+	client := &SyslogClient{
+		filter:  filter,
+		address: u.Host,
+		pid:     strconv.Itoa(os.Getpid()),
+		records: make(chan *Record),
+		cancel:  cancel.New(),
+	}
+
+	switch u.Scheme {
+	case "tcp":
+	case "tls":
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if caCert != "" {
+			var err error
+			tlsConfig, err = shared.GetTLSConfigMem("", "", caCert, "", false)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		tlsConfig.ServerName = u.Hostname()
+		client.tlsConfig = tlsConfig
+	default:
+		return nil, fmt.Errorf("Invalid syslog URL %q: Scheme must be tcp or tls", u.String())
+	}
+
+	client.wg.Add(1)
+	go client.run()
+
+	return client, nil
+}
+
+// HandleEvent handles the event received from the internal event listener.
+func (c *SyslogClient) HandleEvent(event api.Event) {
+	record, ok := c.filter.Record(event)
+	if !ok {
+		return
+	}
+
+	// Drop the record if the client is stopped.
+	select {
+	case c.records <- record:
+	case <-c.cancel.Done():
+	}
+}
+
+// Stop stops the client and closes the connection to the syslog server.
+func (c *SyslogClient) Stop() {
+	c.cancel.Cancel()
+	c.wg.Wait()
+}
+
+func (c *SyslogClient) run() {
+	defer c.wg.Done()
+
+	for {
+		select {
+		case <-c.cancel.Done():
+			if c.conn != nil {
+				_ = c.conn.Close()
+			}
+
+			return
+
+		case record := <-c.records:
+			c.send(syslogMessage(record, c.pid))
+		}
+	}
+}
+
+// send writes the message to the syslog server, reconnecting once if the connection was lost.
+// The message is dropped if the server cannot be reached.
+func (c *SyslogClient) send(msg string) {
+	frame := []byte(strconv.Itoa(len(msg)) + " " + msg)
+
+	for range 2 {
+		if c.conn == nil {
+			conn, err := c.dial()
+			if err != nil {
+				return
+			}
+
+			c.conn = conn
+		}
+
+		_ = c.conn.SetWriteDeadline(time.Now().Add(syslogTimeout))
+
+		_, err := c.conn.Write(frame)
+		if err == nil {
+			return
+		}
+
+		_ = c.conn.Close()
+		c.conn = nil
+	}
+}
+
+func (c *SyslogClient) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: syslogTimeout}
+	if c.tlsConfig != nil {
+		return tls.DialWithDialer(dialer, "tcp", c.address, c.tlsConfig)
+	}
+
+	return dialer.Dial("tcp", c.address)
+}
+
+// syslogMessage formats the record as a RFC 5424 message. The event type is used as message ID and the labels
+// are added to the message as key="value" pairs, before the context and the message itself.
+func syslogMessage(record *Record, pid string) string {
+	hostname := syslogHeaderField(record.Labels["location"], 255)
+	msgID := syslogHeaderField(record.Type, 32)
+
+	var msg strings.Builder
+	for _, k := range slices.Sorted(maps.Keys(record.Labels)) {
+		if k == "app" || k == "type" || k == "location" {
+			continue
+		}
+
+		msg.WriteString(k + `="` + record.Labels[k] + `" `)
+	}
+
+	msg.WriteString(record.Line())
+
+	priority := syslogFacilityDaemon*8 + syslogSeverity(record.Level)
+
+	return fmt.Sprintf("<%d>1 %s %s lxd %s %s - %s", priority, record.Timestamp.UTC().Format(time.RFC3339Nano), hostname, pid, msgID, msg.String())
+}
+
+// syslogHeaderField returns the value usable as a syslog header field, or "-" (nil value) if empty.
+func syslogHeaderField(value string, maxLength int) string {
+	value = strings.Map(func(r rune) rune {
+		// Header fields only allow printable US-ASCII characters.
+		if r < 33 || r > 126 {
+			return -1
+		}
+
+		return r
+	}, value)
+
+	if value == "" {
+		return "-"
+	}
+
+	if len(value) > maxLength {
+		return value[:maxLength]
+	}
+
+	return value
+}
+
+// syslogSeverity returns the syslog severity of a log level. Lifecycle events (without level) are informational.
+func syslogSeverity(level string) int {
+	l, err := logrus.ParseLevel(level)
+	if err != nil {
+		return 6
+	}
+
+	switch l {
+	case logrus.PanicLevel, logrus.FatalLevel:
+		return 2
+	case logrus.ErrorLevel:
+		return 3
+	case logrus.WarnLevel:
+		return 4
+	case logrus.DebugLevel, logrus.TraceLevel:
+		return 7
+	default:
+		return 6
+	}
+}
@@ -4,23 +4,18 @@ import (
 	"bufio"
 	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"maps"
 	"net/http"
 	"net/url"
-	"reflect"
-	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/sirupsen/logrus"
-
+	"github.com/canonical/lxd/lxd/logexport"
 	"github.com/canonical/lxd/shared"
 	"github.com/canonical/lxd/shared/api"
 	"github.com/canonical/lxd/shared/cancel"
@@ -40,11 +35,7 @@ type config struct {
 	caCert   string
 	username string
 	password string
-	labels   []string
-	instance string
-	logLevel string
-	types    []string
-	location string
+	filter   logexport.Filter
 
 	timeout time.Duration
 	url     *url.URL
@@ -73,13 +64,15 @@ func NewClient(ctx context.Context, u *url.URL, username string, password string
 			caCert:    caCert,
 			username:  username,
 			password:  password,
-			instance:  instance,
-			location:  location,
-			labels:    labels,
-			logLevel:  logLevel,
-			timeout:   10 * time.Second,
-			types:     types,
-			url:       u,
+			filter: logexport.Filter{
+				Types:    types,
+				LogLevel: logLevel,
+				Labels:   labels,
+				Instance: instance,
+				Location: location,
+			},
+			timeout: 10 * time.Second,
+			url:     u,
 		},
 		client:  &http.Client{},
 		entries: make(chan entry),
@@ -266,170 +259,30 @@ func (c *Client) Stop() {
 
 // HandleEvent handles the event received from the internal event listener.
 func (c *Client) HandleEvent(event api.Event) {
-	if !slices.Contains(c.cfg.types, event.Type) {
+	record, ok := c.cfg.filter.Record(event)
+	if !ok {
 		return
 	}
 
-	// Support overriding the location field (used on standalone systems).
-	location := event.Location
-	if c.cfg.location != "" {
-		location = c.cfg.location
+	labels := make(LabelSet, len(record.Labels))
+	for k, v := range record.Labels {
+		// Label names may not contain any hyphens.
+		labels[strings.ReplaceAll(k, "-", "_")] = v
 	}
 
-	entry := entry{
-		labels: LabelSet{
-			"app":      "lxd",
-			"type":     event.Type,
-			"location": location,
-			"instance": c.cfg.instance,
-		},
+	e := entry{
+		labels: labels,
 		Entry: Entry{
-			Timestamp: event.Timestamp,
+			Timestamp: record.Timestamp,
+			Line:      record.Line(),
 		},
 	}
 
-	context := make(map[string]string)
-
-	switch event.Type {
-	case api.EventTypeLifecycle:
-		lifecycleEvent := api.EventLifecycle{}
-
-		err := json.Unmarshal(event.Metadata, &lifecycleEvent)
-		if err != nil {
-			return
-		}
-
-		if lifecycleEvent.Name != "" {
-			entry.labels["name"] = lifecycleEvent.Name
-		}
-
-		if lifecycleEvent.Project != "" {
-			entry.labels["project"] = lifecycleEvent.Project
-		}
-
-		// Build map. These key-value pairs will either be added as labels, or be part of the
-		// log message itself.
-		context["action"] = lifecycleEvent.Action
-		context["source"] = lifecycleEvent.Source
-
-		maps.Copy(context, buildNestedContext("context", lifecycleEvent.Context))
-
-		if lifecycleEvent.Requestor != nil {
-			context["requester-address"] = lifecycleEvent.Requestor.Address
-			context["requester-protocol"] = lifecycleEvent.Requestor.Protocol
-			context["requester-username"] = lifecycleEvent.Requestor.Username
-		}
-
-		// Add key-value pairs as labels but don't override any labels.
-		for k, v := range context {
-			if slices.Contains(c.cfg.labels, k) {
-				_, ok := entry.labels[k]
-				if !ok {
-					// Label names may not contain any hyphens.
-					entry.labels[strings.ReplaceAll(k, "-", "_")] = v
-					delete(context, k)
-				}
-			}
-		}
-
-		var line strings.Builder
-
-		// Add the remaining context as the message prefix.
-		for k, v := range context {
-			line.WriteString(k + `="` + v + `" `)
-		}
-
-		line.WriteString(lifecycleEvent.Action)
-
-		entry.Line = line.String()
-	case api.EventTypeLogging, api.EventTypeOVN:
-		logEvent := api.EventLogging{}
-
-		err := json.Unmarshal(event.Metadata, &logEvent)
-		if err != nil {
-			return
-		}
-
-		// The errors can be ignored as the values are validated elsewhere.
-		l1, _ := logrus.ParseLevel(logEvent.Level)
-		l2, _ := logrus.ParseLevel(c.cfg.logLevel)
-
-		// Only consider log messages with a certain log level.
-		if l2 < l1 {
-			return
-		}
-
-		tmpContext := map[string]any{}
-
-		// Convert map[string]string to map[string]any as buildNestedContext takes the latter type.
-		for k, v := range logEvent.Context {
-			tmpContext[k] = v
-		}
-
-		// Build map. These key-value pairs will either be added as labels, or be part of the
-		// log message itself.
-		context["level"] = logEvent.Level
-
-		maps.Copy(context, buildNestedContext("context", tmpContext))
-
-		// Add key-value pairs as labels but don't override any labels.
-		for k, v := range context {
-			if slices.Contains(c.cfg.labels, k) {
-				_, ok := entry.labels[k]
-				if !ok {
-					entry.labels[k] = v
-					delete(context, k)
-				}
-			}
-		}
-
-		keys := make([]string, 0, len(context))
-
-		for k := range context {
-			keys = append(keys, k)
-		}
-
-		sort.Strings(keys)
-
-		var message strings.Builder
-
-		// Add the remaining context as the message prefix. The keys are sorted alphabetically.
-		for _, k := range keys {
-			message.WriteString(k + `="` + context[k] + `" `)
-		}
-
-		message.WriteString(logEvent.Message)
-
-		entry.Line = message.String()
-	}
-
-	c.entries <- entry
-}
-
-func buildNestedContext(prefix string, m map[string]any) map[string]string {
-	labels := map[string]string{}
-
-	for k, v := range m {
-		t := reflect.TypeOf(v)
-
-		if t != nil && t.Kind() == reflect.Map {
-			for k, v := range buildNestedContext(k, v.(map[string]any)) {
-				if prefix == "" {
-					labels[k] = v
-				} else {
-					labels[prefix+"-"+k] = v
-				}
-			}
-		} else {
-			if prefix == "" {
-				labels[k] = fmt.Sprint(v)
-			} else {
-				labels[prefix+"-"+k] = fmt.Sprint(v)
-			}
-		}
+	// Drop the entry if the client is stopped.
+	select {
+	case c.entries <- e:
+	case <-c.cancel.Done():
 	}
-
-	return labels
 }
 
 // MarshalJSON returns the JSON encoding of Entry.
@@ -126,6 +126,10 @@ func main() {
 	forkDNSCmd := cmdForkDNS{global: &globalCmd}
 	app.AddCommand(forkDNSCmd.command())
 
+	// forkdump sub-command
+	forkdumpCmd := cmdForkdump{global: &globalCmd}
+	app.AddCommand(forkdumpCmd.command())
+
 	// forkexec sub-command
 	forkexecCmd := cmdForkexec{global: &globalCmd}
 	app.AddCommand(forkexecCmd.command())
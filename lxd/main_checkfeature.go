@@ -706,7 +706,11 @@ static bool kernel_supports_idmapped_mounts(void)
 import "C"
 
 import (
+	"context"
+	"os/exec"
+
 	_ "github.com/canonical/lxd/lxd/include" // Used by cgo
+	"github.com/canonical/lxd/shared"
 	"github.com/canonical/lxd/shared/logger"
 )
 
@@ -769,3 +773,20 @@ func canUseBinfmt() bool {
 func canUseBPFToken() bool {
 	return bool(C.bpftoken_aware)
 }
+
+// canUseCRIU checks whether the CRIU tool is available for container live migration and whether it can track
+// dirty memory pages, which is required for iterative pre-copy of the container memory.
+func canUseCRIU() (available bool, memoryTracking bool) {
+	_, err := exec.LookPath("criu")
+	if err != nil {
+		return false, false
+	}
+
+	_, err = shared.RunCommand(context.TODO(), "criu", "check", "--feature", "mem_dirty_track")
+	if err != nil {
+		logger.Debug("CRIU memory tracking unavailable", logger.Ctx{"err": err})
+		return true, false
+	}
+
+	return true, true
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	liblxc "github.com/lxc/go-lxc"
+	"github.com/spf13/cobra"
+)
+
+type cmdForkdump struct {
+	global *cmdGlobal
+}
+
+func (c *cmdForkdump) command() *cobra.Command {
+	// Main subcommand
+	cmd := &cobra.Command{}
+	cmd.Use = "forkdump <container name> <containers path> <config> <images path> <pre-dump images path> <pre-dump> <stop> <preserve>"
+	cmd.Short = "Save the container state"
+	cmd.Long = `Description:
+  Save the container state
+
+  This internal command is used to dump or pre-dump the container state
+  from a separate process, so that CRIU can be configured through the
+  environment of this process (for example to send the memory pages to
+  a page server).
+`
+	cmd.RunE = c.run
+	cmd.Hidden = true
+
+	return cmd
+}
+
+func (c *cmdForkdump) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	if len(args) != 8 {
+		_ = cmd.Help()
+
+		if len(args) == 0 {
+			return nil
+		}
+
+		return errors.New("Missing required arguments")
+	}
+
+	// Only root should run this
+	if os.Geteuid() != 0 {
+		return errors.New("This must be run as root")
+	}
+
+	name := args[0]
+	lxcpath := args[1]
+	configPath := args[2]
+	imagesDir := args[3]
+	preDumpDir := args[4]
+
+	preDump, err := strconv.ParseBool(args[5])
+	if err != nil {
+		return err
+	}
+
+	stop, err := strconv.ParseBool(args[6])
+	if err != nil {
+		return err
+	}
+
+	preservesInodes, err := strconv.ParseBool(args[7])
+	if err != nil {
+		return err
+	}
+
+	d, err := liblxc.NewContainer(name, lxcpath)
+	if err != nil {
+		return err
+	}
+
+	err = d.LoadConfigFile(configPath)
+	if err != nil {
+		return fmt.Errorf("Failed loading config file %q: %w", configPath, err)
+	}
+
+	var migrateCmd uint = liblxc.MIGRATE_DUMP
+	if preDump {
+		migrateCmd = liblxc.MIGRATE_PRE_DUMP
+	}
+
+	return d.Migrate(migrateCmd, liblxc.MigrateOptions{
+		Directory:       imagesDir,
+		PredumpDir:      preDumpDir,
+		Stop:            stop,
+		Verbose:         true,
+		PreservesInodes: preservesInodes,
+	})
+}
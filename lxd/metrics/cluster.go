@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// HeartbeatStats represents the heartbeat statistics of a cluster member.
+type HeartbeatStats struct {
+	// RoundTrip is the duration of the last successful heartbeat.
+	RoundTrip time.Duration
+
+	// Failures is the total number of failed heartbeats.
+	Failures int64
+}
+
+var databaseTransactions atomic.Int64
+var databaseTransactionsDuration atomic.Int64
+var databaseTransactionRetries atomic.Int64
+
+var leaderChanges atomic.Int64
+var leaderAddress string
+var leaderAddressLock sync.Mutex
+
+var heartbeats = map[string]HeartbeatStats{}
+var heartbeatsLock sync.Mutex
+
+// TrackDatabaseTransaction records a database transaction that took the given duration.
+func TrackDatabaseTransaction(duration time.Duration) {
+	databaseTransactions.Add(1)
+	databaseTransactionsDuration.Add(int64(duration))
+}
+
+// TrackDatabaseTransactionRetry records the retry of a database transaction after a transient error.
+func TrackDatabaseTransactionRetry() {
+	databaseTransactionRetries.Add(1)
+}
+
+// GetDatabaseTransactions returns the number of database transactions and their cumulated duration.
+func GetDatabaseTransactions() (int64, time.Duration) {
+	return databaseTransactions.Load(), time.Duration(databaseTransactionsDuration.Load())
+}
+
+// GetDatabaseTransactionRetries returns the number of database transaction retries.
+func GetDatabaseTransactionRetries() int64 {
+	return databaseTransactionRetries.Load()
+}
+
+// TrackLeaderAddress records the address of the cluster leader and counts the leader changes.
+func TrackLeaderAddress(address string) {
+	leaderAddressLock.Lock()
+	defer leaderAddressLock.Unlock()
+
+	if leaderAddress != "" && leaderAddress != address {
+		leaderChanges.Add(1)
+	}
+
+	leaderAddress = address
+}
+
+// GetLeaderChanges returns the number of cluster leader changes observed by this member.
+func GetLeaderChanges() int64 {
+	return leaderChanges.Load()
+}
+
+// TrackHeartbeat records the result of a heartbeat sent to the given cluster member.
+func TrackHeartbeat(member string, roundTrip time.Duration, err error) {
+	heartbeatsLock.Lock()
+	defer heartbeatsLock.Unlock()
+
+	stats := heartbeats[member]
+	if err != nil {
+		stats.Failures++
+	} else {
+		stats.RoundTrip = roundTrip
+	}
+
+	heartbeats[member] = stats
+}
+
+// PruneHeartbeats removes the heartbeat statistics of the cluster members that aren't in the given member names,
+// so that removed or renamed members don't keep their statistics.
+func PruneHeartbeats(members []string) {
+	heartbeatsLock.Lock()
+	defer heartbeatsLock.Unlock()
+
+	maps.DeleteFunc(heartbeats, func(member string, _ HeartbeatStats) bool {
+		return !slices.Contains(members, member)
+	})
+}
+
+// GetHeartbeats returns the heartbeat statistics of the cluster members keyed by member name.
+func GetHeartbeats() map[string]HeartbeatStats {
+	heartbeatsLock.Lock()
+	defer heartbeatsLock.Unlock()
+
+	return maps.Clone(heartbeats)
+}
@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackLeaderAddress(t *testing.T) {
+	previous := GetLeaderChanges()
+
+	// The first observed leader isn't a change.
+	TrackLeaderAddress("10.0.0.1:8443")
+	TrackLeaderAddress("10.0.0.1:8443")
+	require.Equal(t, previous, GetLeaderChanges())
+
+	TrackLeaderAddress("10.0.0.2:8443")
+	require.Equal(t, previous+1, GetLeaderChanges())
+}
+
+func TestTrackHeartbeat(t *testing.T) {
+	TrackHeartbeat("member1", 20*time.Millisecond, nil)
+	TrackHeartbeat("member1", 0, errors.New("Connection refused"))
+	TrackHeartbeat("member1", 0, errors.New("Connection refused"))
+	TrackHeartbeat("member2", 10*time.Millisecond, nil)
+
+	heartbeats := GetHeartbeats()
+	require.Equal(t, HeartbeatStats{RoundTrip: 20 * time.Millisecond, Failures: 2}, heartbeats["member1"])
+	require.Equal(t, HeartbeatStats{RoundTrip: 10 * time.Millisecond}, heartbeats["member2"])
+
+	// The statistics of the members that are no longer in the cluster are removed.
+	PruneHeartbeats([]string{"member2"})
+	heartbeats = GetHeartbeats()
+	require.NotContains(t, heartbeats, "member1")
+	require.Contains(t, heartbeats, "member2")
+}
@@ -92,8 +92,18 @@ func (m *MetricSet) String() string {
 		CPUs,
 		GoGoroutines,
 		GoHeapObjects,
+		InstanceHealthy,
 		Instances,
 		APIOngoingRequests,
+		ProjectLimit,
+		ProjectUsage,
+		StorageVolumeSnapshots,
+		ClusterHeartbeatSeconds,
+		ClusterLeader,
+		ClusterMembers,
+		ClusterRaftRole,
+		EventListeners,
+		Operations,
 	}
 
 	for _, metricType := range metricTypes {
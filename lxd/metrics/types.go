@@ -24,6 +24,24 @@ const (
 	CPUs
 	// CPUSecondsTotal represents the total CPU seconds used.
 	CPUSecondsTotal
+	// ClusterHeartbeatFailuresTotal represents the total number of failed heartbeats sent to a cluster member.
+	ClusterHeartbeatFailuresTotal
+	// ClusterHeartbeatSeconds represents the round-trip time of the last heartbeat sent to a cluster member.
+	ClusterHeartbeatSeconds
+	// ClusterLeader represents whether the cluster member is the leader.
+	ClusterLeader
+	// ClusterLeaderChangesTotal represents the number of cluster leader changes.
+	ClusterLeaderChangesTotal
+	// ClusterMembers represents the number of cluster members by status.
+	ClusterMembers
+	// ClusterRaftRole represents the raft role of the cluster member.
+	ClusterRaftRole
+	// DatabaseTransactionRetriesTotal represents the total number of database transaction retries.
+	DatabaseTransactionRetriesTotal
+	// DatabaseTransactionSecondsTotal represents the total time spent in database transactions.
+	DatabaseTransactionSecondsTotal
+	// DatabaseTransactionsTotal represents the total number of database transactions.
+	DatabaseTransactionsTotal
 	// DiskReadBytesTotal represents the read bytes for a disk.
 	DiskReadBytesTotal
 	// DiskReadsCompletedTotal represents the completed for a disk.
@@ -32,6 +50,8 @@ const (
 	DiskWrittenBytesTotal
 	// DiskWritesCompletedTotal represents the completed writes for a disk.
 	DiskWritesCompletedTotal
+	// EventListeners represents the number of connected event listeners.
+	EventListeners
 	// FilesystemAvailBytes represents the available bytes on a filesystem.
 	FilesystemAvailBytes
 	// FilesystemFreeBytes represents the free bytes on a filesystem.
@@ -84,6 +104,8 @@ const (
 	GoStackSysBytes
 	// GoSysBytes represents the number of bytes obtained from system.
 	GoSysBytes
+	// InstanceHealthy represents whether an instance with a health check is healthy.
+	InstanceHealthy
 	// Instances represents the instance count.
 	Instances
 	// MemoryActiveAnonBytes represents the amount of anonymous memory on active LRU list.
@@ -142,10 +164,28 @@ const (
 	NetworkTransmitErrsTotal
 	// NetworkTransmitPacketsTotal represents the amount of transmitted packets on a given interface.
 	NetworkTransmitPacketsTotal
+	// Operations represents the number of operations by type and status.
+	Operations
 	// OperationsTotal represents the number of running operations.
 	OperationsTotal
 	// ProcsTotal represents the number of running processes.
 	ProcsTotal
+	// ProjectLimit represents the limit of a project resource.
+	ProjectLimit
+	// ProjectUsage represents the usage of a project resource.
+	ProjectUsage
+	// StoragePoolAllocatedBytes represents the sum of the sizes of the volumes on a storage pool.
+	StoragePoolAllocatedBytes
+	// StoragePoolSizeBytes represents the size of a storage pool.
+	StoragePoolSizeBytes
+	// StoragePoolUsedBytes represents the used space of a storage pool.
+	StoragePoolUsedBytes
+	// StorageVolumeSizeBytes represents the size of a custom storage volume.
+	StorageVolumeSizeBytes
+	// StorageVolumeSnapshots represents the number of snapshots of a custom storage volume.
+	StorageVolumeSnapshots
+	// StorageVolumeUsedBytes represents the used space of a custom storage volume.
+	StorageVolumeUsedBytes
 	// UptimeSeconds represents the daemon uptime in seconds.
 	UptimeSeconds
 	// WarningsTotal represents the number of active warnings.
@@ -154,142 +194,182 @@ const (
 
 // MetricNames associates a metric type to its name.
 var MetricNames = map[MetricType]string{
-	APICompletedRequests:        "lxd_api_requests_completed_total",
-	APIOngoingRequests:          "lxd_api_requests_ongoing",
-	CPUSecondsTotal:             "lxd_cpu_seconds_total",
-	CPUs:                        "lxd_cpu_effective_total",
-	DiskReadBytesTotal:          "lxd_disk_read_bytes_total",
-	DiskReadsCompletedTotal:     "lxd_disk_reads_completed_total",
-	DiskWrittenBytesTotal:       "lxd_disk_written_bytes_total",
-	DiskWritesCompletedTotal:    "lxd_disk_writes_completed_total",
-	FilesystemAvailBytes:        "lxd_filesystem_avail_bytes",
-	FilesystemFreeBytes:         "lxd_filesystem_free_bytes",
-	FilesystemSizeBytes:         "lxd_filesystem_size_bytes",
-	GoAllocBytes:                "lxd_go_alloc_bytes",
-	GoAllocBytesTotal:           "lxd_go_alloc_bytes_total",
-	GoBuckHashSysBytes:          "lxd_go_buck_hash_sys_bytes",
-	GoFreesTotal:                "lxd_go_frees_total",
-	GoGCSysBytes:                "lxd_go_gc_sys_bytes",
-	GoGoroutines:                "lxd_go_goroutines",
-	GoHeapAllocBytes:            "lxd_go_heap_alloc_bytes",
-	GoHeapIdleBytes:             "lxd_go_heap_idle_bytes",
-	GoHeapInuseBytes:            "lxd_go_heap_inuse_bytes",
-	GoHeapObjects:               "lxd_go_heap_objects",
-	GoHeapReleasedBytes:         "lxd_go_heap_released_bytes",
-	GoHeapSysBytes:              "lxd_go_heap_sys_bytes",
-	GoLookupsTotal:              "lxd_go_lookups_total",
-	GoMallocsTotal:              "lxd_go_mallocs_total",
-	GoMCacheInuseBytes:          "lxd_go_mcache_inuse_bytes",
-	GoMCacheSysBytes:            "lxd_go_mcache_sys_bytes",
-	GoMSpanInuseBytes:           "lxd_go_mspan_inuse_bytes",
-	GoMSpanSysBytes:             "lxd_go_mspan_sys_bytes",
-	GoNextGCBytes:               "lxd_go_next_gc_bytes",
-	GoOtherSysBytes:             "lxd_go_other_sys_bytes",
-	GoStackInuseBytes:           "lxd_go_stack_inuse_bytes",
-	GoStackSysBytes:             "lxd_go_stack_sys_bytes",
-	GoSysBytes:                  "lxd_go_sys_bytes",
-	MemoryActiveAnonBytes:       "lxd_memory_Active_anon_bytes",
-	MemoryActiveFileBytes:       "lxd_memory_Active_file_bytes",
-	MemoryActiveBytes:           "lxd_memory_Active_bytes",
-	MemoryCachedBytes:           "lxd_memory_Cached_bytes",
-	MemoryDirtyBytes:            "lxd_memory_Dirty_bytes",
-	MemoryHugePagesFreeBytes:    "lxd_memory_HugepagesFree_bytes",
-	MemoryHugePagesTotalBytes:   "lxd_memory_HugepagesTotal_bytes",
-	MemoryInactiveAnonBytes:     "lxd_memory_Inactive_anon_bytes",
-	MemoryInactiveFileBytes:     "lxd_memory_Inactive_file_bytes",
-	MemoryInactiveBytes:         "lxd_memory_Inactive_bytes",
-	MemoryMappedBytes:           "lxd_memory_Mapped_bytes",
-	MemoryMemAvailableBytes:     "lxd_memory_MemAvailable_bytes",
-	MemoryMemFreeBytes:          "lxd_memory_MemFree_bytes",
-	MemoryMemTotalBytes:         "lxd_memory_MemTotal_bytes",
-	MemoryRSSBytes:              "lxd_memory_RSS_bytes",
-	MemoryShmemBytes:            "lxd_memory_Shmem_bytes",
-	MemorySwapBytes:             "lxd_memory_Swap_bytes",
-	MemoryUnevictableBytes:      "lxd_memory_Unevictable_bytes",
-	MemoryWritebackBytes:        "lxd_memory_Writeback_bytes",
-	MemoryOOMKillsTotal:         "lxd_memory_OOM_kills_total",
-	NetworkReceiveBytesTotal:    "lxd_network_receive_bytes_total",
-	NetworkReceiveDropTotal:     "lxd_network_receive_drop_total",
-	NetworkReceiveErrsTotal:     "lxd_network_receive_errs_total",
-	NetworkReceivePacketsTotal:  "lxd_network_receive_packets_total",
-	NetworkTransmitBytesTotal:   "lxd_network_transmit_bytes_total",
-	NetworkTransmitDropTotal:    "lxd_network_transmit_drop_total",
-	NetworkTransmitErrsTotal:    "lxd_network_transmit_errs_total",
-	NetworkTransmitPacketsTotal: "lxd_network_transmit_packets_total",
-	OperationsTotal:             "lxd_operations_total",
-	ProcsTotal:                  "lxd_procs_total",
-	UptimeSeconds:               "lxd_uptime_seconds",
-	WarningsTotal:               "lxd_warnings_total",
-	Instances:                   "lxd_instances",
+	APICompletedRequests:            "lxd_api_requests_completed_total",
+	APIOngoingRequests:              "lxd_api_requests_ongoing",
+	CPUSecondsTotal:                 "lxd_cpu_seconds_total",
+	CPUs:                            "lxd_cpu_effective_total",
+	ClusterHeartbeatFailuresTotal:   "lxd_cluster_heartbeat_failures_total",
+	ClusterHeartbeatSeconds:         "lxd_cluster_heartbeat_seconds",
+	ClusterLeader:                   "lxd_cluster_leader",
+	ClusterLeaderChangesTotal:       "lxd_cluster_leader_changes_total",
+	ClusterMembers:                  "lxd_cluster_members",
+	ClusterRaftRole:                 "lxd_cluster_raft_role",
+	DatabaseTransactionRetriesTotal: "lxd_database_transaction_retries_total",
+	DatabaseTransactionSecondsTotal: "lxd_database_transaction_seconds_total",
+	DatabaseTransactionsTotal:       "lxd_database_transactions_total",
+	DiskReadBytesTotal:              "lxd_disk_read_bytes_total",
+	DiskReadsCompletedTotal:         "lxd_disk_reads_completed_total",
+	DiskWrittenBytesTotal:           "lxd_disk_written_bytes_total",
+	DiskWritesCompletedTotal:        "lxd_disk_writes_completed_total",
+	EventListeners:                  "lxd_event_listeners",
+	FilesystemAvailBytes:            "lxd_filesystem_avail_bytes",
+	FilesystemFreeBytes:             "lxd_filesystem_free_bytes",
+	FilesystemSizeBytes:             "lxd_filesystem_size_bytes",
+	GoAllocBytes:                    "lxd_go_alloc_bytes",
+	GoAllocBytesTotal:               "lxd_go_alloc_bytes_total",
+	GoBuckHashSysBytes:              "lxd_go_buck_hash_sys_bytes",
+	GoFreesTotal:                    "lxd_go_frees_total",
+	GoGCSysBytes:                    "lxd_go_gc_sys_bytes",
+	GoGoroutines:                    "lxd_go_goroutines",
+	GoHeapAllocBytes:                "lxd_go_heap_alloc_bytes",
+	GoHeapIdleBytes:                 "lxd_go_heap_idle_bytes",
+	GoHeapInuseBytes:                "lxd_go_heap_inuse_bytes",
+	GoHeapObjects:                   "lxd_go_heap_objects",
+	GoHeapReleasedBytes:             "lxd_go_heap_released_bytes",
+	GoHeapSysBytes:                  "lxd_go_heap_sys_bytes",
+	GoLookupsTotal:                  "lxd_go_lookups_total",
+	GoMallocsTotal:                  "lxd_go_mallocs_total",
+	GoMCacheInuseBytes:              "lxd_go_mcache_inuse_bytes",
+	GoMCacheSysBytes:                "lxd_go_mcache_sys_bytes",
+	GoMSpanInuseBytes:               "lxd_go_mspan_inuse_bytes",
+	GoMSpanSysBytes:                 "lxd_go_mspan_sys_bytes",
+	GoNextGCBytes:                   "lxd_go_next_gc_bytes",
+	GoOtherSysBytes:                 "lxd_go_other_sys_bytes",
+	GoStackInuseBytes:               "lxd_go_stack_inuse_bytes",
+	GoStackSysBytes:                 "lxd_go_stack_sys_bytes",
+	GoSysBytes:                      "lxd_go_sys_bytes",
+	MemoryActiveAnonBytes:           "lxd_memory_Active_anon_bytes",
+	MemoryActiveFileBytes:           "lxd_memory_Active_file_bytes",
+	MemoryActiveBytes:               "lxd_memory_Active_bytes",
+	MemoryCachedBytes:               "lxd_memory_Cached_bytes",
+	MemoryDirtyBytes:                "lxd_memory_Dirty_bytes",
+	MemoryHugePagesFreeBytes:        "lxd_memory_HugepagesFree_bytes",
+	MemoryHugePagesTotalBytes:       "lxd_memory_HugepagesTotal_bytes",
+	MemoryInactiveAnonBytes:         "lxd_memory_Inactive_anon_bytes",
+	MemoryInactiveFileBytes:         "lxd_memory_Inactive_file_bytes",
+	MemoryInactiveBytes:             "lxd_memory_Inactive_bytes",
+	MemoryMappedBytes:               "lxd_memory_Mapped_bytes",
+	MemoryMemAvailableBytes:         "lxd_memory_MemAvailable_bytes",
+	MemoryMemFreeBytes:              "lxd_memory_MemFree_bytes",
+	MemoryMemTotalBytes:             "lxd_memory_MemTotal_bytes",
+	MemoryRSSBytes:                  "lxd_memory_RSS_bytes",
+	MemoryShmemBytes:                "lxd_memory_Shmem_bytes",
+	MemorySwapBytes:                 "lxd_memory_Swap_bytes",
+	MemoryUnevictableBytes:          "lxd_memory_Unevictable_bytes",
+	MemoryWritebackBytes:            "lxd_memory_Writeback_bytes",
+	MemoryOOMKillsTotal:             "lxd_memory_OOM_kills_total",
+	NetworkReceiveBytesTotal:        "lxd_network_receive_bytes_total",
+	NetworkReceiveDropTotal:         "lxd_network_receive_drop_total",
+	NetworkReceiveErrsTotal:         "lxd_network_receive_errs_total",
+	NetworkReceivePacketsTotal:      "lxd_network_receive_packets_total",
+	NetworkTransmitBytesTotal:       "lxd_network_transmit_bytes_total",
+	NetworkTransmitDropTotal:        "lxd_network_transmit_drop_total",
+	NetworkTransmitErrsTotal:        "lxd_network_transmit_errs_total",
+	NetworkTransmitPacketsTotal:     "lxd_network_transmit_packets_total",
+	Operations:                      "lxd_operations",
+	OperationsTotal:                 "lxd_operations_total",
+	ProcsTotal:                      "lxd_procs_total",
+	ProjectLimit:                    "lxd_project_limit",
+	ProjectUsage:                    "lxd_project_usage",
+	StoragePoolAllocatedBytes:       "lxd_storage_pool_allocated_bytes",
+	StoragePoolSizeBytes:            "lxd_storage_pool_size_bytes",
+	StoragePoolUsedBytes:            "lxd_storage_pool_used_bytes",
+	StorageVolumeSizeBytes:          "lxd_storage_volume_size_bytes",
+	StorageVolumeSnapshots:          "lxd_storage_volume_snapshots",
+	StorageVolumeUsedBytes:          "lxd_storage_volume_used_bytes",
+	UptimeSeconds:                   "lxd_uptime_seconds",
+	WarningsTotal:                   "lxd_warnings_total",
+	InstanceHealthy:                 "lxd_instance_healthy",
+	Instances:                       "lxd_instances",
 }
 
 // MetricHeaders represents the metric headers which contain help messages as specified by OpenMetrics.
 var MetricHeaders = map[MetricType]string{
-	APICompletedRequests:        "# HELP lxd_api_requests_completed_total The total number of completed API requests.",
-	APIOngoingRequests:          "# HELP lxd_api_requests_ongoing The number of API requests currently being handled.",
-	CPUSecondsTotal:             "# HELP lxd_cpu_seconds_total The total number of CPU time used in seconds.",
-	CPUs:                        "# HELP lxd_cpu_effective_total The total number of effective CPUs.",
-	DiskReadBytesTotal:          "# HELP lxd_disk_read_bytes_total The total number of bytes read.",
-	DiskReadsCompletedTotal:     "# HELP lxd_disk_reads_completed_total The total number of completed reads.",
-	DiskWrittenBytesTotal:       "# HELP lxd_disk_written_bytes_total The total number of bytes written.",
-	DiskWritesCompletedTotal:    "# HELP lxd_disk_writes_completed_total The total number of completed writes.",
-	FilesystemAvailBytes:        "# HELP lxd_filesystem_avail_bytes The number of available space in bytes.",
-	FilesystemFreeBytes:         "# HELP lxd_filesystem_free_bytes The number of free space in bytes.",
-	FilesystemSizeBytes:         "# HELP lxd_filesystem_size_bytes The size of the filesystem in bytes.",
-	GoAllocBytes:                "# HELP lxd_go_alloc_bytes Number of bytes allocated and still in use.",
-	GoAllocBytesTotal:           "# HELP lxd_go_alloc_bytes_total Total number of bytes allocated, even if freed.",
-	GoBuckHashSysBytes:          "# HELP lxd_go_buck_hash_sys_bytes Number of bytes used by the profiling bucket hash table.",
-	GoFreesTotal:                "# HELP lxd_go_frees_total Total number of frees.",
-	GoGCSysBytes:                "# HELP lxd_go_gc_sys_bytes Number of bytes used for garbage collection system metadata.",
-	GoGoroutines:                "# HELP lxd_go_goroutines Number of goroutines that currently exist.",
-	GoHeapAllocBytes:            "# HELP lxd_go_heap_alloc_bytes Number of heap bytes allocated and still in use.",
-	GoHeapIdleBytes:             "# HELP lxd_go_heap_idle_bytes Number of heap bytes waiting to be used.",
-	GoHeapInuseBytes:            "# HELP lxd_go_heap_inuse_bytes Number of heap bytes that are in use.",
-	GoHeapObjects:               "# HELP lxd_go_heap_objects Number of allocated objects.",
-	GoHeapReleasedBytes:         "# HELP lxd_go_heap_released_bytes Number of heap bytes released to OS.",
-	GoHeapSysBytes:              "# HELP lxd_go_heap_sys_bytes Number of heap bytes obtained from system.",
-	GoLookupsTotal:              "# HELP lxd_go_lookups_total Total number of pointer lookups.",
-	GoMallocsTotal:              "# HELP lxd_go_mallocs_total Total number of mallocs.",
-	GoMCacheInuseBytes:          "# HELP lxd_go_mcache_inuse_bytes Number of bytes in use by mcache structures.",
-	GoMCacheSysBytes:            "# HELP lxd_go_mcache_sys_bytes Number of bytes used for mcache structures obtained from system.",
-	GoMSpanInuseBytes:           "# HELP lxd_go_mspan_inuse_bytes Number of bytes in use by mspan structures.",
-	GoMSpanSysBytes:             "# HELP lxd_go_mspan_sys_bytes Number of bytes used for mspan structures obtained from system.",
-	GoNextGCBytes:               "# HELP lxd_go_next_gc_bytes Number of heap bytes when next garbage collection will take place.",
-	GoOtherSysBytes:             "# HELP lxd_go_other_sys_bytes Number of bytes used for other system allocations.",
-	GoStackInuseBytes:           "# HELP lxd_go_stack_inuse_bytes Number of bytes in use by the stack allocator.",
-	GoStackSysBytes:             "# HELP lxd_go_stack_sys_bytes Number of bytes obtained from system for stack allocator.",
-	GoSysBytes:                  "# HELP lxd_go_sys_bytes Number of bytes obtained from system.",
-	MemoryActiveAnonBytes:       "# HELP lxd_memory_Active_anon_bytes The amount of anonymous memory on active LRU list.",
-	MemoryActiveFileBytes:       "# HELP lxd_memory_Active_file_bytes The amount of file-backed memory on active LRU list.",
-	MemoryActiveBytes:           "# HELP lxd_memory_Active_bytes The amount of memory on active LRU list.",
-	MemoryCachedBytes:           "# HELP lxd_memory_Cached_bytes The amount of cached memory.",
-	MemoryDirtyBytes:            "# HELP lxd_memory_Dirty_bytes The amount of memory waiting to get written back to the disk.",
-	MemoryHugePagesFreeBytes:    "# HELP lxd_memory_HugepagesFree_bytes The amount of free memory for hugetlb.",
-	MemoryHugePagesTotalBytes:   "# HELP lxd_memory_HugepagesTotal_bytes The amount of used memory for hugetlb.",
-	MemoryInactiveAnonBytes:     "# HELP lxd_memory_Inactive_anon_bytes The amount of anonymous memory on inactive LRU list.",
-	MemoryInactiveFileBytes:     "# HELP lxd_memory_Inactive_file_bytes The amount of file-backed memory on inactive LRU list.",
-	MemoryInactiveBytes:         "# HELP lxd_memory_Inactive_bytes The amount of memory on inactive LRU list.",
-	MemoryMappedBytes:           "# HELP lxd_memory_Mapped_bytes The amount of mapped memory.",
-	MemoryMemAvailableBytes:     "# HELP lxd_memory_MemAvailable_bytes The amount of available memory.",
-	MemoryMemFreeBytes:          "# HELP lxd_memory_MemFree_bytes The amount of free memory.",
-	MemoryMemTotalBytes:         "# HELP lxd_memory_MemTotal_bytes The amount of used memory.",
-	MemoryRSSBytes:              "# HELP lxd_memory_RSS_bytes The amount of anonymous and swap cache memory.",
-	MemoryShmemBytes:            "# HELP lxd_memory_Shmem_bytes The amount of cached filesystem data that is swap-backed.",
-	MemorySwapBytes:             "# HELP lxd_memory_Swap_bytes The amount of used swap memory.",
-	MemoryUnevictableBytes:      "# HELP lxd_memory_Unevictable_bytes The amount of unevictable memory.",
-	MemoryWritebackBytes:        "# HELP lxd_memory_Writeback_bytes The amount of memory queued for syncing to disk.",
-	MemoryOOMKillsTotal:         "# HELP lxd_memory_OOM_kills_total The number of out of memory kills.",
-	NetworkReceiveBytesTotal:    "# HELP lxd_network_receive_bytes_total The amount of received bytes on a given interface.",
-	NetworkReceiveDropTotal:     "# HELP lxd_network_receive_drop_total The amount of received dropped bytes on a given interface.",
-	NetworkReceiveErrsTotal:     "# HELP lxd_network_receive_errs_total The amount of received errors on a given interface.",
-	NetworkReceivePacketsTotal:  "# HELP lxd_network_receive_packets_total The amount of received packets on a given interface.",
-	NetworkTransmitBytesTotal:   "# HELP lxd_network_transmit_bytes_total The amount of transmitted bytes on a given interface.",
-	NetworkTransmitDropTotal:    "# HELP lxd_network_transmit_drop_total The amount of transmitted dropped bytes on a given interface.",
-	NetworkTransmitErrsTotal:    "# HELP lxd_network_transmit_errs_total The amount of transmitted errors on a given interface.",
-	NetworkTransmitPacketsTotal: "# HELP lxd_network_transmit_packets_total The amount of transmitted packets on a given interface.",
-	OperationsTotal:             "# HELP lxd_operations_total The number of running operations",
-	ProcsTotal:                  "# HELP lxd_procs_total The number of running processes.",
-	UptimeSeconds:               "# HELP lxd_uptime_seconds The daemon uptime in seconds.",
-	WarningsTotal:               "# HELP lxd_warnings_total The number of active warnings.",
-	Instances:                   "# HELP lxd_instances The number of instances.",
+	APICompletedRequests:            "# HELP lxd_api_requests_completed_total The total number of completed API requests.",
+	APIOngoingRequests:              "# HELP lxd_api_requests_ongoing The number of API requests currently being handled.",
+	CPUSecondsTotal:                 "# HELP lxd_cpu_seconds_total The total number of CPU time used in seconds.",
+	CPUs:                            "# HELP lxd_cpu_effective_total The total number of effective CPUs.",
+	ClusterHeartbeatFailuresTotal:   "# HELP lxd_cluster_heartbeat_failures_total The total number of failed heartbeats sent to the cluster member.",
+	ClusterHeartbeatSeconds:         "# HELP lxd_cluster_heartbeat_seconds The round-trip time of the last heartbeat sent to the cluster member in seconds.",
+	ClusterLeader:                   "# HELP lxd_cluster_leader Whether the cluster member is the leader.",
+	ClusterLeaderChangesTotal:       "# HELP lxd_cluster_leader_changes_total The number of cluster leader changes observed by the cluster member.",
+	ClusterMembers:                  "# HELP lxd_cluster_members The number of cluster members by status.",
+	ClusterRaftRole:                 "# HELP lxd_cluster_raft_role The raft role of the cluster member.",
+	DatabaseTransactionRetriesTotal: "# HELP lxd_database_transaction_retries_total The total number of database transaction retries.",
+	DatabaseTransactionSecondsTotal: "# HELP lxd_database_transaction_seconds_total The total time spent in database transactions in seconds.",
+	DatabaseTransactionsTotal:       "# HELP lxd_database_transactions_total The total number of database transactions.",
+	DiskReadBytesTotal:              "# HELP lxd_disk_read_bytes_total The total number of bytes read.",
+	DiskReadsCompletedTotal:         "# HELP lxd_disk_reads_completed_total The total number of completed reads.",
+	DiskWrittenBytesTotal:           "# HELP lxd_disk_written_bytes_total The total number of bytes written.",
+	DiskWritesCompletedTotal:        "# HELP lxd_disk_writes_completed_total The total number of completed writes.",
+	EventListeners:                  "# HELP lxd_event_listeners The number of connected event listeners.",
+	FilesystemAvailBytes:            "# HELP lxd_filesystem_avail_bytes The number of available space in bytes.",
+	FilesystemFreeBytes:             "# HELP lxd_filesystem_free_bytes The number of free space in bytes.",
+	FilesystemSizeBytes:             "# HELP lxd_filesystem_size_bytes The size of the filesystem in bytes.",
+	GoAllocBytes:                    "# HELP lxd_go_alloc_bytes Number of bytes allocated and still in use.",
+	GoAllocBytesTotal:               "# HELP lxd_go_alloc_bytes_total Total number of bytes allocated, even if freed.",
+	GoBuckHashSysBytes:              "# HELP lxd_go_buck_hash_sys_bytes Number of bytes used by the profiling bucket hash table.",
+	GoFreesTotal:                    "# HELP lxd_go_frees_total Total number of frees.",
+	GoGCSysBytes:                    "# HELP lxd_go_gc_sys_bytes Number of bytes used for garbage collection system metadata.",
+	GoGoroutines:                    "# HELP lxd_go_goroutines Number of goroutines that currently exist.",
+	GoHeapAllocBytes:                "# HELP lxd_go_heap_alloc_bytes Number of heap bytes allocated and still in use.",
+	GoHeapIdleBytes:                 "# HELP lxd_go_heap_idle_bytes Number of heap bytes waiting to be used.",
+	GoHeapInuseBytes:                "# HELP lxd_go_heap_inuse_bytes Number of heap bytes that are in use.",
+	GoHeapObjects:                   "# HELP lxd_go_heap_objects Number of allocated objects.",
+	GoHeapReleasedBytes:             "# HELP lxd_go_heap_released_bytes Number of heap bytes released to OS.",
+	GoHeapSysBytes:                  "# HELP lxd_go_heap_sys_bytes Number of heap bytes obtained from system.",
+	GoLookupsTotal:                  "# HELP lxd_go_lookups_total Total number of pointer lookups.",
+	GoMallocsTotal:                  "# HELP lxd_go_mallocs_total Total number of mallocs.",
+	GoMCacheInuseBytes:              "# HELP lxd_go_mcache_inuse_bytes Number of bytes in use by mcache structures.",
+	GoMCacheSysBytes:                "# HELP lxd_go_mcache_sys_bytes Number of bytes used for mcache structures obtained from system.",
+	GoMSpanInuseBytes:               "# HELP lxd_go_mspan_inuse_bytes Number of bytes in use by mspan structures.",
+	GoMSpanSysBytes:                 "# HELP lxd_go_mspan_sys_bytes Number of bytes used for mspan structures obtained from system.",
+	GoNextGCBytes:                   "# HELP lxd_go_next_gc_bytes Number of heap bytes when next garbage collection will take place.",
+	GoOtherSysBytes:                 "# HELP lxd_go_other_sys_bytes Number of bytes used for other system allocations.",
+	GoStackInuseBytes:               "# HELP lxd_go_stack_inuse_bytes Number of bytes in use by the stack allocator.",
+	GoStackSysBytes:                 "# HELP lxd_go_stack_sys_bytes Number of bytes obtained from system for stack allocator.",
+	GoSysBytes:                      "# HELP lxd_go_sys_bytes Number of bytes obtained from system.",
+	MemoryActiveAnonBytes:           "# HELP lxd_memory_Active_anon_bytes The amount of anonymous memory on active LRU list.",
+	MemoryActiveFileBytes:           "# HELP lxd_memory_Active_file_bytes The amount of file-backed memory on active LRU list.",
+	MemoryActiveBytes:               "# HELP lxd_memory_Active_bytes The amount of memory on active LRU list.",
+	MemoryCachedBytes:               "# HELP lxd_memory_Cached_bytes The amount of cached memory.",
+	MemoryDirtyBytes:                "# HELP lxd_memory_Dirty_bytes The amount of memory waiting to get written back to the disk.",
+	MemoryHugePagesFreeBytes:        "# HELP lxd_memory_HugepagesFree_bytes The amount of free memory for hugetlb.",
+	MemoryHugePagesTotalBytes:       "# HELP lxd_memory_HugepagesTotal_bytes The amount of used memory for hugetlb.",
+	MemoryInactiveAnonBytes:         "# HELP lxd_memory_Inactive_anon_bytes The amount of anonymous memory on inactive LRU list.",
+	MemoryInactiveFileBytes:         "# HELP lxd_memory_Inactive_file_bytes The amount of file-backed memory on inactive LRU list.",
+	MemoryInactiveBytes:             "# HELP lxd_memory_Inactive_bytes The amount of memory on inactive LRU list.",
+	MemoryMappedBytes:               "# HELP lxd_memory_Mapped_bytes The amount of mapped memory.",
+	MemoryMemAvailableBytes:         "# HELP lxd_memory_MemAvailable_bytes The amount of available memory.",
+	MemoryMemFreeBytes:              "# HELP lxd_memory_MemFree_bytes The amount of free memory.",
+	MemoryMemTotalBytes:             "# HELP lxd_memory_MemTotal_bytes The amount of used memory.",
+	MemoryRSSBytes:                  "# HELP lxd_memory_RSS_bytes The amount of anonymous and swap cache memory.",
+	MemoryShmemBytes:                "# HELP lxd_memory_Shmem_bytes The amount of cached filesystem data that is swap-backed.",
+	MemorySwapBytes:                 "# HELP lxd_memory_Swap_bytes The amount of used swap memory.",
+	MemoryUnevictableBytes:          "# HELP lxd_memory_Unevictable_bytes The amount of unevictable memory.",
+	MemoryWritebackBytes:            "# HELP lxd_memory_Writeback_bytes The amount of memory queued for syncing to disk.",
+	MemoryOOMKillsTotal:             "# HELP lxd_memory_OOM_kills_total The number of out of memory kills.",
+	NetworkReceiveBytesTotal:        "# HELP lxd_network_receive_bytes_total The amount of received bytes on a given interface.",
+	NetworkReceiveDropTotal:         "# HELP lxd_network_receive_drop_total The amount of received dropped bytes on a given interface.",
+	NetworkReceiveErrsTotal:         "# HELP lxd_network_receive_errs_total The amount of received errors on a given interface.",
+	NetworkReceivePacketsTotal:      "# HELP lxd_network_receive_packets_total The amount of received packets on a given interface.",
+	NetworkTransmitBytesTotal:       "# HELP lxd_network_transmit_bytes_total The amount of transmitted bytes on a given interface.",
+	NetworkTransmitDropTotal:        "# HELP lxd_network_transmit_drop_total The amount of transmitted dropped bytes on a given interface.",
+	NetworkTransmitErrsTotal:        "# HELP lxd_network_transmit_errs_total The amount of transmitted errors on a given interface.",
+	NetworkTransmitPacketsTotal:     "# HELP lxd_network_transmit_packets_total The amount of transmitted packets on a given interface.",
+	Operations:                      "# HELP lxd_operations The number of operations by type and status.",
+	OperationsTotal:                 "# HELP lxd_operations_total The number of running operations",
+	ProcsTotal:                      "# HELP lxd_procs_total The number of running processes.",
+	ProjectLimit:                    "# HELP lxd_project_limit The limit of a project resource.",
+	ProjectUsage:                    "# HELP lxd_project_usage The current usage of a project resource.",
+	StoragePoolAllocatedBytes:       "# HELP lxd_storage_pool_allocated_bytes The sum of the sizes of the volumes on the storage pool in bytes.",
+	StoragePoolSizeBytes:            "# HELP lxd_storage_pool_size_bytes The size of the storage pool in bytes.",
+	StoragePoolUsedBytes:            "# HELP lxd_storage_pool_used_bytes The used space of the storage pool in bytes.",
+	StorageVolumeSizeBytes:          "# HELP lxd_storage_volume_size_bytes The size of the custom storage volume in bytes.",
+	StorageVolumeSnapshots:          "# HELP lxd_storage_volume_snapshots The number of snapshots of the custom storage volume.",
+	StorageVolumeUsedBytes:          "# HELP lxd_storage_volume_used_bytes The used space of the custom storage volume in bytes.",
+	UptimeSeconds:                   "# HELP lxd_uptime_seconds The daemon uptime in seconds.",
+	WarningsTotal:                   "# HELP lxd_warnings_total The number of active warnings.",
+	InstanceHealthy:                 "# HELP lxd_instance_healthy Whether the instance is healthy according to its health check.",
+	Instances:                       "# HELP lxd_instances The number of instances.",
 }
@@ -5,13 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"net/http"
 	"net/url"
 	"strings"
 	"time"
 
 	"github.com/canonical/lxd/lxd/instance"
-	"github.com/canonical/lxd/lxd/instance/instancetype"
 	"github.com/canonical/lxd/lxd/instance/operationlock"
 	"github.com/canonical/lxd/lxd/operations"
 	"github.com/canonical/lxd/lxd/state"
@@ -39,10 +37,6 @@ func newMigrationSource(inst instance.Instance, stateful bool, instanceOnly bool
 
 	secretNames := []string{api.SecretNameControl, api.SecretNameFilesystem}
 	if stateful && inst.IsRunning() {
-		if inst.Type() == instancetype.Container {
-			return nil, api.StatusErrorf(http.StatusBadRequest, "Live migration is not supported for containers")
-		}
-
 		ret.live = true
 		secretNames = append(secretNames, api.SecretNameState)
 	}
@@ -169,10 +163,6 @@ func newMigrationSink(args *migrationSinkArgs) (*migrationSink, error) {
 
 	secretNames := []string{api.SecretNameControl, api.SecretNameFilesystem}
 	if sink.live {
-		if sink.instance.Type() == instancetype.Container {
-			return nil, api.StatusErrorf(http.StatusBadRequest, "Live migration is not supported for containers")
-		}
-
 		secretNames = append(secretNames, api.SecretNameState)
 	}
 
@@ -0,0 +1,261 @@
+package migration
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// CRIU image magic numbers used by the stats-dump file.
+const (
+	criuImgServiceMagic uint32 = 0x55105940
+	criuStatsMagic      uint32 = 0x57093306
+)
+
+// CRIU StatsEntry and DumpStatsEntry protobuf field numbers.
+const (
+	criuStatsEntryDump              protowire.Number = 1
+	criuDumpStatsPagesSkippedParent protowire.Number = 6
+	criuDumpStatsPagesWritten       protowire.Number = 7
+)
+
+// CRIUDumpStats represents the memory statistics of a CRIU dump or pre-dump.
+type CRIUDumpStats struct {
+	PagesWritten       uint64
+	PagesSkippedParent uint64
+}
+
+// SkippedPercentage returns the percentage of memory pages that didn't need to be written because they were
+// unchanged since the parent pre-dump.
+func (s CRIUDumpStats) SkippedPercentage() int {
+	total := s.PagesWritten + s.PagesSkippedParent
+	if total == 0 {
+		return 100
+	}
+
+	return int(s.PagesSkippedParent * 100 / total)
+}
+
+// ReadCRIUDumpStats reads the memory statistics from the "stats-dump" file in a CRIU images directory.
+func ReadCRIUDumpStats(imagesDir string) (*CRIUDumpStats, error) {
+	f, err := os.Open(filepath.Join(imagesDir, "stats-dump"))
+	if err != nil {
+		return nil, fmt.Errorf("Failed opening CRIU dump statistics: %w", err)
+	}
+
+	defer func() { _ = f.Close() }()
+
+	return parseCRIUDumpStats(f)
+}
+
+// parseCRIUDumpStats parses a CRIU stats image. It consists of the image magic numbers followed by a single
+// size prefixed StatsEntry protobuf message.
+func parseCRIUDumpStats(r io.Reader) (*CRIUDumpStats, error) {
+	var header [3]uint32
+	err := binary.Read(r, binary.LittleEndian, &header)
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading CRIU image header: %w", err)
+	}
+
+	if header[0] != criuImgServiceMagic || header[1] != criuStatsMagic {
+		return nil, errors.New("Invalid CRIU statistics image magic")
+	}
+
+	buf := make([]byte, header[2])
+	_, err = io.ReadFull(r, buf)
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading CRIU statistics entry: %w", err)
+	}
+
+	stats := &CRIUDumpStats{}
+	err = criuProtoFields(buf, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		if num != criuStatsEntryDump || typ != protowire.BytesType {
+			return nil
+		}
+
+		return criuProtoFields(value, func(num protowire.Number, typ protowire.Type, value []byte) error {
+			if typ != protowire.VarintType {
+				return nil
+			}
+
+			v, _ := protowire.ConsumeVarint(value)
+			switch num {
+			case criuDumpStatsPagesSkippedParent:
+				stats.PagesSkippedParent = v
+			case criuDumpStatsPagesWritten:
+				stats.PagesWritten = v
+			}
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing CRIU statistics entry: %w", err)
+	}
+
+	return stats, nil
+}
+
+// criuProtoFields calls fn for each top level field of an encoded protobuf message. For varint fields the value
+// is the encoded varint and for length delimited fields it is the field content.
+func criuProtoFields(buf []byte, fn func(num protowire.Number, typ protowire.Type, value []byte) error) error {
+	for len(buf) > 0 {
+		num, typ, n := protowire.ConsumeTag(buf)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+
+		buf = buf[n:]
+
+		var value []byte
+		switch typ {
+		case protowire.BytesType:
+			value, n = protowire.ConsumeBytes(buf)
+		default:
+			value = buf
+			n = protowire.ConsumeFieldValue(num, typ, buf)
+		}
+
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+
+		if typ != protowire.BytesType {
+			value = value[:n]
+		}
+
+		err := fn(num, typ, value)
+		if err != nil {
+			return err
+		}
+
+		buf = buf[n:]
+	}
+
+	return nil
+}
+
+// CRIUPageServerProxy forwards the traffic between a CRIU page server connection and the migration state connection
+// until both sides are done. The end of the data received from the page server connection is signalled by closing
+// the state connection, which only sends a barrier, and the end of the data received from the state connection is
+// signalled by closing the write side of the page server connection.
+func CRIUPageServerProxy(conn net.Conn, stateConn io.ReadWriteCloser) error {
+	recvDone := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(conn, stateConn)
+		if err == nil {
+			closeWriter, ok := conn.(interface{ CloseWrite() error })
+			if ok {
+				err = closeWriter.CloseWrite()
+			}
+		}
+
+		recvDone <- err
+	}()
+
+	// On error, the receiving routine ends once the migration connections are disconnected.
+	_, err := io.Copy(stateConn, conn)
+	if err != nil {
+		return fmt.Errorf("Failed forwarding CRIU page server traffic: %w", err)
+	}
+
+	err = stateConn.Close()
+	if err != nil {
+		return fmt.Errorf("Failed ending CRIU page server stream: %w", err)
+	}
+
+	err = <-recvDone
+	if err != nil {
+		return fmt.Errorf("Failed forwarding CRIU page server traffic: %w", err)
+	}
+
+	return nil
+}
+
+// AcceptCRIUPageServerConn accepts the connection of CRIU to a page server listener on the loopback interface.
+// Connections from sockets that aren't owned by root are rejected, so that other local users can't inject memory
+// pages into the migrated container.
+func AcceptCRIUPageServerConn(listener net.Listener) (net.Conn, error) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+
+		uid, err := tcpPeerUID(conn)
+		if err == nil && uid == 0 {
+			return conn, nil
+		}
+
+		_ = conn.Close()
+	}
+}
+
+// tcpPeerUID returns the owner of the local socket at the other end of an IPv4 TCP connection on the loopback
+// interface, as reported by /proc/net/tcp.
+func tcpPeerUID(conn net.Conn) (uint32, error) {
+	local, ok := conn.LocalAddr().(*net.TCPAddr)
+	if !ok {
+		return 0, errors.New("Not a TCP connection")
+	}
+
+	remote, ok := conn.RemoteAddr().(*net.TCPAddr)
+	if !ok {
+		return 0, errors.New("Not a TCP connection")
+	}
+
+	// The addresses are encoded as the hexadecimal IPv4 address in host byte order and the hexadecimal port.
+	procAddr := func(addr *net.TCPAddr) string {
+		ip := addr.IP.To4()
+		if ip == nil {
+			return ""
+		}
+
+		return fmt.Sprintf("%02X%02X%02X%02X:%04X", ip[3], ip[2], ip[1], ip[0], addr.Port)
+	}
+
+	// The peer socket has the remote address of the connection as local address, and the other way around.
+	peerLocal := procAddr(remote)
+	peerRemote := procAddr(local)
+	if peerLocal == "" || peerRemote == "" {
+		return 0, errors.New("Not an IPv4 connection")
+	}
+
+	f, err := os.Open("/proc/net/tcp")
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 8 || fields[1] != peerLocal || fields[2] != peerRemote {
+			continue
+		}
+
+		uid, err := strconv.ParseUint(fields[7], 10, 32)
+		if err != nil {
+			return 0, err
+		}
+
+		return uint32(uid), nil
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return 0, err
+	}
+
+	return 0, errors.New("Peer socket not found")
+}
@@ -0,0 +1,147 @@
+package migration
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"os"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func criuStatsImage(written uint64, skipped uint64) []byte {
+	var dump []byte
+	dump = protowire.AppendTag(dump, 1, protowire.VarintType) // freezing_time
+	dump = protowire.AppendVarint(dump, 1234)
+	dump = protowire.AppendTag(dump, criuDumpStatsPagesSkippedParent, protowire.VarintType)
+	dump = protowire.AppendVarint(dump, skipped)
+	dump = protowire.AppendTag(dump, criuDumpStatsPagesWritten, protowire.VarintType)
+	dump = protowire.AppendVarint(dump, written)
+
+	var entry []byte
+	entry = protowire.AppendTag(entry, criuStatsEntryDump, protowire.BytesType)
+	entry = protowire.AppendBytes(entry, dump)
+
+	buf := &bytes.Buffer{}
+	_ = binary.Write(buf, binary.LittleEndian, []uint32{criuImgServiceMagic, criuStatsMagic, uint32(len(entry))})
+	buf.Write(entry)
+
+	return buf.Bytes()
+}
+
+func TestParseCRIUDumpStats(t *testing.T) {
+	stats, err := parseCRIUDumpStats(bytes.NewReader(criuStatsImage(250, 750)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if stats.PagesWritten != 250 || stats.PagesSkippedParent != 750 {
+		t.Fatalf("Unexpected stats: %+v", stats)
+	}
+
+	if stats.SkippedPercentage() != 75 {
+		t.Fatalf("Unexpected skipped percentage: %d", stats.SkippedPercentage())
+	}
+}
+
+func TestParseCRIUDumpStatsInvalidMagic(t *testing.T) {
+	image := criuStatsImage(1, 1)
+	image[0] = 0
+
+	_, err := parseCRIUDumpStats(bytes.NewReader(image))
+	if err == nil {
+		t.Fatal("Expected error for invalid magic")
+	}
+}
+
+// stateConn is a migration state connection whose streams end with a barrier instead of closing the connection.
+type stateConn struct {
+	in       io.Reader
+	out      bytes.Buffer
+	barriers int
+}
+
+func (c *stateConn) Read(p []byte) (int, error) {
+	return c.in.Read(p)
+}
+
+func (c *stateConn) Write(p []byte) (int, error) {
+	return c.out.Write(p)
+}
+
+func (c *stateConn) Close() error {
+	c.barriers++
+	return nil
+}
+
+// tcpConnPair returns both ends of a TCP connection on the loopback interface.
+func tcpConnPair(t *testing.T) (client net.Conn, server net.Conn) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = listener.Close() }()
+
+	client, err = net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server, err = listener.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = client.Close()
+		_ = server.Close()
+	})
+
+	return client, server
+}
+
+func TestCRIUPageServerProxy(t *testing.T) {
+	criu, conn := tcpConnPair(t)
+
+	// CRIU sends its request and receives the reply of the page server.
+	reply := make(chan string, 1)
+	go func() {
+		_, _ = criu.Write([]byte("request"))
+		_ = criu.(*net.TCPConn).CloseWrite()
+
+		data, _ := io.ReadAll(criu)
+		reply <- string(data)
+	}()
+
+	state := &stateConn{in: bytes.NewReader([]byte("reply"))}
+	err := CRIUPageServerProxy(conn, state)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if state.out.String() != "request" || state.barriers != 1 {
+		t.Fatalf("Unexpected data sent over state connection: %q (%d barriers)", state.out.String(), state.barriers)
+	}
+
+	if <-reply != "reply" {
+		t.Fatal("Unexpected data received by CRIU")
+	}
+}
+
+func TestTCPPeerUID(t *testing.T) {
+	_, conn := tcpConnPair(t)
+
+	uid, err := tcpPeerUID(conn)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if uid != uint32(os.Getuid()) {
+		t.Fatalf("Unexpected peer UID %d", uid)
+	}
+}
@@ -64,6 +64,32 @@ func ProtoSend(ws *websocket.Conn, msg proto.Message) error {
 	return w.Close()
 }
 
+// ProtoSendStream sends a protobuf message as a single stream over a reusable migration connection.
+// Closing the connection only sends a barrier, so the connection can be used for further streams.
+func ProtoSendStream(conn io.WriteCloser, msg proto.Message) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Write(data)
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+// ProtoRecvStream receives a protobuf message sent with ProtoSendStream.
+func ProtoRecvStream(conn io.Reader, msg proto.Message) error {
+	data, err := io.ReadAll(conn)
+	if err != nil {
+		return err
+	}
+
+	return proto.Unmarshal(data, msg)
+}
+
 // ProtoSendControl sends a migration control message over a websocket.
 func ProtoSendControl(ws *websocket.Conn, err error) {
 	message := ""
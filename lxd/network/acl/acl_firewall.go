@@ -13,12 +13,39 @@ import (
 
 // FirewallApplyACLRules applies ACL rules to network firewall.
 func FirewallApplyACLRules(ctx context.Context, s *state.State, aclProjectName string, aclNet NetworkACLUsage) error {
+	rules, err := firewallACLRules(ctx, s, aclProjectName, aclNet.Name, aclNet.Config)
+	if err != nil {
+		return fmt.Errorf("Failed generating ACL rules for network %q: %w", aclNet.Name, err)
+	}
+
+	return s.Firewall.NetworkApplyACLRules(aclNet.Name, rules)
+}
+
+// FirewallApplyInstanceNICACLRules applies ACL rules to the firewall for an instance NIC that isn't connected to
+// a bridge or OVN network. The firewall chains for the NIC must have been set up beforehand.
+func FirewallApplyInstanceNICACLRules(ctx context.Context, s *state.State, aclProjectName string, aclNIC InstanceNICACLUsage) error {
+	// Use the host side interface name as log prefix as it is unique and short enough.
+	rules, err := firewallACLRules(ctx, s, aclProjectName, aclNIC.HostName, aclNIC.Config)
+	if err != nil {
+		return fmt.Errorf("Failed generating ACL rules for instance %q NIC %q: %w", aclNIC.InstanceName, aclNIC.DeviceName, err)
+	}
+
+	if aclNIC.ParentFiltered {
+		return s.Firewall.InstanceApplyParentACLRules(aclNIC.ProjectName, aclNIC.InstanceName, aclNIC.DeviceName, rules)
+	}
+
+	return s.Firewall.InstanceSetupACLRules(aclNIC.ProjectName, aclNIC.InstanceName, aclNIC.DeviceName, aclNIC.HostName, rules)
+}
+
+// firewallACLRules generates the firewall rules for the ACLs (and default rules) specified in the config of a
+// network or instance NIC.
+func firewallACLRules(ctx context.Context, s *state.State, aclProjectName string, logPrefix string, config map[string]string) ([]firewallDrivers.ACLRule, error) {
 	var dropRules []firewallDrivers.ACLRule
 	var rejectRules []firewallDrivers.ACLRule
 	var allowRules []firewallDrivers.ACLRule
 
 	// convertACLRules converts the ACL rules to Firewall ACL rules.
-	convertACLRules := func(direction string, logPrefix string, rules ...api.NetworkACLRule) error {
+	convertACLRules := func(aclID int64, direction string, rules ...api.NetworkACLRule) error {
 		for ruleIndex, rule := range rules {
 			if rule.State == "disabled" {
 				continue
@@ -34,12 +61,13 @@ func FirewallApplyACLRules(ctx context.Context, s *state.State, aclProjectName s
 				DestinationPort: rule.DestinationPort,
 				ICMPType:        rule.ICMPType,
 				ICMPCode:        rule.ICMPCode,
+				CounterName:     firewallACLRuleName(aclID, direction, ruleIndex),
 			}
 
 			if rule.State == "logged" {
 				firewallACLRule.Log = true
 				// Max 29 chars.
-				firewallACLRule.LogName = fmt.Sprintf("%s-%s-%d", logPrefix, direction, ruleIndex)
+				firewallACLRule.LogName = firewallACLRule.CounterName
 			}
 
 			switch rule.Action {
@@ -57,31 +85,30 @@ func FirewallApplyACLRules(ctx context.Context, s *state.State, aclProjectName s
 		return nil
 	}
 
-	logPrefix := aclNet.Name
-
-	// Load ACLs specified by network.
-	for _, aclName := range shared.SplitNTrimSpace(aclNet.Config["security.acls"], ",", -1, true) {
+	// Load ACLs specified by config.
+	for _, aclName := range shared.SplitNTrimSpace(config["security.acls"], ",", -1, true) {
+		var aclID int64
 		var aclInfo *api.NetworkACL
 
 		err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
 			var err error
 
-			_, aclInfo, err = tx.GetNetworkACL(ctx, aclProjectName, aclName)
+			aclID, aclInfo, err = tx.GetNetworkACL(ctx, aclProjectName, aclName)
 
 			return err
 		})
 		if err != nil {
-			return fmt.Errorf("Failed loading ACL %q for network %q: %w", aclName, aclNet.Name, err)
+			return nil, fmt.Errorf("Failed loading ACL %q: %w", aclName, err)
 		}
 
-		err = convertACLRules("ingress", logPrefix, aclInfo.Ingress...)
+		err = convertACLRules(aclID, "ingress", aclInfo.Ingress...)
 		if err != nil {
-			return fmt.Errorf("Failed converting ACL %q ingress rules for network %q: %w", aclInfo.Name, aclNet.Name, err)
+			return nil, fmt.Errorf("Failed converting ACL %q ingress rules: %w", aclInfo.Name, err)
 		}
 
-		err = convertACLRules("egress", logPrefix, aclInfo.Egress...)
+		err = convertACLRules(aclID, "egress", aclInfo.Egress...)
 		if err != nil {
-			return fmt.Errorf("Failed converting ACL %q egress rules for network %q: %w", aclInfo.Name, aclNet.Name, err)
+			return nil, fmt.Errorf("Failed converting ACL %q egress rules: %w", aclInfo.Name, err)
 		}
 	}
 
@@ -90,9 +117,9 @@ func FirewallApplyACLRules(ctx context.Context, s *state.State, aclProjectName s
 	rules = append(rules, rejectRules...)
 	rules = append(rules, allowRules...)
 
-	// Add the automatic default ACL rule for the network.
-	egressAction, egressLogged := firewallACLDefaults(aclNet.Config, "egress")
-	ingressAction, ingressLogged := firewallACLDefaults(aclNet.Config, "ingress")
+	// Add the automatic default ACL rules.
+	egressAction, egressLogged := firewallACLDefaults(config, "egress")
+	ingressAction, ingressLogged := firewallACLDefaults(config, "ingress")
 
 	rules = append(rules, firewallDrivers.ACLRule{
 		Direction: "egress",
@@ -108,7 +135,13 @@ func FirewallApplyACLRules(ctx context.Context, s *state.State, aclProjectName s
 		LogName:   logPrefix + "-ingress",
 	})
 
-	return s.Firewall.NetworkApplyACLRules(aclNet.Name, rules)
+	return rules, nil
+}
+
+// firewallACLRuleName returns the name used for the log prefix and hit counters of an ACL rule.
+// This uses the same format as the OVN ACL log names so that entries can be mapped back to the ACL rule.
+func firewallACLRuleName(aclID int64, direction string, ruleIndex int) string {
+	return fmt.Sprintf("%s%d-%s-%d", ovnACLPortGroupPrefix, aclID, direction, ruleIndex)
 }
 
 // firewallACLDefaults returns the action and logging mode to use for the specified direction's default rule.
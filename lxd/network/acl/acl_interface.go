@@ -23,6 +23,9 @@ type NetworkACL interface {
 	// GetLog.
 	GetLog(ctx context.Context, clientType request.ClientType) (string, error)
 
+	// State.
+	State(ctx context.Context, clientType request.ClientType) (*api.NetworkACLState, error)
+
 	// Internal validation.
 	validateName(name string) error
 	validateConfig(ctx context.Context, config *api.NetworkACLPut) error
@@ -277,12 +277,15 @@ func UsedBy(ctx context.Context, s *state.State, aclProjectName string, usageFun
 	return nil
 }
 
+// instanceNICACLTypes are the NIC types not connected to a managed network that can use network ACLs.
+var instanceNICACLTypes = []string{"macvlan", "routed"}
+
 // isInUseByDevice returns any of the supplied matching ACL names found referenced by the NIC device.
 func isInUseByDevice(d deviceConfig.Device, matchACLNames ...string) []string {
 	matchedACLNames := []string{}
 
-	// Only NICs linked to managed networks can use network ACLs.
-	if d["type"] != "nic" || d["network"] == "" {
+	// Only NICs linked to managed networks or using a NIC type that filters on the host can use network ACLs.
+	if d["type"] != "nic" || (d["network"] == "" && !slices.Contains(instanceNICACLTypes, d["nictype"])) {
 		return matchedACLNames
 	}
 
@@ -311,6 +314,10 @@ func NetworkUsage(ctx context.Context, s *state.State, aclProjectName string, ac
 	err := UsedBy(ctx, s, aclProjectName, func(ctx context.Context, tx *db.ClusterTx, matchedACLNames []string, usageType any, _ string, nicConfig map[string]string) error {
 		switch u := usageType.(type) {
 		case db.InstanceArgs, cluster.Profile:
+			if nicConfig["network"] == "" {
+				return nil // Instance NICs not connected to a managed network are handled by InstanceNICUsage.
+			}
+
 			networkID, network, _, err := tx.GetNetworkInAnyState(ctx, aclProjectName, nicConfig["network"])
 			if err != nil {
 				return fmt.Errorf("Failed loading network %q: %w", nicConfig["network"], err)
@@ -360,3 +367,55 @@ func NetworkUsage(ctx context.Context, s *state.State, aclProjectName string, ac
 
 	return nil
 }
+
+// InstanceNICACLUsage info about an instance NIC (not connected to a bridge or OVN network) and what ACL it uses.
+type InstanceNICACLUsage struct {
+	ProjectName    string
+	InstanceName   string
+	DeviceName     string
+	HostName       string
+	ParentFiltered bool // Whether the NIC traffic is filtered on the parent interface rather than the host interface.
+	Config         map[string]string
+}
+
+// InstanceNICUsage populates the provided aclNICs slice with the started instance NICs on this member that are
+// using any of the specified ACLs and that aren't connected to a bridge or OVN network.
+func InstanceNICUsage(ctx context.Context, s *state.State, aclProjectName string, aclNames []string, aclNICs *[]InstanceNICACLUsage) error {
+	return UsedBy(ctx, s, aclProjectName, func(ctx context.Context, tx *db.ClusterTx, _ []string, usageType any, nicName string, nicConfig map[string]string) error {
+		inst, ok := usageType.(db.InstanceArgs)
+		if !ok || inst.Node != s.ServerName {
+			return nil
+		}
+
+		// Only started NICs have a host interface name recorded.
+		hostName := inst.Config["volatile."+nicName+".host_name"]
+		if hostName == "" {
+			return nil
+		}
+
+		nicType := nicConfig["nictype"]
+		if nicConfig["network"] != "" {
+			_, network, _, err := tx.GetNetworkInAnyState(ctx, aclProjectName, nicConfig["network"])
+			if err != nil {
+				return fmt.Errorf("Failed loading network %q: %w", nicConfig["network"], err)
+			}
+
+			nicType = network.Type
+		}
+
+		if !slices.Contains(instanceNICACLTypes, nicType) {
+			return nil
+		}
+
+		*aclNICs = append(*aclNICs, InstanceNICACLUsage{
+			ProjectName:    inst.Project,
+			InstanceName:   inst.Name,
+			DeviceName:     nicName,
+			HostName:       hostName,
+			ParentFiltered: nicType == "macvlan",
+			Config:         nicConfig,
+		})
+
+		return nil
+	}, aclNames...)
+}
@@ -0,0 +1,290 @@
+package acl
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/canonical/lxd/lxd/db"
+	"github.com/canonical/lxd/lxd/state"
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/lxd/shared/api"
+)
+
+// logEventCacheTTL is how long resolved ACLs are cached for when converting log entries into events.
+const logEventCacheTTL = 30 * time.Second
+
+// logEntry represents a single hit of a logged ACL rule.
+type logEntry struct {
+	aclID           int64
+	direction       string
+	ruleIndex       int
+	action          string
+	protocol        string
+	source          string
+	destination     string
+	sourcePort      string
+	destinationPort string
+	icmpType        string
+	icmpCode        string
+	inInterface     string
+	outInterface    string
+}
+
+// logEventACL is a cached ACL used to resolve log entries.
+type logEventACL struct {
+	projectName string
+	info        *api.NetworkACL
+	expiry      time.Time
+}
+
+var logEventACLsMu sync.Mutex
+var logEventACLs = map[int64]*logEventACL{}
+
+// parseLogRuleName parses an ACL rule name in the "lxd_acl<ID>-<direction>-<index>" format.
+// Returns false if the name doesn't refer to an ACL rule (such as the default rules).
+func parseLogRuleName(name string) (aclID int64, direction string, ruleIndex int, ok bool) {
+	name, found := strings.CutPrefix(name, ovnACLPortGroupPrefix)
+	if !found {
+		return -1, "", -1, false
+	}
+
+	fields := strings.Split(name, "-")
+	if len(fields) != 3 {
+		return -1, "", -1, false
+	}
+
+	if fields[1] != string(ruleDirectionIngress) && fields[1] != string(ruleDirectionEgress) {
+		return -1, "", -1, false
+	}
+
+	aclID, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return -1, "", -1, false
+	}
+
+	ruleIndex, err = strconv.Atoi(fields[2])
+	if err != nil {
+		return -1, "", -1, false
+	}
+
+	return aclID, fields[1], ruleIndex, true
+}
+
+// parseFirewallLogEntry parses a kernel log message generated by a logged firewall ACL rule.
+// Expected format: lxd_acl<ID>-<direction>-<index> IN=<iface> OUT=<iface> ... SRC=<ip> DST=<ip> ... PROTO=<proto> ...
+// Returns nil if the message doesn't come from an ACL rule.
+func parseFirewallLogEntry(message string) *logEntry {
+	prefix, fields, found := strings.Cut(message, " ")
+	if !found {
+		return nil
+	}
+
+	aclID, direction, ruleIndex, ok := parseLogRuleName(prefix)
+	if !ok {
+		return nil
+	}
+
+	values := map[string]string{}
+	for _, field := range strings.Fields(fields) {
+		key, value, found := strings.Cut(field, "=")
+		if !found {
+			continue
+		}
+
+		values[key] = value
+	}
+
+	if values["SRC"] == "" || values["DST"] == "" {
+		return nil
+	}
+
+	return &logEntry{
+		aclID:           aclID,
+		direction:       direction,
+		ruleIndex:       ruleIndex,
+		protocol:        strings.ToLower(values["PROTO"]),
+		source:          values["SRC"],
+		destination:     values["DST"],
+		sourcePort:      values["SPT"],
+		destinationPort: values["DPT"],
+		icmpType:        values["TYPE"],
+		icmpCode:        values["CODE"],
+		inInterface:     values["IN"],
+		outInterface:    values["OUT"],
+	}
+}
+
+// parseOVNLogEntry parses the message of an OVN "acl_log" log entry.
+// Expected format: name="lxd_acl<ID>-<direction>-<index>", verdict=drop, severity=info, direction=to-lport: tcp,...
+// Returns nil if the message doesn't come from an ACL rule.
+func parseOVNLogEntry(message string) *logEntry {
+	values := map[string]string{}
+	for _, entry := range shared.SplitNTrimSpace(message, ",", -1, true) {
+		key, value, found := strings.Cut(entry, "=")
+		if !found {
+			continue
+		}
+
+		values[strings.Trim(key, "\"")] = strings.Trim(value, "\"")
+	}
+
+	aclID, direction, ruleIndex, ok := parseLogRuleName(values["name"])
+	if !ok {
+		return nil
+	}
+
+	_, protocol, found := strings.Cut(values["direction"], " ")
+	if !found {
+		return nil
+	}
+
+	entry := &logEntry{
+		aclID:           aclID,
+		direction:       direction,
+		ruleIndex:       ruleIndex,
+		action:          values["verdict"],
+		protocol:        protocol,
+		source:          values["nw_src"],
+		destination:     values["nw_dst"],
+		sourcePort:      values["tp_src"],
+		destinationPort: values["tp_dst"],
+		icmpType:        values["icmp_type"],
+		icmpCode:        values["icmp_code"],
+	}
+
+	if entry.source == "" {
+		entry.source = values["ipv6_src"]
+	}
+
+	if entry.destination == "" {
+		entry.destination = values["ipv6_dst"]
+	}
+
+	return entry
+}
+
+// loadLogEventACL returns the (cached) project name and info of the ACL with the specified ID.
+func loadLogEventACL(ctx context.Context, s *state.State, aclID int64) (string, *api.NetworkACL, error) {
+	logEventACLsMu.Lock()
+	defer logEventACLsMu.Unlock()
+
+	cached, found := logEventACLs[aclID]
+	if found && time.Now().Before(cached.expiry) {
+		return cached.projectName, cached.info, nil
+	}
+
+	var projectName string
+	var aclInfo *api.NetworkACL
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		aclName, aclProjectName, err := tx.GetNetworkACLNameAndProjectWithID(ctx, int(aclID))
+		if err != nil {
+			return err
+		}
+
+		_, aclInfo, err = tx.GetNetworkACL(ctx, aclProjectName, aclName)
+		if err != nil {
+			return err
+		}
+
+		projectName = aclProjectName
+
+		return nil
+	})
+	if err != nil {
+		delete(logEventACLs, aclID)
+		return "", nil, err
+	}
+
+	// Drop expired entries to keep the cache bounded to the recently logged ACLs.
+	for id, entry := range logEventACLs {
+		if time.Now().After(entry.expiry) {
+			delete(logEventACLs, id)
+		}
+	}
+
+	logEventACLs[aclID] = &logEventACL{
+		projectName: projectName,
+		info:        aclInfo,
+		expiry:      time.Now().Add(logEventCacheTTL),
+	}
+
+	return projectName, aclInfo, nil
+}
+
+// logEntryToEvent resolves the ACL of a log entry and converts it into a logging event.
+func logEntryToEvent(ctx context.Context, s *state.State, entry *logEntry, origin string) (string, *api.EventLogging, error) {
+	projectName, aclInfo, err := loadLogEventACL(ctx, s, entry.aclID)
+	if err != nil {
+		return "", nil, fmt.Errorf("Failed loading ACL with ID %d: %w", entry.aclID, err)
+	}
+
+	rules := aclInfo.Ingress
+	if entry.direction == string(ruleDirectionEgress) {
+		rules = aclInfo.Egress
+	}
+
+	// Firewall log entries don't include the verdict, so take it from the rule.
+	if entry.action == "" && entry.ruleIndex < len(rules) {
+		entry.action = rules[entry.ruleIndex].Action
+	}
+
+	event := &api.EventLogging{
+		Level:   "info",
+		Message: fmt.Sprintf("ACL %q %s rule %d matched (%s)", aclInfo.Name, entry.direction, entry.ruleIndex, entry.action),
+		Context: map[string]string{
+			"acl":         aclInfo.Name,
+			"project":     projectName,
+			"direction":   entry.direction,
+			"rule":        strconv.Itoa(entry.ruleIndex),
+			"action":      entry.action,
+			"origin":      origin,
+			"protocol":    entry.protocol,
+			"source":      entry.source,
+			"destination": entry.destination,
+		},
+	}
+
+	optionalContext := map[string]string{
+		"source_port":      entry.sourcePort,
+		"destination_port": entry.destinationPort,
+		"icmp_type":        entry.icmpType,
+		"icmp_code":        entry.icmpCode,
+		"in":               entry.inInterface,
+		"out":              entry.outInterface,
+	}
+
+	for k, v := range optionalContext {
+		if v != "" {
+			event.Context[k] = v
+		}
+	}
+
+	return projectName, event, nil
+}
+
+// FirewallLogEvent converts a kernel log message generated by a logged firewall ACL rule into a logging event.
+// Returns the project of the ACL along with the event, or a nil event if the message isn't an ACL log entry.
+func FirewallLogEvent(ctx context.Context, s *state.State, message string) (string, *api.EventLogging, error) {
+	entry := parseFirewallLogEntry(message)
+	if entry == nil {
+		return "", nil, nil
+	}
+
+	return logEntryToEvent(ctx, s, entry, "firewall")
+}
+
+// OVNLogEvent converts the message of an OVN "acl_log" log entry into a logging event.
+// Returns the project of the ACL along with the event, or a nil event if the message isn't an ACL log entry.
+func OVNLogEvent(ctx context.Context, s *state.State, message string) (string, *api.EventLogging, error) {
+	entry := parseOVNLogEntry(message)
+	if entry == nil {
+		return "", nil, nil
+	}
+
+	return logEntryToEvent(ctx, s, entry, "ovn")
+}
@@ -0,0 +1,115 @@
+package acl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_parseFirewallLogEntry(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected *logEntry
+	}{
+		{
+			name:    "TCP rule",
+			message: "lxd_acl3-ingress-1 IN=eth0 OUT=lxdbr0 MAC=00:16:3e:00:00:01 SRC=192.0.2.1 DST=10.0.0.2 LEN=60 TOS=0x00 PREC=0x00 TTL=63 ID=1 DF PROTO=TCP SPT=40000 DPT=22 WINDOW=64240 RES=0x00 SYN URGP=0",
+			expected: &logEntry{
+				aclID:           3,
+				direction:       "ingress",
+				ruleIndex:       1,
+				protocol:        "tcp",
+				source:          "192.0.2.1",
+				destination:     "10.0.0.2",
+				sourcePort:      "40000",
+				destinationPort: "22",
+				inInterface:     "eth0",
+				outInterface:    "lxdbr0",
+			},
+		},
+		{
+			name:    "ICMP rule",
+			message: "lxd_acl12-egress-0 IN=lxdbr0 OUT= SRC=10.0.0.2 DST=192.0.2.1 LEN=84 PROTO=ICMP TYPE=8 CODE=0 ID=1 SEQ=1",
+			expected: &logEntry{
+				aclID:       12,
+				direction:   "egress",
+				ruleIndex:   0,
+				protocol:    "icmp",
+				source:      "10.0.0.2",
+				destination: "192.0.2.1",
+				icmpType:    "8",
+				icmpCode:    "0",
+				inInterface: "lxdbr0",
+			},
+		},
+		{
+			name:    "Default rule",
+			message: "lxdbr0-ingress IN=eth0 OUT=lxdbr0 SRC=192.0.2.1 DST=10.0.0.2 PROTO=UDP SPT=1 DPT=2",
+		},
+		{
+			name:    "Unrelated message",
+			message: "eth0: link up",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseFirewallLogEntry(tt.message)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func Test_parseOVNLogEntry(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected *logEntry
+	}{
+		{
+			name:    "TCP rule",
+			message: `name="lxd_acl7-egress-2", verdict=drop, severity=info, direction=from-lport: tcp,vlan_tci=0x0000,dl_src=00:16:3e:00:00:01,dl_dst=00:16:3e:00:00:02,nw_src=10.0.0.2,nw_dst=192.0.2.1,nw_tos=0,nw_ecn=0,nw_ttl=64,tp_src=40000,tp_dst=443,tcp_flags=syn`,
+			expected: &logEntry{
+				aclID:           7,
+				direction:       "egress",
+				ruleIndex:       2,
+				action:          "drop",
+				protocol:        "tcp",
+				source:          "10.0.0.2",
+				destination:     "192.0.2.1",
+				sourcePort:      "40000",
+				destinationPort: "443",
+			},
+		},
+		{
+			name:    "ICMPv6 rule",
+			message: `name="lxd_acl7-ingress-0", verdict=allow, severity=info, direction=to-lport: icmp6,vlan_tci=0x0000,ipv6_src=2001:db8::1,ipv6_dst=2001:db8::2,icmp_type=128,icmp_code=0`,
+			expected: &logEntry{
+				aclID:       7,
+				direction:   "ingress",
+				ruleIndex:   0,
+				action:      "allow",
+				protocol:    "icmp6",
+				source:      "2001:db8::1",
+				destination: "2001:db8::2",
+				icmpType:    "128",
+				icmpCode:    "0",
+			},
+		},
+		{
+			name:    "Default rule",
+			message: `name="lxd_acl7", verdict=drop, severity=info, direction=to-lport: tcp,nw_src=10.0.0.2,nw_dst=192.0.2.1`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseOVNLogEntry(tt.message)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, result)
+			}
+		})
+	}
+}
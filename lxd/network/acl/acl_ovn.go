@@ -855,6 +855,10 @@ func OVNPortGroupDeleteIfUnused(ctx context.Context, s *state.State, l logger.Lo
 				return nil
 			}
 
+			if nicConfig["network"] == "" {
+				return nil // Not connected to an OVN network.
+			}
+
 			netID, network, _, err := tx.GetNetworkInAnyState(ctx, aclProjectName, nicConfig["network"])
 			if err != nil {
 				return fmt.Errorf("Failed loading network %q: %w", nicConfig["network"], err)
@@ -911,6 +915,10 @@ func OVNPortGroupDeleteIfUnused(ctx context.Context, s *state.State, l logger.Lo
 				return nil
 			}
 
+			if nicConfig["network"] == "" {
+				return nil // Not connected to an OVN network.
+			}
+
 			netID, network, _, err := tx.GetNetworkInAnyState(ctx, aclProjectName, nicConfig["network"])
 			if err != nil {
 				return fmt.Errorf("Failed loading network %q: %w", nicConfig["network"], err)
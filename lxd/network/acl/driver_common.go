@@ -620,6 +620,20 @@ func (d *common) Update(ctx context.Context, config *api.NetworkACLPut, clientTy
 		}
 	}
 
+	// Apply ACL changes to started instance NICs on this member that are not connected to a network.
+	aclNICs := []InstanceNICACLUsage{}
+	err = InstanceNICUsage(context.TODO(), d.state, d.projectName, []string{d.info.Name}, &aclNICs)
+	if err != nil {
+		return fmt.Errorf("Failed getting ACL instance NIC usage: %w", err)
+	}
+
+	for _, aclNIC := range aclNICs {
+		err = FirewallApplyInstanceNICACLRules(ctx, d.state, d.projectName, aclNIC)
+		if err != nil {
+			return err
+		}
+	}
+
 	// If there are affected OVN networks, then apply the changes, but only if the request type is normal.
 	// This way we won't apply the same changes multiple times for each LXD cluster member.
 	if len(aclOVNNets) > 0 && clientType == request.ClientTypeNormal {
@@ -661,8 +675,9 @@ func (d *common) Update(ctx context.Context, config *api.NetworkACLPut, clientTy
 		}
 	}
 
-	// Apply ACL changes to non-OVN networks on cluster members.
-	if clientType == request.ClientTypeNormal && len(aclNets) > 0 {
+	// Apply ACL changes to non-OVN networks and instance NICs on cluster members.
+	// Started instance NICs using the ACL may be on any member, so always notify them.
+	if clientType == request.ClientTypeNormal {
 		// Notify all other nodes to update the ACL synchronously.
 		notifier, err := cluster.NewOperationNotifier(d.state, d.state.Endpoints.NetworkCert(), d.state.ServerCert(), cluster.NotifyAll)
 		if err != nil {
@@ -838,3 +853,87 @@ func (d *common) GetLog(ctx context.Context, clientType request.ClientType) (str
 
 	return strings.Join(logEntries, "\n") + "\n", nil
 }
+
+// State returns the hit counters of the ACL's rules. The counters are aggregated from all the cluster members and
+// only cover the rules applied by the host firewall (bridge networks and instance NICs), not by OVN. The state is
+// flagged as incomplete if the ACL is used by OVN networks.
+func (d *common) State(ctx context.Context, clientType request.ClientType) (*api.NetworkACLState, error) {
+	counters, err := d.state.Firewall.NetworkACLRuleCounters()
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting firewall rule counters: %w", err)
+	}
+
+	aclState := &api.NetworkACLState{
+		Ingress: make([]api.NetworkACLRuleState, len(d.info.Ingress)),
+		Egress:  make([]api.NetworkACLRuleState, len(d.info.Egress)),
+	}
+
+	for ruleIndex := range aclState.Ingress {
+		ruleCounters := counters[firewallACLRuleName(d.id, string(ruleDirectionIngress), ruleIndex)]
+		aclState.Ingress[ruleIndex].Packets = ruleCounters.Packets
+		aclState.Ingress[ruleIndex].Bytes = ruleCounters.Bytes
+	}
+
+	for ruleIndex := range aclState.Egress {
+		ruleCounters := counters[firewallACLRuleName(d.id, string(ruleDirectionEgress), ruleIndex)]
+		aclState.Egress[ruleIndex].Packets = ruleCounters.Packets
+		aclState.Egress[ruleIndex].Bytes = ruleCounters.Bytes
+	}
+
+	// Aggregates the counters from the rest of the cluster.
+	if clientType == request.ClientTypeNormal {
+		// Flag the counters as incomplete if the ACL is used by OVN networks.
+		aclNets := map[string]NetworkACLUsage{}
+		err = NetworkUsage(ctx, d.state, d.projectName, []string{d.info.Name}, aclNets)
+		if err != nil {
+			return nil, fmt.Errorf("Failed getting ACL network usage: %w", err)
+		}
+
+		for _, aclNet := range aclNets {
+			if aclNet.Type == "ovn" {
+				aclState.Incomplete = true
+				break
+			}
+		}
+
+		// Setup notifier to reach the rest of the cluster.
+		notifier, err := cluster.NewNotifier(d.state, d.state.Endpoints.NetworkCert(), d.state.ServerCert(), cluster.NotifyAll)
+		if err != nil {
+			return nil, err
+		}
+
+		mu := sync.Mutex{}
+		err = notifier(func(member db.NodeInfo, client lxd.InstanceServer) error {
+			memberState, err := client.UseProject(d.projectName).GetNetworkACLState(d.info.Name)
+			if err != nil {
+				return err
+			}
+
+			// Prevent concurrent writes to the state.
+			mu.Lock()
+			defer mu.Unlock()
+
+			// Ignore the member's counters if its view of the rules differs (e.g. during an update).
+			if len(memberState.Ingress) != len(aclState.Ingress) || len(memberState.Egress) != len(aclState.Egress) {
+				return nil
+			}
+
+			for ruleIndex, ruleState := range memberState.Ingress {
+				aclState.Ingress[ruleIndex].Packets += ruleState.Packets
+				aclState.Ingress[ruleIndex].Bytes += ruleState.Bytes
+			}
+
+			for ruleIndex, ruleState := range memberState.Egress {
+				aclState.Egress[ruleIndex].Packets += ruleState.Packets
+				aclState.Egress[ruleIndex].Bytes += ruleState.Bytes
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return aclState, nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/fs"
 	"maps"
+	"math"
 	"net"
 	"net/http"
 	"os"
@@ -54,6 +55,10 @@ const ForkdnsServersListFile = "servers.conf"
 
 var forkdnsServersLock sync.Mutex
 
+// wireguardMeshPeers holds the last known WireGuard mesh peers of each bridge, indexed by network name and member ID.
+var wireguardMeshPeers = map[string]map[int64]wireguardPeer{}
+var wireguardMeshPeersLock sync.Mutex
+
 // Default MTU for bridge interface.
 const bridgeMTUDefault = 1500
 
@@ -288,7 +293,7 @@ func (n *bridge) Validate(config map[string]string) error {
 		// The default value varies depending on whether the bridge uses a tunnel or a fan setup.
 		// ---
 		//  type: integer
-		//  defaultdesc: `1400` when tunnels are configured, otherwise `1500` if `bridge.mode=standard` or `1450` if `bridge.mode=fan`
+		//  defaultdesc: `1360` when WireGuard tunnels are configured, `1400` when other tunnels are configured, otherwise `1500` if `bridge.mode=standard` or `1450` if `bridge.mode=fan`
 		//  shortdesc: Bridge MTU
 		//  scope: global
 		"bridge.mtu": validate.Optional(validate.IsNetworkMTU),
@@ -647,6 +652,23 @@ func (n *bridge) Validate(config map[string]string) error {
 		//  shortdesc: Whether to log egress traffic that doesn’t match any ACL rule
 		//  scope: global
 		"security.acls.default.egress.logged": validate.Optional(validate.IsBool),
+		// lxdmeta:generate(entities=network-bridge; group=network-conf; key=wireguard.mesh)
+		// When enabled, every cluster member running the network connects its bridge to all other members over WireGuard.
+		// ---
+		//  type: bool
+		//  condition: standard mode
+		//  defaultdesc: `false`
+		//  shortdesc: Whether to mesh the bridge across cluster members over WireGuard
+		//  scope: global
+		"wireguard.mesh": validate.Optional(validate.IsBool),
+		// lxdmeta:generate(entities=network-bridge; group=network-conf; key=wireguard.port)
+		// This port is used by WireGuard tunnels and by the cluster mesh.
+		// ---
+		//  type: integer
+		//  defaultdesc: `51820`
+		//  shortdesc: UDP port the WireGuard interface listens on
+		//  scope: global
+		"wireguard.port": networkValidPort,
 
 		// lxdmeta:generate(entities=network-bridge; group=network-conf; key=user.*)
 		//
@@ -670,18 +692,22 @@ func (n *bridge) Validate(config map[string]string) error {
 				return fmt.Errorf("Network name too long for tunnel interface: %s-%s", n.name, fields[1])
 			}
 
+			if strings.HasPrefix(fields[1], "wg") && n.hasWireGuard(config) {
+				return fmt.Errorf("Tunnel name %q is reserved when using WireGuard", fields[1])
+			}
+
 			tunnelKey := fields[2]
 
 			// Add the correct validation rule for the dynamic field based on last part of key.
 			switch tunnelKey {
 			case "protocol":
 				// lxdmeta:generate(entities=network-bridge; group=network-conf; key=tunnel.NAME.protocol)
-				// Possible values are `vxlan` and `gre`.
+				// Possible values are `vxlan`, `gre` and `wireguard`.
 				// ---
 				//  type: string
 				//  condition: standard mode
 				//  shortdesc: Tunneling protocol
-				rules[k] = validate.Optional(validate.IsOneOf("gre", "vxlan"))
+				rules[k] = validate.Optional(validate.IsOneOf("gre", "vxlan", "wireguard"))
 			case "local":
 				// lxdmeta:generate(entities=network-bridge; group=network-conf; key=tunnel.NAME.local)
 				//
@@ -693,22 +719,30 @@ func (n *bridge) Validate(config map[string]string) error {
 				rules[k] = validate.Optional(validate.IsNetworkAddress)
 			case "remote":
 				// lxdmeta:generate(entities=network-bridge; group=network-conf; key=tunnel.NAME.remote)
-				//
+				// For `wireguard`, this is the endpoint of the peer. It can be omitted if the peer connects to this host.
 				// ---
 				//  type: string
-				//  condition: `gre` or `vxlan`
-				//  required: not required for multicast `vxlan`
+				//  condition: `gre`, `vxlan` or `wireguard`
+				//  required: not required for multicast `vxlan` or `wireguard`
 				//  shortdesc: Remote address for the tunnel
 				rules[k] = validate.Optional(validate.IsNetworkAddress)
 			case "port":
 				// lxdmeta:generate(entities=network-bridge; group=network-conf; key=tunnel.NAME.port)
-				//
+				// For `wireguard`, this is the UDP port the peer listens on.
 				// ---
 				//  type: integer
-				//  condition: `vxlan`
-				//  defaultdesc: `0`
-				//  shortdesc: Specific port to use for the `vxlan` tunnel
+				//  condition: `vxlan` or `wireguard`
+				//  defaultdesc: `0` for `vxlan`, `51820` for `wireguard`
+				//  shortdesc: Specific port to use for the tunnel
 				rules[k] = networkValidPort
+			case "public_key":
+				// lxdmeta:generate(entities=network-bridge; group=network-conf; key=tunnel.NAME.public_key)
+				// The public key of the local end is shown in the output of `lxc network info`.
+				// ---
+				//  type: string
+				//  condition: `wireguard`
+				//  shortdesc: WireGuard public key of the peer
+				rules[k] = validate.Optional(validateWireGuardKey)
 			case "group":
 				// lxdmeta:generate(entities=network-bridge; group=network-conf; key=tunnel.NAME.group)
 				// This address is used if {config:option}`network-bridge-network-conf:tunnel.NAME.local` and {config:option}`network-bridge-network-conf:tunnel.NAME.remote` aren’t set.
@@ -748,6 +782,96 @@ func (n *bridge) Validate(config map[string]string) error {
 		}
 	}
 
+	// Add the DHCP validation rules.
+	for k := range config {
+		// DHCP reservation keys have the reservation name in their name, extract the suffix.
+		reservationKey, found := strings.CutPrefix(k, "dhcp.reservations.")
+		if found {
+			reservationName, field, found := strings.Cut(reservationKey, ".")
+			if !found || reservationName == "" {
+				return fmt.Errorf("Invalid network configuration key: %s", k)
+			}
+
+			// Add the correct validation rule for the dynamic field based on the rest of the key.
+			switch field {
+			case "hwaddr":
+				// lxdmeta:generate(entities=network-bridge; group=network-conf; key=dhcp.reservations.NAME.hwaddr)
+				// Either this option or {config:option}`network-bridge-network-conf:dhcp.reservations.NAME.hostname` must be set.
+				// ---
+				//  type: string
+				//  condition: DHCP
+				//  shortdesc: MAC address of the client for the DHCP reservation
+				//  scope: global
+				rules[k] = validate.Optional(validate.IsNetworkMAC)
+			case "hostname":
+				// lxdmeta:generate(entities=network-bridge; group=network-conf; key=dhcp.reservations.NAME.hostname)
+				// If {config:option}`network-bridge-network-conf:dhcp.reservations.NAME.hwaddr` isn't set, the reservation is matched against the host name that the client sends.
+				// ---
+				//  type: string
+				//  condition: DHCP
+				//  shortdesc: Host name of the client for the DHCP reservation
+				//  scope: global
+				rules[k] = validate.Optional(validate.IsHostname)
+			case "ipv4.address":
+				// lxdmeta:generate(entities=network-bridge; group=network-conf; key=dhcp.reservations.NAME.ipv4.address)
+				// The address must be within the subnet of {config:option}`network-bridge-network-conf:ipv4.address`
+				// and outside of {config:option}`network-bridge-network-conf:ipv4.dhcp.ranges` and {config:option}`network-bridge-network-conf:ipv4.ovn.ranges`.
+				// It can't be assigned statically to an instance NIC connected to the network.
+				// ---
+				//  type: string
+				//  condition: IPv4 DHCP
+				//  shortdesc: IPv4 address to assign to the client
+				//  scope: global
+				rules[k] = validate.Optional(validate.IsNetworkAddressV4)
+			case "ipv6.address":
+				// lxdmeta:generate(entities=network-bridge; group=network-conf; key=dhcp.reservations.NAME.ipv6.address)
+				// The address must be within the subnet of {config:option}`network-bridge-network-conf:ipv6.address`
+				// and outside of {config:option}`network-bridge-network-conf:ipv6.dhcp.ranges` and {config:option}`network-bridge-network-conf:ipv6.ovn.ranges`.
+				// It can't be assigned statically to an instance NIC connected to the network.
+				// ---
+				//  type: string
+				//  condition: IPv6 stateful DHCP
+				//  shortdesc: IPv6 address to assign to the client
+				//  scope: global
+				rules[k] = validate.Optional(validate.IsNetworkAddressV6)
+			}
+
+			continue
+		}
+
+		// DHCP option keys have the option code or name in their name.
+		for _, ipVersion := range []uint{4, 6} {
+			option, found := strings.CutPrefix(k, fmt.Sprintf("ipv%d.dhcp.options.", ipVersion))
+			if !found {
+				continue
+			}
+
+			// lxdmeta:generate(entities=network-bridge; group=network-conf; key=ipv4.dhcp.options.OPTION)
+			// Replace `OPTION` with the DHCP option code (for example, `42`) or the `dnsmasq` option name (for example, `ntp-server`).
+			// Options that LXD manages itself (router, MTU and domain search) cannot be set.
+			// ---
+			//  type: string
+			//  condition: DHCP
+			//  shortdesc: Value of a DHCPv4 option to send to clients
+			//  scope: global
+
+			// lxdmeta:generate(entities=network-bridge; group=network-conf; key=ipv6.dhcp.options.OPTION)
+			// Replace `OPTION` with the DHCPv6 option code (for example, `31`) or the `dnsmasq` option name (for example, `sntp-server`).
+			// IPv6 addresses in the value must be enclosed in square brackets.
+			// ---
+			//  type: string
+			//  condition: DHCP
+			//  shortdesc: Value of a DHCPv6 option to send to clients
+			//  scope: global
+			err := validateDHCPOptionName(ipVersion, option)
+			if err != nil {
+				return fmt.Errorf("Invalid network configuration key %q: %w", k, err)
+			}
+
+			rules[k] = validate.Optional(validateDHCPOptionValue)
+		}
+	}
+
 	// Add the BGP validation rules.
 	bgpRules, err := n.bgpValidationRules(config)
 	if err != nil {
@@ -818,12 +942,35 @@ func (n *bridge) Validate(config map[string]string) error {
 
 			if config["bridge.mode"] == "fan" && mtu > 1450 {
 				return errors.New("Maximum MTU for a FAN bridge is 1450")
+			} else if n.hasWireGuard(config) && mtu > wireguardBridgeMTU {
+				return fmt.Errorf("Maximum MTU for a bridge with WireGuard tunnels is %d", wireguardBridgeMTU)
 			} else if n.hasTunnels(config) && mtu > 1400 {
 				return errors.New("Maximum MTU for a bridge with tunnels is 1400")
 			}
 		}
 	}
 
+	// Check interface names fit and the mesh isn't combined with the FAN overlay.
+	if n.hasWireGuard(config) {
+		if len(n.name) > 12 {
+			return fmt.Errorf("Network name too long for WireGuard interface: %s-wg", n.name)
+		}
+
+		if shared.IsTrue(config["wireguard.mesh"]) {
+			if config["bridge.mode"] == "fan" {
+				return errors.New("WireGuard mesh cannot be used when in 'fan' mode")
+			}
+
+			if config["bridge.driver"] == "openvswitch" {
+				return errors.New("WireGuard mesh cannot be used with the 'openvswitch' bridge driver")
+			}
+
+			if len(n.name) > 9 {
+				return errors.New("Network name too long for WireGuard mesh interfaces, must be at most 9 characters")
+			}
+		}
+	}
+
 	// Check using same MAC address on every cluster node is safe.
 	if config["bridge.hwaddr"] != "" {
 		err = n.checkClusterWideMACSafe(config)
@@ -904,9 +1051,303 @@ func (n *bridge) Validate(config map[string]string) error {
 		}
 	}
 
+	// Check DHCP reservations.
+	err = n.validateDHCPReservations(config)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// dhcpReservation represents a static DHCP reservation for a client that isn't managed by LXD.
+type dhcpReservation struct {
+	name     string
+	hwaddr   net.HardwareAddr
+	hostname string
+	ipv4     net.IP
+	ipv6     net.IP
+}
+
+// dnsmasqHost returns the dnsmasq dhcp-host value for the reservation.
+func (r dhcpReservation) dnsmasqHost() string {
+	fields := []string{}
+	if r.hwaddr != nil {
+		fields = append(fields, r.hwaddr.String())
+	}
+
+	if r.ipv4 != nil {
+		fields = append(fields, r.ipv4.String())
+	}
+
+	if r.ipv6 != nil {
+		fields = append(fields, "["+r.ipv6.String()+"]")
+	}
+
+	if r.hostname != "" {
+		fields = append(fields, r.hostname)
+	}
+
+	return strings.Join(fields, ",")
+}
+
+// dhcpReservations returns the DHCP reservations defined by the dhcp.reservations.NAME.* keys, sorted by name.
+func dhcpReservations(config map[string]string) []dhcpReservation {
+	reservations := map[string]*dhcpReservation{}
+	for k, v := range config {
+		reservationKey, found := strings.CutPrefix(k, "dhcp.reservations.")
+		if !found || v == "" {
+			continue
+		}
+
+		reservationName, field, found := strings.Cut(reservationKey, ".")
+		if !found {
+			continue
+		}
+
+		if reservations[reservationName] == nil {
+			reservations[reservationName] = &dhcpReservation{name: reservationName}
+		}
+
+		switch field {
+		case "hwaddr":
+			reservations[reservationName].hwaddr, _ = net.ParseMAC(v)
+		case "hostname":
+			reservations[reservationName].hostname = v
+		case "ipv4.address":
+			reservations[reservationName].ipv4 = net.ParseIP(v)
+		case "ipv6.address":
+			reservations[reservationName].ipv6 = net.ParseIP(v)
+		}
+	}
+
+	result := make([]dhcpReservation, 0, len(reservations))
+	for _, reservation := range reservations {
+		result = append(result, *reservation)
+	}
+
+	slices.SortFunc(result, func(a dhcpReservation, b dhcpReservation) int { return strings.Compare(a.name, b.name) })
+
+	return result
+}
+
+// validateDHCPReservations checks the DHCP reservations against the network's subnets and ranges.
+func (n *bridge) validateDHCPReservations(config map[string]string) error {
+	reservations := dhcpReservations(config)
+	if len(reservations) == 0 {
+		return nil
+	}
+
+	if config["bridge.mode"] == "fan" {
+		return errors.New("DHCP reservations may not be set when in 'fan' mode")
+	}
+
+	// checkAddress validates a reserved address against the network address and the ranges reserved for OVN.
+	checkAddress := func(reservation dhcpReservation, ip net.IP, ipVersion uint) error {
+		gatewayIP, subnet, err := net.ParseCIDR(config[fmt.Sprintf("ipv%d.address", ipVersion)])
+		if err != nil {
+			return fmt.Errorf("DHCP reservation %q requires %q to be set on the network", reservation.name, fmt.Sprintf("ipv%d.address", ipVersion))
+		}
+
+		if shared.IsFalse(config[fmt.Sprintf("ipv%d.dhcp", ipVersion)]) {
+			return fmt.Errorf("DHCP reservation %q requires %q to be enabled on the network", reservation.name, fmt.Sprintf("ipv%d.dhcp", ipVersion))
+		}
+
+		// The address must be usable by a host in the network's subnet.
+		if !dhcpalloc.DHCPValidIP(subnet, nil, ip) || ip.Equal(subnet.IP) || (ipVersion == 4 && ip.Equal(dhcpalloc.GetIP(subnet, -1))) {
+			return fmt.Errorf("DHCP reservation %q address %q is not within the network subnet %q", reservation.name, ip.String(), subnet.String())
+		}
+
+		if ip.Equal(gatewayIP) {
+			return fmt.Errorf("DHCP reservation %q address %q is assigned to the network", reservation.name, ip.String())
+		}
+
+		// The DHCP ranges are allocated dynamically, and the OVN ranges are allocated to downstream OVN
+		// routers by LXD.
+		for _, rangesKey := range []string{fmt.Sprintf("ipv%d.dhcp.ranges", ipVersion), fmt.Sprintf("ipv%d.ovn.ranges", ipVersion)} {
+			if config[rangesKey] == "" {
+				continue
+			}
+
+			ranges, err := shared.ParseIPRanges(config[rangesKey])
+			if err != nil {
+				return fmt.Errorf("Failed parsing %q: %w", rangesKey, err)
+			}
+
+			for _, ipRange := range ranges {
+				if ipRange.ContainsIP(ip) {
+					return fmt.Errorf("DHCP reservation %q address %q cannot be within %q", reservation.name, ip.String(), rangesKey)
+				}
+			}
+		}
+
+		return nil
+	}
+
+	hwaddrs := map[string]string{}
+	addresses := map[string]string{}
+	for _, reservation := range reservations {
+		if reservation.hwaddr == nil && reservation.hostname == "" {
+			return fmt.Errorf("DHCP reservation %q requires either %q or %q", reservation.name, "hwaddr", "hostname")
+		}
+
+		if reservation.ipv4 == nil && reservation.ipv6 == nil {
+			return fmt.Errorf("DHCP reservation %q requires at least one of %q or %q", reservation.name, "ipv4.address", "ipv6.address")
+		}
+
+		if reservation.hwaddr != nil {
+			otherName, found := hwaddrs[reservation.hwaddr.String()]
+			if found {
+				return fmt.Errorf("DHCP reservations %q and %q use the same MAC address %q", otherName, reservation.name, reservation.hwaddr.String())
+			}
+
+			hwaddrs[reservation.hwaddr.String()] = reservation.name
+		}
+
+		for _, ip := range []net.IP{reservation.ipv4, reservation.ipv6} {
+			if ip == nil {
+				continue
+			}
+
+			otherName, found := addresses[ip.String()]
+			if found {
+				return fmt.Errorf("DHCP reservations %q and %q use the same address %q", otherName, reservation.name, ip.String())
+			}
+
+			addresses[ip.String()] = reservation.name
+		}
+
+		if reservation.ipv4 != nil {
+			err := checkAddress(reservation, reservation.ipv4, 4)
+			if err != nil {
+				return err
+			}
+		}
+
+		if reservation.ipv6 != nil {
+			if shared.IsFalseOrEmpty(config["ipv6.dhcp.stateful"]) {
+				return fmt.Errorf("DHCP reservation %q requires %q to be enabled on the network", reservation.name, "ipv6.dhcp.stateful")
+			}
+
+			err := checkAddress(reservation, reservation.ipv6, 6)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if n.state == nil {
+		return nil
+	}
+
+	// Check that the reserved addresses aren't statically assigned to instance NICs connected to the network.
+	return UsedByInstanceDevices(n.state, n.Project(), n.Name(), n.Type(), func(inst db.InstanceArgs, nicName string, nicConfig map[string]string) error {
+		for _, key := range []string{"ipv4.address", "ipv6.address"} {
+			ip := net.ParseIP(nicConfig[key])
+			if ip == nil {
+				continue
+			}
+
+			reservationName, found := addresses[ip.String()]
+			if found {
+				return fmt.Errorf("DHCP reservation %q address %q is assigned to NIC %q of instance %q in project %q", reservationName, ip.String(), nicName, inst.Name, inst.Project)
+			}
+		}
+
+		return nil
+	})
+}
+
+// DHCPReservationName returns the name of the DHCP reservation that reserves the given address in the bridge network
+// config, or an empty string if the address isn't reserved.
+func DHCPReservationName(config map[string]string, ip net.IP) string {
+	for _, reservation := range dhcpReservations(config) {
+		if ip.Equal(reservation.ipv4) || ip.Equal(reservation.ipv6) {
+			return reservation.name
+		}
+	}
+
+	return ""
+}
+
+// dhcpOptionsManaged lists the DHCPv4 options (by code and dnsmasq name) that LXD sets itself and the keys controlling them.
+var dhcpOptionsManaged = map[string]string{
+	"3":             "ipv4.dhcp.gateway",
+	"router":        "ipv4.dhcp.gateway",
+	"26":            "bridge.mtu",
+	"mtu":           "bridge.mtu",
+	"119":           "dns.search",
+	"domain-search": "dns.search",
+}
+
+// validateDHCPOptionName checks that option is a valid DHCP option code or dnsmasq option name for the IP version.
+func validateDHCPOptionName(ipVersion uint, option string) error {
+	if option == "" {
+		return errors.New("DHCP option code or name is required")
+	}
+
+	code, err := strconv.ParseUint(option, 10, 64)
+	if err == nil {
+		if code == 0 || (ipVersion == 4 && code > 254) || code > math.MaxUint16 {
+			return fmt.Errorf("Invalid DHCPv%d option code %d", ipVersion, code)
+		}
+
+		// Only accept the canonical form of the code, so that managed options can't be set as "03" for example.
+		if strconv.FormatUint(code, 10) != option {
+			return fmt.Errorf("Invalid DHCPv%d option code %q (leading zeros aren't allowed)", ipVersion, option)
+		}
+	} else {
+		for _, r := range option {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Errorf("Invalid DHCP option name %q", option)
+			}
+		}
+	}
+
+	if ipVersion == 4 {
+		key, found := dhcpOptionsManaged[option]
+		if found {
+			return fmt.Errorf("DHCP option %q is managed through %q", option, key)
+		}
+	}
+
 	return nil
 }
 
+// validateDHCPOptionValue checks that value can be passed to dnsmasq as a DHCP option value.
+func validateDHCPOptionValue(value string) error {
+	if strings.ContainsAny(value, "\n\r") {
+		return errors.New("DHCP option value cannot contain line breaks")
+	}
+
+	return nil
+}
+
+// dnsmasqDHCPOptions returns the dnsmasq dhcp-option values for the ipvX.dhcp.options.* keys, sorted by key.
+func dnsmasqDHCPOptions(config map[string]string, ipVersion uint) []string {
+	prefix := fmt.Sprintf("ipv%d.dhcp.options.", ipVersion)
+
+	options := []string{}
+	for _, k := range slices.Sorted(maps.Keys(config)) {
+		option, found := strings.CutPrefix(k, prefix)
+		if !found || config[k] == "" {
+			continue
+		}
+
+		// Option names and DHCPv6 options must be prefixed for dnsmasq to tell them apart from tags.
+		_, err := strconv.ParseUint(option, 10, 64)
+		if ipVersion == 6 {
+			option = "option6:" + option
+		} else if err != nil {
+			option = "option:" + option
+		}
+
+		options = append(options, option+","+config[k])
+	}
+
+	return options
+}
+
 // Create checks whether the bridge interface name is used already.
 func (n *bridge) Create(clientType request.ClientType) error {
 	n.logger.Debug("Create", logger.Ctx{"clientType": clientType, "config": n.config})
@@ -1084,6 +1525,10 @@ func (n *bridge) getDnsmasqArgs(bridge *ip.Bridge) ([]string, error) {
 				dnsmasqCmd = append(dnsmasqCmd, "--dhcp-option-force=119,"+strings.Trim(dnsSearch, " "))
 			}
 
+			for _, option := range dnsmasqDHCPOptions(n.config, 4) {
+				dnsmasqCmd = append(dnsmasqCmd, "--dhcp-option="+option)
+			}
+
 			expiry := "1h"
 			if n.config["ipv4.dhcp.expiry"] != "" {
 				expiry = n.config["ipv4.dhcp.expiry"]
@@ -1118,6 +1563,10 @@ func (n *bridge) getDnsmasqArgs(bridge *ip.Bridge) ([]string, error) {
 				dnsmasqCmd = append(dnsmasqCmd, "--dhcp-no-override", "--dhcp-authoritative", "--dhcp-leasefile="+leasefile, "--dhcp-hostsdir="+hostsDir)
 			}
 
+			for _, option := range dnsmasqDHCPOptions(n.config, 6) {
+				dnsmasqCmd = append(dnsmasqCmd, "--dhcp-option="+option)
+			}
+
 			expiry := "1h"
 			if n.config["ipv6.dhcp.expiry"] != "" {
 				expiry = n.config["ipv6.dhcp.expiry"]
@@ -1140,6 +1589,13 @@ func (n *bridge) getDnsmasqArgs(bridge *ip.Bridge) ([]string, error) {
 		}
 	}
 
+	// Add the DHCP reservations for clients not managed by LXD.
+	if n.DHCPv4Subnet() != nil || n.DHCPv6Subnet() != nil {
+		for _, reservation := range dhcpReservations(n.config) {
+			dnsmasqCmd = append(dnsmasqCmd, "--dhcp-host="+reservation.dnsmasqHost())
+		}
+	}
+
 	return dnsmasqCmd, nil
 }
 
@@ -1294,6 +1750,14 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 
 	n.logger.Debug("Setting up network")
 
+	// Check WireGuard tools are available.
+	if n.hasWireGuard(n.config) {
+		_, err := exec.LookPath("wg")
+		if err != nil {
+			return errors.New("The wg command (wireguard-tools) is required for WireGuard tunnels and mesh")
+		}
+	}
+
 	revert := revert.New()
 	defer revert.Fail()
 
@@ -1323,6 +1787,8 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 		}
 
 		bridge.MTU = uint32(mtuInt)
+	} else if n.hasWireGuard(n.config) {
+		bridge.MTU = wireguardBridgeMTU
 	} else if len(tunnels) > 0 {
 		bridge.MTU = 1400
 	} else if n.config["bridge.mode"] == "fan" {
@@ -1993,6 +2459,15 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 		dnsClusteredAddress, _, _ = strings.Cut(fanAddress, "/")
 	}
 
+	// Configure the WireGuard interface carrying the WireGuard tunnels.
+	var wireguardAddress net.IP
+	if n.hasWireGuard(n.config) {
+		wireguardAddress, err = n.wireguardSetup()
+		if err != nil {
+			return err
+		}
+	}
+
 	// Configure tunnels.
 	for _, tunnel := range tunnels {
 		getConfig := func(key string) string {
@@ -2079,6 +2554,24 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 			if err != nil {
 				return err
 			}
+		} else if tunProtocol == "wireguard" {
+			tunPublicKey := getConfig("public_key")
+
+			// Skip partial configs.
+			if tunPublicKey == "" {
+				continue
+			}
+
+			gretap := &ip.IP6Gretap{
+				Link:   ip.Link{Name: tunName},
+				Local:  wireguardAddress.String(),
+				Remote: wireguardInnerAddress(tunPublicKey).String(),
+			}
+
+			err := gretap.Add()
+			if err != nil {
+				return err
+			}
 		}
 
 		// Bridge it and bring up.
@@ -2236,6 +2729,11 @@ func (n *bridge) Stop() error {
 		return err
 	}
 
+	// Forget the WireGuard mesh peers.
+	wireguardMeshPeersLock.Lock()
+	delete(wireguardMeshPeers, n.name)
+	wireguardMeshPeersLock.Unlock()
+
 	// Destroy the bridge interface
 	if n.config["bridge.driver"] == "openvswitch" {
 		ovs := openvswitch.NewOVS()
@@ -2454,9 +2952,50 @@ func (n *bridge) spawnForkDNS(listenAddress string) error {
 	return nil
 }
 
-// HandleHeartbeat refreshes forkdns servers. Retrieves the IPv4 address of each cluster node (excluding ourselves)
-// for this network. It then updates the forkdns server list file if there are changes.
+// State returns the api.NetworkState for the network, including the WireGuard state when WireGuard is in use.
+func (n *bridge) State() (*api.NetworkState, error) {
+	state, err := n.common.State()
+	if err != nil {
+		return nil, err
+	}
+
+	wgName := n.wireguardInterfaceName()
+	if !n.hasWireGuard(n.config) || !InterfaceExists(wgName) {
+		return state, nil
+	}
+
+	privateKey, err := n.wireguardPrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading WireGuard private key: %w", err)
+	}
+
+	publicKey, err := wireguardPublicKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := shared.RunCommand(context.TODO(), "wg", "show", wgName, "peers")
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting WireGuard peers of %q: %w", wgName, err)
+	}
+
+	state.WireGuard = &api.NetworkStateWireGuard{
+		PublicKey:  publicKey,
+		ListenPort: n.wireguardListenPort(),
+		Peers:      strings.Fields(out),
+	}
+
+	return state, nil
+}
+
+// HandleHeartbeat refreshes the WireGuard mesh peers when the mesh is enabled, otherwise it refreshes forkdns servers.
+// For forkdns, it retrieves the IPv4 address of each cluster node (excluding ourselves) for this network.
+// It then updates the forkdns server list file if there are changes.
 func (n *bridge) HandleHeartbeat(heartbeatData *cluster.APIHeartbeat) error {
+	if shared.IsTrue(n.config["wireguard.mesh"]) {
+		return n.refreshWireGuardMesh(heartbeatData)
+	}
+
 	// Make sure forkdns has been setup.
 	if !shared.PathExists(shared.VarPath("networks", n.name, "forkdns.pid")) {
 		return nil
@@ -2539,6 +3078,352 @@ func (n *bridge) getTunnels() []string {
 	return tunnels
 }
 
+// hasWireGuard returns true if the given config enables the WireGuard mesh or contains any WireGuard tunnel.
+func (n *bridge) hasWireGuard(config map[string]string) bool {
+	if shared.IsTrue(config["wireguard.mesh"]) {
+		return true
+	}
+
+	for k, v := range config {
+		if strings.HasPrefix(k, "tunnel.") && strings.HasSuffix(k, ".protocol") && v == "wireguard" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// wireguardInterfaceName returns the name of the WireGuard interface of the network.
+func (n *bridge) wireguardInterfaceName() string {
+	return n.name + "-wg"
+}
+
+// wireguardListenPort returns the UDP port the WireGuard interface of the network listens on.
+func (n *bridge) wireguardListenPort() int {
+	port, err := strconv.Atoi(n.config["wireguard.port"])
+	if err != nil {
+		return wireguardDefaultPort
+	}
+
+	return port
+}
+
+// wireguardPrivateKey returns the WireGuard private key of the network on this member.
+func (n *bridge) wireguardPrivateKey() (string, error) {
+	content, err := os.ReadFile(shared.VarPath("networks", n.name, "wireguard.key"))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
+
+// wireguardTunnelPeers returns the WireGuard peers of the WireGuard tunnels, sorted by tunnel name.
+func (n *bridge) wireguardTunnelPeers() []wireguardPeer {
+	tunnels := n.getTunnels()
+	slices.Sort(tunnels)
+
+	peers := []wireguardPeer{}
+	for _, tunnel := range tunnels {
+		getConfig := func(key string) string {
+			return n.config[fmt.Sprintf("tunnel.%s.%s", tunnel, key)]
+		}
+
+		if getConfig("protocol") != "wireguard" || getConfig("public_key") == "" {
+			continue
+		}
+
+		peer := wireguardPeer{publicKey: getConfig("public_key")}
+
+		tunRemote := getConfig("remote")
+		if tunRemote != "" {
+			tunPort := getConfig("port")
+			if tunPort == "" {
+				tunPort = strconv.Itoa(wireguardDefaultPort)
+			}
+
+			peer.endpoint = net.JoinHostPort(tunRemote, tunPort)
+		}
+
+		peers = append(peers, peer)
+	}
+
+	return peers
+}
+
+// wireguardSetup creates the WireGuard interface of the network and configures the peers of the WireGuard
+// tunnels, generating the private key of the network on first use.
+// Returns the local address inside of the WireGuard interface.
+func (n *bridge) wireguardSetup() (net.IP, error) {
+	privateKey, err := n.wireguardPrivateKey()
+	if errors.Is(err, fs.ErrNotExist) {
+		privateKey, err = wireguardGeneratePrivateKey()
+		if err != nil {
+			return nil, err
+		}
+
+		err = os.WriteFile(shared.VarPath("networks", n.name, "wireguard.key"), []byte(privateKey+"\n"), 0600)
+		if err != nil {
+			return nil, fmt.Errorf("Failed writing WireGuard private key: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("Failed loading WireGuard private key: %w", err)
+	}
+
+	publicKey, err := wireguardPublicKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	wgLink := &ip.Wireguard{
+		Link: ip.Link{
+			Name: n.wireguardInterfaceName(),
+			MTU:  wireguardMTU,
+		},
+	}
+
+	err = wgLink.Add()
+	if err != nil {
+		return nil, err
+	}
+
+	address := wireguardInnerAddress(publicKey)
+	addr := &ip.Addr{
+		DevName: wgLink.Name,
+		Address: address.String() + "/128",
+		Family:  ip.FamilyV6,
+	}
+
+	err = addr.Add()
+	if err != nil {
+		return nil, fmt.Errorf("Failed adding address to WireGuard interface %q: %w", wgLink.Name, err)
+	}
+
+	err = wgLink.SetUp()
+	if err != nil {
+		return nil, err
+	}
+
+	// Mesh peers are added on the next cluster heartbeat.
+	err = n.wireguardSyncPeers(privateKey, n.wireguardTunnelPeers())
+	if err != nil {
+		return nil, err
+	}
+
+	return address, nil
+}
+
+// wireguardSyncPeers replaces the peers of the WireGuard interface and the routes towards them.
+func (n *bridge) wireguardSyncPeers(privateKey string, peers []wireguardPeer) error {
+	wgName := n.wireguardInterfaceName()
+	configPath := shared.VarPath("networks", n.name, "wireguard.conf")
+
+	err := os.WriteFile(configPath, []byte(wireguardConfig(privateKey, n.wireguardListenPort(), peers)), 0600)
+	if err != nil {
+		return fmt.Errorf("Failed writing WireGuard configuration: %w", err)
+	}
+
+	_, err = shared.RunCommand(context.TODO(), "wg", "syncconf", wgName, configPath)
+	if err != nil {
+		return fmt.Errorf("Failed configuring WireGuard peers on %q: %w", wgName, err)
+	}
+
+	r := &ip.Route{
+		DevName: wgName,
+		Proto:   "static",
+		Family:  ip.FamilyV6,
+	}
+
+	err = r.Flush()
+	if err != nil {
+		return err
+	}
+
+	for _, peer := range peers {
+		r := &ip.Route{
+			DevName: wgName,
+			Route:   wireguardInnerAddress(peer.publicKey).String() + "/128",
+			Proto:   "static",
+			Family:  ip.FamilyV6,
+		}
+
+		err = r.Add()
+		if err != nil {
+			return fmt.Errorf("Failed adding route to WireGuard peer %q: %w", peer.publicKey, err)
+		}
+	}
+
+	return nil
+}
+
+// refreshWireGuardMesh connects the bridge to every other online cluster member running the network.
+// The public keys of the other members are retrieved from their network state and each member gets an
+// isolated bridge port so that broadcast traffic isn't looped through the mesh.
+func (n *bridge) refreshWireGuardMesh(heartbeatData *cluster.APIHeartbeat) error {
+	wgName := n.wireguardInterfaceName()
+
+	// Make sure the WireGuard interface has been setup.
+	if !InterfaceExists(wgName) {
+		return nil
+	}
+
+	localClusterAddress := n.state.LocalConfig.ClusterAddress()
+	networkCert := n.state.Endpoints.NetworkCert()
+	meshPeers := map[int64]wireguardPeer{}
+
+	wireguardMeshPeersLock.Lock()
+	defer wireguardMeshPeersLock.Unlock()
+
+	lastMeshPeers := wireguardMeshPeers[n.name]
+
+	for _, node := range heartbeatData.Members {
+		if node.Address == localClusterAddress {
+			// No need to query ourselves.
+			continue
+		}
+
+		if !node.Online {
+			n.logger.Debug("Excluding offline member from WireGuard mesh", logger.Ctx{"address": node.Address, "ID": node.ID})
+			continue
+		}
+
+		peer, err := n.wireguardMeshPeer(node.Address, networkCert)
+		if err != nil {
+			// Keep the last known peer of online members that can't be reached, so that a slow heartbeat
+			// doesn't interrupt the mesh.
+			lastPeer, found := lastMeshPeers[node.ID]
+			if found {
+				n.logger.Warn("Failed getting WireGuard state of member, keeping last known peer", logger.Ctx{"address": node.Address, "err": err})
+				meshPeers[node.ID] = lastPeer
+			} else {
+				n.logger.Warn("Failed getting WireGuard state of member", logger.Ctx{"address": node.Address, "err": err})
+			}
+
+			continue
+		}
+
+		if peer == nil {
+			n.logger.Debug("Excluding member without WireGuard state from WireGuard mesh", logger.Ctx{"address": node.Address})
+			continue
+		}
+
+		meshPeers[node.ID] = *peer
+	}
+
+	wireguardMeshPeers[n.name] = meshPeers
+
+	privateKey, err := n.wireguardPrivateKey()
+	if err != nil {
+		return fmt.Errorf("Failed loading WireGuard private key: %w", err)
+	}
+
+	publicKey, err := wireguardPublicKey(privateKey)
+	if err != nil {
+		return err
+	}
+
+	memberIDs := slices.Sorted(maps.Keys(meshPeers))
+	peers := n.wireguardTunnelPeers()
+	for _, memberID := range memberIDs {
+		peers = append(peers, meshPeers[memberID])
+	}
+
+	// Nothing to do if the peers haven't changed since the last refresh.
+	curConfig, err := os.ReadFile(shared.VarPath("networks", n.name, "wireguard.conf"))
+	if err == nil && string(curConfig) == wireguardConfig(privateKey, n.wireguardListenPort(), peers) {
+		return nil
+	}
+
+	err = n.wireguardSyncPeers(privateKey, peers)
+	if err != nil {
+		return err
+	}
+
+	bridgeIface, err := net.InterfaceByName(n.name)
+	if err != nil {
+		return err
+	}
+
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return err
+	}
+
+	// Recreate the mesh ports.
+	for _, iface := range ifaces {
+		if iface.Name != wgName && strings.HasPrefix(iface.Name, wgName) {
+			portLink := &ip.Link{Name: iface.Name}
+			err = portLink.Delete()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, memberID := range memberIDs {
+		gretap := &ip.IP6Gretap{
+			Link: ip.Link{
+				Name: fmt.Sprintf("%s%d", wgName, memberID),
+				MTU:  uint32(bridgeIface.MTU),
+			},
+			Local:  wireguardInnerAddress(publicKey).String(),
+			Remote: wireguardInnerAddress(meshPeers[memberID].publicKey).String(),
+		}
+
+		err = gretap.Add()
+		if err != nil {
+			return err
+		}
+
+		err = AttachInterface(n.name, gretap.Name)
+		if err != nil {
+			return err
+		}
+
+		err = gretap.BridgeLinkSetIsolated(true)
+		if err != nil {
+			return err
+		}
+
+		err = gretap.SetUp()
+		if err != nil {
+			return err
+		}
+	}
+
+	n.logger.Info("Updated WireGuard mesh peers", logger.Ctx{"members": memberIDs})
+
+	return nil
+}
+
+// wireguardMeshPeer returns the WireGuard mesh peer of the cluster member with the given address, or nil if the
+// network of the member has no WireGuard state.
+func (n *bridge) wireguardMeshPeer(address string, networkCert *shared.CertInfo) (*wireguardPeer, error) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing member address %q: %w", address, err)
+	}
+
+	client, err := cluster.Connect(context.Background(), address, networkCert, n.state.ServerCert(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := client.GetNetworkState(n.name)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.WireGuard == nil {
+		return nil, nil
+	}
+
+	return &wireguardPeer{
+		publicKey: state.WireGuard.PublicKey,
+		endpoint:  net.JoinHostPort(host, strconv.Itoa(state.WireGuard.ListenPort)),
+	}, nil
+}
+
 // hasTunnels returns true if the given config contains any tunnel entries.
 func (n *bridge) hasTunnels(config map[string]string) bool {
 	for k := range config {
@@ -3553,6 +4438,32 @@ func (n *bridge) Leases(projectName string, clientType request.ClientType) ([]ap
 				}
 			}
 
+			// Add the DHCP reservations.
+			for _, reservation := range dhcpReservations(n.config) {
+				hostname := reservation.hostname
+				if hostname == "" {
+					hostname = reservation.name
+				}
+
+				for _, ip := range []net.IP{reservation.ipv4, reservation.ipv6} {
+					if ip == nil {
+						continue
+					}
+
+					lease := api.NetworkLease{
+						Hostname: hostname,
+						Address:  ip.String(),
+						Type:     "static",
+					}
+
+					if reservation.hwaddr != nil {
+						lease.Hwaddr = reservation.hwaddr.String()
+					}
+
+					leases = append(leases, lease)
+				}
+			}
+
 			// Include downstream OVN routers using the network as an uplink.
 			var projectNetworks map[string]map[int64]api.Network
 			err = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
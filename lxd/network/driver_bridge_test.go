@@ -0,0 +1,77 @@
+package network
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_dnsmasqDHCPOptions(t *testing.T) {
+	config := map[string]string{
+		"ipv4.address":                  "192.0.2.1/24",
+		"ipv4.dhcp.options.42":          "192.0.2.10",
+		"ipv4.dhcp.options.tftp-server": "tftp.example.net",
+		"ipv4.dhcp.options.66":          "",
+		"ipv6.dhcp.options.31":          "[2001:db8::10]",
+		"ipv6.dhcp.options.dns-server":  "[2001:db8::53]",
+	}
+
+	assert.Equal(t, []string{"42,192.0.2.10", "option:tftp-server,tftp.example.net"}, dnsmasqDHCPOptions(config, 4))
+	assert.Equal(t, []string{"option6:31,[2001:db8::10]", "option6:dns-server,[2001:db8::53]"}, dnsmasqDHCPOptions(config, 6))
+}
+
+func Test_validateDHCPOptionName(t *testing.T) {
+	tests := []struct {
+		ipVersion uint
+		option    string
+		wantErr   bool
+	}{
+		{ipVersion: 4, option: "42"},
+		{ipVersion: 4, option: "ntp-server"},
+		{ipVersion: 4, option: "0", wantErr: true},
+		{ipVersion: 4, option: "255", wantErr: true},
+		{ipVersion: 4, option: "3", wantErr: true},
+		{ipVersion: 4, option: "03", wantErr: true},
+		{ipVersion: 4, option: "026", wantErr: true},
+		{ipVersion: 4, option: "042", wantErr: true},
+		{ipVersion: 4, option: "domain-search", wantErr: true},
+		{ipVersion: 4, option: "tag:foo", wantErr: true},
+		{ipVersion: 4, option: "", wantErr: true},
+		{ipVersion: 6, option: "300"},
+		{ipVersion: 6, option: "3"},
+		{ipVersion: 6, option: "031", wantErr: true},
+		{ipVersion: 6, option: "70000", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := validateDHCPOptionName(tt.ipVersion, tt.option)
+		if tt.wantErr {
+			assert.Error(t, err, "DHCPv%d option %q", tt.ipVersion, tt.option)
+		} else {
+			assert.NoError(t, err, "DHCPv%d option %q", tt.ipVersion, tt.option)
+		}
+	}
+}
+
+func Test_dhcpReservations(t *testing.T) {
+	config := map[string]string{
+		"dhcp.reservations.printer.hwaddr":       "00:16:3e:00:00:01",
+		"dhcp.reservations.printer.ipv4.address": "192.0.2.10",
+		"dhcp.reservations.printer.ipv6.address": "2001:db8::10",
+		"dhcp.reservations.nas.hostname":         "nas",
+		"dhcp.reservations.nas.ipv4.address":     "192.0.2.20",
+		"ipv4.address":                           "192.0.2.1/24",
+	}
+
+	reservations := dhcpReservations(config)
+
+	hwaddr, _ := net.ParseMAC("00:16:3e:00:00:01")
+	assert.Equal(t, []dhcpReservation{
+		{name: "nas", hostname: "nas", ipv4: net.ParseIP("192.0.2.20")},
+		{name: "printer", hwaddr: hwaddr, ipv4: net.ParseIP("192.0.2.10"), ipv6: net.ParseIP("2001:db8::10")},
+	}, reservations)
+
+	assert.Equal(t, "192.0.2.20,nas", reservations[0].dnsmasqHost())
+	assert.Equal(t, "00:16:3e:00:00:01,192.0.2.10,[2001:db8::10]", reservations[1].dnsmasqHost())
+}
@@ -0,0 +1,97 @@
+package network
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// wireguardDefaultPort is the default UDP port used for WireGuard tunnels.
+const wireguardDefaultPort = 51820
+
+// wireguardMTU is the MTU of the WireGuard interface, leaving room for the WireGuard header over an IPv6 underlay.
+const wireguardMTU = 1420
+
+// wireguardBridgeMTU is the maximum bridge MTU when using WireGuard tunnels, leaving room for the ip6gretap
+// header on top of the WireGuard interface.
+const wireguardBridgeMTU = 1360
+
+// wireguardPeer represents a WireGuard peer.
+type wireguardPeer struct {
+	publicKey string
+	endpoint  string
+}
+
+// validateWireGuardKey checks that the value is a base64 encoded WireGuard key.
+func validateWireGuardKey(value string) error {
+	key, err := base64.StdEncoding.DecodeString(value)
+	if err != nil || len(key) != 32 {
+		return errors.New("Invalid WireGuard key, must be a base64 encoded 32 bytes key")
+	}
+
+	return nil
+}
+
+// wireguardGeneratePrivateKey returns a new base64 encoded WireGuard private key.
+func wireguardGeneratePrivateKey() (string, error) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", fmt.Errorf("Failed generating WireGuard private key: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(key.Bytes()), nil
+}
+
+// wireguardPublicKey returns the base64 encoded public key matching the given WireGuard private key.
+func wireguardPublicKey(privateKey string) (string, error) {
+	keyBytes, err := base64.StdEncoding.DecodeString(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("Failed decoding WireGuard private key: %w", err)
+	}
+
+	key, err := ecdh.X25519().NewPrivateKey(keyBytes)
+	if err != nil {
+		return "", fmt.Errorf("Failed loading WireGuard private key: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(key.PublicKey().Bytes()), nil
+}
+
+// wireguardInnerAddress returns the IPv6 unique local address used inside of the WireGuard interface by the peer
+// with the given public key. The address is derived from the key so that both ends of a tunnel agree on it
+// without any further configuration.
+func wireguardInnerAddress(publicKey string) net.IP {
+	sum := sha256.Sum256([]byte(publicKey))
+	sum[0] = 0xfd
+
+	return net.IP(sum[:net.IPv6len])
+}
+
+// wireguardConfig returns the WireGuard configuration for the given private key and peers in the format
+// expected by `wg syncconf`.
+func wireguardConfig(privateKey string, listenPort int, peers []wireguardPeer) string {
+	var sb strings.Builder
+
+	sb.WriteString("[Interface]\n")
+	fmt.Fprintf(&sb, "PrivateKey = %s\n", privateKey)
+	fmt.Fprintf(&sb, "ListenPort = %d\n", listenPort)
+
+	for _, peer := range peers {
+		sb.WriteString("\n[Peer]\n")
+		fmt.Fprintf(&sb, "PublicKey = %s\n", peer.publicKey)
+
+		if peer.endpoint != "" {
+			fmt.Fprintf(&sb, "Endpoint = %s\n", peer.endpoint)
+		}
+
+		fmt.Fprintf(&sb, "AllowedIPs = %s/128\n", wireguardInnerAddress(peer.publicKey).String())
+		sb.WriteString("PersistentKeepalive = 25\n")
+	}
+
+	return sb.String()
+}
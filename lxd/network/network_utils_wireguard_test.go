@@ -0,0 +1,53 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_wireguardPublicKey(t *testing.T) {
+	// Test vector from RFC 7748 section 6.1.
+	publicKey, err := wireguardPublicKey("dwdtCnMYpX08FsFyUbJmRd9ML4frwJkqsXf7pR25LCo=")
+	require.NoError(t, err)
+	assert.Equal(t, "hSDwCYkwp1R0i33ctD73Wg2/Og0mOBr066SpjqqbTmo=", publicKey)
+
+	privateKey, err := wireguardGeneratePrivateKey()
+	require.NoError(t, err)
+	assert.NoError(t, validateWireGuardKey(privateKey))
+
+	_, err = wireguardPublicKey("invalid")
+	assert.Error(t, err)
+}
+
+func Test_validateWireGuardKey(t *testing.T) {
+	assert.NoError(t, validateWireGuardKey("hSDwCYkwp1R0i33ctD73Wg2/Og0mOBr066SpjqqbTmo="))
+	assert.Error(t, validateWireGuardKey("hSDwCYkwp1R0i33ctD73Wg2/Og0mOBr066Spjqqb"))
+	assert.Error(t, validateWireGuardKey("not a key"))
+}
+
+func Test_wireguardConfig(t *testing.T) {
+	peers := []wireguardPeer{
+		{publicKey: "hSDwCYkwp1R0i33ctD73Wg2/Og0mOBr066SpjqqbTmo=", endpoint: "[2001:db8::1]:51820"},
+		{publicKey: "hSDwCYkwp1R0i33ctD73Wg2/Og0mOBr066SpjqqbTmo="},
+	}
+
+	want := `[Interface]
+PrivateKey = dwdtCnMYpX08FsFyUbJmRd9ML4frwJkqsXf7pR25LCo=
+ListenPort = 51821
+
+[Peer]
+PublicKey = hSDwCYkwp1R0i33ctD73Wg2/Og0mOBr066SpjqqbTmo=
+Endpoint = [2001:db8::1]:51820
+AllowedIPs = fd33:ca1f:dc18:1eee:a706:5f8c:436c:e60e/128
+PersistentKeepalive = 25
+
+[Peer]
+PublicKey = hSDwCYkwp1R0i33ctD73Wg2/Og0mOBr066SpjqqbTmo=
+AllowedIPs = fd33:ca1f:dc18:1eee:a706:5f8c:436c:e60e/128
+PersistentKeepalive = 25
+`
+
+	assert.Equal(t, want, wireguardConfig("dwdtCnMYpX08FsFyUbJmRd9ML4frwJkqsXf7pR25LCo=", 51821, peers))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 
+	"github.com/canonical/lxd/lxd/lifecycle"
 	"github.com/canonical/lxd/lxd/request"
 	"github.com/canonical/lxd/lxd/state"
 	"github.com/canonical/lxd/shared/api"
@@ -29,6 +30,7 @@ type NetworkZone interface {
 	GetRecord(ctx context.Context, name string) (*api.NetworkZoneRecord, error)
 	UpdateRecord(ctx context.Context, name string, req api.NetworkZoneRecordPut) error
 	DeleteRecord(ctx context.Context, name string) error
+	ModifyRecords(ctx context.Context, modify func(records []api.NetworkZoneRecord) ([]api.NetworkZoneRecord, error)) (map[string]lifecycle.NetworkZoneRecordAction, error)
 
 	// Internal validation.
 	validateName(name string) error
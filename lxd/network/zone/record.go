@@ -8,6 +8,7 @@ import (
 	"github.com/miekg/dns"
 
 	"github.com/canonical/lxd/lxd/db"
+	"github.com/canonical/lxd/lxd/lifecycle"
 	"github.com/canonical/lxd/shared/api"
 )
 
@@ -185,6 +186,98 @@ func (d *zone) DeleteRecord(ctx context.Context, name string) error {
 	return nil
 }
 
+// ModifyRecords replaces the network zone records with the ones returned by modify.
+// The current records are loaded and the changes are written within a single transaction.
+// Returns the lifecycle action that applies to each record that was changed.
+func (d *zone) ModifyRecords(ctx context.Context, modify func(records []api.NetworkZoneRecord) ([]api.NetworkZoneRecord, error)) (map[string]lifecycle.NetworkZoneRecordAction, error) {
+	changes := map[string]lifecycle.NetworkZoneRecordAction{}
+
+	err := d.state.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		// Load the current records.
+		names, err := tx.GetNetworkZoneRecordNames(ctx, d.id)
+		if err != nil {
+			return err
+		}
+
+		oldRecords := make(map[string]int64, len(names))
+		records := make([]api.NetworkZoneRecord, 0, len(names))
+		for _, name := range names {
+			id, record, err := tx.GetNetworkZoneRecord(ctx, d.id, name)
+			if err != nil {
+				return err
+			}
+
+			oldRecords[name] = id
+			records = append(records, *record)
+		}
+
+		// Keep a copy of the current records to detect changes.
+		current := make(map[string]api.NetworkZoneRecord, len(records))
+		for _, record := range records {
+			record.Entries = slices.Clone(record.Entries)
+			current[record.Name] = record
+		}
+
+		newRecords, err := modify(records)
+		if err != nil {
+			return err
+		}
+
+		for _, record := range newRecords {
+			err = d.validateRecordConfig(record.Writable())
+			if err != nil {
+				return err
+			}
+
+			err = d.validateEntries(record.Writable())
+			if err != nil {
+				return err
+			}
+
+			id, found := oldRecords[record.Name]
+			if !found {
+				_, err = tx.CreateNetworkZoneRecord(ctx, d.id, api.NetworkZoneRecordsPost{Name: record.Name, NetworkZoneRecordPut: record.Writable()})
+				if err != nil {
+					return err
+				}
+
+				changes[record.Name] = lifecycle.NetworkZoneRecordCreated
+				continue
+			}
+
+			delete(oldRecords, record.Name)
+
+			if slices.Equal(current[record.Name].Entries, record.Entries) {
+				continue
+			}
+
+			err = tx.UpdateNetworkZoneRecord(ctx, id, record.Writable())
+			if err != nil {
+				return err
+			}
+
+			changes[record.Name] = lifecycle.NetworkZoneRecordUpdated
+		}
+
+		// Delete the records which are no longer present.
+		for name, id := range oldRecords {
+			err = tx.DeleteNetworkZoneRecord(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			changes[name] = lifecycle.NetworkZoneRecordDeleted
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
 // validateRecordConfig checks the config and rules are valid.
 func (d *zone) validateRecordConfig(info api.NetworkZoneRecordPut) error {
 	rules := map[string]func(value string) error{}
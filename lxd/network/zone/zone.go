@@ -5,11 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"path"
 	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/miekg/dns"
+
 	"github.com/canonical/lxd/client"
 	"github.com/canonical/lxd/lxd/cluster"
 	"github.com/canonical/lxd/lxd/config"
@@ -206,6 +209,32 @@ func (d *zone) validateConfig(info *api.NetworkZonePut) error {
 		//  type: string
 		//  required: no
 		//  shortdesc: TSIG key for the server
+
+		// lxdmeta:generate(entities=network-zone; group=config-options; key=peers.NAME.update)
+		// Dynamic updates must be signed with the peer's TSIG key, so {config:option}`network-zone-config-options:peers.NAME.key` must also be set.
+		// Peers that can update the zone cannot transfer it. Use a separate peer for zone transfers.
+		// ---
+		//  type: bool
+		//  defaultdesc: `false`
+		//  required: no
+		//  shortdesc: Whether the peer can add and remove records using dynamic DNS updates (RFC 2136)
+
+		// lxdmeta:generate(entities=network-zone; group=config-options; key=peers.NAME.update.names)
+		// Specify a comma-separated list of record names (relative to the zone) that the peer is allowed to update.
+		// Shell-style wildcards are supported, for example `_acme-challenge.*`.
+		// If not set, the peer can update any record name within the zone.
+		// ---
+		//  type: string set
+		//  required: no
+		//  shortdesc: Record names the peer can update
+
+		// lxdmeta:generate(entities=network-zone; group=config-options; key=peers.NAME.update.types)
+		// Specify a comma-separated list of record types (for example, `TXT` or `A,AAAA`) that the peer is allowed to update.
+		// If not set, the peer can update records of any type except `SOA`.
+		// ---
+		//  type: string set
+		//  required: no
+		//  shortdesc: Record types the peer can update
 		suffix, found := strings.CutPrefix(k, "peers.")
 		if !found {
 			continue
@@ -223,6 +252,12 @@ func (d *zone) validateConfig(info *api.NetworkZonePut) error {
 			rules[k] = validate.Optional(validate.IsNetworkAddress)
 		case "key":
 			rules[k] = validate.IsAny
+		case "update":
+			rules[k] = validate.Optional(validate.IsBool)
+		case "update.names":
+			rules[k] = validate.Optional(validate.IsListOf(validateUpdateName))
+		case "update.types":
+			rules[k] = validate.Optional(validate.IsListOf(validateUpdateType))
 		default:
 			return fmt.Errorf("Invalid network zone peer configuration key %q (unknown field %q)", k, peerKey)
 		}
@@ -233,6 +268,51 @@ func (d *zone) validateConfig(info *api.NetworkZonePut) error {
 		return err
 	}
 
+	// Dynamic updates are only accepted when signed, so require a TSIG key.
+	for k, v := range info.Config {
+		peerName, found := strings.CutPrefix(k, "peers.")
+		if !found {
+			continue
+		}
+
+		peerName, found = strings.CutSuffix(peerName, ".update")
+		if !found || shared.IsFalseOrEmpty(v) {
+			continue
+		}
+
+		if info.Config["peers."+peerName+".key"] == "" {
+			return fmt.Errorf("Peer %q requires a TSIG key (%q) to allow dynamic updates", peerName, "peers."+peerName+".key")
+		}
+	}
+
+	return nil
+}
+
+// validateUpdateName checks that value is a valid record name pattern for dynamic updates.
+func validateUpdateName(value string) error {
+	if value == "" {
+		return errors.New("Record name pattern cannot be empty")
+	}
+
+	_, err := path.Match(value, "")
+	if err != nil {
+		return fmt.Errorf("Invalid record name pattern %q: %w", value, err)
+	}
+
+	return nil
+}
+
+// validateUpdateType checks that value is a DNS record type that can be dynamically updated.
+func validateUpdateType(value string) error {
+	rrType, found := dns.StringToType[strings.ToUpper(value)]
+	if !found {
+		return fmt.Errorf("Unknown record type %q", value)
+	}
+
+	if rrType == dns.TypeSOA {
+		return errors.New("SOA records cannot be dynamically updated")
+	}
+
 	return nil
 }
 
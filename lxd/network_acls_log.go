@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/canonical/lxd/lxd/network/acl"
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/logger"
+)
+
+// networkACLLogStartup starts forwarding the hits of logged network ACL rules as "network-acl" events.
+// Entries are taken from the kernel log (rules applied by the host firewall) and from the OVN "acl_log" messages
+// received by the syslog socket (rules applied by OVN).
+func networkACLLogStartup(d *Daemon) {
+	s := d.State()
+
+	// Convert the OVN ACL log messages forwarded through the syslog socket.
+	d.internalListener.AddHandler("network-acl-log", func(event api.Event) {
+		if event.Type != api.EventTypeOVN {
+			return
+		}
+
+		logEvent := api.EventLogging{}
+		err := json.Unmarshal(event.Metadata, &logEvent)
+		if err != nil || logEvent.Context["module"] != "acl_log" {
+			return
+		}
+
+		projectName, aclEvent, err := acl.OVNLogEvent(d.shutdownCtx, s, logEvent.Message)
+		if err != nil {
+			logger.Debug("Failed converting OVN ACL log entry", logger.Ctx{"err": err})
+			return
+		}
+
+		if aclEvent != nil {
+			_ = d.events.Send(projectName, api.EventTypeNetworkACL, aclEvent)
+		}
+	})
+
+	// Follow the kernel log for the entries of the firewall ACL rules.
+	err := networkACLLogFollowKernel(d.shutdownCtx, func(message string) {
+		projectName, aclEvent, err := acl.FirewallLogEvent(d.shutdownCtx, s, message)
+		if err != nil {
+			logger.Debug("Failed converting firewall ACL log entry", logger.Ctx{"err": err})
+			return
+		}
+
+		if aclEvent != nil {
+			_ = d.events.Send(projectName, api.EventTypeNetworkACL, aclEvent)
+		}
+	})
+	if err != nil {
+		logger.Warn("Failed following kernel log, firewall ACL log events will not be available", logger.Ctx{"err": err})
+	}
+}
+
+// networkACLLogFollowKernel calls handler for each new kernel log message generated by an ACL rule until the
+// context is cancelled.
+func networkACLLogFollowKernel(ctx context.Context, handler func(message string)) error {
+	fd, err := unix.Open("/dev/kmsg", unix.O_RDONLY|unix.O_NONBLOCK|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+
+	// Only consider the messages logged from now on.
+	_, err = unix.Seek(fd, 0, io.SeekEnd)
+	if err != nil {
+		_ = unix.Close(fd)
+		return err
+	}
+
+	// Using a non-blocking file descriptor allows closing the file to interrupt a pending read.
+	kmsg := os.NewFile(uintptr(fd), "/dev/kmsg")
+
+	go func() {
+		<-ctx.Done()
+		_ = kmsg.Close()
+	}()
+
+	go func() {
+		buf := make([]byte, 8192)
+
+		for {
+			// Each read returns a single record in the "<prio>,<seq>,<time>,<flags>;<message>" format.
+			n, err := kmsg.Read(buf)
+			if err != nil {
+				// Records were overwritten before they could be read, resume from the next one.
+				if errors.Is(err, unix.EPIPE) {
+					continue
+				}
+
+				return
+			}
+
+			_, message, found := strings.Cut(string(buf[:n]), ";")
+			if !found {
+				continue
+			}
+
+			// Ignore the continuation lines containing the record's dictionary.
+			message, _, _ = strings.Cut(message, "\n")
+
+			if !strings.HasPrefix(message, "lxd_acl") {
+				continue
+			}
+
+			handler(message)
+		}
+	}()
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/canonical/lxd/lxd/db"
 	"github.com/canonical/lxd/lxd/network"
 	"github.com/canonical/lxd/lxd/state"
+	"github.com/canonical/lxd/shared"
 	"github.com/canonical/lxd/shared/api"
 	"github.com/canonical/lxd/shared/logger"
 )
@@ -76,6 +77,41 @@ func networkUpdateForkdnsServersTask(s *state.State, heartbeatData *cluster.APIH
 	return nil
 }
 
+// networkUpdateWireGuardMeshTask refreshes the peers of the bridge networks meshed over WireGuard.
+func networkUpdateWireGuardMeshTask(s *state.State, heartbeatData *cluster.APIHeartbeat) error {
+	// Use api.ProjectDefaultName here as bridge networks don't support projects.
+	projectName := api.ProjectDefaultName
+
+	// Get a list of managed networks
+	var networks []string
+	err := s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, c *db.ClusterTx) error {
+		var err error
+		networks, err = c.GetCreatedNetworkNamesByProject(ctx, projectName)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, name := range networks {
+		n, err := network.LoadByName(s, projectName, name)
+		if err != nil {
+			logger.Errorf("Failed loading network %q from project %q for heartbeat", name, projectName)
+			continue
+		}
+
+		if n.Type() == "bridge" && shared.IsTrue(n.Config()["wireguard.mesh"]) {
+			err := n.HandleHeartbeat(heartbeatData)
+			if err != nil {
+				logger.Error("Failed refreshing WireGuard mesh", logger.Ctx{"network": name, "err": err})
+			}
+		}
+	}
+
+	return nil
+}
+
 // networkUpdateOVNChassis gets called on heartbeats to check if OVN needs reconfiguring.
 func networkUpdateOVNChassis(s *state.State, heartbeatData *cluster.APIHeartbeat, localAddress string) error {
 	// Check if we have at least one active OVN chassis.
@@ -13,12 +13,15 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/canonical/lxd/lxd/db/operationtype"
 	"github.com/canonical/lxd/lxd/events"
 	"github.com/canonical/lxd/lxd/metrics"
 	"github.com/canonical/lxd/lxd/request"
 	"github.com/canonical/lxd/lxd/state"
+	"github.com/canonical/lxd/lxd/tracing"
 	"github.com/canonical/lxd/shared/api"
 	"github.com/canonical/lxd/shared/cancel"
 	"github.com/canonical/lxd/shared/entity"
@@ -105,6 +108,10 @@ type Operation struct {
 	logger          logger.Logger
 	location        string
 
+	// spanContext is the trace context the operation span is a child of. Once the operation is running, it is
+	// replaced by the context of the operation span itself so that operations scheduled from it are its children.
+	spanContext trace.SpanContext
+
 	// Those functions are called at various points in the Operation lifecycle
 	onRun     func(context.Context, *Operation) error
 	onConnect func(*Operation, *http.Request, http.ResponseWriter) error
@@ -146,6 +153,7 @@ type OperationArgs struct {
 	ConnectHook     func(op *Operation, r *http.Request, w http.ResponseWriter) error
 	requestor       *opRequestor
 	metricsCallback func(result metrics.RequestResult)
+	spanContext     trace.SpanContext
 	Inputs          map[string]any
 	// ConflictReference allows to create the operation only if no other operation with the same conflict reference is running.
 	// Empty ConflictReference means the operation can be started anytime.
@@ -178,6 +186,7 @@ func ScheduleUserOperationFromRequest(s *state.State, r *http.Request, args Oper
 	}
 
 	args.metricsCallback = metricsCallback
+	args.spanContext = trace.SpanContextFromContext(r.Context())
 	return scheduleOperation(s, args)
 }
 
@@ -190,6 +199,7 @@ func ScheduleUserOperationFromOperation(s *state.State, op *Operation, args Oper
 	}
 
 	args.requestor = requestor
+	args.spanContext = op.spanContext
 	return scheduleOperation(s, args)
 }
 
@@ -249,6 +259,7 @@ func scheduleOperation(s *state.State, args OperationArgs) (*Operation, error) {
 		op.state = s
 		op.requestor = args.requestor
 		op.metricsCallback = args.metricsCallback
+		op.spanContext = args.spanContext
 		op.logger = logger.AddContext(logger.Ctx{"operation": op.id, "project": op.projectName, "class": op.class.String(), "description": op.description})
 		op.inputs = args.Inputs
 		op.conflictReference = args.ConflictReference
@@ -320,6 +331,7 @@ func scheduleOperation(s *state.State, args OperationArgs) (*Operation, error) {
 		// Child operations inherit the requestor from the parent operation.
 		// metricsCallback is set only on the parent operation, so that it's called only once for the whole bulk operation.
 		childArgs.requestor = args.requestor
+		childArgs.spanContext = args.spanContext
 		childOp, err := initOperation(s, *childArgs)
 		if err != nil {
 			return nil, fmt.Errorf("Failed creating child operation: %w", err)
@@ -530,12 +542,23 @@ func (op *Operation) start() {
 		go func(ctx context.Context, op *Operation) {
 			var err error
 
+			ctx, span := tracing.Start(trace.ContextWithSpanContext(ctx, op.spanContext), op.description,
+				attribute.String("lxd.operation.id", op.id),
+				attribute.String("lxd.operation.class", op.class.String()),
+				attribute.String("lxd.project", op.projectName),
+			)
+
+			defer func() { tracing.End(span, err) }()
+
+			op.spanContext = span.SpanContext()
+
 			// Parent operation with children: start all children, wait for them to finish, then
 			// run the optional RunHook. This ensures the parent remains visible in the API until
 			// all child operations have completed so that the user can see the overall progress.
 			if op.parent == nil && len(op.children) > 0 {
 				// Start child operations
 				for _, childOp := range op.children {
+					childOp.spanContext = op.spanContext
 					childOp.start()
 				}
 
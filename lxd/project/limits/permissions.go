@@ -64,6 +64,13 @@ func HiddenStoragePools(ctx context.Context, tx *db.ClusterTx, projectName strin
 // AllowInstanceCreation returns an error if any project-specific limit or
 // restriction is violated when creating a new instance.
 func AllowInstanceCreation(ctx context.Context, globalConfig *clusterConfig.Config, tx *db.ClusterTx, projectName string, req api.InstancesPost) error {
+	return AllowInstancesCreation(ctx, globalConfig, tx, projectName, req, []string{req.Name})
+}
+
+// AllowInstancesCreation returns an error if any project-specific limit or
+// restriction is violated when creating new instances with the given names from
+// the same request.
+func AllowInstancesCreation(ctx context.Context, globalConfig *clusterConfig.Config, tx *db.ClusterTx, projectName string, req api.InstancesPost, names []string) error {
 	info, err := fetchProject(ctx, tx, projectName, true)
 	if err != nil {
 		return err
@@ -92,34 +99,36 @@ func AllowInstanceCreation(ctx context.Context, globalConfig *clusterConfig.Conf
 		return err
 	}
 
-	err = checkInstanceCountLimit(info, instanceType)
-	if err != nil {
-		return err
-	}
+	// Allow stripping volatile keys if dealing with a copy or migration.
+	strip := slices.Contains([]api.SourceType{api.SourceTypeCopy, api.SourceTypeMigration}, req.Source.Type)
 
-	err = checkTotalInstanceCountLimit(info)
-	if err != nil {
-		return err
-	}
+	for _, name := range names {
+		err = checkInstanceCountLimit(info, instanceType)
+		if err != nil {
+			return err
+		}
 
-	// Add the instance being created.
-	instance := api.Instance{
-		Name:    req.Name,
-		Project: projectName,
-		Type:    string(req.Type),
-	}
+		err = checkTotalInstanceCountLimit(info)
+		if err != nil {
+			return err
+		}
 
-	instance.SetWritable(req.InstancePut)
-	info.Instances = append(info.Instances, instance)
+		// Add the instance being created.
+		instance := api.Instance{
+			Name:    name,
+			Project: projectName,
+			Type:    string(req.Type),
+		}
 
-	// Allow stripping volatile keys if dealing with a copy or migration.
-	strip := slices.Contains([]api.SourceType{api.SourceTypeCopy, api.SourceTypeMigration}, req.Source.Type)
+		instance.SetWritable(req.InstancePut)
+		info.Instances = append(info.Instances, instance)
 
-	// Special case restriction checks on volatile.* keys.
-	err = checkRestrictionsOnVolatileConfig(
-		info.Project, instanceType, req.Name, req.Config, map[string]string{}, strip)
-	if err != nil {
-		return err
+		// Special case restriction checks on volatile.* keys.
+		err = checkRestrictionsOnVolatileConfig(
+			info.Project, instanceType, name, req.Config, map[string]string{}, strip)
+		if err != nil {
+			return err
+		}
 	}
 
 	err = checkInstanceRestrictionsAndAggregateLimits(globalConfig, tx, info)
@@ -96,6 +96,30 @@ func TestAllowInstanceCreation_Above(t *testing.T) {
 	assert.EqualError(t, err, `Reached maximum number of instances of type "container" in project "p1"`)
 }
 
+// If a limit is configured and creating all the requested instances would
+// exceed it, the check fails.
+func TestAllowInstancesCreation_Above(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	id, err := cluster.CreateProject(ctx, tx.Tx(), cluster.Project{Name: "p1"})
+	require.NoError(t, err)
+
+	err = cluster.CreateProjectConfig(ctx, tx.Tx(), id, map[string]string{"limits.containers": "2"})
+	require.NoError(t, err)
+
+	req := api.InstancesPost{
+		Type: api.InstanceTypeContainer,
+	}
+
+	err = limits.AllowInstancesCreation(context.Background(), nil, tx, "p1", req, []string{"c1", "c2"})
+	assert.NoError(t, err)
+
+	err = limits.AllowInstancesCreation(context.Background(), nil, tx, "p1", req, []string{"c1", "c2", "c3"})
+	assert.EqualError(t, err, `Reached maximum number of instances of type "container" in project "p1"`)
+}
+
 // If a limit is configured, but for a different instance type, the check
 // passes.
 func TestAllowInstanceCreation_DifferentType(t *testing.T) {
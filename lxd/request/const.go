@@ -27,6 +27,9 @@ const (
 
 	// CtxOpenFGARequestCache is used to set a cache for the OpenFGA datastore to improve driver performance on a per request basis.
 	CtxOpenFGARequestCache CtxKey = "openfga_request_cache"
+
+	// CtxAuditChecks is used to collect the authorization checks performed while handling a request for the audit log.
+	CtxAuditChecks CtxKey = "audit_checks"
 )
 
 // Headers.
@@ -39,6 +42,9 @@ const (
 
 	// headerForwardedProtocol is the forwarded protocol field in request header.
 	headerForwardedProtocol = "X-LXD-forwarded-protocol"
+
+	// headerForwardedTokenScope is the forwarded bearer token scope field in request header.
+	headerForwardedTokenScope = "X-LXD-forwarded-token-scope"
 )
 
 const (
@@ -2,6 +2,7 @@ package request
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -9,6 +10,7 @@ import (
 	"slices"
 	"time"
 
+	"github.com/canonical/lxd/lxd/auth"
 	"github.com/canonical/lxd/lxd/identity"
 	"github.com/canonical/lxd/shared"
 	"github.com/canonical/lxd/shared/api"
@@ -58,6 +60,10 @@ type RequestorArgs struct {
 	// It is set only when the client is trusted and the authentication method is either
 	// [api.AuthenticationMethodBearer] or [api.AuthenticationMethodTLS].
 	ExpiresAt *time.Time
+
+	// TokenScope restricts the access of the caller to a subset of the access of its identity.
+	// It is only set when the caller authenticated with a scoped bearer token.
+	TokenScope *auth.TokenScope
 }
 
 // Requestor contains all fields from RequestorArgs, unexported. Plus additional fields gathered from request headers
@@ -80,6 +86,7 @@ type Requestor struct {
 	projects                        []string
 	identityType                    identity.Type
 	expiresAt                       *time.Time
+	tokenScope                      *auth.TokenScope
 }
 
 // IsClusterNotification returns true if this an API request coming from a
@@ -113,6 +120,12 @@ func (r *Requestor) ExpiresAt() *time.Time {
 	return r.expiresAt
 }
 
+// CallerTokenScope returns the scope of the bearer token used by the caller.
+// Returns nil if the caller did not authenticate with a scoped bearer token.
+func (r *Requestor) CallerTokenScope() *auth.TokenScope {
+	return r.tokenScope
+}
+
 // OriginAddress returns the original address of the caller.
 func (r *Requestor) OriginAddress() string {
 	if r.IsForwarded() {
@@ -240,6 +253,17 @@ func RequestorForwardProxy(r RequestorAuditor) func(req *http.Request) (*url.URL
 			req.Header.Add(headerForwardedProtocol, protocol)
 		}
 
+		// Forward the scope of the caller's token so that it is also enforced by the receiving cluster member.
+		requestor, ok := r.(*Requestor)
+		if ok && requestor.tokenScope != nil {
+			scope, err := json.Marshal(requestor.tokenScope)
+			if err != nil {
+				return nil, fmt.Errorf("Failed encoding token scope: %w", err)
+			}
+
+			req.Header.Add(headerForwardedTokenScope, string(scope))
+		}
+
 		return shared.ProxyFromEnvironment(req)
 	}
 }
@@ -259,11 +283,12 @@ func (r *Requestor) setForwardingDetails(req *http.Request) error {
 	forwardedAddress := req.Header.Get(headerForwardedAddress)
 	forwardedUsername := req.Header.Get(headerForwardedUsername)
 	forwardedProtocol := req.Header.Get(headerForwardedProtocol)
+	forwardedTokenScope := req.Header.Get(headerForwardedTokenScope)
 
 	// Requests can only be forwarded from other cluster members.
 	if r.protocol != ProtocolCluster {
 		// No forwarding headers may be set if the protocol is not ProtocolCluster.
-		if forwardedAddress != "" || forwardedUsername != "" || forwardedProtocol != "" {
+		if forwardedAddress != "" || forwardedUsername != "" || forwardedProtocol != "" || forwardedTokenScope != "" {
 			return errors.New("Received forwarded request information from non-cluster member")
 		}
 
@@ -296,6 +321,16 @@ func (r *Requestor) setForwardingDetails(req *http.Request) error {
 	r.forwardedUsername = forwardedUsername
 	r.forwardedProtocol = forwardedProtocol
 
+	if forwardedTokenScope != "" {
+		var scope auth.TokenScope
+		err := json.Unmarshal([]byte(forwardedTokenScope), &scope)
+		if err != nil {
+			return fmt.Errorf("Received forwarded request with invalid token scope: %w", err)
+		}
+
+		r.tokenScope = &scope
+	}
+
 	return nil
 }
 
@@ -357,6 +392,7 @@ func SetRequestor(req *http.Request, hook RequestorHook, args RequestorArgs) err
 		protocol:      args.Protocol,
 		clientType:    clientType,
 		expiresAt:     args.ExpiresAt,
+		tokenScope:    args.TokenScope,
 	}
 
 	err := r.setForwardingDetails(req)
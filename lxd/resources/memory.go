@@ -15,6 +15,7 @@ import (
 
 var sysDevicesNode = "/sys/devices/system/node"
 var sysDevicesSystemMemory = "/sys/devices/system/memory"
+var procPressureMemory = "/proc/pressure/memory"
 
 type meminfo struct {
 	Cached         uint64
@@ -277,5 +278,65 @@ func GetMemory() (*api.ResourcesMemory, error) {
 		}
 	}
 
+	// Get memory pressure information (not available if PSI is disabled in the kernel).
+	pressure, err := GetMemoryPressure()
+	if err == nil {
+		memory.Pressure = pressure
+	}
+
 	return &memory, nil
 }
+
+// GetMemoryPressure returns the memory pressure stall information of the system.
+func GetMemoryPressure() (*api.ResourcesMemoryPressure, error) {
+	content, err := os.ReadFile(procPressureMemory)
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading %q: %w", procPressureMemory, err)
+	}
+
+	pressure := api.ResourcesMemoryPressure{}
+
+	// Each line is in the form: "some avg10=0.00 avg60=0.00 avg300=0.00 total=0".
+	for line := range strings.SplitSeq(strings.TrimSpace(string(content)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+
+		var avg10, avg60, avg300 *float64
+		switch fields[0] {
+		case "some":
+			avg10, avg60, avg300 = &pressure.SomeAvg10, &pressure.SomeAvg60, &pressure.SomeAvg300
+		case "full":
+			avg10, avg60, avg300 = &pressure.FullAvg10, &pressure.FullAvg60, &pressure.FullAvg300
+		default:
+			continue
+		}
+
+		for _, field := range fields[1:] {
+			key, value, found := strings.Cut(field, "=")
+			if !found {
+				continue
+			}
+
+			var target *float64
+			switch key {
+			case "avg10":
+				target = avg10
+			case "avg60":
+				target = avg60
+			case "avg300":
+				target = avg300
+			default:
+				continue
+			}
+
+			*target, err = strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("Failed parsing %q in %q: %w", field, procPressureMemory, err)
+			}
+		}
+	}
+
+	return &pressure, nil
+}
@@ -15,9 +15,12 @@ import (
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/canonical/lxd/client"
 	"github.com/canonical/lxd/lxd/metrics"
 	"github.com/canonical/lxd/lxd/request"
+	"github.com/canonical/lxd/lxd/tracing"
 	"github.com/canonical/lxd/lxd/ucred"
 	"github.com/canonical/lxd/lxd/util"
 	"github.com/canonical/lxd/shared/api"
@@ -459,6 +462,8 @@ func (r *errorResponse) Render(w http.ResponseWriter, req *http.Request) error {
 		}
 	}()
 
+	tracing.RecordHTTPError(req.Context(), r.code, r.err)
+
 	err := json.NewEncoder(output).Encode(resp)
 
 	if err != nil {
@@ -651,6 +656,9 @@ func (r *forwardedResponse) Render(w http.ResponseWriter, req *http.Request) err
 		return err
 	}
 
+	ctx, span := tracing.Start(req.Context(), "cluster.forward", attribute.String("server.address", info.Addresses[0]))
+	defer span.End()
+
 	url := info.Addresses[0] + req.URL.RequestURI()
 	forwarded, err := http.NewRequest(req.Method, url, req.Body)
 	if err != nil {
@@ -661,6 +669,9 @@ func (r *forwardedResponse) Render(w http.ResponseWriter, req *http.Request) err
 		forwarded.Header.Set(key, req.Header.Get(key))
 	}
 
+	// Continue the trace on the target member with the forwarding span as parent.
+	tracing.Inject(ctx, forwarded.Header)
+
 	httpClient, err := r.client.GetHTTPClient()
 	if err != nil {
 		return err
@@ -668,9 +679,12 @@ func (r *forwardedResponse) Render(w http.ResponseWriter, req *http.Request) err
 
 	response, err := httpClient.Do(forwarded)
 	if err != nil {
+		tracing.End(span, err)
 		return err
 	}
 
+	span.SetAttributes(attribute.Int("http.response.status_code", response.StatusCode))
+
 	for key := range response.Header {
 		w.Header().Set(key, response.Header.Get(key))
 	}
@@ -21,6 +21,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.yaml.in/yaml/v2"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sys/unix"
@@ -49,6 +51,7 @@ import (
 	"github.com/canonical/lxd/lxd/storage/drivers"
 	"github.com/canonical/lxd/lxd/storage/filesystem"
 	"github.com/canonical/lxd/lxd/storage/memorypipe"
+	"github.com/canonical/lxd/lxd/tracing"
 	"github.com/canonical/lxd/lxd/util"
 	"github.com/canonical/lxd/shared"
 	"github.com/canonical/lxd/shared/api"
@@ -209,6 +212,16 @@ func (b *lxdBackend) isStatusReady() error {
 	return nil
 }
 
+// startSpan starts a tracing span for a storage pool call on the given instance, volume or image.
+func (b *lxdBackend) startSpan(ctx context.Context, name string, projectName string, volName string) (context.Context, trace.Span) {
+	return tracing.Start(ctx, "storage."+name,
+		attribute.String("lxd.storage.pool", b.name),
+		attribute.String("lxd.storage.driver", b.driver.Info().Name),
+		attribute.String("lxd.project", projectName),
+		attribute.String("lxd.storage.volume", volName),
+	)
+}
+
 // ToAPI returns the storage pool as an API representation.
 func (b *lxdBackend) ToAPI() api.StoragePool {
 	return b.db
@@ -1124,6 +1137,9 @@ func (b *lxdBackend) CreateInstanceFromCopy(ctx context.Context, inst instance.I
 	l.Debug("CreateInstanceFromCopy started")
 	defer l.Debug("CreateInstanceFromCopy finished")
 
+	ctx, span := b.startSpan(ctx, "CreateInstanceFromCopy", inst.Project().Name, inst.Name())
+	defer span.End()
+
 	err := b.isStatusReady()
 	if err != nil {
 		return err
@@ -1385,6 +1401,9 @@ func (b *lxdBackend) RefreshCustomVolume(ctx context.Context, projectName, srcPr
 	l.Debug("RefreshCustomVolume started")
 	defer l.Debug("RefreshCustomVolume finished")
 
+	ctx, span := b.startSpan(ctx, "RefreshCustomVolume", projectName, volName)
+	defer span.End()
+
 	err := b.isStatusReady()
 	if err != nil {
 		return err
@@ -1681,6 +1700,9 @@ func (b *lxdBackend) RefreshInstance(ctx context.Context, inst instance.Instance
 	l.Debug("RefreshInstance started")
 	defer l.Debug("RefreshInstance finished")
 
+	ctx, span := b.startSpan(ctx, "RefreshInstance", inst.Project().Name, inst.Name())
+	defer span.End()
+
 	// This indicates whether or not it's a volume-only refresh.
 	snapshots := len(srcSnapshots) > 0
 
@@ -2123,6 +2145,9 @@ func (b *lxdBackend) CreateInstanceFromImage(ctx context.Context, inst instance.
 	l.Debug("CreateInstanceFromImage started")
 	defer l.Debug("CreateInstanceFromImage finished")
 
+	ctx, span := b.startSpan(ctx, "CreateInstanceFromImage", inst.Project().Name, inst.Name())
+	defer span.End()
+
 	err := b.isStatusReady()
 	if err != nil {
 		return err
@@ -2230,6 +2255,9 @@ func (b *lxdBackend) CreateInstanceFromMigration(ctx context.Context, inst insta
 	l.Debug("CreateInstanceFromMigration started")
 	defer l.Debug("CreateInstanceFromMigration finished")
 
+	ctx, span := b.startSpan(ctx, "CreateInstanceFromMigration", inst.Project().Name, inst.Name())
+	defer span.End()
+
 	err := b.isStatusReady()
 	if err != nil {
 		return err
@@ -3133,6 +3161,9 @@ func (b *lxdBackend) MigrateInstance(ctx context.Context, inst instance.Instance
 	l.Debug("MigrateInstance started")
 	defer l.Debug("MigrateInstance finished")
 
+	ctx, span := b.startSpan(ctx, "MigrateInstance", inst.Project().Name, inst.Name())
+	defer span.End()
+
 	volType, err := InstanceTypeToVolumeType(inst.Type())
 	if err != nil {
 		return err
@@ -3927,6 +3958,9 @@ func (b *lxdBackend) RestoreInstanceSnapshot(ctx context.Context, inst instance.
 	l.Debug("RestoreInstanceSnapshot started")
 	defer l.Debug("RestoreInstanceSnapshot finished")
 
+	ctx, span := b.startSpan(ctx, "RestoreInstanceSnapshot", inst.Project().Name, inst.Name())
+	defer span.End()
+
 	revert := revert.New()
 	defer revert.Fail()
 
@@ -4183,6 +4217,9 @@ func (b *lxdBackend) EnsureImage(ctx context.Context, fingerprint string, projec
 	l.Debug("EnsureImage started")
 	defer l.Debug("EnsureImage finished")
 
+	ctx, span := b.startSpan(ctx, "EnsureImage", projectName, fingerprint)
+	defer span.End()
+
 	err := b.isStatusReady()
 	if err != nil {
 		return err
@@ -4875,6 +4912,9 @@ func (b *lxdBackend) CreateCustomVolume(ctx context.Context, projectName string,
 	l.Debug("CreateCustomVolume started")
 	defer l.Debug("CreateCustomVolume finished")
 
+	ctx, span := b.startSpan(ctx, "CreateCustomVolume", projectName, volName)
+	defer span.End()
+
 	err := b.isStatusReady()
 	if err != nil {
 		return err
@@ -4925,6 +4965,9 @@ func (b *lxdBackend) CreateCustomVolumeFromCopy(ctx context.Context, projectName
 	l.Debug("CreateCustomVolumeFromCopy started")
 	defer l.Debug("CreateCustomVolumeFromCopy finished")
 
+	ctx, span := b.startSpan(ctx, "CreateCustomVolumeFromCopy", projectName, volName)
+	defer span.End()
+
 	err := b.isStatusReady()
 	if err != nil {
 		return err
@@ -5367,6 +5410,9 @@ func (b *lxdBackend) CreateCustomVolumeFromMigration(ctx context.Context, projec
 	l.Debug("CreateCustomVolumeFromMigration started")
 	defer l.Debug("CreateCustomVolumeFromMigration finished")
 
+	ctx, span := b.startSpan(ctx, "CreateCustomVolumeFromMigration", projectName, args.Name)
+	defer span.End()
+
 	err := b.isStatusReady()
 	if err != nil {
 		return err
@@ -5965,6 +6011,9 @@ func (b *lxdBackend) DeleteCustomVolume(ctx context.Context, projectName string,
 	l.Debug("DeleteCustomVolume started")
 	defer l.Debug("DeleteCustomVolume finished")
 
+	ctx, span := b.startSpan(ctx, "DeleteCustomVolume", projectName, volName)
+	defer span.End()
+
 	if shared.IsSnapshot(volName) {
 		return errors.New("Volume name cannot be a snapshot")
 	}
@@ -6238,6 +6287,9 @@ func (b *lxdBackend) CreateCustomVolumeSnapshot(ctx context.Context, projectName
 	l.Debug("CreateCustomVolumeSnapshot started")
 	defer l.Debug("CreateCustomVolumeSnapshot finished")
 
+	ctx, span := b.startSpan(ctx, "CreateCustomVolumeSnapshot", projectName, volName)
+	defer span.End()
+
 	if shared.IsSnapshot(volName) {
 		return nil, errors.New("Volume does not support snapshots")
 	}
@@ -6593,6 +6645,9 @@ func (b *lxdBackend) RestoreCustomVolume(ctx context.Context, projectName string
 	l.Debug("RestoreCustomVolume started")
 	defer l.Debug("RestoreCustomVolume finished")
 
+	ctx, span := b.startSpan(ctx, "RestoreCustomVolume", projectName, volName)
+	defer span.End()
+
 	// Quick checks.
 	if shared.IsSnapshot(volName) {
 		return errors.New("Volume cannot be snapshot")
@@ -7857,6 +7912,9 @@ func (b *lxdBackend) CreateCustomVolumeFromISO(ctx context.Context, projectName
 	l.Debug("CreateCustomVolumeFromISO started")
 	defer l.Debug("CreateCustomVolumeFromISO finished")
 
+	ctx, span := b.startSpan(ctx, "CreateCustomVolumeFromISO", projectName, volName)
+	defer span.End()
+
 	// Validate the name of the volume as this could be malicious.
 	err := drivers.ValidVolumeName(volName)
 	if err != nil {
@@ -7937,6 +7995,9 @@ func (b *lxdBackend) CreateCustomVolumeFromTarball(ctx context.Context, projectN
 	l.Debug("CreateCustomVolumeFromTarball started")
 	defer l.Debug("CreateCustomVolumeFromTarball finished")
 
+	ctx, span := b.startSpan(ctx, "CreateCustomVolumeFromTarball", projectName, volName)
+	defer span.End()
+
 	// Validate the name of the volume as this could be malicious.
 	err := drivers.ValidVolumeName(volName)
 	if err != nil {
@@ -8035,6 +8096,9 @@ func (b *lxdBackend) CreateCustomVolumeFromBackup(ctx context.Context, srcBackup
 	l.Debug("CreateCustomVolumeFromBackup started")
 	defer l.Debug("CreateCustomVolumeFromBackup finished")
 
+	ctx, span := b.startSpan(ctx, "CreateCustomVolumeFromBackup", srcBackup.Project, srcBackup.Name)
+	defer span.End()
+
 	if srcBackup.Config == nil {
 		return errors.New("Valid volume config not found in index")
 	}
@@ -105,6 +105,10 @@ type OS struct {
 	// LXC features
 	LXCFeatures map[string]bool
 
+	// CRIU features
+	CRIU               bool // CRIU indicates the CRIU tool is available for container live migration.
+	CRIUMemoryTracking bool // CRIUMemoryTracking indicates CRIU supports dirty memory tracking for pre-copy migration.
+
 	// OS info
 	ReleaseInfo map[string]string
 	Uname       *shared.Utsname
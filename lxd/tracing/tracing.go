@@ -0,0 +1,196 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/canonical/lxd/shared/version"
+)
+
+const (
+	// ProtocolHTTP exports traces using OTLP over HTTP (protobuf encoding).
+	ProtocolHTTP = "http"
+
+	// ProtocolGRPC exports traces using OTLP over gRPC.
+	ProtocolGRPC = "grpc"
+)
+
+// tracerName is the instrumentation scope used for all LXD spans.
+const tracerName = "github.com/canonical/lxd/lxd"
+
+// httpTracesPath is the default OTLP/HTTP path used when the endpoint doesn't specify one.
+const httpTracesPath = "/v1/traces"
+
+var provider *sdktrace.TracerProvider
+var providerLock sync.Mutex
+
+func init() {
+	// Always propagate the W3C trace context so that a trace started by a client or another cluster member
+	// is continued even if this member doesn't export spans itself.
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+}
+
+// Configure sets up the export of spans to the OTLP collector at the given endpoint, replacing any previous
+// configuration. Exporting is disabled if the endpoint is empty.
+func Configure(ctx context.Context, endpoint string, protocol string, sampleRatio float64, instance string) error {
+	providerLock.Lock()
+	defer providerLock.Unlock()
+
+	err := shutdown(ctx)
+	if err != nil {
+		return err
+	}
+
+	if endpoint == "" {
+		return nil
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("Invalid OTLP endpoint %q: %w", endpoint, err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("Invalid OTLP endpoint %q: Scheme must be http or https", endpoint)
+	}
+
+	var exporter sdktrace.SpanExporter
+	switch protocol {
+	case ProtocolHTTP, "":
+		if u.Path == "" || u.Path == "/" {
+			u.Path = httpTracesPath
+		}
+
+		exporter, err = otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(u.String()))
+	case ProtocolGRPC:
+		exporter, err = otlptracegrpc.New(ctx, otlptracegrpc.WithEndpointURL(u.String()))
+	default:
+		return fmt.Errorf("Unsupported OTLP protocol %q", protocol)
+	}
+
+	if err != nil {
+		return fmt.Errorf("Failed creating OTLP trace exporter: %w", err)
+	}
+
+	res := resource.NewSchemaless(
+		attribute.String("service.name", "lxd"),
+		attribute.String("service.version", version.Version),
+		attribute.String("service.instance.id", instance),
+	)
+
+	provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+
+	return nil
+}
+
+// Shutdown flushes the pending spans and stops exporting them.
+func Shutdown(ctx context.Context) error {
+	providerLock.Lock()
+	defer providerLock.Unlock()
+
+	return shutdown(ctx)
+}
+
+// shutdown stops the current tracer provider. It must be called with providerLock held.
+func shutdown(ctx context.Context) error {
+	if provider == nil {
+		return nil
+	}
+
+	otel.SetTracerProvider(noop.NewTracerProvider())
+
+	err := provider.Shutdown(ctx)
+	provider = nil
+	if err != nil {
+		return fmt.Errorf("Failed stopping trace exporter: %w", err)
+	}
+
+	return nil
+}
+
+// Start starts a new span with the given name, as a child of the span in the context if any.
+// The returned span must be ended by the caller.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartChild starts a new span like Start but only if the context already has a span. This avoids creating
+// a trace for each of the frequent internal calls (like database transactions) made outside of a request.
+func StartChild(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+
+	return Start(ctx, name, attrs...)
+}
+
+// StartServer starts the span of an incoming API request, continuing the trace context from its headers.
+func StartServer(r *http.Request, route string) (context.Context, trace.Span) {
+	ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+	return otel.Tracer(tracerName).Start(ctx, r.Method+" "+route,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("http.request.method", r.Method),
+			attribute.String("http.route", route),
+			attribute.String("url.path", r.URL.Path),
+		),
+	)
+}
+
+// End records the error, if any, on the span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, context.Canceled) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// RecordHTTPError records an API error response on the span in the context.
+// Only server errors mark the span as failed, client errors are expected outcomes of a request.
+func RecordHTTPError(ctx context.Context, code int, err error) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", code))
+	if code < http.StatusInternalServerError {
+		return
+	}
+
+	if err == nil {
+		err = errors.New(http.StatusText(code))
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// Inject adds the trace context of the span in the context to the given headers.
+func Inject(ctx context.Context, header http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
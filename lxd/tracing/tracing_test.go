@@ -0,0 +1,73 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestConfigure(t *testing.T) {
+	var exports atomic.Int64
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == httpTracesPath {
+			exports.Add(1)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	defer collector.Close()
+
+	ctx := context.Background()
+
+	require.Error(t, Configure(ctx, "ftp://127.0.0.1", ProtocolHTTP, 1, "member1"))
+	require.Error(t, Configure(ctx, collector.URL, "thrift", 1, "member1"))
+
+	require.NoError(t, Configure(ctx, collector.URL, ProtocolHTTP, 1, "member1"))
+
+	spanCtx, span := Start(ctx, "test")
+	_, child := StartChild(spanCtx, "child")
+	require.True(t, child.SpanContext().IsValid())
+	require.Equal(t, span.SpanContext().TraceID(), child.SpanContext().TraceID())
+	child.End()
+	span.End()
+
+	// Spans are sent when exporting is stopped.
+	require.NoError(t, Shutdown(ctx))
+	require.Equal(t, int64(1), exports.Load())
+
+	// No span is created after shutdown.
+	_, span = Start(ctx, "test")
+	require.False(t, span.IsRecording())
+}
+
+func TestStartChild(t *testing.T) {
+	ctx := context.Background()
+
+	// Without a parent span, no span is started.
+	childCtx, span := StartChild(ctx, "child")
+	require.Equal(t, ctx, childCtx)
+	require.False(t, span.SpanContext().IsValid())
+
+	// The trace context of the request headers is continued.
+	header := http.Header{}
+	header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r := httptest.NewRequest(http.MethodGet, "/1.0", nil)
+	r.Header = header
+
+	serverCtx, _ := StartServer(r, "/1.0")
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", trace.SpanContextFromContext(serverCtx).TraceID().String())
+
+	_, span = StartChild(serverCtx, "child")
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+
+	// The trace context is propagated to outgoing requests.
+	outgoing := http.Header{}
+	Inject(serverCtx, outgoing)
+	require.Contains(t, outgoing.Get("traceparent"), "4bf92f3577b34da6a3ce929d0e0e4736")
+}
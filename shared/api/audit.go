@@ -0,0 +1,132 @@
+package api
+
+import (
+	"time"
+)
+
+const (
+	// AuditResultSuccess is the result of a request that was allowed and succeeded.
+	AuditResultSuccess = "success"
+
+	// AuditResultFailure is the result of a request that was allowed but failed.
+	AuditResultFailure = "failure"
+
+	// AuditResultDenied is the result of a request that was rejected by authentication or authorization.
+	AuditResultDenied = "denied"
+)
+
+// AuditEntry represents an entry of the audit log.
+//
+// swagger:model
+//
+// API extension: audit_log.
+type AuditEntry struct {
+	// Sequence number of the entry in the audit log of the cluster member
+	// Example: 42
+	Sequence int64 `json:"sequence" yaml:"sequence"`
+
+	// Time the request was received
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+
+	// What cluster member handled the request
+	// Example: node1
+	Location string `json:"location,omitempty" yaml:"location,omitempty"`
+
+	// Name of the authenticated identity
+	// Example: foo@example.com
+	Username string `json:"username,omitempty" yaml:"username,omitempty"`
+
+	// Authentication method used by the identity
+	// Example: oidc
+	Protocol string `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+
+	// Authorization groups the identity is a member of (directly or through identity provider groups)
+	// Example: ["operators"]
+	Groups []string `json:"groups,omitempty" yaml:"groups,omitempty"`
+
+	// Source address of the request
+	// Example: 10.0.2.15:52324
+	SourceAddress string `json:"source_address" yaml:"source_address"`
+
+	// HTTP method of the request
+	// Example: DELETE
+	Method string `json:"method" yaml:"method"`
+
+	// URL of the request
+	// Example: /1.0/instances/c1?project=default
+	URL string `json:"url" yaml:"url"`
+
+	// JSON body of the request, with the values of secret fields (passwords, tokens, secrets and private keys) redacted
+	// Example: {"config": {"user.foo": "bar"}}
+	Body any `json:"body,omitempty" yaml:"body,omitempty"`
+
+	// HTTP status code of the response
+	// Example: 202
+	StatusCode int `json:"status_code" yaml:"status_code"`
+
+	// Result of the request (success, failure or denied)
+	// Example: success
+	Result string `json:"result" yaml:"result"`
+
+	// URL of the background operation created by the request
+	// Example: /1.0/operations/b8d84888-1dc2-44fd-b386-7f679e171ba5
+	Operation string `json:"operation,omitempty" yaml:"operation,omitempty"`
+
+	// Authorization checks performed while handling the request
+	Authorization []AuditAuthorizationCheck `json:"authorization,omitempty" yaml:"authorization,omitempty"`
+
+	// Hash of the previous entry of the audit log
+	// Example: 5c0e63bb2ec6e1a7c4d4b0e8e0e5c0f3b5d8b0a9a4d46c1e0e5e46a0c1f9a0b2
+	PreviousHash string `json:"previous_hash" yaml:"previous_hash"`
+
+	// HMAC-SHA256 of the entry keyed with a secret of the cluster member, covering all the other fields including the hash of the previous entry
+	// Example: 9f1b5e0b76a7b9a02e3f2e7f44f0d3c3c7a2a9e3d1c6b3f7d2f6e1c9f3a1d4e5
+	Hash string `json:"hash,omitempty" yaml:"hash,omitempty"`
+}
+
+// AuditAuthorizationCheck represents an authorization check recorded in the audit log.
+//
+// swagger:model
+//
+// API extension: audit_log.
+type AuditAuthorizationCheck struct {
+	// URL of the entity the entitlement was checked against
+	// Example: /1.0/instances/c1?project=default
+	EntityURL string `json:"entity_url" yaml:"entity_url"`
+
+	// Entitlement that was checked
+	// Example: can_delete
+	Entitlement string `json:"entitlement" yaml:"entitlement"`
+
+	// Whether the entitlement was granted
+	// Example: false
+	Allowed bool `json:"allowed" yaml:"allowed"`
+}
+
+// AuditLogVerification represents the result of the verification of the audit log hash chain.
+//
+// swagger:model
+//
+// API extension: audit_log.
+type AuditLogVerification struct {
+	// Whether the hash chain of the audit log is intact
+	// Example: true
+	Valid bool `json:"valid" yaml:"valid"`
+
+	// Number of verified entries
+	// Example: 1024
+	Entries int64 `json:"entries" yaml:"entries"`
+
+	// Sequence number of the last verified entry
+	// Example: 1024
+	LastSequence int64 `json:"last_sequence" yaml:"last_sequence"`
+
+	// Hash of the last verified entry
+	// Example: 9f1b5e0b76a7b9a02e3f2e7f44f0d3c3c7a2a9e3d1c6b3f7d2f6e1c9f3a1d4e5
+	LastHash string `json:"last_hash" yaml:"last_hash"`
+
+	// Description of the first inconsistency found in the audit log
+	// Example: Entry 512 has an invalid hash
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
@@ -8,10 +8,11 @@ import (
 
 // LXD event types.
 const (
-	EventTypeLifecycle = "lifecycle"
-	EventTypeLogging   = "logging"
-	EventTypeOperation = "operation"
-	EventTypeOVN       = "ovn"
+	EventTypeLifecycle  = "lifecycle"
+	EventTypeLogging    = "logging"
+	EventTypeOperation  = "operation"
+	EventTypeOVN        = "ovn"
+	EventTypeNetworkACL = "network-acl"
 )
 
 // Event represents an event entry (over websocket)
@@ -46,7 +47,7 @@ type Event struct {
 // ToLogging creates log record for the event.
 func (event *Event) ToLogging() (EventLogRecord, error) {
 	switch event.Type {
-	case EventTypeLogging, EventTypeOVN:
+	case EventTypeLogging, EventTypeOVN, EventTypeNetworkACL:
 		e := &EventLogging{}
 		err := json.Unmarshal(event.Metadata, &e)
 		if err != nil {
@@ -40,6 +40,7 @@ const (
 	EventLifecycleImageRetrieved                    = "image-retrieved"
 	EventLifecycleImageSecretCreated                = "image-secret-created"
 	EventLifecycleImageUpdated                      = "image-updated"
+	EventLifecycleInstanceAutoRestarted             = "instance-auto-restarted"
 	EventLifecycleInstanceBackupCreated             = "instance-backup-created"
 	EventLifecycleInstanceBackupDeleted             = "instance-backup-deleted"
 	EventLifecycleInstanceBackupRenamed             = "instance-backup-renamed"
@@ -53,6 +54,9 @@ const (
 	EventLifecycleInstanceFileDeleted               = "instance-file-deleted"
 	EventLifecycleInstanceFilePushed                = "instance-file-pushed"
 	EventLifecycleInstanceFileRetrieved             = "instance-file-retrieved"
+	EventLifecycleInstanceHealthChanged             = "instance-health-changed"
+	EventLifecycleInstanceSessionStarted            = "instance-session-started"
+	EventLifecycleInstanceSessionFinished           = "instance-session-finished"
 	EventLifecycleInstanceLogDeleted                = "instance-log-deleted"
 	EventLifecycleInstanceLogRetrieved              = "instance-log-retrieved"
 	EventLifecycleInstanceMetadataRetrieved         = "instance-metadata-retrieved"
@@ -135,10 +139,14 @@ const (
 	EventLifecycleIdentityCreated                   = "identity-created"
 	EventLifecycleIdentityUpdated                   = "identity-updated"
 	EventLifecycleIdentityDeleted                   = "identity-deleted"
+	EventLifecycleIdentityGroupExpired              = "identity-group-expired"
 	EventLifecycleAuthGroupCreated                  = "auth-group-created"
 	EventLifecycleAuthGroupUpdated                  = "auth-group-updated"
 	EventLifecycleAuthGroupRenamed                  = "auth-group-renamed"
 	EventLifecycleAuthGroupDeleted                  = "auth-group-deleted"
+	EventLifecycleAuthGroupRequestCreated           = "auth-group-request-created"
+	EventLifecycleAuthGroupRequestApproved          = "auth-group-request-approved"
+	EventLifecycleAuthGroupRequestDeleted           = "auth-group-request-deleted"
 	EventLifecycleIdentityProviderGroupCreated      = "identity-provider-group-created"
 	EventLifecycleIdentityProviderGroupUpdated      = "identity-provider-group-updated"
 	EventLifecycleIdentityProviderGroupRenamed      = "identity-provider-group-renamed"
@@ -147,4 +155,8 @@ const (
 	EventLifecyclePlacementGroupDeleted             = "placement-group-deleted"
 	EventLifecyclePlacementGroupRenamed             = "placement-group-renamed"
 	EventLifecyclePlacementGroupUpdated             = "placement-group-updated"
+	EventLifecycleInstanceTemplateCreated           = "instance-template-created"
+	EventLifecycleInstanceTemplateDeleted           = "instance-template-deleted"
+	EventLifecycleInstanceTemplateRenamed           = "instance-template-renamed"
+	EventLifecycleInstanceTemplateUpdated           = "instance-template-updated"
 )
@@ -70,6 +70,18 @@ type InstancesPost struct {
 	//
 	// API extension: instance_create_start
 	Start bool `json:"start" yaml:"start"`
+
+	// Name of the instance template to create the instance from
+	// Example: web
+	//
+	// API extension: instance_templates
+	Template string `json:"template,omitempty" yaml:"template,omitempty"`
+
+	// Number of instances to create (when set, name is used as a pattern where "{n}" is replaced by the instance index)
+	// Example: 3
+	//
+	// API extension: instances_bulk_create
+	Count int `json:"count,omitempty" yaml:"count,omitempty"`
 }
 
 // InstancesPut represents the fields available for a mass update.
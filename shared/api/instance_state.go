@@ -1,5 +1,9 @@
 package api
 
+import (
+	"time"
+)
+
 // InstanceStatePut represents the modifiable fields of a LXD instance's state.
 //
 // swagger:model
@@ -56,6 +60,36 @@ type InstanceState struct {
 
 	// CPU usage information
 	CPU InstanceStateCPU `json:"cpu" yaml:"cpu"`
+
+	// Number of consecutive automatic restarts following unexpected stops
+	// Example: 2
+	//
+	// API extension: instance_boot_autorestart
+	AutoRestarts int64 `json:"auto_restarts" yaml:"auto_restarts"`
+
+	// Health of the instance workload (only set for running instances with a health check)
+	//
+	// API extension: instance_healthcheck
+	Health *InstanceStateHealth `json:"health,omitempty" yaml:"health,omitempty"`
+}
+
+// InstanceStateHealth represents the health section of a LXD instance's state.
+//
+// swagger:model
+//
+// API extension: instance_healthcheck.
+type InstanceStateHealth struct {
+	// Health status (starting, healthy or unhealthy)
+	// Example: healthy
+	Status string `json:"status" yaml:"status"`
+
+	// Number of consecutive failed health checks
+	// Example: 0
+	Failures int `json:"failures" yaml:"failures"`
+
+	// Time of the last health check
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	LastCheck time.Time `json:"last_check" yaml:"last_check"`
 }
 
 // InstanceStateDisk represents the disk information section of a LXD instance's state.
@@ -113,6 +147,34 @@ type InstanceStateMemory struct {
 	// Peak SWAP usage in bytes
 	// Example: 12297557
 	SwapUsagePeak int64 `json:"swap_usage_peak" yaml:"swap_usage_peak"`
+
+	// Memory hotplug state (only set for virtual machines with memory hotplug enabled)
+	//
+	// API extension: instance_memory_hotplug
+	Hotplug *InstanceStateMemoryHotplug `json:"hotplug,omitempty" yaml:"hotplug,omitempty"`
+}
+
+// InstanceStateMemoryHotplug represents the memory hotplug state of a LXD virtual machine.
+//
+// swagger:model
+//
+// API extension: instance_memory_hotplug.
+type InstanceStateMemoryHotplug struct {
+	// Maximum memory size the instance can be grown to while running, in bytes
+	// Example: 17179869184
+	Max int64 `json:"max" yaml:"max"`
+
+	// Memory size above the boot time size requested from the guest, in bytes
+	// Example: 2147483648
+	Requested int64 `json:"requested" yaml:"requested"`
+
+	// Memory size above the boot time size plugged by the guest, in bytes
+	// Example: 2147483648
+	Plugged int64 `json:"plugged" yaml:"plugged"`
+
+	// Whether the guest supports memory hotplug (supported, unsupported or unknown)
+	// Example: supported
+	GuestSupport string `json:"guest_support" yaml:"guest_support"`
 }
 
 // InstanceStateNetwork represents the network information section of a LXD instance's state.
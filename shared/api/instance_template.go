@@ -0,0 +1,116 @@
+package api
+
+// InstanceTemplate represents a reusable set of instance creation arguments.
+//
+// swagger:model
+//
+// API extension: instance_templates.
+type InstanceTemplate struct {
+	WithEntitlements `yaml:",inline"`
+
+	// Name of the instance template.
+	// Example: web
+	Name string `json:"name" yaml:"name"`
+
+	// Description of the instance template.
+	// Example: Web server
+	Description string `json:"description" yaml:"description"`
+
+	// Project the instance template belongs to.
+	// Example: default
+	Project string `json:"project" yaml:"project"`
+
+	// Type (container or virtual-machine)
+	// Example: virtual-machine
+	Type InstanceType `json:"type" yaml:"type"`
+
+	// Cloud instance type (AWS, GCP, Azure, ...) to emulate with limits
+	// Example: c2-m4
+	InstanceType string `json:"instance_type" yaml:"instance_type"`
+
+	// Creation source
+	Source InstanceSource `json:"source" yaml:"source"`
+
+	// List of profiles applied to the instance
+	// Example: ["default"]
+	Profiles []string `json:"profiles" yaml:"profiles"`
+
+	// Instance configuration (see doc/instances.md)
+	// Example: {"cloud-init.user-data": "#cloud-config\npackages: [nginx]", "placement.group": "web"}
+	Config map[string]string `json:"config" yaml:"config"`
+
+	// Instance devices (see doc/instances.md)
+	// Example: {"root": {"type": "disk", "pool": "default", "path": "/", "size": "20GiB"}}
+	Devices map[string]map[string]string `json:"devices" yaml:"devices"`
+}
+
+// InstanceTemplatesPost represents the fields required to create a new instance template.
+//
+// swagger:model
+//
+// API extension: instance_templates.
+type InstanceTemplatesPost struct {
+	// Name of the instance template.
+	// Example: web
+	Name string `json:"name" yaml:"name"`
+
+	InstanceTemplatePut `yaml:",inline"`
+}
+
+// InstanceTemplatePut represents the modifiable fields of an instance template.
+//
+// swagger:model
+//
+// API extension: instance_templates.
+type InstanceTemplatePut struct {
+	// Description of the instance template.
+	// Example: Web server
+	Description string `json:"description" yaml:"description"`
+
+	// Type (container or virtual-machine)
+	// Example: virtual-machine
+	Type InstanceType `json:"type" yaml:"type"`
+
+	// Cloud instance type (AWS, GCP, Azure, ...) to emulate with limits
+	// Example: c2-m4
+	InstanceType string `json:"instance_type" yaml:"instance_type"`
+
+	// Creation source
+	Source InstanceSource `json:"source" yaml:"source"`
+
+	// List of profiles applied to the instance
+	// Example: ["default"]
+	Profiles []string `json:"profiles" yaml:"profiles"`
+
+	// Instance configuration (see doc/instances.md)
+	// Example: {"cloud-init.user-data": "#cloud-config\npackages: [nginx]", "placement.group": "web"}
+	Config map[string]string `json:"config" yaml:"config"`
+
+	// Instance devices (see doc/instances.md)
+	// Example: {"root": {"type": "disk", "pool": "default", "path": "/", "size": "20GiB"}}
+	Devices map[string]map[string]string `json:"devices" yaml:"devices"`
+}
+
+// Writable returns the editable fields of an [InstanceTemplate] as [InstanceTemplatePut].
+func (t InstanceTemplate) Writable() InstanceTemplatePut {
+	return InstanceTemplatePut{
+		Description:  t.Description,
+		Type:         t.Type,
+		InstanceType: t.InstanceType,
+		Source:       t.Source,
+		Profiles:     t.Profiles,
+		Config:       t.Config,
+		Devices:      t.Devices,
+	}
+}
+
+// InstanceTemplatePost represents the fields required to rename an instance template.
+//
+// swagger:model
+//
+// API extension: instance_templates.
+type InstanceTemplatePost struct {
+	// New name of the instance template.
+	// Example: web-v2
+	Name string `json:"name" yaml:"name"`
+}
@@ -213,6 +213,11 @@ type NetworkState struct {
 	//
 	// API extension: network_state_ovn
 	OVN *NetworkStateOVN `json:"ovn" yaml:"ovn"`
+
+	// Additional WireGuard information
+	//
+	// API extension: network_bridge_wireguard
+	WireGuard *NetworkStateWireGuard `json:"wireguard" yaml:"wireguard"`
 }
 
 // NetworkStateAddress represents a network address
@@ -347,3 +352,22 @@ type NetworkStateOVN struct {
 	// OVN network chassis name
 	Chassis string `json:"chassis" yaml:"chassis"`
 }
+
+// NetworkStateWireGuard represents WireGuard specific state
+//
+// swagger:model
+//
+// API extension: network_bridge_wireguard.
+type NetworkStateWireGuard struct {
+	// Public key of the local WireGuard interface
+	// Example: xTIBA5rboUvnH4htodjb6e697QjLERt1NAB4mZqp8Dg=
+	PublicKey string `json:"public_key" yaml:"public_key"`
+
+	// UDP port the local WireGuard interface listens on
+	// Example: 51820
+	ListenPort int `json:"listen_port" yaml:"listen_port"`
+
+	// Public keys of the configured WireGuard peers
+	// Example: ["HIgo9xNzJMWLKASShiTqIybxZ0U3wGLiUeJ1PKf8ykw="]
+	Peers []string `json:"peers" yaml:"peers"`
+}
@@ -302,3 +302,35 @@ type NetworkACLsPost struct {
 	NetworkACLPost `yaml:",inline"`
 	NetworkACLPut  `yaml:",inline"`
 }
+
+// NetworkACLState represents the runtime state of an ACL.
+//
+// swagger:model
+//
+// API extension: network_acl_state.
+type NetworkACLState struct {
+	// Hit counters of the egress rules (in the same order as the rules)
+	Egress []NetworkACLRuleState `json:"egress" yaml:"egress"`
+
+	// Hit counters of the ingress rules (in the same order as the rules)
+	Ingress []NetworkACLRuleState `json:"ingress" yaml:"ingress"`
+
+	// Whether the ACL is used by OVN networks, whose rule hits aren't included in the counters
+	// Example: false
+	Incomplete bool `json:"incomplete" yaml:"incomplete"`
+}
+
+// NetworkACLRuleState represents the runtime state of a single ACL rule.
+//
+// swagger:model
+//
+// API extension: network_acl_state.
+type NetworkACLRuleState struct {
+	// Number of packets that matched the rule
+	// Example: 1182
+	Packets uint64 `json:"packets" yaml:"packets"`
+
+	// Number of bytes that matched the rule
+	// Example: 250542
+	Bytes uint64 `json:"bytes" yaml:"bytes"`
+}
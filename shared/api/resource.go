@@ -761,6 +761,43 @@ type ResourcesMemory struct {
 	// Total system memory (bytes)
 	// Example: 687194767360
 	Total uint64 `json:"total" yaml:"total"`
+
+	// Memory pressure stall information (if supported by the kernel)
+	//
+	// API extension: instances_memory_pressure_suspend
+	Pressure *ResourcesMemoryPressure `json:"pressure,omitempty" yaml:"pressure,omitempty"`
+}
+
+// ResourcesMemoryPressure represents the memory pressure stall information of the system.
+// The values are the percentage of time during which tasks were stalled waiting for memory.
+//
+// swagger:model
+//
+// API extension: instances_memory_pressure_suspend.
+type ResourcesMemoryPressure struct {
+	// Percentage of time some tasks were stalled over the last 10 seconds
+	// Example: 1.5
+	SomeAvg10 float64 `json:"some_avg10" yaml:"some_avg10"`
+
+	// Percentage of time some tasks were stalled over the last 60 seconds
+	// Example: 0.8
+	SomeAvg60 float64 `json:"some_avg60" yaml:"some_avg60"`
+
+	// Percentage of time some tasks were stalled over the last 300 seconds
+	// Example: 0.2
+	SomeAvg300 float64 `json:"some_avg300" yaml:"some_avg300"`
+
+	// Percentage of time all tasks were stalled over the last 10 seconds
+	// Example: 0.5
+	FullAvg10 float64 `json:"full_avg10" yaml:"full_avg10"`
+
+	// Percentage of time all tasks were stalled over the last 60 seconds
+	// Example: 0.3
+	FullAvg60 float64 `json:"full_avg60" yaml:"full_avg60"`
+
+	// Percentage of time all tasks were stalled over the last 300 seconds
+	// Example: 0.1
+	FullAvg300 float64 `json:"full_avg300" yaml:"full_avg300"`
 }
 
 // ResourcesMemoryNode represents the node-specific memory resources available on the system
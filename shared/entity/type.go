@@ -121,6 +121,9 @@ const (
 
 	// TypeReplicator represents replicator resources.
 	TypeReplicator Type = "replicator"
+
+	// TypeInstanceTemplate represents instance template resources.
+	TypeInstanceTemplate Type = "instance_template"
 )
 
 const (
@@ -183,6 +186,7 @@ var entityTypes = map[Type]typeInfo{
 	TypePlacementGroup:        placementGroup{},
 	TypeClusterLink:           clusterLink{},
 	TypeReplicator:            replicator{},
+	TypeInstanceTemplate:      instanceTemplate{},
 }
 
 // metricsEntityTypes is the source of truth for which entity types can be used to categorize endpoints
@@ -203,6 +207,7 @@ var metricsEntityTypes = []Type{
 	TypePlacementGroup,
 	TypeClusterLink,
 	TypeReplicator,
+	TypeInstanceTemplate,
 }
 
 // APIMetricsEntityTypes returns the list of entity types relevant for the API metrics.
@@ -631,3 +636,19 @@ func (replicator) path() []string {
 func (replicator) pathArgNames() []string {
 	return []string{"name"}
 }
+
+type instanceTemplate struct {
+	typeInfoCommon
+}
+
+func (instanceTemplate) requiresProject() bool {
+	return true
+}
+
+func (instanceTemplate) path() []string {
+	return []string{"instance-templates", pathPlaceholder}
+}
+
+func (instanceTemplate) pathArgNames() []string {
+	return []string{"name"}
+}
@@ -344,3 +344,8 @@ func ClusterLinkURL(clusterLinkName string) *api.URL {
 func ReplicatorURL(projectName string, replicatorName string) *api.URL {
 	return TypeReplicator.urlMust(projectName, "", replicatorName)
 }
+
+// InstanceTemplateURL returns an [*api.URL] to an instance template.
+func InstanceTemplateURL(projectName string, instanceTemplateName string) *api.URL {
+	return TypeInstanceTemplate.urlMust(projectName, "", instanceTemplateName)
+}
@@ -146,6 +146,15 @@ func TestEntityPermissionURL_RoundTrip(t *testing.T) {
 				"name":     "snap-0",
 			},
 		},
+		{
+			Name:        "Instance template",
+			URL:         "/1.0/instance-templates/web?project=foo",
+			WantType:    TypeInstanceTemplate,
+			WantProject: "foo",
+			WantArgs: map[string]string{
+				"name": "web",
+			},
+		},
 		{
 			Name:        "Network",
 			URL:         "/1.0/networks/lxdbr0",
@@ -0,0 +1,170 @@
+// Package sshsig implements the SSH signature format of OpenSSH (as used by "ssh-keygen -Y sign").
+// See https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig.
+package sshsig
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// magicPreamble starts both the signature and the signed data.
+	magicPreamble = "SSHSIG"
+
+	// signatureVersion is the only supported version of the signature format.
+	signatureVersion = 1
+
+	// pemType is the type of the PEM block of armored signatures.
+	pemType = "SSH SIGNATURE"
+)
+
+// signature is the binary representation of an SSH signature.
+type signature struct {
+	MagicPreamble [6]byte
+	Version       uint32
+	PublicKey     []byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Signature     []byte
+}
+
+// signedData is the data that is actually signed by the key.
+type signedData struct {
+	MagicPreamble [6]byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Hash          []byte
+}
+
+// newHash returns a hash for the given SSH signature hash algorithm.
+func newHash(algorithm string) (hash.Hash, error) {
+	switch algorithm {
+	case "sha256":
+		return sha256.New(), nil
+	case "sha512":
+		return sha512.New(), nil
+	default:
+		return nil, fmt.Errorf("Unsupported hash algorithm %q", algorithm)
+	}
+}
+
+// dataToSign returns the data that is signed by the key for the given message.
+func dataToSign(namespace string, hashAlgorithm string, message []byte) ([]byte, error) {
+	h, err := newHash(hashAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	h.Write(message)
+
+	data := signedData{
+		Namespace:     namespace,
+		HashAlgorithm: hashAlgorithm,
+		Hash:          h.Sum(nil),
+	}
+
+	copy(data.MagicPreamble[:], magicPreamble)
+	return ssh.Marshal(data), nil
+}
+
+// Sign signs the message with the given key for the given namespace, and returns the armored signature.
+// RSA keys are used with SHA-512 as SHA-1 signatures aren't allowed.
+func Sign(signer ssh.Signer, namespace string, message []byte) (string, error) {
+	if namespace == "" {
+		return "", errors.New("A namespace is required")
+	}
+
+	data, err := dataToSign(namespace, "sha512", message)
+	if err != nil {
+		return "", err
+	}
+
+	key := signer.PublicKey()
+	cert, ok := key.(*ssh.Certificate)
+	if ok {
+		key = cert.Key
+	}
+
+	var sig *ssh.Signature
+	algorithmSigner, ok := signer.(ssh.AlgorithmSigner)
+	if ok && key.Type() == ssh.KeyAlgoRSA {
+		sig, err = algorithmSigner.SignWithAlgorithm(rand.Reader, data, ssh.KeyAlgoRSASHA512)
+	} else {
+		sig, err = signer.Sign(rand.Reader, data)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	blob := signature{
+		Version:       signatureVersion,
+		PublicKey:     signer.PublicKey().Marshal(),
+		Namespace:     namespace,
+		HashAlgorithm: "sha512",
+		Signature:     ssh.Marshal(sig),
+	}
+
+	copy(blob.MagicPreamble[:], magicPreamble)
+	return string(pem.EncodeToMemory(&pem.Block{Type: pemType, Bytes: ssh.Marshal(blob)})), nil
+}
+
+// Verify checks that the armored signature of the message is valid for the given namespace, and returns the public
+// key (or certificate) that signed it. It is up to the caller to check that the key is trusted.
+func Verify(armoredSignature string, namespace string, message []byte) (ssh.PublicKey, error) {
+	block, _ := pem.Decode([]byte(armoredSignature))
+	if block == nil || block.Type != pemType {
+		return nil, errors.New("Invalid armored SSH signature")
+	}
+
+	var blob signature
+	err := ssh.Unmarshal(block.Bytes, &blob)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing SSH signature: %w", err)
+	}
+
+	if string(blob.MagicPreamble[:]) != magicPreamble || blob.Version != signatureVersion {
+		return nil, errors.New("Unsupported SSH signature format")
+	}
+
+	if blob.Namespace != namespace {
+		return nil, fmt.Errorf("SSH signature is for namespace %q instead of %q", blob.Namespace, namespace)
+	}
+
+	key, err := ssh.ParsePublicKey(blob.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing SSH signature public key: %w", err)
+	}
+
+	var sig ssh.Signature
+	err = ssh.Unmarshal(blob.Signature, &sig)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing SSH signature: %w", err)
+	}
+
+	// RSA signatures using SHA-1 are deprecated.
+	if sig.Format == ssh.KeyAlgoRSA {
+		return nil, errors.New("RSA signatures must use SHA-2")
+	}
+
+	data, err := dataToSign(blob.Namespace, blob.HashAlgorithm, message)
+	if err != nil {
+		return nil, err
+	}
+
+	err = key.Verify(data, &sig)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid SSH signature: %w", err)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,84 @@
+package sshsig
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// signatureEd25519 was generated with "ssh-keygen -Y sign -n test -f key" for the message "message\n".
+const signatureEd25519 = `-----BEGIN SSH SIGNATURE-----
+U1NIU0lHAAAAAQAAADMAAAALc3NoLWVkMjU1MTkAAAAgrHbUBVg7fU3FKcMldUZy5Aryrr
+KsCNRoQ4nBrL7VGEkAAAAEdGVzdAAAAAAAAAAGc2hhNTEyAAAAUwAAAAtzc2gtZWQyNTUx
+OQAAAECCqJGrgLwWoaO1my13OUO6jr7/97IDKrdwJ3nrmBklJ3Nm9BSo0FhidyuHxe9/16
+hH23SO4Vj5G2uQF9QmbpMA
+-----END SSH SIGNATURE-----
+`
+
+// publicKeyEd25519 is the public key of signatureEd25519.
+const publicKeyEd25519 = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKx21AVYO31NxSnDJXVGcuQK8q6yrAjUaEOJway+1RhJ"
+
+func TestSignVerify(t *testing.T) {
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, privateKey := range []any{ed25519Key, rsaKey} {
+		signer, err := ssh.NewSignerFromKey(privateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sig, err := Sign(signer, "test", []byte("message"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		key, err := Verify(sig, "test", []byte("message"))
+		if err != nil {
+			t.Fatalf("Failed verifying %s signature: %v", signer.PublicKey().Type(), err)
+		}
+
+		if !bytes.Equal(key.Marshal(), signer.PublicKey().Marshal()) {
+			t.Fatal("Unexpected signing key")
+		}
+
+		// The signature is only valid for the signed message and namespace.
+		_, err = Verify(sig, "test", []byte("other"))
+		if err == nil {
+			t.Fatal("Expected signature of another message to be rejected")
+		}
+
+		_, err = Verify(sig, "other", []byte("message"))
+		if err == nil {
+			t.Fatal("Expected signature for another namespace to be rejected")
+		}
+	}
+
+	_, err = Verify("not a signature", "test", []byte("message"))
+	if err == nil {
+		t.Fatal("Expected invalid signature to be rejected")
+	}
+}
+
+func TestVerifyOpenSSH(t *testing.T) {
+	key, err := Verify(signatureEd25519, "test", []byte("message\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key))) != publicKeyEd25519 {
+		t.Fatalf("Unexpected signing key %q", ssh.MarshalAuthorizedKey(key))
+	}
+}
@@ -488,6 +488,31 @@ var APIExtensions = []string{
 	"image_extended_metadata",
 	"cluster_links",
 	"replicators",
+	"network_zones_dns_updates",
+	"network_bridge_dhcp_reservations",
+	"network_bridge_wireguard",
+	"instance_nic_routed_macvlan_acls",
+	"network_acl_state",
+	"instance_boot_autorestart",
+	"instance_healthcheck",
+	"instance_memory_hotplug",
+	"instance_console_vnc",
+	"instance_session_recording",
+	"instance_boot_schedule",
+	"instance_templates",
+	"instances_bulk_create",
+	"container_live_migration",
+	"instances_memory_pressure_suspend",
+	"metrics_storage_and_project_limits",
+	"metrics_cluster_health",
+	"tracing_otlp",
+	"logs_otlp_syslog",
+	"audit_log",
+	"auth_ldap",
+	"auth_ssh",
+	"auth_temporary_groups",
+	"auth_bearer_token_scopes",
+	"auth_check",
 }
 
 // APIExtensionsCount returns the number of available API extensions.
@@ -0,0 +1,28 @@
+# mini-ldap related test helpers.
+
+spawn_ldap() {
+  # Return if LDAP is already set up.
+  [ -e "${TEST_DIR}/ldap.pid" ] && return
+
+  touch "${TEST_DIR}/ldap.users"
+  PORT="$(local_tcp_port)"
+  echo "${PORT}" > "${TEST_DIR}/ldap.port"
+  mini-ldap "${PORT}" "${TEST_DIR}/ldap.users" &
+  echo $! > "${TEST_DIR}/ldap.pid"
+
+  sleep 1
+}
+
+kill_ldap() {
+  [ ! -e "${TEST_DIR}/ldap.pid" ] && return
+
+  kill_go_proc "$(< "${TEST_DIR}/ldap.pid")"
+  rm -f "${TEST_DIR}/ldap.pid"
+  rm -f "${TEST_DIR}/ldap.port"
+  rm -f "${TEST_DIR}/ldap.users"
+}
+
+# set_ldap_user adds a user to the directory with the given password and optional comma separated list of groups.
+set_ldap_user() {
+  echo "${1} ${2} ${3:-}" >> "${TEST_DIR}/ldap.users"
+}
@@ -0,0 +1,17 @@
+# OpenTelemetry collector related test helpers.
+
+spawn_otlp() {
+  # Return if the collector is already set up.
+  [ -e "${TEST_DIR}/otlp.pid" ] && return
+
+  mini-otlp "${TEST_DIR}" &
+  echo $! > "${TEST_DIR}/otlp.pid"
+  sleep 0.1
+}
+
+kill_otlp() {
+  [ ! -e "${TEST_DIR}/otlp.pid" ] && return
+
+  kill_go_proc "$(< "${TEST_DIR}/otlp.pid")"
+  rm "${TEST_DIR}/otlp.pid"
+}
@@ -70,12 +70,18 @@ readonly test_group_instance=(
     "concurrent"  # Disabled as flaky.
     "concurrent_exec"
     "console"
+    "console_recording"
     "console_vm"
+    "container_autorestart"
+    "container_boot_schedule"
+    "container_bulk_create"
     "container_devices_gpu"
     "container_devices_none"
     "container_devices_proxy"
     "container_devices_tpm"
     "container_devices_unix"
+    "container_healthcheck"
+    "container_instance_template"
     "container_metadata"
     "container_snapshot_config"
     "container_syscall_interception"
@@ -85,6 +91,7 @@ readonly test_group_instance=(
     "exec_exit_code"
     "lxd_benchmark_basic"
     "vm_empty"
+    "vm_memory_pressure"
     "vm_pcie_bus"
 )
 
@@ -110,6 +117,7 @@ readonly test_group_network=(
     "bgp"
     "container_devices_infiniband_physical"
     "container_devices_infiniband_sriov"
+    "container_devices_nic_acl"
     "container_devices_nic_bridged"
     "container_devices_nic_bridged_acl"
     "container_devices_nic_bridged_filtering"
@@ -133,6 +141,10 @@ readonly test_group_standalone=(
     "acme"
     "alias"
     "apparmor"
+    "audit"
+    "auth_bearer_token_scopes"
+    "auth_check"
+    "auth_group_requests"
     "authorization"
     "ui_initial_access_link"
     "basic_usage"
@@ -152,6 +164,8 @@ readonly test_group_standalone=(
     "operations_conflict_reference"
     "instances_selective_recursion"
     "kernel_limits"
+    "ldap"
+    "logs_export"
     "loki"
     "lxd_user"
     "metrics"
@@ -181,10 +195,12 @@ readonly test_group_standalone=(
     "server_info"
     "shutdown"
     "sql"
+    "ssh"
     "syslog_socket"
     "template"
     "tls_restrictions"
     "tls_version"
+    "tracing"
     "waitready"
     "warnings"
 )
@@ -145,7 +145,7 @@ run_dependency_checks() {
   fi
 
   echo "==> Checking test dependencies"
-  if ! check_dependencies devlxd-client lxd-client fuidshift mini-acme mini-loki mini-oidc sysinfo; then
+  if ! check_dependencies devlxd-client lxd-client fuidshift mini-acme mini-ldap mini-loki mini-oidc mini-otlp sysinfo; then
     make -C "${MAIN_DIR}/.." test-binaries
   fi
 
@@ -347,6 +347,7 @@ cleanup() {
   else
     echo "==> Cleaning up"
 
+    kill_ldap
     kill_oidc
     clear_ovn_nb_db
     mountpoint -q "${TEST_DIR}/dev" && umount -l "${TEST_DIR}/dev"
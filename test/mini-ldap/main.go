@@ -0,0 +1,286 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	// baseDN is the suffix of all entries of the directory.
+	baseDN = "dc=lxd,dc=test"
+
+	// searchDN and searchPassword are the credentials of the service account allowed to search the directory.
+	searchDN       = "cn=search," + baseDN
+	searchPassword = "search"
+)
+
+// entry is an entry of the directory.
+type entry struct {
+	dn         string
+	attributes map[string][]string
+}
+
+func main() {
+	if len(os.Args) != 3 {
+		fmt.Fprintln(os.Stderr, "Usage: mini-ldap PORT USERS-FILE")
+		os.Exit(1)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:"+os.Args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		go func() {
+			err := serve(conn, os.Args[2])
+			if err != nil && !errors.Is(err, io.EOF) {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			}
+		}()
+	}
+}
+
+// loadEntries reads the users file and returns the entries of the directory.
+// Each line of the file contains a username, a password and an optional comma separated list of groups.
+// Users are stored under "ou=users" and groups under "ou=groups" (with a "member" attribute for each user).
+func loadEntries(path string) ([]entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	entries := []entry{{dn: searchDN, attributes: map[string][]string{"cn": {"search"}, "userPassword": {searchPassword}}}}
+	groups := map[string]*entry{}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		user := entry{
+			dn: "uid=" + fields[0] + ",ou=users," + baseDN,
+			attributes: map[string][]string{
+				"objectClass":  {"inetOrgPerson"},
+				"uid":          {fields[0]},
+				"cn":           {fields[0]},
+				"displayName":  {"User " + fields[0]},
+				"userPassword": {fields[1]},
+			},
+		}
+
+		entries = append(entries, user)
+
+		if len(fields) < 3 {
+			continue
+		}
+
+		for _, name := range strings.Split(fields[2], ",") {
+			group, ok := groups[name]
+			if !ok {
+				group = &entry{dn: "cn=" + name + ",ou=groups," + baseDN, attributes: map[string][]string{"objectClass": {"groupOfNames"}, "cn": {name}}}
+				groups[name] = group
+			}
+
+			group.attributes["member"] = append(group.attributes["member"], user.dn)
+		}
+	}
+
+	for _, group := range groups {
+		entries = append(entries, *group)
+	}
+
+	return entries, scanner.Err()
+}
+
+// serve handles the LDAP requests of a client. Only simple binds and searches are supported.
+func serve(conn net.Conn, usersPath string) error {
+	defer conn.Close()
+
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil {
+			return err
+		}
+
+		if len(packet.Children) < 2 {
+			return errors.New("Invalid LDAP message")
+		}
+
+		messageID := packet.Children[0].Value.(int64)
+		request := packet.Children[1]
+
+		entries, err := loadEntries(usersPath)
+		if err != nil {
+			return err
+		}
+
+		var responses []*ber.Packet
+		switch request.Tag {
+		case ldap.ApplicationBindRequest:
+			responses = []*ber.Packet{bind(entries, request)}
+		case ldap.ApplicationSearchRequest:
+			responses = search(entries, request)
+		case ldap.ApplicationUnbindRequest:
+			return nil
+		default:
+			return fmt.Errorf("Unsupported LDAP operation %d", request.Tag)
+		}
+
+		for _, response := range responses {
+			envelope := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+			envelope.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "Message ID"))
+			envelope.AppendChild(response)
+
+			_, err = conn.Write(envelope.Bytes())
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// result returns an LDAP result packet with the given application tag and result code.
+func result(tag ber.Tag, code uint16) *ber.Packet {
+	packet := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "Result Code"))
+	packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+
+	return packet
+}
+
+// bind checks the credentials of a simple bind request. Anonymous binds are allowed.
+func bind(entries []entry, request *ber.Packet) *ber.Packet {
+	dn := request.Children[1].Data.String()
+	password := request.Children[2].Data.String()
+
+	if dn == "" && password == "" {
+		return result(ldap.ApplicationBindResponse, ldap.LDAPResultSuccess)
+	}
+
+	for _, e := range entries {
+		if strings.EqualFold(e.dn, dn) && password != "" && password == first(e.attributes["userPassword"]) {
+			return result(ldap.ApplicationBindResponse, ldap.LDAPResultSuccess)
+		}
+	}
+
+	return result(ldap.ApplicationBindResponse, ldap.LDAPResultInvalidCredentials)
+}
+
+// search returns the entries under the base DN that match the filter of the search request.
+func search(entries []entry, request *ber.Packet) []*ber.Packet {
+	base := request.Children[0].Data.String()
+	sizeLimit := request.Children[3].Value.(int64)
+	filter := request.Children[6]
+
+	var responses []*ber.Packet
+	for _, e := range entries {
+		if !strings.HasSuffix(strings.ToLower(e.dn), strings.ToLower(base)) || !match(e, filter) {
+			continue
+		}
+
+		if sizeLimit > 0 && int64(len(responses)) >= sizeLimit {
+			return append(responses, result(ldap.ApplicationSearchResultDone, ldap.LDAPResultSizeLimitExceeded))
+		}
+
+		packet := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+		packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.dn, "DN"))
+
+		attributes := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+		for name, values := range e.attributes {
+			if name == "userPassword" {
+				continue
+			}
+
+			attribute := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+			attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Type"))
+
+			set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+			for _, value := range values {
+				set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, "Value"))
+			}
+
+			attribute.AppendChild(set)
+			attributes.AppendChild(attribute)
+		}
+
+		packet.AppendChild(attributes)
+		responses = append(responses, packet)
+	}
+
+	return append(responses, result(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess))
+}
+
+// match returns whether the entry matches the filter. Only the and, or, not, equality and presence filters are supported.
+func match(e entry, filter *ber.Packet) bool {
+	switch filter.Tag {
+	case ldap.FilterAnd:
+		for _, child := range filter.Children {
+			if !match(e, child) {
+				return false
+			}
+		}
+
+		return true
+	case ldap.FilterOr:
+		for _, child := range filter.Children {
+			if match(e, child) {
+				return true
+			}
+		}
+
+		return false
+	case ldap.FilterNot:
+		return !match(e, filter.Children[0])
+	case ldap.FilterPresent:
+		return len(values(e, filter.Data.String())) > 0
+	case ldap.FilterEqualityMatch:
+		for _, value := range values(e, filter.Children[0].Data.String()) {
+			if strings.EqualFold(value, filter.Children[1].Data.String()) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// values returns the values of an attribute of the entry, ignoring the case of the attribute name.
+func values(e entry, name string) []string {
+	for k, v := range e.attributes {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// first returns the first value of the list or an empty string.
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
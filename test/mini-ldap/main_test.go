@@ -0,0 +1,87 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/canonical/lxd/lxd/auth/ldap"
+	"github.com/canonical/lxd/shared/api"
+)
+
+func TestAuthenticatorLogin(t *testing.T) {
+	usersPath := filepath.Join(t.TempDir(), "ldap.users")
+	err := os.WriteFile(usersPath, []byte("alice secret admins,operators\nbob hunter2\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer listener.Close()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() { _ = serve(conn, usersPath) }()
+		}
+	}()
+
+	authenticator, err := ldap.NewAuthenticator(ldap.Args{
+		URL:             "ldap://" + listener.Addr().String(),
+		BindDN:          searchDN,
+		BindPassword:    searchPassword,
+		UserBaseDN:      "ou=users," + baseDN,
+		UserFilter:      "(&(objectClass=inetOrgPerson)(uid={username}))",
+		GroupsBaseDN:    "ou=groups," + baseDN,
+		GroupsFilter:    "(member={dn})",
+		GroupsAttribute: "cn",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := authenticator.Login("Alice", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Username != "alice" || res.DN != "uid=alice,ou=users,"+baseDN || res.Name != "User alice" {
+		t.Fatalf("Unexpected authentication result %+v", res)
+	}
+
+	if len(res.IdentityProviderGroups) != 2 || res.IdentityProviderGroups[0] != "admins" || res.IdentityProviderGroups[1] != "operators" {
+		t.Fatalf("Unexpected groups %v", res.IdentityProviderGroups)
+	}
+
+	res, err = authenticator.Login("bob", "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(res.IdentityProviderGroups) != 0 {
+		t.Fatalf("Unexpected groups %v", res.IdentityProviderGroups)
+	}
+
+	// Invalid credentials, unknown users, empty passwords and filter injections are all rejected the same way.
+	for _, creds := range [][2]string{{"alice", "wrong"}, {"carol", "secret"}, {"alice", ""}, {"*", "secret"}} {
+		_, err = authenticator.Login(creds[0], creds[1])
+		if !api.StatusErrorCheck(err, http.StatusForbidden) {
+			t.Fatalf("Expected login of %q to be forbidden, got %v", creds[0], err)
+		}
+	}
+
+	_, err = ldap.NewAuthenticator(ldap.Args{URL: "http://127.0.0.1", UserBaseDN: baseDN, UserFilter: "(uid={username})"})
+	if err == nil {
+		t.Fatal("Expected invalid URL to be rejected")
+	}
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	"golang.org/x/sys/unix"
+	"google.golang.org/protobuf/proto"
+)
+
+// span is the representation of a received span written to the spans file.
+type span struct {
+	Name         string            `json:"name"`
+	TraceID      string            `json:"trace_id"`
+	SpanID       string            `json:"span_id"`
+	ParentSpanID string            `json:"parent_span_id"`
+	Service      map[string]string `json:"service"`
+	Attributes   map[string]string `json:"attributes"`
+}
+
+// logRecord is the representation of a received log record written to the logs file.
+type logRecord struct {
+	Severity   string            `json:"severity"`
+	Body       string            `json:"body"`
+	Service    map[string]string `json:"service"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+func main() {
+	err := run()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func run() (err error) {
+	var workdir string
+	if len(os.Args) > 1 {
+		workdir = os.Args[1]
+	} else {
+		workdir, err = os.Getwd()
+		if err != nil {
+			return err
+		}
+	}
+
+	spansFile, err := os.Create(filepath.Join(workdir, "otlp.spans"))
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = spansFile.Close()
+	}()
+
+	logsFile, err := os.Create(filepath.Join(workdir, "otlp.logs"))
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = logsFile.Close()
+	}()
+
+	sigchan := make(chan os.Signal, 1)
+	signal.Notify(sigchan, unix.SIGINT, unix.SIGTERM)
+
+	l, err := net.Listen("tcp", "127.0.0.1:4318")
+	if err != nil {
+		return err
+	}
+
+	s := &http.Server{Handler: &collector{spansFile: spansFile, logsFile: logsFile}}
+
+	go func() {
+		<-sigchan
+		_ = s.Close()
+	}()
+
+	err = s.Serve(l)
+	if !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// collector is a minimal OTLP/HTTP collector writing the received spans and log records as JSON lines.
+type collector struct {
+	spansFile io.Writer
+	logsFile  io.Writer
+	lock      sync.Mutex
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req proto.Message
+	var resp proto.Message
+	var write func() error
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/traces":
+		traceReq := &coltracepb.ExportTraceServiceRequest{}
+		req = traceReq
+		resp = &coltracepb.ExportTraceServiceResponse{}
+		write = func() error { return c.writeSpans(traceReq) }
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/logs":
+		logsReq := &collogspb.ExportLogsServiceRequest{}
+		req = logsReq
+		resp = &collogspb.ExportLogsServiceResponse{}
+		write = func() error { return c.writeLogs(logsReq) }
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if r.Header.Get("Content-Type") != "application/x-protobuf" {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = proto.Unmarshal(body, req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = write()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	respBody, err := proto.Marshal(resp)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(respBody)
+}
+
+// stringAttributes returns the string attributes as a map.
+func stringAttributes(attrs []*commonpb.KeyValue) map[string]string {
+	values := map[string]string{}
+	for _, attr := range attrs {
+		values[attr.GetKey()] = attr.GetValue().GetStringValue()
+	}
+
+	return values
+}
+
+// writeSpans appends the spans of the request to the spans file.
+func (c *collector) writeSpans(req *coltracepb.ExportTraceServiceRequest) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	encoder := json.NewEncoder(c.spansFile)
+	for _, resourceSpans := range req.GetResourceSpans() {
+		service := stringAttributes(resourceSpans.GetResource().GetAttributes())
+
+		for _, scopeSpans := range resourceSpans.GetScopeSpans() {
+			for _, s := range scopeSpans.GetSpans() {
+				err := encoder.Encode(span{
+					Name:         s.GetName(),
+					TraceID:      hex.EncodeToString(s.GetTraceId()),
+					SpanID:       hex.EncodeToString(s.GetSpanId()),
+					ParentSpanID: hex.EncodeToString(s.GetParentSpanId()),
+					Service:      service,
+					Attributes:   stringAttributes(s.GetAttributes()),
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeLogs appends the log records of the request to the logs file.
+func (c *collector) writeLogs(req *collogspb.ExportLogsServiceRequest) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	encoder := json.NewEncoder(c.logsFile)
+	for _, resourceLogs := range req.GetResourceLogs() {
+		service := stringAttributes(resourceLogs.GetResource().GetAttributes())
+
+		for _, scopeLogs := range resourceLogs.GetScopeLogs() {
+			for _, l := range scopeLogs.GetLogRecords() {
+				err := encoder.Encode(logRecord{
+					Severity:   l.GetSeverityText(),
+					Body:       l.GetBody().GetStringValue(),
+					Service:    service,
+					Attributes: stringAttributes(l.GetAttributes()),
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
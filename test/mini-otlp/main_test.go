@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCollector_ServeHTTP(t *testing.T) {
+	spans := &bytes.Buffer{}
+	logs := &bytes.Buffer{}
+	c := &collector{spansFile: spans, logsFile: logs}
+
+	stringAttr := func(key string, value string) *commonpb.KeyValue {
+		return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}}}
+	}
+
+	body, err := proto.Marshal(&coltracepb.ExportTraceServiceRequest{
+		ResourceSpans: []*tracepb.ResourceSpans{{
+			Resource: &resourcepb.Resource{Attributes: []*commonpb.KeyValue{stringAttr("service.name", "lxd")}},
+			ScopeSpans: []*tracepb.ScopeSpans{{
+				Spans: []*tracepb.Span{{
+					Name:         "GET /1.0",
+					TraceId:      []byte{0x01, 0x02},
+					SpanId:       []byte{0x03},
+					ParentSpanId: []byte{0x04},
+					Attributes:   []*commonpb.KeyValue{stringAttr("http.route", "/1.0")},
+				}},
+			}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Failed encoding request: %v", err)
+	}
+
+	logsBody, err := proto.Marshal(&collogspb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{{
+			Resource: &resourcepb.Resource{Attributes: []*commonpb.KeyValue{stringAttr("service.name", "lxd")}},
+			ScopeLogs: []*logspb.ScopeLogs{{
+				LogRecords: []*logspb.LogRecord{{
+					SeverityText: "INFO",
+					Body:         &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: "instance-started"}},
+					Attributes:   []*commonpb.KeyValue{stringAttr("name", "c1")},
+				}},
+			}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Failed encoding request: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		contentType    string
+		body           []byte
+		expectedStatus int
+		expectedSpans  string
+		expectedLogs   string
+	}{
+		{
+			name:           "Export spans",
+			method:         http.MethodPost,
+			url:            "/v1/traces",
+			contentType:    "application/x-protobuf",
+			body:           body,
+			expectedStatus: http.StatusOK,
+			expectedSpans:  `{"name":"GET /1.0","trace_id":"0102","span_id":"03","parent_span_id":"04","service":{"service.name":"lxd"},"attributes":{"http.route":"/1.0"}}` + "\n",
+		},
+		{
+			name:           "Export logs",
+			method:         http.MethodPost,
+			url:            "/v1/logs",
+			contentType:    "application/x-protobuf",
+			body:           logsBody,
+			expectedStatus: http.StatusOK,
+			expectedLogs:   `{"severity":"INFO","body":"instance-started","service":{"service.name":"lxd"},"attributes":{"name":"c1"}}` + "\n",
+		},
+		{
+			name:           "Invalid content type",
+			method:         http.MethodPost,
+			url:            "/v1/traces",
+			contentType:    "application/json",
+			body:           []byte("{}"),
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:           "Invalid body",
+			method:         http.MethodPost,
+			url:            "/v1/traces",
+			contentType:    "application/x-protobuf",
+			body:           []byte("invalid"),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Not found",
+			method:         http.MethodGet,
+			url:            "/unknown",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spans.Reset()
+			logs.Reset()
+
+			req := httptest.NewRequest(tt.method, tt.url, bytes.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+
+			w := httptest.NewRecorder()
+
+			c.ServeHTTP(w, req)
+
+			resp := w.Result()
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+
+			if spans.String() != tt.expectedSpans {
+				t.Errorf("Expected spans %q, got %q", tt.expectedSpans, spans.String())
+			}
+
+			if logs.String() != tt.expectedLogs {
+				t.Errorf("Expected logs %q, got %q", tt.expectedLogs, logs.String())
+			}
+		})
+	}
+}
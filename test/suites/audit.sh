@@ -0,0 +1,55 @@
+test_audit() {
+  local audit_log="${LXD_DIR}/audit/audit.log"
+
+  # Check the configuration is validated.
+  ! lxc config set audit.max_size="big" || false
+  ! lxc config set audit.max_files="-1" || false
+
+  # Nothing is recorded while the audit log is disabled.
+  lxc init --empty c1
+  lxc delete c1
+  [ ! -e "${audit_log}" ]
+
+  lxc config set audit.enabled=true
+
+  lxc init --empty c1
+  lxc config set c1 user.foo=bar
+  lxc list > /dev/null
+  lxc delete c1
+
+  # Untrusted requests are recorded as denied.
+  [ "$(curl --insecure --silent --output /dev/null --write-out '%{http_code}' "https://${LXD_ADDR}/1.0/instances")" = "403" ]
+
+  # Check the mutating requests are recorded.
+  lxc query /1.0/audit | jq --exit-status '.[] | select(.method == "POST" and .url == "/1.0/instances" and .protocol == "unix" and .result == "success" and .status_code == 202 and .operation != null)'
+  lxc query /1.0/audit | jq --exit-status '.[] | select(.method == "PUT" and .url == "/1.0/instances/c1" and .result == "success" and .body.config["user.foo"] == "bar")'
+  lxc query /1.0/audit | jq --exit-status '.[] | select(.method == "DELETE" and .url == "/1.0/instances/c1" and .result == "success")'
+
+  # Check the denied requests are recorded, and the allowed read-only requests are not.
+  lxc query /1.0/audit | jq --exit-status '.[] | select(.method == "GET" and .url == "/1.0/instances" and .result == "denied" and .status_code == 403 and .username == null)'
+  [ "$(lxc query /1.0/audit | jq '[.[] | select(.method == "GET" and .result != "denied")] | length')" = "0" ]
+
+  # Check the entries can be filtered.
+  [ "$(lxc query '/1.0/audit?result=denied' | jq 'length')" = "1" ]
+  [ "$(lxc query '/1.0/audit?limit=2' | jq 'length')" = "2" ]
+  [ "$(lxc query "/1.0/audit?since=$(date --utc --date '+1 hour' +%Y-%m-%dT%H:%M:%SZ)" | jq 'length')" = "0" ]
+  ! lxc query '/1.0/audit?result=unknown' || false
+
+  # Check the entries are chained.
+  lxc query /1.0/audit | jq --exit-status '.[0].previous_hash == "" and .[1].previous_hash == .[0].hash'
+  lxc query /1.0/audit/verify | jq --exit-status '.valid == true and .entries > 3 and .last_hash != ""'
+
+  # Check modifying an entry is detected.
+  lxc config unset audit.enabled
+  cp "${audit_log}" "${TEST_DIR}/audit.log"
+  sed -i '0,/"method":"DELETE"/s//"method":"PATCH"/' "${audit_log}"
+  lxc query /1.0/audit/verify | jq --exit-status '.valid == false and (.error | test("invalid hash"))'
+
+  # Check removing the last entry is detected.
+  sed '$d' "${TEST_DIR}/audit.log" > "${audit_log}"
+  lxc query /1.0/audit/verify | jq --exit-status '.valid == false and (.error | test("missing"))'
+  rm "${TEST_DIR}/audit.log"
+
+  # Cleanup
+  rm -rf "${LXD_DIR}/audit"
+}
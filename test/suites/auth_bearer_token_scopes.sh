@@ -0,0 +1,46 @@
+test_auth_bearer_token_scopes() {
+  lxc project create foo
+  lxc auth group create ci
+  lxc auth group permission add ci project default can_view
+  lxc auth group permission add ci project default can_view_profiles
+  lxc auth group permission add ci project default can_create_profiles
+  lxc auth identity create bearer/ci --group ci
+
+  # Scopes can only be set with valid permissions and subnets.
+  ! lxc auth identity token issue bearer/ci --permission "project default can_fly" || false
+  ! lxc auth identity token issue bearer/ci --permission "project not-found can_view" || false
+  ! lxc auth identity token issue bearer/ci --allowed-cidr 127.0.0.1 || false
+
+  # Scopes can't be set for DevLXD identities.
+  lxc auth identity create devlxd/ci-devlxd
+  ! lxc auth identity token issue devlxd/ci-devlxd --permission "project default can_view" || false
+  lxc auth identity delete devlxd/ci-devlxd
+
+  # An unscoped token carries all permissions of the identity.
+  token="$(lxc auth identity token issue bearer/ci --quiet)"
+  curl -s -k -H "Authorization: Bearer ${token}" "https://${LXD_ADDR}/1.0/projects" | jq --exit-status '.metadata == ["/1.0/projects/default"]'
+  curl -s -k -H "Authorization: Bearer ${token}" -X POST -d '{"name":"p1"}' "https://${LXD_ADDR}/1.0/profiles" | jq --exit-status '.status_code == 200'
+
+  # A scoped token only carries the permissions of the scope.
+  token="$(lxc auth identity token issue bearer/ci --quiet --permission "project default can_view" --permission "project default can_view_profiles")"
+  curl -s -k -H "Authorization: Bearer ${token}" "https://${LXD_ADDR}/1.0/projects" | jq --exit-status '.metadata == ["/1.0/projects/default"]'
+  curl -s -k -H "Authorization: Bearer ${token}" "https://${LXD_ADDR}/1.0/profiles/p1" | jq --exit-status '.status_code == 200'
+  curl -s -k -H "Authorization: Bearer ${token}" -X POST -d '{"name":"p2"}' "https://${LXD_ADDR}/1.0/profiles" | jq --exit-status '.error_code == 403'
+
+  # The scope does not grant permissions that the identity does not have.
+  token="$(lxc auth identity token issue bearer/ci --quiet --permission "project foo can_view")"
+  curl -s -k -H "Authorization: Bearer ${token}" "https://${LXD_ADDR}/1.0/projects/foo" | jq --exit-status '.error_code == 404'
+  curl -s -k -H "Authorization: Bearer ${token}" "https://${LXD_ADDR}/1.0/projects/default" | jq --exit-status '.error_code == 404'
+
+  # Tokens can be restricted to subnets.
+  token="$(lxc auth identity token issue bearer/ci --quiet --allowed-cidr 192.0.2.0/24)"
+  curl -s -k -H "Authorization: Bearer ${token}" "https://${LXD_ADDR}/1.0" | jq --exit-status '.error_code == 403'
+  token="$(lxc auth identity token issue bearer/ci --quiet --allowed-cidr 192.0.2.0/24 --allowed-cidr 127.0.0.0/8)"
+  curl -s -k -H "Authorization: Bearer ${token}" "https://${LXD_ADDR}/1.0" | jq --exit-status '.metadata.auth == "trusted"'
+
+  # Cleanup.
+  lxc auth identity delete bearer/ci
+  lxc auth group delete ci
+  lxc profile delete p1
+  lxc project delete foo
+}
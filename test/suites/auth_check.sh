@@ -0,0 +1,39 @@
+test_auth_check() {
+  lxc auth group create ci
+  lxc auth group permission add ci project default can_view
+  lxc auth identity create bearer/ci --group ci
+
+  # Checks must be for an existing entity, a valid entitlement, and an identity or an entitlement.
+  ! lxc auth check project not-found --identity bearer/ci || false
+  ! lxc auth check project default --identity bearer/ci --entitlement can_fly || false
+  ! lxc auth check project default --identity bearer/not-found || false
+  ! lxc query -X POST -d '{"entity_type":"project","url":"/1.0/projects/default"}' /1.0/auth/check || false
+  ! lxc query -X POST -d '{"entity_type":"instance","url":"/1.0/projects/default","entitlement":"can_view"}' /1.0/auth/check || false
+
+  # Check an entitlement of an identity.
+  [ "$(lxc auth check project default --identity bearer/ci --entitlement can_view --format csv)" = "bearer,ci,can_view,YES" ]
+  [ "$(lxc auth check project default --identity bearer/ci --entitlement can_edit --format csv)" = "bearer,ci,can_edit,NO" ]
+
+  # Check all entitlements of an identity.
+  lxc auth check project default --identity bearer/ci --format csv | grep -xF "bearer,ci,can_view,YES"
+  lxc auth check project default --identity bearer/ci --format csv | grep -xF "bearer,ci,can_delete,NO"
+
+  # List the identities that have an entitlement.
+  lxc auth check project default --entitlement can_view --format csv | grep -xF "bearer,ci,can_view,YES"
+  ! lxc auth check project default --entitlement can_edit --format csv | grep -F "bearer,ci," || false
+
+  # Explain why an identity has an entitlement.
+  lxc query -X POST -d '{"identity":"bearer/ci","entity_type":"project","url":"/1.0/projects/default","entitlement":"can_view"}' /1.0/auth/explain | jq --exit-status '.allowed == true and (.reasons | length) == 1 and .reasons[0].group == "ci" and .reasons[0].permission.entitlement == "can_view"'
+  lxc query -X POST -d '{"identity":"bearer/ci","entity_type":"project","url":"/1.0/projects/default","entitlement":"can_edit"}' /1.0/auth/explain | jq --exit-status '.allowed == false and .reasons == []'
+  identifier="$(lxc query /1.0/auth/identities/bearer/ci | jq -r '.id')"
+  lxc auth check identity "bearer/${identifier}" --identity bearer/ci --entitlement can_view --explain | grep -F "Identities can always view and delete themselves"
+  ! lxc auth check project default --identity bearer/ci --explain || false
+
+  # Permissions granted via other entities are explained.
+  lxc auth group permission add ci server viewer
+  lxc query -X POST -d '{"identity":"bearer/ci","entity_type":"project","url":"/1.0/projects/default","entitlement":"can_view"}' /1.0/auth/explain | jq --exit-status '(.reasons | length) == 2'
+
+  # Cleanup.
+  lxc auth identity delete bearer/ci
+  lxc auth group delete ci
+}
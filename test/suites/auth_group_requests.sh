@@ -0,0 +1,87 @@
+test_auth_group_requests() {
+  # shellcheck disable=2153
+  ensure_has_localhost_remote "${LXD_ADDR}"
+
+  lxc auth group create operators
+  lxc auth group permission add operators project default can_view
+
+  # Add a fine-grained TLS identity without any group.
+  tls_identity_token="$(lxc auth identity create tls/test-user --quiet)"
+  LXD_CONF2=$(mktemp -d -p "${TEST_DIR}" XXX)
+  LXD_CONF="${LXD_CONF2}" gen_cert_and_key "client"
+  LXD_CONF="${LXD_CONF2}" lxc remote add tls "${tls_identity_token}"
+  [ "$(LXD_CONF="${LXD_CONF2}" lxc_remote query tls:/1.0/projects | jq 'length')" = 0 ]
+
+  # Temporary memberships must expire in the future and can only be set for groups of the identity.
+  ! lxc query -X PATCH -d "{\"groups\":[\"operators\"],\"group_expiry\":{\"operators\":\"$(date -u -d '-1 minute' +%Y-%m-%dT%H:%M:%SZ)\"}}" /1.0/auth/identities/tls/test-user || false
+  ! lxc query -X PATCH -d "{\"groups\":[],\"group_expiry\":{\"operators\":\"$(date -u -d '+1 hour' +%Y-%m-%dT%H:%M:%SZ)\"}}" /1.0/auth/identities/tls/test-user || false
+  ! lxc auth identity group add tls/test-user operators --expires -1h || false
+
+  # Add a temporary membership.
+  lxc auth identity group add tls/test-user operators --expires 2h
+  lxc query /1.0/auth/identities/tls/test-user | jq --exit-status '.groups == ["operators"] and (.group_expiry.operators | length > 0)'
+  [ "$(LXD_CONF="${LXD_CONF2}" lxc_remote query tls:/1.0/projects | jq 'length')" = 1 ]
+
+  # Replacing the identity without an expiry keeps the expiry of the remaining groups.
+  expiry="$(lxc query /1.0/auth/identities/tls/test-user | jq -r '.group_expiry.operators')"
+  lxc query -X PUT -d '{"groups":["operators"]}' /1.0/auth/identities/tls/test-user
+  lxc query /1.0/auth/identities/tls/test-user | jq --exit-status --arg expiry "${expiry}" '.groups == ["operators"] and .group_expiry.operators == $expiry'
+
+  # Removing the group removes its expiry.
+  lxc auth identity group remove tls/test-user operators
+  lxc query /1.0/auth/identities/tls/test-user | jq --exit-status '.groups == [] and .group_expiry == null'
+
+  # Memberships are revoked once they expire.
+  lxc query -X PATCH -d "{\"groups\":[\"operators\"],\"group_expiry\":{\"operators\":\"$(date -u -d '+3 seconds' +%Y-%m-%dT%H:%M:%SZ)\"}}" /1.0/auth/identities/tls/test-user
+  [ "$(LXD_CONF="${LXD_CONF2}" lxc_remote query tls:/1.0/projects | jq 'length')" = 1 ]
+  sleep 4
+  [ "$(LXD_CONF="${LXD_CONF2}" lxc_remote query tls:/1.0/projects | jq 'length')" = 0 ]
+  lxc query /1.0/auth/identities/tls/test-user | jq --exit-status '.groups == []'
+
+  # Requests must be for an existing group and a positive duration.
+  ! LXD_CONF="${LXD_CONF2}" lxc auth group-request create tls:not-found 2h || false
+  ! LXD_CONF="${LXD_CONF2}" lxc auth group-request create tls:operators 0s || false
+  ! LXD_CONF="${LXD_CONF2}" lxc auth group-request create tls:operators tomorrow || false
+
+  # Request temporary membership.
+  LXD_CONF="${LXD_CONF2}" lxc auth group-request create tls:operators 2h --reason "Testing"
+  ! LXD_CONF="${LXD_CONF2}" lxc auth group-request create tls:operators 1h || false # Already pending
+  request_id="$(LXD_CONF="${LXD_CONF2}" lxc auth group-request list tls: --format csv --columns i)"
+  LXD_CONF="${LXD_CONF2}" lxc auth group-request show "tls:${request_id}" | grep -xF "reason: Testing"
+  lxc auth group-request list --format csv | grep -F "${request_id},tls,test-user,operators,2h,Testing,"
+
+  # Requests can't be approved by the requesting identity.
+  ! LXD_CONF="${LXD_CONF2}" lxc auth group-request approve "tls:${request_id}" || false
+  [ "$(LXD_CONF="${LXD_CONF2}" lxc_remote query tls:/1.0/projects | jq 'length')" = 0 ]
+
+  # Approving the request grants the membership and deletes the request.
+  lxc auth group-request approve "${request_id}"
+  lxc query /1.0/auth/identities/tls/test-user | jq --exit-status '.groups == ["operators"] and (.group_expiry.operators | length > 0)'
+  [ "$(LXD_CONF="${LXD_CONF2}" lxc_remote query tls:/1.0/projects | jq 'length')" = 1 ]
+  [ "$(lxc auth group-request list --format csv | wc -l)" = 0 ]
+  ! lxc auth group-request show "${request_id}" || false
+
+  # Requests can be cancelled by the requesting identity and rejected by an administrator.
+  lxc auth identity group remove tls/test-user operators
+  LXD_CONF="${LXD_CONF2}" lxc auth group-request create tls:operators 1h
+  request_id="$(lxc auth group-request list --format csv --columns i)"
+  LXD_CONF="${LXD_CONF2}" lxc auth group-request delete "tls:${request_id}"
+  LXD_CONF="${LXD_CONF2}" lxc auth group-request create tls:operators 1h
+  request_id="$(lxc auth group-request list --format csv --columns i)"
+  lxc auth group-request delete "${request_id}"
+  [ "$(lxc auth group-request list --format csv | wc -l)" = 0 ]
+
+  # Permanent members can't request membership.
+  lxc auth identity group add tls/test-user operators
+  ! LXD_CONF="${LXD_CONF2}" lxc auth group-request create tls:operators 1h || false
+
+  # Requests are deleted with the identity.
+  lxc auth identity group remove tls/test-user operators
+  LXD_CONF="${LXD_CONF2}" lxc auth group-request create tls:operators 1h
+  lxc auth identity delete tls/test-user
+  [ "$(lxc auth group-request list --format csv | wc -l)" = 0 ]
+
+  # Cleanup.
+  lxc auth group delete operators
+  rm -rf "${LXD_CONF2}"
+}
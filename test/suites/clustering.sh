@@ -2098,6 +2098,18 @@ test_clustering_metrics() {
   LXD_DIR="${LXD_ONE_DIR}" lxc query "/1.0/metrics" | grep -xF "lxd_warnings_total 2"
   LXD_DIR="${LXD_TWO_DIR}" lxc query "/1.0/metrics" | grep -xF "lxd_warnings_total 1"
 
+  # Check the cluster health metrics.
+  LXD_DIR="${LXD_ONE_DIR}" lxc query "/1.0/metrics" | grep -xF "lxd_cluster_leader 1"
+  LXD_DIR="${LXD_TWO_DIR}" lxc query "/1.0/metrics" | grep -xF "lxd_cluster_leader 0"
+  LXD_DIR="${LXD_ONE_DIR}" lxc query "/1.0/metrics" | grep -xF 'lxd_cluster_raft_role{role="voter"} 1'
+  LXD_DIR="${LXD_ONE_DIR}" lxc query "/1.0/metrics" | grep -xF "lxd_cluster_leader_changes_total 0"
+  LXD_DIR="${LXD_ONE_DIR}" lxc query "/1.0/metrics" | grep -xF 'lxd_cluster_members{status="online"} 2'
+  LXD_DIR="${LXD_TWO_DIR}" lxc query "/1.0/metrics" | grep -xF 'lxd_cluster_members{status="offline"} 0'
+  LXD_DIR="${LXD_ONE_DIR}" lxc query "/1.0/metrics" | grep -E '^lxd_cluster_heartbeat_seconds\{member="node2"\} [0-9.e+-]+$'
+  ! LXD_DIR="${LXD_TWO_DIR}" lxc query "/1.0/metrics" | grep -F "lxd_cluster_heartbeat_seconds" || false
+  LXD_DIR="${LXD_ONE_DIR}" lxc query "/1.0/metrics" | grep -E "^lxd_database_transactions_total [0-9.e+]+$"
+  LXD_DIR="${LXD_ONE_DIR}" lxc query "/1.0/metrics" | grep -E "^lxd_database_transaction_retries_total [0-9.e+]+$"
+
   LXD_DIR="${LXD_ONE_DIR}" lxc delete -f c1 stopped c2
   LXD_DIR="${LXD_ONE_DIR}" lxc image delete testimage
 
@@ -77,7 +77,67 @@ test_console_vm() {
   wait "${CONSOLE_PID}" || true
   ! [ -e "${SPICE_UNIX_SOCKET}" ] || false
 
+  # The VNC console is available for VMs
+  echo "===> Check VNC console address"
+  lxc console --type vnc v1 > "${OUTPUT}" &
+  CONSOLE_PID=$!
+  sleep 0.1
+  grep -F "vnc://127.0.0.1:" < "${OUTPUT}"
+
+  VNC_PORT="$(sed -n '/vnc:/ s|^\s\+vnc://127.0.0.1:|| p' < "${OUTPUT}")"
+
+  echo "===> Test VNC server handshake"
+  # The server sends its RFB protocol version on connection
+  # This will cause the `lxc console --type vnc` command to exit once disconnected
+  nc -w 2 127.0.0.1 "${VNC_PORT}" < /dev/null | grep -F "RFB 003."
+
+  echo "===> Verify console command has exited"
+  wait "${CONSOLE_PID}" || true
+
+  # The VNC console isn't available for containers
+  lxc init --empty c1
+  ! lxc console --type vnc c1 || false
+  lxc delete c1
+
   # Cleanup
   lxc delete --force v1
   rm "${OUTPUT}"
 }
+
+test_console_recording() {
+  ensure_import_testimage
+
+  # Invalid settings are rejected
+  ! lxc project set default sessions.recording.expiry=invalid || false
+  ! lxc project set default sessions.recording.volume=invalid || false
+
+  lxc launch testimage c1
+  lxc project set default sessions.recording=true
+  RECORDINGS_DIR="${LXD_DIR}/logs/c1/recordings"
+
+  echo "==> Non-interactive sessions aren't recorded"
+  lxc exec c1 -- true
+  ! [ -d "${RECORDINGS_DIR}" ] || false
+
+  echo "==> Interactive sessions are recorded"
+  lxc exec c1 --force-interactive -- echo recorded-output
+  RECORDING="$(ls "${RECORDINGS_DIR}"/*-exec.cast)"
+  head -n1 "${RECORDING}" | jq --exit-status '.version == 2 and .command == "echo recorded-output"'
+  grep -F 'recorded-output' "${RECORDING}"
+
+  echo "==> Expired recordings are removed"
+  touch -d "2 days ago" "${RECORDING}"
+  lxc project set default sessions.recording.expiry=1d
+  lxc exec c1 --force-interactive -- true
+  ! [ -e "${RECORDING}" ] || false
+  [ "$(find "${RECORDINGS_DIR}" -name '*.cast' | wc -l)" = "1" ]
+
+  echo "==> Sessions aren't recorded once disabled"
+  lxc project unset default sessions.recording
+  lxc exec c1 --force-interactive -- true
+  [ "$(find "${RECORDINGS_DIR}" -name '*.cast' | wc -l)" = "1" ]
+
+  # Cleanup
+  lxc project unset default sessions.recording.expiry
+  lxc delete --force c1
+}
@@ -0,0 +1,76 @@
+test_container_autorestart() {
+  ensure_import_testimage
+
+  lxc launch testimage c1 -c boot.autorestart=true -c boot.autorestart.delay=1 -c boot.autorestart.max_retries=2
+  waitInstanceReady c1
+  [ "$(lxc query /1.0/instances/c1/state | jq '.auto_restarts')" = "0" ]
+
+  echo "==> Check the container is restarted after its init process is killed."
+  kill -9 "$(lxc query /1.0/instances/c1/state | jq '.pid')"
+  for _ in $(seq 30); do
+    [ "$(lxc query /1.0/instances/c1/state | jq '.auto_restarts')" = "1" ] && [ "$(lxc list -f csv -c s c1)" = "RUNNING" ] && break
+    sleep 1
+  done
+
+  [ "$(lxc list -f csv -c s c1)" = "RUNNING" ]
+  [ "$(lxc config get c1 volatile.autorestart.count)" = "1" ]
+  waitInstanceReady c1
+
+  echo "==> Check the container is left stopped once the maximum number of retries is reached."
+  kill -9 "$(lxc query /1.0/instances/c1/state | jq '.pid')"
+  for _ in $(seq 30); do
+    [ "$(lxc config get c1 volatile.autorestart.count)" = "2" ] && [ "$(lxc list -f csv -c s c1)" = "RUNNING" ] && break
+    sleep 1
+  done
+
+  waitInstanceReady c1
+  kill -9 "$(lxc query /1.0/instances/c1/state | jq '.pid')"
+  sleep 5
+  [ "$(lxc list -f csv -c s c1)" = "STOPPED" ]
+
+  echo "==> Check a stop requested through LXD doesn't trigger a restart."
+  lxc config unset c1 volatile.autorestart.count
+  lxc start c1
+  lxc stop -f c1
+  sleep 3
+  [ "$(lxc list -f csv -c s c1)" = "STOPPED" ]
+
+  echo "==> Check a start and stop requested through LXD during the restart delay cancel the pending restart."
+  lxc config unset c1 volatile.autorestart.count
+  lxc config set c1 boot.autorestart.delay=5
+  lxc start c1
+  waitInstanceReady c1
+  kill -9 "$(lxc query /1.0/instances/c1/state | jq '.pid')"
+  for _ in $(seq 30); do
+    [ "$(lxc config get c1 volatile.autorestart.pending)" = "true" ] && [ "$(lxc list -f csv -c s c1)" = "STOPPED" ] && break
+    sleep 1
+  done
+
+  lxc start c1
+  [ "$(lxc config get c1 volatile.autorestart.pending)" = "" ]
+  lxc stop -f c1
+  sleep 7
+  [ "$(lxc list -f csv -c s c1)" = "STOPPED" ]
+
+  echo "==> Check a pending restart is resumed after LXD is restarted."
+  lxc config unset c1 volatile.autorestart.count
+  lxc start c1
+  waitInstanceReady c1
+  kill -9 "$(lxc query /1.0/instances/c1/state | jq '.pid')"
+  for _ in $(seq 30); do
+    [ "$(lxc config get c1 volatile.autorestart.pending)" = "true" ] && [ "$(lxc list -f csv -c s c1)" = "STOPPED" ] && break
+    sleep 1
+  done
+
+  shutdown_lxd "${LXD_DIR}"
+  respawn_lxd "${LXD_DIR}" true
+  for _ in $(seq 30); do
+    [ "$(lxc list -f csv -c s c1)" = "RUNNING" ] && break
+    sleep 1
+  done
+
+  [ "$(lxc list -f csv -c s c1)" = "RUNNING" ]
+  [ "$(lxc config get c1 volatile.autorestart.pending)" = "" ]
+
+  lxc delete -f c1
+}
@@ -0,0 +1,34 @@
+test_container_boot_schedule() {
+  ensure_import_testimage
+
+  echo "==> Check schedules are validated."
+  lxc init testimage c1
+  ! lxc config set c1 boot.schedule.start="invalid" || false
+  ! lxc config set c1 boot.schedule.stop="@startup" || false
+  lxc config set c1 boot.schedule.stop="0 20 * * 1-5, 0 22 * * 0,6"
+  lxc config unset c1 boot.schedule.stop
+
+  echo "==> Check the container is started on schedule through a profile."
+  lxc profile create schedule
+  lxc profile set schedule boot.schedule.start="* * * * *"
+  lxc profile add c1 schedule
+  for _ in $(seq 130); do
+    [ "$(lxc list -f csv -c s c1)" = "RUNNING" ] && break
+    sleep 1
+  done
+
+  [ "$(lxc list -f csv -c s c1)" = "RUNNING" ]
+
+  echo "==> Check the container is stopped on schedule."
+  lxc profile unset schedule boot.schedule.start
+  lxc config set c1 boot.schedule.stop="* * * * *" boot.host_shutdown_timeout=5
+  for _ in $(seq 130); do
+    [ "$(lxc list -f csv -c s c1)" = "STOPPED" ] && break
+    sleep 1
+  done
+
+  [ "$(lxc list -f csv -c s c1)" = "STOPPED" ]
+
+  lxc delete c1
+  lxc profile delete schedule
+}
@@ -0,0 +1,36 @@
+test_container_bulk_create() {
+  ensure_import_testimage
+
+  echo "==> Check the request is validated."
+  ! lxc init testimage c --count -1 || false
+  ! lxc init testimage c --count 101 || false
+  ! lxc query --request POST /1.0/instances --data '{"name": "c-{n}", "count": 2, "source": {"type": "migration"}}' || false
+
+  echo "==> Create instances from an image using a name pattern."
+  lxc init testimage "c-{n}" --count 3
+  [ "$(lxc list -f csv -c n 'c-' | sort | xargs)" = "c-1 c-2 c-3" ]
+
+  echo "==> Check existing names are rejected."
+  ! lxc init testimage "c-{n}" --count 2 || false
+  [ "$(lxc list -f csv -c n 'c-' | wc -l)" = "3" ]
+
+  echo "==> Create and start instances from a snapshot, appending the index to the name."
+  lxc snapshot c-1 snap0
+  lxc query --request POST /1.0/instances --data '{"name": "copy", "count": 2, "start": true, "source": {"type": "copy", "source": "c-1/snap0"}}'
+  [ "$(lxc list -f csv -c ns 'copy-' | sort | xargs)" = "copy-1,RUNNING copy-2,RUNNING" ]
+
+  echo "==> Create and start instances with generated names."
+  lxc launch testimage --count 2 --config user.bulk=true
+  [ "$(lxc list -f csv -c n user.bulk=true | wc -l)" = "2" ]
+
+  echo "==> Check project limits account for all the instances before any is created."
+  lxc project create bulk -c features.images=false -c features.profiles=false -c limits.containers=2
+  ! lxc init testimage "l-{n}" --count 3 --project bulk || false
+  [ "$(lxc list -f csv -c n --project bulk | wc -l)" = "0" ]
+  lxc init testimage "l-{n}" --count 2 --project bulk
+  lxc delete l-1 l-2 --project bulk
+  lxc project delete bulk
+
+  # Cleanup
+  lxc list -f csv -c n | xargs lxc delete -f
+}
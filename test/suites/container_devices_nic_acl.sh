@@ -0,0 +1,107 @@
+test_container_devices_nic_acl() {
+  ensure_import_testimage
+
+  firewallDriver=$(lxc info | awk -F ":" '/firewall:/{gsub(/ /, "", $0); print $2}')
+
+  if [ "$firewallDriver" != "xtables" ] && [ "$firewallDriver" != "nftables" ]; then
+    echo "Unrecognised firewall driver: ${firewallDriver}"
+    false
+  fi
+
+  ctName="nt$$"
+
+  lxc network acl create "${ctName}A"
+  lxc init testimage "${ctName}"
+
+  echo "==> Check that non-existent ACLs are rejected."
+  ! lxc config device add "${ctName}" eth0 nic nictype=routed ipv4.address=192.0.2.2 security.acls=invalid || false
+
+  echo "==> Check that ACLs are rejected on sriov NICs."
+  ! lxc config device add "${ctName}" eth0 nic nictype=sriov parent=invalid security.acls="${ctName}A" || false
+
+  echo "==> Check routed NIC ACL rules are applied on the host side interface."
+  lxc config device add "${ctName}" eth0 nic nictype=routed name=eth0 ipv4.address=192.0.2.2 security.acls="${ctName}A"
+  lxc start "${ctName}"
+  hostName=$(lxc config get "${ctName}" volatile.eth0.host_name)
+
+  if [ "$firewallDriver" = "xtables" ]; then
+      [ "$(iptables -S | grep -cF "\-j lxd_acl_${hostName}")" = "4" ]
+      [ "$(iptables -S "lxd_acl_${hostName}" | grep -cF "\-j REJECT")" = "2" ]
+  else
+      [ "$(nft -nn list chain inet lxd "aclfwd.${hostName}" | grep -cF "jump acl.${hostName}")" = "2" ]
+      [ "$(nft -nn list chain inet lxd "acl.${hostName}" | grep -cF "reject")" = "2" ]
+  fi
+
+  echo "==> Check ACL changes are applied to the running routed NIC."
+  lxc network acl rule add "${ctName}A" ingress action=allow protocol=tcp destination_port=22
+  if [ "$firewallDriver" = "xtables" ]; then
+      [ "$(iptables -S "lxd_acl_${hostName}" | grep -cF "\-j ACCEPT")" = "2" ]
+  else
+      nft -nn list chain inet lxd "acl.${hostName}" | grep -F "tcp dport 22 accept"
+  fi
+
+  echo "==> Check ACL rules are tagged for hit counters."
+  aclID="$(lxd sql global --format csv "SELECT id FROM networks_acls WHERE name = '${ctName}A'")"
+  if [ "$firewallDriver" = "xtables" ]; then
+      iptables -S "lxd_acl_${hostName}" | grep -F -- "--comment lxd_acl${aclID}-ingress-0"
+  else
+      nft -nn list chain inet lxd "acl.${hostName}" | grep -F "counter packets"
+      nft -nn list chain inet lxd "acl.${hostName}" | grep -F "comment \"lxd_acl${aclID}-ingress-0\""
+  fi
+
+  echo "==> Check the ACL state reports counters for each rule."
+  [ "$(lxc query "/1.0/network-acls/${ctName}A/state" | jq '.ingress | length')" = "1" ]
+  [ "$(lxc query "/1.0/network-acls/${ctName}A/state" | jq '.egress | length')" = "0" ]
+  lxc network acl info "${ctName}A" | grep -F "Rule 0 (allow, enabled): "
+
+  echo "==> Check the default action can be changed live."
+  lxc config device set "${ctName}" eth0 security.acls.default.ingress.action=drop
+  if [ "$firewallDriver" = "xtables" ]; then
+      [ "$(iptables -S "lxd_acl_${hostName}" | grep -cF "\-j REJECT")" = "1" ]
+  else
+      [ "$(nft -nn list chain inet lxd "acl.${hostName}" | grep -cF "reject")" = "1" ]
+  fi
+
+  echo "==> Check the ACL cannot be deleted while in use."
+  ! lxc network acl delete "${ctName}A" || false
+
+  echo "==> Check ACL rules are removed when the instance stops."
+  lxc stop -f "${ctName}"
+  if [ "$firewallDriver" = "xtables" ]; then
+      ! iptables -S | grep "\-j lxd_acl_${hostName}" || false
+      ! iptables -S "lxd_acl_${hostName}" || false
+  else
+      ! nft -nn list chain inet lxd "aclfwd.${hostName}" || false
+      ! nft -nn list chain inet lxd "acl.${hostName}" || false
+  fi
+
+  lxc config device remove "${ctName}" eth0
+
+  echo "==> Check macvlan NIC ACL rules are applied on the parent interface."
+  ip link add "${ctName}" type dummy
+  ip link set "${ctName}" up
+
+  if [ "$firewallDriver" = "xtables" ]; then
+      ! lxc config device add "${ctName}" eth0 nic nictype=macvlan name=eth0 parent="${ctName}" security.acls="${ctName}A" || false
+  else
+      lxc config device add "${ctName}" eth0 nic nictype=macvlan name=eth0 parent="${ctName}" hwaddr=00:16:3e:00:00:01 security.acls="${ctName}A"
+      lxc start "${ctName}"
+      chainLabel="${ctName}.eth0"
+
+      nft -nn list chain netdev lxd "ingress.acl.${chainLabel}" | grep -F "ether daddr 00:16:3e:00:00:01 jump aclin.${chainLabel}"
+      nft -nn list chain netdev lxd "egress.acl.${chainLabel}" | grep -F "ether saddr 00:16:3e:00:00:01 jump aclout.${chainLabel}"
+      nft -nn list chain netdev lxd "aclin.${chainLabel}" | grep -F "tcp dport 22 accept"
+
+      # Reject actions are turned into drop actions as there is no connection tracking on the parent.
+      ! nft -nn list chain netdev lxd "aclin.${chainLabel}" | grep -F "reject" || false
+      nft -nn list chain netdev lxd "aclout.${chainLabel}" | grep -F "drop"
+
+      lxc stop -f "${ctName}"
+      ! nft -nn list chain netdev lxd "ingress.acl.${chainLabel}" || false
+      ! nft -nn list chain netdev lxd "aclin.${chainLabel}" || false
+  fi
+
+  lxc delete -f "${ctName}"
+  ip link delete "${ctName}"
+  lxc network acl delete "${ctName}A"
+}
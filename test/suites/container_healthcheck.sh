@@ -0,0 +1,51 @@
+test_container_healthcheck() {
+  ensure_import_testimage
+
+  echo "==> Check the configuration is validated."
+  ! lxc launch testimage c1 -c healthcheck.type=exec || false
+  ! lxc launch testimage c1 -c healthcheck.type=tcp || false
+  ! lxc launch testimage c1 -c healthcheck.type=invalid -c healthcheck.command=true || false
+
+  lxc launch testimage c1 -c healthcheck.type=exec -c healthcheck.command="test -e /tmp/healthy" -c healthcheck.interval=1 -c healthcheck.retries=2
+  waitInstanceReady c1
+
+  echo "==> Check the container becomes unhealthy when the check keeps failing."
+  for _ in $(seq 30); do
+    [ "$(lxc query /1.0/instances/c1/state | jq -r '.health.status')" = "unhealthy" ] && break
+    sleep 1
+  done
+
+  [ "$(lxc query /1.0/instances/c1/state | jq -r '.health.status')" = "unhealthy" ]
+  [ "$(lxc query /1.0/instances/c1/state | jq '.health.failures')" -ge 2 ]
+
+  echo "==> Check the container becomes healthy once the check succeeds."
+  lxc exec c1 -- touch /tmp/healthy
+  for _ in $(seq 30); do
+    [ "$(lxc query /1.0/instances/c1/state | jq -r '.health.status')" = "healthy" ] && break
+    sleep 1
+  done
+
+  [ "$(lxc query /1.0/instances/c1/state | jq -r '.health.status')" = "healthy" ]
+  [ "$(lxc query /1.0/instances/c1/state | jq '.health.failures')" = "0" ]
+  lxc info c1 | grep -xF "Health: healthy"
+  lxc query "/1.0/metrics" | grep -F 'lxd_instance_healthy{name="c1",project="default",type="container"} 1'
+
+  echo "==> Check the unhealthy container is restarted when requested."
+  pid="$(lxc query /1.0/instances/c1/state | jq '.pid')"
+  lxc config set c1 healthcheck.action=restart
+  lxc exec c1 -- rm /tmp/healthy
+  for _ in $(seq 60); do
+    newPid="$(lxc query /1.0/instances/c1/state | jq '.pid')"
+    [ "${newPid}" != "${pid}" ] && [ "${newPid}" != "-1" ] && break
+    sleep 1
+  done
+
+  [ "$(lxc query /1.0/instances/c1/state | jq '.pid')" != "${pid}" ]
+  [ "$(lxc list -f csv -c s c1)" = "RUNNING" ]
+
+  echo "==> Check the health is only reported when a health check is configured."
+  lxc config unset c1 healthcheck.type
+  [ "$(lxc query /1.0/instances/c1/state | jq -r '.health')" = "null" ]
+
+  lxc delete -f c1
+}
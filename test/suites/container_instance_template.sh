@@ -0,0 +1,55 @@
+test_container_instance_template() {
+  ensure_import_testimage
+
+  echo "==> Check the template is validated."
+  ! lxc instance-template create t1 testimage -c invalid.key=foo || false
+  ! lxc instance-template create t1 testimage -t invalid-type || false
+  ! lxc instance-template show t1 || false
+
+  echo "==> Create a template and check its configuration."
+  lxc instance-template create t1 testimage -c user.foo=bar -c limits.memory=128MiB --description "Test template"
+  lxc instance-template list | grep -wF t1
+  [ "$(lxc query /1.0/instance-templates/t1 | jq -r '.source.type')" = "image" ]
+  [ "$(lxc query /1.0/instance-templates/t1 | jq -r '.config["user.foo"]')" = "bar" ]
+  [ "$(lxc query /1.0/instance-templates/t1 | jq -r '.description')" = "Test template" ]
+  ! lxc instance-template create t1 testimage || false
+
+  echo "==> Create instances from the template."
+  lxc init --template t1 c1
+  [ "$(lxc config get c1 user.foo)" = "bar" ]
+  [ "$(lxc config get c1 limits.memory)" = "128MiB" ]
+  [ "$(lxc query /1.0/instances/c1 | jq -r '.type')" = "container" ]
+
+  echo "==> Check that request options override the template."
+  lxc init --template t1 c2 -c user.foo=baz
+  [ "$(lxc config get c2 user.foo)" = "baz" ]
+  [ "$(lxc config get c2 limits.memory)" = "128MiB" ]
+
+  echo "==> Check that the template is reported as used by the project."
+  lxc query /1.0/projects/default | jq --exit-status '.used_by | index("/1.0/instance-templates/t1")'
+
+  echo "==> Update the template."
+  lxc instance-template show t1 | sed 's/user.foo: bar/user.foo: qux/' | lxc instance-template edit t1
+  [ "$(lxc query /1.0/instance-templates/t1 | jq -r '.config["user.foo"]')" = "qux" ]
+  lxc query --request PATCH /1.0/instance-templates/t1 --data '{"description": "Patched"}'
+  [ "$(lxc query /1.0/instance-templates/t1 | jq -r '.description')" = "Patched" ]
+  [ "$(lxc query /1.0/instance-templates/t1 | jq -r '.config["user.foo"]')" = "qux" ]
+
+  echo "==> Rename the template."
+  lxc instance-template rename t1 t2
+  ! lxc instance-template show t1 || false
+  lxc launch --template t2 c3
+  [ "$(lxc config get c3 user.foo)" = "qux" ]
+  ! lxc init --template t1 c4 || false
+
+  echo "==> Check templates are project specific."
+  lxc project create foo -c features.images=false -c features.profiles=false
+  ! lxc init --template t2 c1 --project foo || false
+  lxc instance-template list --all-projects | grep -wF default
+  lxc project delete foo
+
+  # Cleanup
+  lxc delete -f c1 c2 c3
+  lxc instance-template delete t2
+  ! lxc instance-template show t2 || false
+}
@@ -0,0 +1,89 @@
+test_ldap() {
+  # shellcheck disable=2153
+  ensure_has_localhost_remote "${LXD_ADDR}"
+
+  spawn_ldap
+  ldap_url="ldap://127.0.0.1:$(< "${TEST_DIR}/ldap.port")"
+
+  # LDAP login isn't available until configured.
+  curl -s -k -X POST "https://${LXD_ADDR}/1.0/auth/ldap/login" -d '{"username":"alice","password":"secret"}' | jq --exit-status '.error_code == 400'
+  ! lxc query /1.0 | jq --exit-status '.auth_methods | index("ldap")' || false
+
+  # Check configuration validation.
+  ! lxc config set ldap.url "http://127.0.0.1" || false # Wrong scheme
+  ! lxc config set ldap.url "${ldap_url}" || false # Missing user base DN
+  ! lxc config set ldap.user.filter "(uid=alice)" || false # Missing placeholder
+  ! lxc config set ldap.session.expiry "10M" || false # Less than an hour
+  ! lxc config set ldap.session.expiry=1d core.auth_secret_expiry=1H || false # Greater than the auth secret expiry
+
+  lxc config set ldap.url="${ldap_url}" ldap.user.base_dn="ou=users,dc=lxd,dc=test" ldap.bind.dn="cn=search,dc=lxd,dc=test" ldap.bind.password="search" ldap.groups.base_dn="ou=groups,dc=lxd,dc=test"
+  lxc query /1.0 | jq --exit-status '.auth_methods | index("ldap")'
+
+  set_ldap_user alice secret admins,operators
+  set_ldap_user bob hunter2
+
+  # Invalid credentials are rejected without creating an identity.
+  curl -s -k -X POST "https://${LXD_ADDR}/1.0/auth/ldap/login" -d '{"username":"alice","password":"wrong"}' | jq --exit-status '.error_code == 403'
+  curl -s -k -X POST "https://${LXD_ADDR}/1.0/auth/ldap/login" -d '{"username":"carol","password":"secret"}' | jq --exit-status '.error_code == 403'
+  curl -s -k -X POST "https://${LXD_ADDR}/1.0/auth/ldap/login" -d '{"username":"alice","password":""}' | jq --exit-status '.error_code == 403'
+  [ "$(lxc auth identity list --format csv | grep -cF ldap)" = 0 ]
+
+  # Log in and check the session token is accepted as a bearer token.
+  token="$(curl -s -k -X POST "https://${LXD_ADDR}/1.0/auth/ldap/login" -d '{"username":"Alice","password":"secret"}' | jq -r '.metadata.token')"
+  curl -s -k -H "Authorization: Bearer ${token}" "https://${LXD_ADDR}/1.0" | jq --exit-status '.metadata.auth == "trusted"'
+  curl -s -k -H "Authorization: Bearer ${token}" "https://${LXD_ADDR}/1.0" | jq --exit-status '.metadata.auth_user_method == "ldap"'
+  curl -s -k -H "Authorization: Bearer ${token}" "https://${LXD_ADDR}/1.0" | jq --exit-status '.metadata.auth_user_name == "alice"'
+
+  # The identity is created with the directory details.
+  lxc query /1.0/auth/identities/ldap/alice | jq --exit-status '.type == "LDAP user" and .name == "User alice"'
+
+  # The session token is also accepted as a cookie, and cleared on logout.
+  curl -s -k --cookie "ldap_session=${token}" "https://${LXD_ADDR}/1.0" | jq --exit-status '.metadata.auth == "trusted"'
+  curl -s -k -i -X POST "https://${LXD_ADDR}/1.0/auth/ldap/logout" | grep -i '^set-cookie: ldap_session=;'
+
+  # A tampered token is rejected.
+  curl -s -k -H "Authorization: Bearer ${token}x" "https://${LXD_ADDR}/1.0" | jq --exit-status '.error_code == 403'
+
+  # Without any permissions, the identity can't see any projects.
+  [ "$(curl -s -k -H "Authorization: Bearer ${token}" "https://${LXD_ADDR}/1.0/projects" | jq '.metadata | length')" = 0 ]
+
+  # Map an LDAP group to an authorization group.
+  lxc auth group create ldap-viewers
+  lxc auth group permission add ldap-viewers project default can_view
+  lxc auth identity-provider-group create operators
+  lxc auth identity-provider-group group add operators ldap-viewers
+
+  curl -s -k -H "Authorization: Bearer ${token}" "https://${LXD_ADDR}/1.0/auth/identities/current" | jq --exit-status '.metadata.effective_groups == ["ldap-viewers"]'
+  [ "$(curl -s -k -H "Authorization: Bearer ${token}" "https://${LXD_ADDR}/1.0/projects" | jq '.metadata | length')" = 1 ]
+
+  # Group membership changes in the directory are applied on the next login.
+  sed -i 's/^alice secret admins,operators$/alice secret admins/' "${TEST_DIR}/ldap.users"
+  token="$(curl -s -k -X POST "https://${LXD_ADDR}/1.0/auth/ldap/login" -d '{"username":"alice","password":"secret"}' | jq -r '.metadata.token')"
+  [ "$(curl -s -k -H "Authorization: Bearer ${token}" "https://${LXD_ADDR}/1.0/projects" | jq '.metadata | length')" = 0 ]
+
+  # Log in with the CLI.
+  printf 'bob\nhunter2\n' | lxc remote add ldap "${LXD_ADDR}" --accept-certificate --auth-type ldap
+  lxc query ldap:/1.0 | jq --exit-status '.auth == "trusted"'
+  lxc query ldap:/1.0 | jq --exit-status '.auth_user_name == "bob"'
+  lxc remote remove ldap
+  [ ! -e "${LXD_CONF}/ldaptokens/ldap.token" ]
+
+  # Deleting the identity revokes the session.
+  lxc auth identity delete ldap/alice
+  curl -s -k -H "Authorization: Bearer ${token}" "https://${LXD_ADDR}/1.0/projects" | jq --exit-status '.type == "error"'
+
+  # Disabling LDAP rejects session tokens.
+  lxc auth identity delete ldap/bob
+  lxc config set ldap.url=""
+  ! lxc query /1.0 | jq --exit-status '.auth_methods | index("ldap")' || false
+  curl -s -k -X POST "https://${LXD_ADDR}/1.0/auth/ldap/login" -d '{"username":"alice","password":"secret"}' | jq --exit-status '.error_code == 400'
+
+  # Cleanup.
+  lxc auth identity-provider-group delete operators
+  lxc auth group delete ldap-viewers
+  lxc config unset ldap.user.base_dn
+  lxc config unset ldap.bind.dn
+  lxc config unset ldap.bind.password
+  lxc config unset ldap.groups.base_dn
+  kill_ldap
+}
@@ -0,0 +1,52 @@
+test_logs_export() {
+  local logs_file="${TEST_DIR}/otlp.logs"
+  local syslog_file="${TEST_DIR}/syslog.logs"
+  spawn_otlp
+
+  # Start a syslog server writing the received messages to a file.
+  socat -u TCP-LISTEN:6514,bind=127.0.0.1,reuseaddr,fork OPEN:"${syslog_file}",creat,append &
+  local syslog_pid=$!
+  sleep 0.1
+
+  # Check the configuration is validated.
+  ! lxc config set logs.otlp.endpoint="127.0.0.1:4318" || false
+  ! lxc config set logs.otlp.types="lifecycle,unknown" || false
+  ! lxc config set logs.syslog.address="udp://127.0.0.1:514" || false
+  ! lxc config set logs.syslog.address="tcp://127.0.0.1" || false
+  ! lxc config set logs.syslog.loglevel="verbose" || false
+
+  # Both exporters run at the same time.
+  lxc config set logs.otlp.endpoint="http://127.0.0.1:4318" logs.otlp.labels="requester-username" logs.otlp.types="lifecycle,logging"
+  lxc config set logs.syslog.address="tcp://127.0.0.1:6514" logs.syslog.instance="cluster1" logs.syslog.types="lifecycle"
+
+  lxc init --empty c1
+  lxc delete c1
+
+  # Unsetting the endpoint sends the pending log records to the collector.
+  lxc config unset logs.otlp.endpoint
+  lxc config unset logs.syslog.address
+
+  # Check the expected lifecycle events were sent to the OTLP collector.
+  jq --exit-status 'select(.service."service.name" == "lxd")' "${logs_file}"
+  jq --exit-status 'select(.attributes.name == "c1" and .attributes.action == "instance-created" and .body == "instance-created")' "${logs_file}"
+  jq --exit-status 'select(.attributes.name == "c1" and .attributes.action == "instance-deleted" and .severity == "INFO")' "${logs_file}"
+  jq --exit-status 'select(.attributes.type == "lifecycle" and .attributes."requester-username" != null)' "${logs_file}"
+
+  # Check the expected lifecycle events were sent to the syslog server.
+  grep -F ' lxd ' "${syslog_file}" | grep -F ' lifecycle - ' | grep -F 'instance="cluster1"' | grep -F 'name="c1"' | grep -qF 'action="instance-created"'
+  grep -qF 'action="instance-deleted"' "${syslog_file}"
+  ! grep -F ' logging - ' "${syslog_file}" || false
+
+  # Check no log records are sent once the exporters are removed.
+  local records
+  records="$(wc -l < "${logs_file}")"
+  lxc init --empty c2
+  lxc delete c2
+  [ "$(wc -l < "${logs_file}")" = "${records}" ]
+  ! grep -qF 'name="c2"' "${syslog_file}" || false
+
+  # Cleanup
+  kill "${syslog_pid}"
+  kill_otlp
+  rm "${logs_file}" "${syslog_file}" "${TEST_DIR}/otlp.spans"
+}
@@ -164,6 +164,12 @@ test_metrics() {
   ! lxc init testimage failed-container -s broken || false  # Error when creating a container on broken.
   [ "$(curl -k -s -X GET "https://${metrics_addr}/1.0/metrics" | awk '/^lxd_api_requests_completed_total{entity_type="instance",result="error_server"}/ {print $2}')" -eq $((previous+1)) ]
 
+  echo "==> Database, event listener and operation metrics should be included"
+  curl -k -s -X GET "https://${metrics_addr}/1.0/metrics" | grep -E "^lxd_database_transactions_total [0-9.e+]+$"
+  curl -k -s -X GET "https://${metrics_addr}/1.0/metrics" | grep -E "^lxd_database_transaction_seconds_total [0-9.e+-]+$"
+  curl -k -s -X GET "https://${metrics_addr}/1.0/metrics" | grep -E "^lxd_event_listeners [0-9]+$"
+  ! curl -k -s -X GET "https://${metrics_addr}/1.0/metrics" | grep -F "lxd_cluster_leader" || false
+
   echo "==> Test lxd_api_requests_ongoing increment and decrement"
   previous="$(curl -k -s -X GET "https://${metrics_addr}/1.0/metrics" | awk '/^lxd_api_requests_ongoing{entity_type="instance"}/ {print $2}')"
   lxc exec c1 -- sleep 0.5 &
@@ -172,6 +178,24 @@ test_metrics() {
   wait $!
   [ "$(curl -k -s -X GET "https://${metrics_addr}/1.0/metrics" | awk '/^lxd_api_requests_ongoing{entity_type="instance"}/ {print $2}')" -eq "$previous" ]
 
+  echo "==> Storage pool, custom volume and project limit metrics"
+  pool="$(lxc profile device get default root pool)"
+  lxc project create foo3 -c features.images=false -c features.profiles=false -c limits.instances=5 -c limits.disk=1GiB
+  lxc storage volume create "${pool}" vol1 size=32MiB --project foo3
+  lxc storage volume snapshot "${pool}" vol1 --project foo3
+  lxc storage volume snapshot "${pool}" vol1 --project foo3
+  curl -k -s -X GET "https://${metrics_addr}/1.0/metrics" | grep -E "^lxd_storage_pool_size_bytes\{driver=\"[a-z]+\",pool=\"${pool}\"\} [0-9]+$"
+  curl -k -s -X GET "https://${metrics_addr}/1.0/metrics" | grep -E "^lxd_storage_pool_used_bytes\{driver=\"[a-z]+\",pool=\"${pool}\"\} [0-9]+$"
+  curl -k -s -X GET "https://${metrics_addr}/1.0/metrics" | grep -E "^lxd_storage_pool_allocated_bytes\{driver=\"[a-z]+\",pool=\"${pool}\"\} [0-9]+$"
+  curl -k -s -X GET "https://${metrics_addr}/1.0/metrics?project=foo3" | grep -xF "lxd_storage_volume_snapshots{name=\"vol1\",pool=\"${pool}\",project=\"foo3\"} 2"
+  curl -k -s -X GET "https://${metrics_addr}/1.0/metrics?project=foo3" | grep -E "^lxd_storage_volume_used_bytes\{name=\"vol1\",pool=\"${pool}\",project=\"foo3\"\} [0-9]+$"
+  curl -k -s -X GET "https://${metrics_addr}/1.0/metrics?project=foo3" | grep -xF 'lxd_project_limit{project="foo3",resource="instances"} 5'
+  curl -k -s -X GET "https://${metrics_addr}/1.0/metrics?project=foo3" | grep -xF 'lxd_project_usage{project="foo3",resource="instances"} 0'
+  curl -k -s -X GET "https://${metrics_addr}/1.0/metrics?project=foo3" | grep -xF 'lxd_project_limit{project="foo3",resource="disk"} 1.073741824e+09'
+  ! curl -k -s -X GET "https://${metrics_addr}/1.0/metrics?project=foo3" | grep -F 'lxd_project_limit{project="foo3",resource="cpu"}' || false
+  lxc storage volume delete "${pool}" vol1 --project foo3
+  lxc project delete foo3
+
   if [ "${LXD_VM_TESTS}" = "1" ]; then
     lxc delete -f v1
     lxc delete v2
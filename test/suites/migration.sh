@@ -793,7 +793,7 @@ migration() {
   lxc storage volume delete l2:"${remote_pool}" iso2
   rm -f foo.iso
 
-  echo "==> Test container live migration (not supported)"
+  echo "==> Test container live migration"
   lxc_remote launch testimage l1:migratee -c raw.lxc=lxc.console.path=none
 
   # Stateful stop is not supported for containers.
@@ -808,8 +808,21 @@ migration() {
   # Check container isn't frozen.
   lxc_remote exec l1:migratee -- ls
 
-  # Live migration is not supported for containers.
-  ! lxc_remote move l1:migratee l2:migratee || false
+  if [ "$(lxc_remote query l1:/1.0 | jq -r '.environment.lxc_features.criu')" = "true" ] && [ "$(lxc_remote query l2:/1.0 | jq -r '.environment.lxc_features.criu')" = "true" ]; then
+    # Live migration with memory pre-copy.
+    lxc_remote config set l1:migratee migration.incremental.memory=true
+    lxc_remote move l1:migratee l2:migratee
+    [ "$(lxc_remote list l2:migratee -c s -f csv)" = "RUNNING" ]
+    lxc_remote exec l2:migratee -- ls
+
+    # And back without pre-copy.
+    lxc_remote config unset l2:migratee migration.incremental.memory
+    lxc_remote move l2:migratee l1:migratee
+    [ "$(lxc_remote list l1:migratee -c s -f csv)" = "RUNNING" ]
+  else
+    # Live migration requires CRIU.
+    ! lxc_remote move l1:migratee l2:migratee || false
+  fi
 
   # Test stateless move of running container with snapshot.
   lxc_remote move --stateless l1:migratee l2:migratee
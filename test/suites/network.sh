@@ -137,6 +137,20 @@ test_network() {
   grep -F ",${v4_addr_foo},STATIC" <<< "${list_leases}"
   grep -F ",${v6_addr_foo},STATIC" <<< "${list_leases}"
 
+  # Check DHCP reservations and options
+  v4_addr_res="$(lxc network get lxdt$$ ipv4.address | cut -d/ -f1)2"
+  ! lxc network set lxdt$$ dhcp.reservations.printer.ipv4.address="${v4_addr_res}" || false
+  ! lxc network set lxdt$$ dhcp.reservations.printer.hwaddr=00:16:3e:00:00:01 dhcp.reservations.printer.ipv4.address="${v4_addr}" || false
+  ! lxc network set lxdt$$ ipv4.dhcp.options.3=192.0.2.1 || false
+  lxc network set lxdt$$ dhcp.reservations.printer.hwaddr=00:16:3e:00:00:01 dhcp.reservations.printer.ipv4.address="${v4_addr_res}" ipv4.dhcp.options.42="${v4_addr_res}"
+  dnsmasq_cmd="$(pgrep -a dnsmasq | grep -F -- "--interface=lxdt$$ ")"
+  grep -F -- "--dhcp-host=00:16:3e:00:00:01,${v4_addr_res} " <<< "${dnsmasq_cmd}"
+  grep -F -- "--dhcp-option=42,${v4_addr_res} " <<< "${dnsmasq_cmd}"
+  lxc network list-leases -f csv lxdt$$ | grep -F "printer,00:16:3e:00:00:01,${v4_addr_res},STATIC"
+  ! lxc network set lxdt$$ ipv4.dhcp.ranges="${v4_addr_res}-${v4_addr_res}" || false
+  ! lxc config device set nettest eth0 ipv4.address="${v4_addr_res}" || false
+  lxc network set lxdt$$ dhcp.reservations.printer.hwaddr= dhcp.reservations.printer.ipv4.address= ipv4.dhcp.options.42=
+
   # Request DHCPv6 lease (if udhcpc6 is in busybox image).
   if lxc exec nettest -- busybox --list | grep -wF udhcpc6 ; then
     lxc exec nettest -- udhcpc6 -f -i eth0 -n -q -t5 2>&1 | grep -F 'IPv6 obtained'
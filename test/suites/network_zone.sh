@@ -157,6 +157,49 @@ test_network_zone() {
 
   lxc network zone record delete lxd.example.net patchtest
 
+  # Test dynamic updates (RFC 2136).
+  tsig_secret="dGVzdC1zZWNyZXQtZm9yLWR5bmFtaWMtdXBkYXRlcw=="
+  tsig_key="hmac-sha256:lxd.example.net_acme.:${tsig_secret}"
+  ! lxc network zone set lxd.example.net peers.acme.update=true || false
+  lxc network zone set lxd.example.net peers.acme.key="${tsig_secret}" peers.acme.update=true peers.acme.update.names="_acme-challenge*" peers.acme.update.types=TXT
+  ! lxc network zone set lxd.example.net peers.acme.update.types=SOA || false
+
+  nsupdate -y "${tsig_key}" << EOF
+server ${DNS_ADDR} ${DNS_PORT}
+zone lxd.example.net
+update add _acme-challenge.lxd.example.net. 60 TXT "token"
+send
+EOF
+  lxc network zone record show lxd.example.net _acme-challenge | grep -F '"token"'
+  dig "@${DNS_ADDR}" -p "${DNS_PORT}" axfr lxd.example.net | grep '_acme-challenge.lxd.example.net.\s\+60\s\+IN\s\+TXT\s\+"token"'
+
+  # Updates outside of the allowed names and types are refused.
+  ! nsupdate -y "${tsig_key}" << EOF || false
+server ${DNS_ADDR} ${DNS_PORT}
+zone lxd.example.net
+update add www.lxd.example.net. 60 TXT "token"
+send
+EOF
+  ! nsupdate -y "${tsig_key}" << EOF || false
+server ${DNS_ADDR} ${DNS_PORT}
+zone lxd.example.net
+update add _acme-challenge.lxd.example.net. 60 A 192.0.2.1
+send
+EOF
+  ! lxc network zone record show lxd.example.net www || false
+
+  nsupdate -y "${tsig_key}" << EOF
+server ${DNS_ADDR} ${DNS_PORT}
+zone lxd.example.net
+update delete _acme-challenge.lxd.example.net. TXT
+send
+EOF
+  ! lxc network zone record show lxd.example.net _acme-challenge || false
+  lxc network zone unset lxd.example.net peers.acme.update
+  lxc network zone unset lxd.example.net peers.acme.update.names
+  lxc network zone unset lxd.example.net peers.acme.update.types
+  lxc network zone unset lxd.example.net peers.acme.key
+
   # Check that the listener survives a restart of LXD
   shutdown_lxd "${LXD_DIR}"
   respawn_lxd "${LXD_DIR}" true
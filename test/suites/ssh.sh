@@ -0,0 +1,129 @@
+test_ssh() {
+  if ! command -v ssh-keygen > /dev/null 2>&1 || ! command -v ssh-agent > /dev/null 2>&1; then
+    echo "==> SKIP: ssh-keygen or ssh-agent not available (please install openssh-client)"
+    return
+  fi
+
+  # shellcheck disable=2153
+  ensure_has_localhost_remote "${LXD_ADDR}"
+
+  ssh_dir="$(mktemp -d -p "${TEST_DIR}" XXX)"
+  ssh-keygen -q -t ed25519 -N "" -C "ca" -f "${ssh_dir}/ca"
+  ssh-keygen -q -t ed25519 -N "" -C "alice@laptop" -f "${ssh_dir}/alice"
+  ssh-keygen -q -t ed25519 -N "" -C "bob@laptop" -f "${ssh_dir}/bob"
+  ssh-keygen -q -s "${ssh_dir}/ca" -I alice -n alice,operators -V -5m:+1h "${ssh_dir}/alice.pub"
+
+  # Check configuration validation.
+  ! lxc config set ssh.ca_keys "not a key" || false
+  ! lxc config set ssh.ca_keys "$(cat "${ssh_dir}/alice-cert.pub")" || false # Certificates can't be CA keys
+  ! lxc config set ssh.session.expiry "10M" || false # Less than an hour
+  ! lxc config set ssh.session.expiry=1d core.auth_secret_expiry=1H || false # Greater than the auth secret expiry
+
+  # Invalid challenges and signatures are rejected.
+  curl -s -k -X POST "https://${LXD_ADDR}/1.0/auth/ssh/challenge" -d '{"username":""}' | jq --exit-status '.error_code == 400'
+  challenge="$(curl -s -k -X POST "https://${LXD_ADDR}/1.0/auth/ssh/challenge" -d '{"username":"alice"}' | jq -r '.metadata.challenge')"
+  curl -s -k -X POST "https://${LXD_ADDR}/1.0/auth/ssh/login" -d "{\"username\":\"bob\",\"challenge\":\"${challenge}\"}" | jq --exit-status '.error_code == 403'
+  curl -s -k -X POST "https://${LXD_ADDR}/1.0/auth/ssh/login" -d "{\"username\":\"alice\",\"challenge\":\"${challenge}x\"}" | jq --exit-status '.error_code == 403'
+  curl -s -k -X POST "https://${LXD_ADDR}/1.0/auth/ssh/login" -d "{\"username\":\"alice\",\"challenge\":\"${challenge}\",\"public_key\":\"$(cat "${ssh_dir}/alice.pub")\",\"signature\":\"AAAA\"}" | jq --exit-status '.error_code == 403'
+
+  eval "$(ssh-agent -s)" > /dev/null
+  ssh-add -q "${ssh_dir}/alice"
+
+  # Without any trusted CA key, SSH authentication isn't offered and no identity is created.
+  curl -s -k "https://${LXD_ADDR}/1.0" | jq --exit-status '.metadata.auth_methods | index("ssh") == null'
+  ! printf 'alice\n' | lxc remote add ssh "${LXD_ADDR}" --accept-certificate --auth-type ssh || false
+  [ "$(lxc auth identity list --format csv | grep -cF ssh)" = 0 ]
+
+  lxc config set ssh.ca_keys="$(cat "${ssh_dir}/ca.pub")"
+  curl -s -k "https://${LXD_ADDR}/1.0" | jq --exit-status '.metadata.auth_methods | index("ssh") != null'
+
+  # The challenge must be signed for the LXD namespace, and the session expires with the certificate.
+  challenge="$(curl -s -k -X POST "https://${LXD_ADDR}/1.0/auth/ssh/challenge" -d '{"username":"alice"}' | jq -r '.metadata.challenge')"
+  for namespace in file lxd; do
+    signature="$(printf '%s' "${challenge}" | ssh-keygen -q -Y sign -n "${namespace}" -f "${ssh_dir}/alice-cert.pub")"
+    jq -n --arg challenge "${challenge}" --arg key "$(cat "${ssh_dir}/alice-cert.pub")" --arg signature "${signature}" '{"username":"alice","challenge":$challenge,"public_key":$key,"signature":$signature}' > "${ssh_dir}/login.json"
+    if [ "${namespace}" = "lxd" ]; then
+      curl -s -k -X POST "https://${LXD_ADDR}/1.0/auth/ssh/login" --data-binary "@${ssh_dir}/login.json" | jq --exit-status '.metadata.expires_at | sub("\\.[0-9]+"; "") | fromdateiso8601 < now + 3600'
+    else
+      curl -s -k -X POST "https://${LXD_ADDR}/1.0/auth/ssh/login" --data-binary "@${ssh_dir}/login.json" | jq --exit-status '.error_code == 403'
+    fi
+  done
+
+  lxc auth identity delete ssh/alice
+
+  # The username must be a principal of the certificate.
+  ! printf 'carol\n' | lxc remote add ssh "${LXD_ADDR}" --accept-certificate --auth-type ssh || false
+  [ "$(lxc auth identity list --format csv | grep -cF ssh)" = 0 ]
+
+  # Log in with the certificate. The identity is created on the first login.
+  printf 'alice\n' | lxc remote add ssh "${LXD_ADDR}" --accept-certificate --auth-type ssh
+  lxc query ssh:/1.0 | jq --exit-status '.auth == "trusted"'
+  lxc query ssh:/1.0 | jq --exit-status '.auth_user_method == "ssh"'
+  lxc query ssh:/1.0 | jq --exit-status '.auth_user_name == "alice"'
+  lxc query /1.0/auth/identities/ssh/alice | jq --exit-status '.type == "SSH user" and .name == "alice"'
+
+  # Without any permissions, the identity can't see any projects.
+  [ "$(lxc query ssh:/1.0/projects | jq 'length')" = 0 ]
+
+  # Map a principal of the certificate to an authorization group.
+  lxc auth group create ssh-viewers
+  lxc auth group permission add ssh-viewers project default can_view
+  lxc auth identity-provider-group create operators
+  lxc auth identity-provider-group group add operators ssh-viewers
+
+  lxc query ssh:/1.0/auth/identities/current | jq --exit-status '.effective_groups == ["ssh-viewers"]'
+  [ "$(lxc query ssh:/1.0/projects | jq 'length')" = 1 ]
+
+  # A tampered token is rejected.
+  token="$(cat "${LXD_CONF}/sshtokens/ssh.token")"
+  curl -s -k -H "Authorization: Bearer ${token}" "https://${LXD_ADDR}/1.0" | jq --exit-status '.metadata.auth == "trusted"'
+  curl -s -k -H "Authorization: Bearer ${token}x" "https://${LXD_ADDR}/1.0" | jq --exit-status '.error_code == 403'
+
+  lxc remote remove ssh
+  [ ! -e "${LXD_CONF}/sshtokens/ssh.token" ]
+
+  # Deleting the identity revokes the session.
+  lxc auth identity delete ssh/alice
+  curl -s -k -H "Authorization: Bearer ${token}" "https://${LXD_ADDR}/1.0/projects" | jq --exit-status '.type == "error"'
+
+  # Users without a certificate must be added with their authorized keys.
+  ssh-add -D -q
+  ssh-add -q "${ssh_dir}/bob"
+  ! printf 'bob\n' | lxc remote add ssh "${LXD_ADDR}" --accept-certificate --auth-type ssh || false
+  [ "$(lxc auth identity list --format csv | grep -cF ssh)" = 0 ]
+
+  ! lxc auth identity create ssh/bob "${ssh_dir}/alice-cert.pub" || false
+  ! lxc auth identity create ssh/bob "${ssh_dir}/ca" || false
+  lxc auth identity create ssh/bob "${ssh_dir}/bob.pub"
+  lxc query /1.0/auth/identities/ssh/bob | jq --exit-status --arg key "$(cat "${ssh_dir}/bob.pub")" '.ssh_authorized_keys == [$key]'
+
+  printf 'bob\n' | lxc remote add ssh "${LXD_ADDR}" --accept-certificate --auth-type ssh
+  lxc query ssh:/1.0 | jq --exit-status '.auth_user_name == "bob"'
+  token="$(cat "${LXD_CONF}/sshtokens/ssh.token")"
+  lxc remote remove ssh
+
+  # Only the selected key is used if the SSH agent holds several keys.
+  ssh-add -q "${ssh_dir}/alice"
+  ! printf 'bob\n' | lxc remote add ssh "${LXD_ADDR}" --accept-certificate --auth-type ssh || false
+  ! printf 'bob\n' | lxc remote add ssh "${LXD_ADDR}" --accept-certificate --auth-type ssh --ssh-key "${ssh_dir}/alice" || false
+  printf 'bob\n' | lxc remote add ssh "${LXD_ADDR}" --accept-certificate --auth-type ssh --ssh-key "${ssh_dir}/bob"
+  lxc query ssh:/1.0 | jq --exit-status '.auth_user_name == "bob"'
+  lxc remote remove ssh
+
+  # Authorized keys must be valid keys.
+  ! lxc query -X PATCH -d '{"ssh_authorized_keys":["not a key"]}' /1.0/auth/identities/ssh/bob || false
+
+  # Removing the authorized key revokes the session and prevents logging in.
+  curl -s -k -H "Authorization: Bearer ${token}" "https://${LXD_ADDR}/1.0" | jq --exit-status '.metadata.auth == "trusted"'
+  lxc query -X PATCH -d '{"ssh_authorized_keys":[]}' /1.0/auth/identities/ssh/bob
+  curl -s -k -H "Authorization: Bearer ${token}" "https://${LXD_ADDR}/1.0" | jq --exit-status '.error_code == 403'
+  ! printf 'bob\n' | lxc remote add ssh "${LXD_ADDR}" --accept-certificate --auth-type ssh --ssh-key "${ssh_dir}/bob" || false
+
+  # Cleanup.
+  ssh-agent -k > /dev/null
+  lxc auth identity delete ssh/bob
+  lxc auth identity-provider-group delete operators
+  lxc auth group delete ssh-viewers
+  lxc config unset ssh.ca_keys
+  rm -rf "${ssh_dir}"
+}
@@ -0,0 +1,47 @@
+test_tracing() {
+  local spans_file="${TEST_DIR}/otlp.spans"
+  spawn_otlp
+
+  # Check the configuration is validated.
+  ! lxc config set tracing.otlp.endpoint="127.0.0.1:4318" || false
+  ! lxc config set tracing.otlp.protocol="thrift" || false
+  ! lxc config set tracing.sample_ratio="2" || false
+
+  lxc config set tracing.otlp.endpoint="http://127.0.0.1:4318" tracing.otlp.protocol="http" tracing.sample_ratio="1"
+
+  ensure_import_testimage
+  lxc init testimage c1
+  lxc delete c1
+
+  # Check a trace context provided by the client is continued.
+  local trace_id="4bf92f3577b34da6a3ce929d0e0e4736"
+  local parent_id="00f067aa0ba902b7"
+  curl --silent --unix-socket "${LXD_DIR}/unix.socket" --fail-with-body -H "traceparent: 00-${trace_id}-${parent_id}-01" "lxd/1.0" > /dev/null
+
+  # Unsetting the endpoint sends the pending spans to the collector.
+  lxc config unset tracing.otlp.endpoint
+
+  # Check there are spans for the API request, its operation and the storage and database calls.
+  jq --exit-status 'select(.name == "POST /1.0/instances")' "${spans_file}"
+  jq --exit-status 'select(.name == "Creating instance")' "${spans_file}"
+  jq --exit-status 'select(.name == "storage.CreateInstanceFromImage" and .attributes."lxd.storage.volume" == "c1")' "${spans_file}"
+  jq --exit-status 'select(.name == "db.transaction")' "${spans_file}"
+  jq --exit-status 'select(.service."service.name" == "lxd")' "${spans_file}"
+
+  # Check the operation is part of the trace of the request that created it.
+  local request_trace_id
+  request_trace_id="$(jq --raw-output 'select(.name == "POST /1.0/instances") | .trace_id' "${spans_file}")"
+  jq --exit-status --arg trace_id "${request_trace_id}" 'select(.name == "Creating instance" and .trace_id == $trace_id)' "${spans_file}"
+
+  jq --exit-status --arg trace_id "${trace_id}" --arg parent_id "${parent_id}" 'select(.name == "GET /1.0" and .trace_id == $trace_id and .parent_span_id == $parent_id)' "${spans_file}"
+
+  # Check no spans are sent once the endpoint is unset.
+  local spans
+  spans="$(wc -l < "${spans_file}")"
+  lxc query /1.0 > /dev/null
+  [ "$(wc -l < "${spans_file}")" = "${spans}" ]
+
+  # Cleanup
+  kill_otlp
+  rm "${spans_file}"
+}
@@ -61,6 +61,58 @@ test_vm_empty() {
 
   echo "==> Test VM log directory cleanup after deletion"
   [ ! -d "${LXD_DIR}/logs/v1" ]
+
+  if [ "$(uname -m)" = "x86_64" ] || [ "$(uname -m)" = "aarch64" ]; then
+    echo "==> Memory hotplug"
+    ! lxc init --vm --empty v1 -c limits.memory=256MiB -c limits.memory.hotplug=128MiB -d "${SMALL_ROOT_DISK}" || false
+    ! lxc init --vm --empty v1 -c limits.memory.hotplug=invalid -d "${SMALL_ROOT_DISK}" || false
+    lxc launch --vm --empty v1 -c limits.memory=128MiB -c limits.memory.hotplug=512MiB -d "${SMALL_ROOT_DISK}"
+    [ "$(lxc query /1.0/instances/v1/state | jq '.memory.hotplug.max')" = "$((512 * 1024 * 1024))" ]
+    [ "$(lxc query /1.0/instances/v1/state | jq '.memory.hotplug.plugged')" = "0" ]
+    ! lxc config set v1 limits.memory.hotplug=1GiB || false
+    ! lxc config set v1 limits.memory=1GiB || false
+
+    # The empty VM has no guest kernel to plug the memory.
+    ! lxc config set v1 limits.memory=256MiB || false
+    [ "$(lxc query /1.0/instances/v1/state | jq -r '.memory.hotplug.guest_support')" = "unknown" ]
+    lxc delete -f v1
+  fi
+}
+
+test_vm_memory_pressure() {
+  if [ "${LXD_TMPFS:-0}" = "1" ] && ! runsMinimumKernel 6.6; then
+    export TEST_UNMET_REQUIREMENT="QEMU requires direct-io support which requires a kernel >= 6.6 for tmpfs support (LXD_TMPFS=${LXD_TMPFS})"
+    return 0
+  fi
+
+  echo "==> Server configuration validation"
+  ! lxc config set instances.memory_pressure.suspend_threshold=101 || false
+  ! lxc config set instances.memory_pressure.resume_threshold=-1 || false
+  lxc config set instances.memory_pressure.suspend_threshold=100
+  lxc config unset instances.memory_pressure.suspend_threshold
+
+  echo "==> Instance configuration validation"
+  lxc init --vm --empty v1 -c limits.memory=128MiB -c migration.stateful=true -d root,size.state=256MiB -d "${SMALL_ROOT_DISK}"
+  ! lxc config set v1 limits.memory.suspend.priority=11 || false
+  ! lxc config set v1 limits.memory.suspend.priority=invalid || false
+  lxc config set v1 limits.memory.suspend.priority=5
+
+  echo "==> Resume on demand of a suspended VM"
+  lxc start v1
+  lxc stop --stateful v1
+  [ "$(lxc list -f csv -c s v1)" = "STOPPED" ]
+  lxc config set v1 volatile.memory_pressure.suspended=true
+
+  # The empty VM has no agent so the command fails but the VM is resumed beforehand.
+  ! lxc exec v1 -- true || false
+  [ "$(lxc list -f csv -c s v1)" = "RUNNING" ]
+  [ "$(lxc config get v1 volatile.memory_pressure.suspended)" = "" ]
+  lxc delete -f v1
+
+  if [ -e /proc/pressure/memory ]; then
+    echo "==> Host memory pressure in resources"
+    lxc query /1.0/resources | jq -e '.memory.pressure.some_avg10 >= 0'
+  fi
 }
 
 test_vm_pcie_bus() {